@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"path/filepath"
+	"time"
 
 	"github.com/sethvargo/go-envconfig"
 	"go.uber.org/zap"
@@ -52,6 +53,17 @@ type Config struct {
 			Extensions []string `env:"EXTENSIONS"`
 		} `env:",prefix=IMAGE_"`
 	} `env:",prefix=STATIC_"`
+	Webhook struct {
+		MaxAttempt int           `env:"MAX_ATTEMPT,default=5"`
+		Backoff    time.Duration `env:"BACKOFF,default=1s"`
+		Timeout    time.Duration `env:"TIMEOUT,default=10s"`
+	} `env:",prefix=WEBHOOK_"`
+	DailyChallenge struct {
+		Sources    []string `env:"SOURCES"`
+		ThemeIds   []string `env:"THEME_IDS"`
+		NbQuestion int      `env:"NB_QUESTION,default=10"`
+		NbAnswer   int      `env:"NB_ANSWER,default=4"`
+	} `env:",prefix=DAILY_CHALLENGE_"`
 }
 
 func readConfig(ctx context.Context) *Config {
@@ -79,3 +91,21 @@ func (c *Config) ImageFileFilter(logger *zap.Logger) *model.FileFilter {
 	}
 	return filter
 }
+
+func (c *Config) DailyChallengeSettings() *model.DailyChallengeSettings {
+	settings := &model.DailyChallengeSettings{
+		NbQuestion: c.DailyChallenge.NbQuestion,
+		NbAnswer:   c.DailyChallenge.NbAnswer,
+	}
+	for _, value := range c.DailyChallenge.Sources {
+		if source := model.ToSource(value); source != "" {
+			settings.Sources = append(settings.Sources, source)
+		}
+	}
+	for _, value := range c.DailyChallenge.ThemeIds {
+		if themeId := model.ToThemeId(value); themeId != 0 {
+			settings.ThemeIds = append(settings.ThemeIds, themeId)
+		}
+	}
+	return settings
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gre-ory/amnezic-go/internal/client"
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// music importer
+
+const (
+	// e.g. amnezic-go import-music -dry-run
+	importMusicCommand = "import-music"
+)
+
+func NewMusicImporter(logger *zap.Logger, config *Config) *MusicImporter {
+	return &MusicImporter{
+		logger: logger,
+		config: config,
+	}
+}
+
+type MusicImporter struct {
+	logger *zap.Logger
+	config *Config
+}
+
+// Run registers the local mp3 files from their ID3 tags and prints the report.
+func (i *MusicImporter) Run(ctx context.Context, args []string) {
+
+	flags := flag.NewFlagSet(importMusicCommand, flag.ExitOnError)
+	dryRun := flags.Bool("dry-run", false, "report the musics to import without creating them")
+	flags.Parse(args)
+
+	//
+	// store
+	//
+
+	db, _ := sql.Open("sqlite3", i.config.Sqlite.DataSource)
+	defer db.Close()
+
+	// the catalog search needs fts5: fail now rather than on the first write
+	if err := store.CheckSearchSupport(ctx, db); err != nil {
+		i.logger.Fatal("unsupported sqlite library", zap.Error(err))
+	}
+
+	musicFilter := i.config.MusicFileFilter(i.logger)
+	imageFilter := i.config.ImageFileFilter(i.logger)
+
+	searchStore := store.NewSearchStore(i.logger)
+	musicStore := store.NewMusicStore(i.logger, searchStore)
+	albumStore := store.NewMusicAlbumStore(i.logger, searchStore)
+	artistStore := store.NewMusicArtistStore(i.logger, searchStore)
+	genreStore := store.NewMusicGenreStore(i.logger)
+	contributorStore := store.NewMusicContributorStore(i.logger)
+	themeStore := store.NewThemeStore(i.logger)
+	themeQuestionStore := store.NewThemeQuestionStore(i.logger, searchStore)
+	fileStore := store.NewFileStore(i.logger)
+
+	musicFileValidator := fileStore.PathValidator(ctx, musicFilter)
+	imageFileValidator := fileStore.PathValidator(ctx, imageFilter)
+
+	//
+	// service
+	//
+
+	deezerClient := client.NewDeezerClient(i.logger)
+	downloadClient := client.NewDownloadClient(i.logger, musicFilter, imageFilter)
+
+	musicService := service.NewMusicService(i.logger, deezerClient, downloadClient, db, musicStore, albumStore, artistStore, genreStore, contributorStore, themeStore, themeQuestionStore, musicFileValidator, imageFileValidator)
+	musicImportService := service.NewMusicImportService(i.logger, db, fileStore, musicFilter, imageFilter, musicService, musicStore, albumStore, artistStore)
+
+	// the music identity index needs the title keys computed by the application ( see db/00017_music_title_key.sql )
+	if err := musicService.KeyMusicTitles(ctx); err != nil {
+		i.logger.Fatal("music title keys failed", zap.Error(err))
+	}
+
+	//
+	// import
+	//
+
+	report, err := musicImportService.ImportLocalMusics(ctx, *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "import failed: %s\n", err)
+		os.Exit(1)
+	}
+	printMusicImportReport(report)
+}
+
+func printMusicImportReport(report *model.MusicImportReport) {
+	for _, item := range report.Items {
+		switch {
+		case item.Reason != "":
+			fmt.Printf("%-8s %s: %s\n", item.Status, item.Path, item.Reason)
+		case item.Music != nil && item.Music.Artist != nil:
+			fmt.Printf("%-8s %s: %s - %s\n", item.Status, item.Path, item.Music.Artist.Name, item.Music.Name)
+		case item.Music != nil:
+			fmt.Printf("%-8s %s: %s\n", item.Status, item.Path, item.Music.Name)
+		default:
+			fmt.Printf("%-8s %s\n", item.Status, item.Path)
+		}
+	}
+	if report.DryRun {
+		fmt.Printf("dry run: ")
+	}
+	fmt.Printf("%d new, %d existing, %d invalid\n", report.Count(model.MusicImportStatus_New), report.Count(model.MusicImportStatus_Existing), report.Count(model.MusicImportStatus_Invalid))
+}
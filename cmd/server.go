@@ -47,6 +47,18 @@ func main() {
 	logger.Info("starting app...", zap.Any("config", config))
 	// logger.Info("secrets...", zap.Any("secrets", secrets))
 
+	//
+	// commands
+	//
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case importMusicCommand:
+			NewMusicImporter(logger, config).Run(ctx, os.Args[2:])
+			os.Exit(0)
+		}
+	}
+
 	//
 	// servers
 	//
@@ -89,22 +101,36 @@ func (s *Server) Run(ctx context.Context) {
 	db, _ := sql.Open("sqlite3", s.config.Sqlite.DataSource)
 	defer db.Close()
 
+	// the catalog search needs fts5: fail now rather than on the first write
+	if err := store.CheckSearchSupport(ctx, db); err != nil {
+		s.logger.Fatal("unsupported sqlite library", zap.Error(err))
+	}
+
 	musicFilter := s.config.MusicFileFilter(s.logger)
 	imageFilter := s.config.ImageFileFilter(s.logger)
 
-	gameStore := memory.NewGameMemoryStore()
+	gameEventStore := store.NewGameEventStore(s.logger)
+	tournamentStore := memory.NewTournamentMemoryStore()
 	gameQuestionStore := legacy.NewGameQuestionLegacyStore(s.logger, legacy.RootPath_FreeDotFr)
 
 	// musicStore := store.NewMusicMemoryStore()
 	// albumStore := store.NewMusicAlbumMemoryStore()
 	// artistStore := store.NewMusicArtistMemoryStore()
 
-	musicStore := store.NewMusicStore(s.logger)
-	albumStore := store.NewMusicAlbumStore(s.logger)
-	artistStore := store.NewMusicArtistStore(s.logger)
+	searchStore := store.NewSearchStore(s.logger)
+	musicStore := store.NewMusicStore(s.logger, searchStore)
+	albumStore := store.NewMusicAlbumStore(s.logger, searchStore)
+	artistStore := store.NewMusicArtistStore(s.logger, searchStore)
+	genreStore := store.NewMusicGenreStore(s.logger)
+	contributorStore := store.NewMusicContributorStore(s.logger)
 	themeStore := store.NewThemeStore(s.logger)
-	themeQuestionStore := store.NewThemeQuestionStore(s.logger)
+	themeQuestionStore := store.NewThemeQuestionStore(s.logger, searchStore)
 	userStore := store.NewUserStore(s.logger)
+	gamePresetStore := store.NewGamePresetStore(s.logger)
+	webhookStore := store.NewWebhookStore(s.logger)
+	webhookDeliveryStore := store.NewWebhookDeliveryStore(s.logger)
+	dailyChallengeStore := store.NewDailyChallengeStore(s.logger)
+	dailyChallengeRunStore := store.NewDailyChallengeRunStore(s.logger)
 	sessionStore := store.NewSessionStore(s.logger)
 	fileStore := store.NewFileStore(s.logger)
 
@@ -117,33 +143,55 @@ func (s *Server) Run(ctx context.Context) {
 
 	deezerClient := client.NewDeezerClient(s.logger)
 	downloadClient := client.NewDownloadClient(s.logger, musicFilter, imageFilter)
+	webhookClient := client.NewWebhookClient(s.logger, s.config.Webhook.MaxAttempt, s.config.Webhook.Backoff, s.config.Webhook.Timeout)
 
 	//
 	// service
 	//
 
-	gameService := service.NewGameService(s.logger, db, gameStore, gameQuestionStore, musicStore, artistStore, albumStore, themeStore, themeQuestionStore, deezerClient)
-	musicService := service.NewMusicService(s.logger, deezerClient, downloadClient, db, musicStore, albumStore, artistStore, themeStore, themeQuestionStore, musicFileValidator, imageFileValidator)
-	artistService := service.NewArtistService(s.logger, downloadClient, db, artistStore, musicStore, imageFileValidator)
-	albumService := service.NewAlbumService(s.logger, downloadClient, db, albumStore, musicStore, imageFileValidator)
+	webhookService := service.NewWebhookService(s.logger, db, webhookClient, webhookStore, webhookDeliveryStore)
+	gameService := service.NewGameService(s.logger, db, gameEventStore, gameQuestionStore, musicStore, artistStore, albumStore, contributorStore, themeStore, themeQuestionStore, deezerClient, webhookService)
+	gamePackService := service.NewGamePackService(s.logger, gameService, downloadClient, musicFilter, imageFilter)
+	musicService := service.NewMusicService(s.logger, deezerClient, downloadClient, db, musicStore, albumStore, artistStore, genreStore, contributorStore, themeStore, themeQuestionStore, musicFileValidator, imageFileValidator)
+	artistService := service.NewArtistService(s.logger, downloadClient, db, artistStore, musicStore, contributorStore, genreStore, themeQuestionStore, imageFileValidator)
+	albumService := service.NewAlbumService(s.logger, downloadClient, db, albumStore, musicStore, genreStore, imageFileValidator)
+	genreService := service.NewGenreService(s.logger, downloadClient, db, genreStore, albumStore, musicStore, imageFileValidator)
 	themeService := service.NewThemeService(s.logger, db, themeStore, themeQuestionStore, musicStore, artistStore, albumStore)
+	gamePresetService := service.NewGamePresetService(s.logger, db, gamePresetStore)
+	tournamentService := service.NewTournamentService(s.logger, db, gameService, tournamentStore)
+	dailyChallengeService := service.NewDailyChallengeService(s.logger, db, s.config.DailyChallengeSettings(), gameService, dailyChallengeStore, dailyChallengeRunStore)
 	userService := service.NewUserService(s.logger, db, userStore, defaultAdminUser)
 	sessionService := service.NewSessionService(s.logger, s.config.Session.SecretKey, db, sessionStore, userStore)
 	fileService := service.NewFileService(s.logger, fileStore)
+	searchService := service.NewSearchService(s.logger, db, searchStore, themeStore)
+	musicImportService := service.NewMusicImportService(s.logger, db, fileStore, musicFilter, imageFilter, musicService, musicStore, albumStore, artistStore)
+
+	// the music identity index needs the title keys computed by the application ( see db/00017_music_title_key.sql )
+	if err := musicService.KeyMusicTitles(ctx); err != nil {
+		s.logger.Fatal("music title keys failed", zap.Error(err))
+	}
 
 	//
 	// api
 	//
 
-	gameHandler := api.NewGamehandler(s.logger, gameService)
+	gameHandler := api.NewGamehandler(s.logger, gameService, gamePresetService, sessionService)
+	gamePackHandler := api.NewGamePackHandler(s.logger, gamePackService, gameService, sessionService)
+	gamePresetHandler := api.NewGamePresetHandler(s.logger, gamePresetService, sessionService)
+	webhookHandler := api.NewWebhookHandler(s.logger, webhookService, sessionService)
+	tournamentHandler := api.NewTournamentHandler(s.logger, tournamentService, sessionService)
+	dailyChallengeHandler := api.NewDailyChallengeHandler(s.logger, dailyChallengeService, sessionService)
 	playlistHandler := api.NewPlaylisthandler(s.logger, musicService)
 	musicHandler := api.NewMusichandler(s.logger, musicService, sessionService)
 	artistHandler := api.NewArtisthandler(s.logger, artistService, sessionService)
 	albumHandler := api.NewAlbumhandler(s.logger, albumService, sessionService)
+	genreHandler := api.NewGenrehandler(s.logger, genreService, sessionService)
 	themeHandler := api.NewThemehandler(s.logger, themeService, musicService, sessionService)
 	userHandler := api.NewUserHandler(s.logger, userService, sessionService)
 	sessionHandler := api.NewSessionhandler(s.logger, sessionService)
 	fileHandler := api.NewFilehandler(s.logger, musicFilter, imageFilter, fileService, sessionService)
+	searchHandler := api.NewSearchHandler(s.logger, searchService)
+	musicImportHandler := api.NewMusicImportHandler(s.logger, musicImportService, sessionService)
 
 	//
 	// router
@@ -151,14 +199,28 @@ func (s *Server) Run(ctx context.Context) {
 
 	router := httprouter.New()
 	gameHandler.RegisterRoutes(router)
+	gamePackHandler.RegisterRoutes(router)
+	gamePresetHandler.RegisterRoutes(router)
+	webhookHandler.RegisterRoutes(router)
+	tournamentHandler.RegisterRoutes(router)
+	dailyChallengeHandler.RegisterRoutes(router)
 	musicHandler.RegisterRoutes(router)
 	artistHandler.RegisterRoutes(router)
 	albumHandler.RegisterRoutes(router)
+	genreHandler.RegisterRoutes(router)
 	themeHandler.RegisterRoutes(router)
 	playlistHandler.RegisterRoutes(router)
 	userHandler.RegisterRoutes(router)
 	sessionHandler.RegisterRoutes(router)
 	fileHandler.RegisterRoutes(router)
+	searchHandler.RegisterRoutes(router)
+	musicImportHandler.RegisterRoutes(router)
+
+	//
+	// scheduler
+	//
+
+	dailyChallengeService.StartScheduler(ctx)
 
 	//
 	// server
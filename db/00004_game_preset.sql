@@ -0,0 +1,14 @@
+-- +goose Up
+
+-- game preset
+CREATE TABLE game_preset (
+	id       	INTEGER PRIMARY KEY,
+	name     	TEXT NOT NULL,
+	owner_id 	INTEGER NOT NULL,
+	settings 	TEXT NOT NULL,
+	public   	INTEGER DEFAULT 0 NOT NULL
+);
+
+-- +goose Down
+
+DROP TABLE game_preset;
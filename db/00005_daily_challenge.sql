@@ -0,0 +1,26 @@
+-- +goose Up
+
+-- daily challenge
+CREATE TABLE daily_challenge (
+	date     	TEXT PRIMARY KEY,
+	settings 	TEXT NOT NULL
+);
+
+-- daily challenge run
+CREATE TABLE daily_challenge_run (
+	id      		INTEGER PRIMARY KEY,
+	date    		TEXT NOT NULL,
+	user_id 		INTEGER NOT NULL,
+	user_name 		TEXT NOT NULL,
+	score   		INTEGER NOT NULL,
+	nb_correct 		INTEGER NOT NULL,
+	submitted_at	INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX daily_challenge_run_date_user ON daily_challenge_run (date, user_id);
+
+-- +goose Down
+
+DROP INDEX daily_challenge_run_date_user;
+DROP TABLE daily_challenge_run;
+DROP TABLE daily_challenge;
@@ -0,0 +1,28 @@
+-- +goose Up
+
+-- webhook
+CREATE TABLE webhook (
+	id     	INTEGER PRIMARY KEY,
+	url    	TEXT NOT NULL,
+	secret 	TEXT NOT NULL,
+	events 	TEXT NOT NULL,
+	active 	INTEGER DEFAULT 1 NOT NULL
+);
+
+-- webhook delivery
+CREATE TABLE webhook_delivery (
+	id           	INTEGER PRIMARY KEY,
+	webhook_id   	INTEGER NOT NULL,
+	event        	TEXT NOT NULL,
+	game_id      	INTEGER NOT NULL,
+	attempt      	INTEGER NOT NULL,
+	status_code  	INTEGER NOT NULL,
+	error        	TEXT DEFAULT '' NOT NULL,
+	success      	INTEGER DEFAULT 0 NOT NULL,
+	delivered_at 	INTEGER NOT NULL
+);
+
+-- +goose Down
+
+DROP TABLE webhook_delivery;
+DROP TABLE webhook;
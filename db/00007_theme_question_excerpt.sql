@@ -0,0 +1,11 @@
+-- +goose Up
+
+-- theme question excerpt ( milliseconds )
+ALTER TABLE theme_question ADD excerpt_start INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE theme_question ADD excerpt_duration INTEGER DEFAULT 0 NOT NULL;
+
+-- +goose Down
+
+-- theme question excerpt
+ALTER TABLE theme_question DROP COLUMN excerpt_start;
+ALTER TABLE theme_question DROP COLUMN excerpt_duration;
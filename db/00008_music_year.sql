@@ -0,0 +1,9 @@
+-- +goose Up
+
+-- music release year ( 0 when unknown )
+ALTER TABLE music ADD year INTEGER DEFAULT 0 NOT NULL;
+
+-- +goose Down
+
+-- music release year
+ALTER TABLE music DROP COLUMN year;
@@ -0,0 +1,22 @@
+-- +goose Up
+
+-- full-text search index over catalog names and theme question texts
+-- ( requires sqlite built with fts5, e.g. go-sqlite3 with the 'sqlite_fts5' build tag )
+-- diacritics are removed so that 'beyonce' matches 'Beyoncé'
+CREATE VIRTUAL TABLE search USING fts5(
+    kind UNINDEXED,
+    ref_id UNINDEXED,
+    parent_id UNINDEXED,
+    text,
+    tokenize = 'unicode61 remove_diacritics 2'
+);
+
+INSERT INTO search (kind, ref_id, parent_id, text) SELECT 'music', id, 0, name FROM music WHERE name != '';
+INSERT INTO search (kind, ref_id, parent_id, text) SELECT 'artist', id, 0, name FROM music_artist WHERE name != '';
+INSERT INTO search (kind, ref_id, parent_id, text) SELECT 'album', id, 0, name FROM music_album WHERE name != '';
+INSERT INTO search (kind, ref_id, parent_id, text) SELECT 'theme-question', id, theme_id, text FROM theme_question WHERE text != '';
+
+-- +goose Down
+
+-- full-text search index
+DROP TABLE search;
@@ -0,0 +1,24 @@
+-- +goose Up
+
+-- music release metadata ( empty or 0 when unknown )
+-- duration in seconds, rank is the deezer popularity
+ALTER TABLE music ADD release_date TEXT DEFAULT '' NOT NULL;
+ALTER TABLE music ADD duration INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE music ADD genre TEXT DEFAULT '' NOT NULL;
+ALTER TABLE music ADD explicit INTEGER DEFAULT 0 NOT NULL;
+ALTER TABLE music ADD rank INTEGER DEFAULT 0 NOT NULL;
+
+CREATE INDEX music_year_idx ON music (year);
+CREATE INDEX music_genre_idx ON music (genre);
+
+-- +goose Down
+
+-- music release metadata
+DROP INDEX music_genre_idx;
+DROP INDEX music_year_idx;
+
+ALTER TABLE music DROP COLUMN rank;
+ALTER TABLE music DROP COLUMN explicit;
+ALTER TABLE music DROP COLUMN genre;
+ALTER TABLE music DROP COLUMN duration;
+ALTER TABLE music DROP COLUMN release_date;
@@ -0,0 +1,47 @@
+-- +goose Up
+
+-- music_genre
+CREATE TABLE music_genre (
+	id        INTEGER PRIMARY KEY,
+	deezer_id INTEGER NOT NULL,
+	name      TEXT NOT NULL,
+	img_url   TEXT
+);
+
+CREATE UNIQUE INDEX music_genre_name_idx ON music_genre (name COLLATE NOCASE);
+CREATE INDEX music_genre_deezer_id_idx ON music_genre (deezer_id);
+
+-- music_genre_album
+CREATE TABLE music_genre_album (
+	genre_id INTEGER NOT NULL,
+	album_id INTEGER NOT NULL,
+	PRIMARY KEY (genre_id, album_id)
+);
+
+CREATE INDEX music_genre_album_album_id_idx ON music_genre_album (album_id);
+
+-- music_genre_music
+CREATE TABLE music_genre_music (
+	genre_id INTEGER NOT NULL,
+	music_id INTEGER NOT NULL,
+	PRIMARY KEY (genre_id, music_id)
+);
+
+CREATE INDEX music_genre_music_music_id_idx ON music_genre_music (music_id);
+
+-- backfill from the free text genre of the musics
+INSERT INTO music_genre (deezer_id, name)
+	SELECT 0, min(genre) FROM music WHERE genre != '' GROUP BY genre COLLATE NOCASE;
+
+INSERT INTO music_genre_music (genre_id, music_id)
+	SELECT music_genre.id, music.id FROM music JOIN music_genre ON music_genre.name = music.genre COLLATE NOCASE;
+
+INSERT OR IGNORE INTO music_genre_album (genre_id, album_id)
+	SELECT music_genre.id, music.album_id FROM music JOIN music_genre ON music_genre.name = music.genre COLLATE NOCASE WHERE music.album_id != 0;
+
+-- +goose Down
+
+-- music_genre
+DROP TABLE music_genre_music;
+DROP TABLE music_genre_album;
+DROP TABLE music_genre;
@@ -0,0 +1,20 @@
+-- +goose Up
+
+-- music_contributor
+CREATE TABLE music_contributor (
+	music_id  INTEGER NOT NULL,
+	artist_id INTEGER NOT NULL,
+	role      TEXT NOT NULL,
+	PRIMARY KEY (music_id, artist_id, role)
+);
+
+CREATE INDEX music_contributor_artist_id_idx ON music_contributor (artist_id);
+
+-- backfill the main artist of the musics
+INSERT INTO music_contributor (music_id, artist_id, role)
+	SELECT id, artist_id, 'main' FROM music WHERE artist_id != 0;
+
+-- +goose Down
+
+-- music_contributor
+DROP TABLE music_contributor;
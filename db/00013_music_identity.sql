@@ -0,0 +1,94 @@
+-- +goose Up
+
+-- a music is identified by its title and artist, or by its deezer id
+-- ( the services compare titles without case, accents nor punctuation, the index is the last safeguard )
+
+-- existing duplicates are merged into the first registered music, in two passes: same title and artist, then same deezer id
+-- their theme questions, contributors and genres move to the surviving music ( a theme keeps a single question per music )
+-- the dropped questions are kept in music_identity_deleted_question, along with the music they were merged into
+CREATE TABLE music_identity_deleted_question (
+	id               INTEGER PRIMARY KEY,
+	theme_id         INTEGER NOT NULL,
+	music_id         INTEGER NOT NULL,
+	merged_music_id  INTEGER NOT NULL,
+	text             TEXT NOT NULL,
+	hint             TEXT,
+	excerpt_start    INTEGER NOT NULL,
+	excerpt_duration INTEGER NOT NULL
+);
+
+CREATE TEMP TABLE music_duplicate (
+	duplicate_id INTEGER PRIMARY KEY,
+	music_id     INTEGER NOT NULL
+);
+
+-- pass 1: same title and artist
+
+INSERT INTO music_duplicate (duplicate_id, music_id)
+	SELECT id, (SELECT min(other.id) FROM music other WHERE IFNULL(other.artist_id, 0) = IFNULL(music.artist_id, 0) AND other.name = music.name COLLATE NOCASE) AS music_id
+	FROM music WHERE music_id != id;
+
+INSERT INTO music_identity_deleted_question (id, theme_id, music_id, merged_music_id, text, hint, excerpt_start, excerpt_duration)
+	SELECT question.id, question.theme_id, question.music_id, duplicate.music_id, question.text, question.hint, question.excerpt_start, question.excerpt_duration
+	FROM theme_question question JOIN music_duplicate duplicate ON question.music_id = duplicate.duplicate_id
+	WHERE EXISTS (
+		SELECT 1 FROM theme_question other LEFT JOIN music_duplicate other_duplicate ON other.music_id = other_duplicate.duplicate_id
+		WHERE other.theme_id = question.theme_id AND other.id != question.id AND IFNULL(other_duplicate.music_id, other.music_id) = duplicate.music_id
+		AND ( other_duplicate.duplicate_id IS NULL OR other.id < question.id )
+	);
+DELETE FROM theme_question WHERE id IN ( SELECT id FROM music_identity_deleted_question );
+UPDATE theme_question SET music_id = ( SELECT music_id FROM music_duplicate WHERE duplicate_id = theme_question.music_id )
+	WHERE music_id IN ( SELECT duplicate_id FROM music_duplicate );
+
+INSERT OR IGNORE INTO music_contributor (music_id, artist_id, role)
+	SELECT duplicate.music_id, contributor.artist_id, contributor.role FROM music_contributor contributor JOIN music_duplicate duplicate ON contributor.music_id = duplicate.duplicate_id;
+DELETE FROM music_contributor WHERE music_id IN ( SELECT duplicate_id FROM music_duplicate );
+
+INSERT OR IGNORE INTO music_genre_music (genre_id, music_id)
+	SELECT link.genre_id, duplicate.music_id FROM music_genre_music link JOIN music_duplicate duplicate ON link.music_id = duplicate.duplicate_id;
+DELETE FROM music_genre_music WHERE music_id IN ( SELECT duplicate_id FROM music_duplicate );
+
+DELETE FROM music WHERE id IN ( SELECT duplicate_id FROM music_duplicate );
+DELETE FROM music_duplicate;
+
+-- pass 2: same deezer id
+
+INSERT INTO music_duplicate (duplicate_id, music_id)
+	SELECT id, (SELECT min(other.id) FROM music other WHERE other.deezer_id = music.deezer_id) AS music_id
+	FROM music WHERE deezer_id != 0 AND music_id != id;
+
+INSERT INTO music_identity_deleted_question (id, theme_id, music_id, merged_music_id, text, hint, excerpt_start, excerpt_duration)
+	SELECT question.id, question.theme_id, question.music_id, duplicate.music_id, question.text, question.hint, question.excerpt_start, question.excerpt_duration
+	FROM theme_question question JOIN music_duplicate duplicate ON question.music_id = duplicate.duplicate_id
+	WHERE EXISTS (
+		SELECT 1 FROM theme_question other LEFT JOIN music_duplicate other_duplicate ON other.music_id = other_duplicate.duplicate_id
+		WHERE other.theme_id = question.theme_id AND other.id != question.id AND IFNULL(other_duplicate.music_id, other.music_id) = duplicate.music_id
+		AND ( other_duplicate.duplicate_id IS NULL OR other.id < question.id )
+	);
+DELETE FROM theme_question WHERE id IN ( SELECT id FROM music_identity_deleted_question );
+UPDATE theme_question SET music_id = ( SELECT music_id FROM music_duplicate WHERE duplicate_id = theme_question.music_id )
+	WHERE music_id IN ( SELECT duplicate_id FROM music_duplicate );
+
+INSERT OR IGNORE INTO music_contributor (music_id, artist_id, role)
+	SELECT duplicate.music_id, contributor.artist_id, contributor.role FROM music_contributor contributor JOIN music_duplicate duplicate ON contributor.music_id = duplicate.duplicate_id;
+DELETE FROM music_contributor WHERE music_id IN ( SELECT duplicate_id FROM music_duplicate );
+
+INSERT OR IGNORE INTO music_genre_music (genre_id, music_id)
+	SELECT link.genre_id, duplicate.music_id FROM music_genre_music link JOIN music_duplicate duplicate ON link.music_id = duplicate.duplicate_id;
+DELETE FROM music_genre_music WHERE music_id IN ( SELECT duplicate_id FROM music_duplicate );
+
+DELETE FROM music WHERE id IN ( SELECT duplicate_id FROM music_duplicate );
+DROP TABLE music_duplicate;
+
+-- search index of the deleted musics and theme questions
+DELETE FROM search WHERE kind = 'music' AND ref_id NOT IN ( SELECT id FROM music );
+DELETE FROM search WHERE kind = 'theme-question' AND ref_id NOT IN ( SELECT id FROM theme_question );
+
+CREATE UNIQUE INDEX music_identity_idx ON music (artist_id, name COLLATE NOCASE);
+CREATE UNIQUE INDEX music_deezer_id_idx ON music (deezer_id) WHERE deezer_id != 0;
+
+-- +goose Down
+
+DROP INDEX music_deezer_id_idx;
+DROP INDEX music_identity_idx;
+DROP TABLE music_identity_deleted_question;
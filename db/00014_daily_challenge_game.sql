@@ -0,0 +1,8 @@
+-- +goose Up
+
+-- generated game of the daily challenge ( json ), so that the questions of a day never change
+ALTER TABLE daily_challenge ADD game TEXT DEFAULT '' NOT NULL;
+
+-- +goose Down
+
+ALTER TABLE daily_challenge DROP COLUMN game;
@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- append-only log of the events of each game ( payload in json )
+CREATE TABLE game_event (
+	game_id 		INTEGER NOT NULL,
+	sequence 		INTEGER NOT NULL,
+	type 			TEXT NOT NULL,
+	occurred_at		INTEGER NOT NULL,
+	user_id 		INTEGER DEFAULT 0 NOT NULL,
+	data 			TEXT NOT NULL,
+	PRIMARY KEY (game_id, sequence)
+);
+
+-- +goose Down
+
+DROP TABLE game_event;
@@ -0,0 +1,16 @@
+-- +goose Up
+
+-- number of each created game ( see model.NewGameId ), never reused thanks to AUTOINCREMENT
+-- so that the events of a game are never mixed with the ones of a former game
+CREATE TABLE game_sequence (
+	number 			INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at		INTEGER NOT NULL
+);
+
+-- games created before the sequence
+INSERT INTO game_sequence (number, created_at)
+	SELECT game_id / 10000000, min(occurred_at) FROM game_event GROUP BY game_id;
+
+-- +goose Down
+
+DROP TABLE game_sequence;
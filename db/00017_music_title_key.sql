@@ -0,0 +1,15 @@
+-- +goose Up
+
+-- a music is identified by the normalized title the services compare ( without case, accents nor punctuation ) and its artist
+-- the key is computed by the application ( model.ToMusicTitleKey ): the existing musics are keyed, and their duplicates merged, when the server starts
+ALTER TABLE music ADD title_key TEXT DEFAULT '' NOT NULL;
+
+DROP INDEX music_identity_idx;
+CREATE UNIQUE INDEX music_title_key_idx ON music (IFNULL(artist_id, 0), title_key) WHERE title_key != '';
+
+-- +goose Down
+
+DROP INDEX music_title_key_idx;
+CREATE UNIQUE INDEX music_identity_idx ON music (artist_id, name COLLATE NOCASE);
+
+ALTER TABLE music DROP COLUMN title_key;
@@ -34,6 +34,7 @@ type albumHandler struct {
 // register
 
 func (h *albumHandler) RegisterRoutes(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodGet, "/api/album", h.handleListAlbum)
 	router.HandlerFunc(http.MethodGet, "/api/album/:album_id", h.handleRetrieveAlbum)
 
 	withAlbumPermission := WithPermission(h.logger, h.sessionService, model.Permission_Music)
@@ -41,6 +42,71 @@ func (h *albumHandler) RegisterRoutes(router *httprouter.Router) {
 	router.HandlerFunc(http.MethodPut, "/api/album/new", withAlbumPermission(h.handleCreateAlbum))
 	router.HandlerFunc(http.MethodPost, "/api/album/:album_id", withAlbumPermission(h.handleUpdateAlbum))
 	router.HandlerFunc(http.MethodDelete, "/api/album/:album_id", withAlbumPermission(h.handleDeleteAlbum))
+	router.HandlerFunc(http.MethodGet, "/api/album-duplicate", withAlbumPermission(h.handleSuggestAlbumDuplicates))
+	router.HandlerFunc(http.MethodPost, "/api/album/:album_id/merge", withAlbumPermission(h.handleMergeAlbums))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (h *albumHandler) handleListAlbum(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var filter *model.MusicAlbumFilter
+	var page *model.MusicAlbumPage
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		sort := model.ToMusicSort(extractParameter(req, "sort"))
+		if sort == "" && extractParameter(req, "sort") != "" {
+			err = model.ErrInvalidMusicSort
+			break
+		}
+
+		filter = model.NewMusicAlbumFilter().
+			WithName(extractParameter(req, "name")).
+			WithGenreId(model.MusicGenreId(toInt64(extractParameter(req, "genre_id")))).
+			WithSort(sort).
+			WithOffset(toInt(extractParameter(req, "offset"))).
+			WithLimit(model.ToMusicPageLimit(toInt(extractParameter(req, "limit"))))
+
+		h.logger.Info("[api] list album", zap.Object("filter", filter))
+
+		//
+		// execute
+		//
+
+		page, err = h.service.ListAlbum(ctx, filter)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonAlbumPageResponse(page))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
 }
 
 // //////////////////////////////////////////////////
@@ -272,6 +338,121 @@ func (h *albumHandler) handleDeleteAlbum(resp http.ResponseWriter, req *http.Req
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
 
+// //////////////////////////////////////////////////
+// suggest duplicates
+
+func (h *albumHandler) handleSuggestAlbumDuplicates(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var minSimilarity int
+	var duplicates []*model.MusicAlbumDuplicate
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		minSimilarity = model.ToDuplicateSimilarity(toInt(extractParameter(req, "similarity")))
+		if minSimilarity == 0 {
+			err = model.ErrInvalidSimilarity
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] suggest album duplicates ( similarity: %d%% )", minSimilarity))
+
+		//
+		// execute
+		//
+
+		duplicates, err = h.service.SuggestAlbumDuplicates(ctx, minSimilarity)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonAlbumDuplicatesResponse(duplicates))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// merge
+
+func (h *albumHandler) handleMergeAlbums(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var albumId model.MusicAlbumId
+	var duplicateIds []int64
+	var album *model.MusicAlbum
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		albumId = model.MusicAlbumId(toInt64(extractPathParameter(req, "album_id")))
+		if albumId == 0 {
+			err = model.ErrInvalidMusicAlbumId
+			break
+		}
+		duplicateIds, err = extractDuplicateIdsFromBody(req)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] merge albums %v into album %d", duplicateIds, albumId))
+
+		//
+		// execute
+		//
+
+		album, err = h.service.MergeAlbums(ctx, albumId, util.Convert(duplicateIds, func(id int64) model.MusicAlbumId { return model.MusicAlbumId(id) }))
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonAlbumResponse(album))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
 // //////////////////////////////////////////////////
 // decode
 
@@ -311,6 +492,16 @@ func toJsonAlbumsResponse(albums []*model.MusicAlbum) *JsonAlbumsResponse {
 	}
 }
 
+func toJsonAlbumPageResponse(page *model.MusicAlbumPage) *JsonAlbumPageResponse {
+	return &JsonAlbumPageResponse{
+		Success: true,
+		Albums:  util.Convert(page.Albums, toJsonAlbumLite),
+		Total:   page.Total,
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+	}
+}
+
 func toJsonAlbumLite(album *model.MusicAlbum) *JsonAlbumLite {
 	if album == nil {
 		return nil
@@ -320,6 +511,7 @@ func toJsonAlbumLite(album *model.MusicAlbum) *JsonAlbumLite {
 		DeezerId: int64(album.DeezerId),
 		Name:     album.Name,
 		ImgUrl:   string(album.ImgUrl),
+		NbMusic:  album.NbMusic,
 	}
 }
 
@@ -339,20 +531,54 @@ func toJsonAlbum(album *model.MusicAlbum) *JsonAlbum {
 		DeezerId: int64(album.DeezerId),
 		Name:     album.Name,
 		ImgUrl:   string(album.ImgUrl),
+		Genres:   util.Convert(album.Genres, toJsonGenreLite),
 		Musics:   util.Convert(album.Musics, toJsonMusicLite),
 	}
 }
 
+func toJsonAlbumDuplicatesResponse(duplicates []*model.MusicAlbumDuplicate) *JsonAlbumDuplicatesResponse {
+	return &JsonAlbumDuplicatesResponse{
+		Success:    true,
+		Duplicates: util.Convert(duplicates, toJsonAlbumDuplicate),
+	}
+}
+
+func toJsonAlbumDuplicate(duplicate *model.MusicAlbumDuplicate) *JsonAlbumDuplicate {
+	return &JsonAlbumDuplicate{
+		Similarity: duplicate.Similarity,
+		Albums:     util.Convert(duplicate.Albums, toJsonAlbumLite),
+	}
+}
+
+type JsonAlbumDuplicatesResponse struct {
+	Success    bool                  `json:"success,omitempty"`
+	Duplicates []*JsonAlbumDuplicate `json:"duplicates"`
+}
+
+type JsonAlbumDuplicate struct {
+	Similarity int              `json:"similarity"`
+	Albums     []*JsonAlbumLite `json:"albums,omitempty"`
+}
+
 type JsonAlbumsResponse struct {
 	Success bool             `json:"success,omitempty"`
 	Albums  []*JsonAlbumLite `json:"albums,omitempty"`
 }
 
+type JsonAlbumPageResponse struct {
+	Success bool             `json:"success,omitempty"`
+	Albums  []*JsonAlbumLite `json:"albums,omitempty"`
+	Total   int              `json:"total"`
+	Offset  int              `json:"offset,omitempty"`
+	Limit   int              `json:"limit,omitempty"`
+}
+
 type JsonAlbumLite struct {
 	Id       int64  `json:"id,omitempty"`
 	DeezerId int64  `json:"deezerId,omitempty"`
 	Name     string `json:"name,omitempty"`
 	ImgUrl   string `json:"imgUrl,omitempty"`
+	NbMusic  int    `json:"nbMusic,omitempty"`
 }
 
 type JsonAlbumResponse struct {
@@ -365,5 +591,6 @@ type JsonAlbum struct {
 	DeezerId int64            `json:"deezerId,omitempty"`
 	Name     string           `json:"name,omitempty"`
 	ImgUrl   string           `json:"imgUrl,omitempty"`
+	Genres   []*JsonGenreLite `json:"genres,omitempty"`
 	Musics   []*JsonMusicLite `json:"musics,omitempty"`
 }
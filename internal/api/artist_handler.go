@@ -34,6 +34,7 @@ type artistHandler struct {
 // register
 
 func (h *artistHandler) RegisterRoutes(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodGet, "/api/artist", h.handleListArtist)
 	router.HandlerFunc(http.MethodGet, "/api/artist/:artist_id", h.handleRetrieveArtist)
 
 	withArtistPermission := WithPermission(h.logger, h.sessionService, model.Permission_Music)
@@ -41,6 +42,70 @@ func (h *artistHandler) RegisterRoutes(router *httprouter.Router) {
 	router.HandlerFunc(http.MethodPut, "/api/artist/new", withArtistPermission(h.handleCreateArtist))
 	router.HandlerFunc(http.MethodPost, "/api/artist/:artist_id", withArtistPermission(h.handleUpdateArtist))
 	router.HandlerFunc(http.MethodDelete, "/api/artist/:artist_id", withArtistPermission(h.handleDeleteArtist))
+	router.HandlerFunc(http.MethodGet, "/api/artist-duplicate", withArtistPermission(h.handleSuggestArtistDuplicates))
+	router.HandlerFunc(http.MethodPost, "/api/artist/:artist_id/merge", withArtistPermission(h.handleMergeArtists))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (h *artistHandler) handleListArtist(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var filter *model.MusicArtistFilter
+	var page *model.MusicArtistPage
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		sort := model.ToMusicSort(extractParameter(req, "sort"))
+		if sort == "" && extractParameter(req, "sort") != "" {
+			err = model.ErrInvalidMusicSort
+			break
+		}
+
+		filter = model.NewMusicArtistFilter().
+			WithName(extractParameter(req, "name")).
+			WithSort(sort).
+			WithOffset(toInt(extractParameter(req, "offset"))).
+			WithLimit(model.ToMusicPageLimit(toInt(extractParameter(req, "limit"))))
+
+		h.logger.Info("[api] list artist", zap.Object("filter", filter))
+
+		//
+		// execute
+		//
+
+		page, err = h.service.ListArtist(ctx, filter)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonArtistPageResponse(page))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
 }
 
 // //////////////////////////////////////////////////
@@ -272,6 +337,125 @@ func (h *artistHandler) handleDeleteArtist(resp http.ResponseWriter, req *http.R
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
 
+// //////////////////////////////////////////////////
+// suggest duplicates
+
+func (h *artistHandler) handleSuggestArtistDuplicates(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var minSimilarity int
+	var duplicates []*model.MusicArtistDuplicate
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		minSimilarity = model.ToDuplicateSimilarity(toInt(extractParameter(req, "similarity")))
+		if minSimilarity == 0 {
+			err = model.ErrInvalidSimilarity
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] suggest artist duplicates ( similarity: %d%% )", minSimilarity))
+
+		//
+		// execute
+		//
+
+		duplicates, err = h.service.SuggestArtistDuplicates(ctx, minSimilarity)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonArtistDuplicatesResponse(duplicates))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// merge
+
+func (h *artistHandler) handleMergeArtists(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var artistId model.MusicArtistId
+	var duplicateIds []int64
+	var artist *model.MusicArtist
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		artistId = model.MusicArtistId(toInt64(extractPathParameter(req, "artist_id")))
+		if artistId == 0 {
+			err = model.ErrInvalidMusicArtistId
+			break
+		}
+		duplicateIds, err = extractDuplicateIdsFromBody(req)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] merge artists %v into artist %d", duplicateIds, artistId))
+
+		//
+		// execute
+		//
+
+		artist, err = h.service.MergeArtists(ctx, artistId, util.Convert(duplicateIds, func(id int64) model.MusicArtistId { return model.MusicArtistId(id) }))
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonArtistResponse(artist))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	if encodeMusicConflict(resp, err) {
+		return
+	}
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
 // //////////////////////////////////////////////////
 // decode
 
@@ -288,6 +472,24 @@ func extractArtistFromBody(req *http.Request) (*model.MusicArtist, error) {
 	return toArtist(jsonBody.Artist), nil
 }
 
+// extractDuplicateIdsFromBody decodes the ids of the duplicates to merge.
+func extractDuplicateIdsFromBody(req *http.Request) ([]int64, error) {
+	var jsonBody JsonMergeBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		return nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		return nil, model.ErrInvalidBody
+	}
+
+	return jsonBody.DuplicateIds, nil
+}
+
+type JsonMergeBody struct {
+	DuplicateIds []int64 `json:"duplicateIds,omitempty"`
+}
+
 type JsonArtistBody struct {
 	Artist *JsonArtistLite `json:"artist,omitempty"`
 }
@@ -311,6 +513,16 @@ func toJsonArtistsResponse(artists []*model.MusicArtist) *JsonArtistsResponse {
 	}
 }
 
+func toJsonArtistPageResponse(page *model.MusicArtistPage) *JsonArtistPageResponse {
+	return &JsonArtistPageResponse{
+		Success: true,
+		Artists: util.Convert(page.Artists, toJsonArtistLite),
+		Total:   page.Total,
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+	}
+}
+
 func toJsonArtistLite(artist *model.MusicArtist) *JsonArtistLite {
 	if artist == nil {
 		return nil
@@ -320,6 +532,7 @@ func toJsonArtistLite(artist *model.MusicArtist) *JsonArtistLite {
 		DeezerId: int64(artist.DeezerId),
 		Name:     artist.Name,
 		ImgUrl:   string(artist.ImgUrl),
+		NbMusic:  artist.NbMusic,
 	}
 }
 
@@ -343,16 +556,49 @@ func toJsonArtist(artist *model.MusicArtist) *JsonArtist {
 	}
 }
 
+func toJsonArtistDuplicatesResponse(duplicates []*model.MusicArtistDuplicate) *JsonArtistDuplicatesResponse {
+	return &JsonArtistDuplicatesResponse{
+		Success:    true,
+		Duplicates: util.Convert(duplicates, toJsonArtistDuplicate),
+	}
+}
+
+func toJsonArtistDuplicate(duplicate *model.MusicArtistDuplicate) *JsonArtistDuplicate {
+	return &JsonArtistDuplicate{
+		Similarity: duplicate.Similarity,
+		Artists:    util.Convert(duplicate.Artists, toJsonArtistLite),
+	}
+}
+
+type JsonArtistDuplicatesResponse struct {
+	Success    bool                   `json:"success,omitempty"`
+	Duplicates []*JsonArtistDuplicate `json:"duplicates"`
+}
+
+type JsonArtistDuplicate struct {
+	Similarity int               `json:"similarity"`
+	Artists    []*JsonArtistLite `json:"artists,omitempty"`
+}
+
 type JsonArtistsResponse struct {
 	Success bool              `json:"success,omitempty"`
 	Artists []*JsonArtistLite `json:"artists,omitempty"`
 }
 
+type JsonArtistPageResponse struct {
+	Success bool              `json:"success,omitempty"`
+	Artists []*JsonArtistLite `json:"artists,omitempty"`
+	Total   int               `json:"total"`
+	Offset  int               `json:"offset,omitempty"`
+	Limit   int               `json:"limit,omitempty"`
+}
+
 type JsonArtistLite struct {
 	Id       int64  `json:"id,omitempty"`
 	DeezerId int64  `json:"deezerId,omitempty"`
 	Name     string `json:"name,omitempty"`
 	ImgUrl   string `json:"imgUrl,omitempty"`
+	NbMusic  int    `json:"nbMusic,omitempty"`
 }
 
 type JsonArtistResponse struct {
@@ -0,0 +1,379 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// daily challenge handler
+
+func NewDailyChallengeHandler(logger *zap.Logger, dailyChallengeService service.DailyChallengeService, sessionService service.SessionService) Handler {
+	return &dailyChallengeHandler{
+		logger:                logger,
+		dailyChallengeService: dailyChallengeService,
+		sessionService:        sessionService,
+	}
+}
+
+type dailyChallengeHandler struct {
+	logger                *zap.Logger
+	dailyChallengeService service.DailyChallengeService
+	sessionService        service.SessionService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *dailyChallengeHandler) RegisterRoutes(router *httprouter.Router) {
+	withSession := WithSession(h.logger, h.sessionService)
+
+	router.HandlerFunc(http.MethodGet, "/api/daily-challenge", h.handleRetrieveDailyChallenge)
+	router.HandlerFunc(http.MethodPut, "/api/daily-challenge/run", withSession(h.handleSubmitDailyChallengeRun))
+	router.HandlerFunc(http.MethodGet, "/api/daily-challenge/leaderboard", h.handleListDailyChallengeLeaderboard)
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (h *dailyChallengeHandler) handleRetrieveDailyChallenge(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var date model.DailyChallengeDate
+	var challenge *model.DailyChallenge
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		date, err = extractDailyChallengeDate(req)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] retrieve daily challenge %q", date))
+
+		//
+		// execute
+		//
+
+		challenge, err = h.dailyChallengeService.RetrieveChallenge(ctx, date)
+		if err != nil {
+			break
+		}
+		if challenge == nil {
+			err = model.ErrDailyChallengeNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonDailyChallengeResponse(challenge))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// submit run
+
+func (h *dailyChallengeHandler) handleSubmitDailyChallengeRun(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var date model.DailyChallengeDate
+	var answers map[model.GameQuestionId]model.GameAnswerId
+	var run *model.DailyChallengeRun
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		date, answers, err = extractDailyChallengeRunFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] submit daily challenge run %s with %d answers", date, len(answers)))
+
+		//
+		// execute
+		//
+
+		run, err = h.dailyChallengeService.SubmitRun(ctx, date, answers)
+		if err != nil {
+			break
+		}
+		if run == nil {
+			err = model.ErrDailyChallengeRunNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonDailyChallengeRunResponse(run))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// leaderboard
+
+func (h *dailyChallengeHandler) handleListDailyChallengeLeaderboard(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var date model.DailyChallengeDate
+	var runs []*model.DailyChallengeRun
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		date, err = extractDailyChallengeDate(req)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] list daily challenge leaderboard %q", date))
+
+		//
+		// execute
+		//
+
+		runs, err = h.dailyChallengeService.ListLeaderboard(ctx, date)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonDailyChallengeLeaderboardResponse(date, runs))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// decode
+
+func extractDailyChallengeDate(req *http.Request) (model.DailyChallengeDate, error) {
+	value := extractParameter(req, "date")
+	if value == "" {
+		return "", nil
+	}
+	date := model.ToDailyChallengeDate(value)
+	if date == "" {
+		return "", model.ErrInvalidDailyChallengeDate
+	}
+	return date, nil
+}
+
+// extractDailyChallengeRunFromBody returns the date of the challenge played by the client along with its answers.
+func extractDailyChallengeRunFromBody(req *http.Request, logger *zap.Logger) (model.DailyChallengeDate, map[model.GameQuestionId]model.GameAnswerId, error) {
+	var jsonBody JsonDailyChallengeRunBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		logger.Info("failed to decode daily challenge run body: EOF")
+		return "", nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		logger.Info("failed to decode daily challenge run body", zap.Error(jsonErr))
+		return "", nil, model.ErrInvalidBody
+	case len(jsonBody.Answers) == 0:
+		logger.Info("failed to decode daily challenge run body: missing answers")
+		return "", nil, model.ErrInvalidBody
+	}
+
+	date := model.ToDailyChallengeDate(jsonBody.Date)
+	if date == "" {
+		logger.Info(fmt.Sprintf("failed to decode daily challenge run body: invalid date %q", jsonBody.Date))
+		return "", nil, model.ErrInvalidDailyChallengeDate
+	}
+
+	answers := make(map[model.GameQuestionId]model.GameAnswerId, len(jsonBody.Answers))
+	for questionId, answerId := range jsonBody.Answers {
+		answers[model.GameQuestionId(questionId)] = model.GameAnswerId(answerId)
+	}
+	return date, answers, nil
+}
+
+type JsonDailyChallengeRunBody struct {
+	Date    string          `json:"date,omitempty"`
+	Answers map[int64]int64 `json:"answers,omitempty"`
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func toJsonDailyChallengeResponse(challenge *model.DailyChallenge) *JsonDailyChallengeResponse {
+	return &JsonDailyChallengeResponse{
+		Success:   true,
+		Challenge: toJsonDailyChallenge(challenge),
+	}
+}
+
+// toJsonDailyChallenge only discloses what the player needs to play: no music details nor correct flags.
+func toJsonDailyChallenge(challenge *model.DailyChallenge) *JsonDailyChallenge {
+	jsonChallenge := &JsonDailyChallenge{
+		Date: challenge.Date.String(),
+	}
+	if challenge.Game != nil {
+		jsonChallenge.Questions = util.Convert(challenge.Game.Questions, toJsonDailyChallengeQuestion)
+	}
+	return jsonChallenge
+}
+
+func toJsonDailyChallengeQuestion(question *model.GameQuestion) *JsonDailyChallengeQuestion {
+	jsonQuestion := &JsonDailyChallengeQuestion{
+		Id:      int64(question.Id),
+		Theme:   toJsonGameTheme(question.Theme),
+		Media:   toJsonGameMedia(question.Media),
+		Answers: util.Convert(question.Answers, toJsonDailyChallengeAnswer),
+	}
+	if question.Music != nil {
+		jsonQuestion.Mp3Url = string(question.Music.Mp3Url)
+	}
+	return jsonQuestion
+}
+
+func toJsonDailyChallengeAnswer(answer *model.GameAnswer) *JsonGameAnswer {
+	return &JsonGameAnswer{
+		Id:   int64(answer.Id),
+		Text: answer.Text,
+	}
+}
+
+func toJsonDailyChallengeRunResponse(run *model.DailyChallengeRun) *JsonDailyChallengeRunResponse {
+	return &JsonDailyChallengeRunResponse{
+		Success: true,
+		Run:     toJsonDailyChallengeRun(run),
+	}
+}
+
+func toJsonDailyChallengeLeaderboardResponse(date model.DailyChallengeDate, runs []*model.DailyChallengeRun) *JsonDailyChallengeLeaderboardResponse {
+	if date == "" {
+		date = model.NewDailyChallengeDate(time.Now())
+	}
+	return &JsonDailyChallengeLeaderboardResponse{
+		Success: true,
+		Date:    date.String(),
+		Runs:    util.Convert(runs, toJsonDailyChallengeRun),
+	}
+}
+
+func toJsonDailyChallengeRun(run *model.DailyChallengeRun) *JsonDailyChallengeRun {
+	return &JsonDailyChallengeRun{
+		Id:          int64(run.Id),
+		Date:        run.Date.String(),
+		UserId:      int64(run.UserId),
+		UserName:    run.UserName,
+		Score:       run.Score,
+		NbCorrect:   run.NbCorrect,
+		SubmittedAt: run.SubmittedAt.Unix(),
+		Rank:        run.Rank,
+	}
+}
+
+type JsonDailyChallengeResponse struct {
+	Success   bool                `json:"success,omitempty"`
+	Challenge *JsonDailyChallenge `json:"challenge,omitempty"`
+}
+
+type JsonDailyChallenge struct {
+	Date      string                        `json:"date"`
+	Questions []*JsonDailyChallengeQuestion `json:"questions,omitempty"`
+}
+
+type JsonDailyChallengeQuestion struct {
+	Id      int64             `json:"id"`
+	Theme   *JsonGameTheme    `json:"theme"`
+	Mp3Url  string            `json:"mp3Url,omitempty"`
+	Media   *JsonGameMedia    `json:"media,omitempty"`
+	Answers []*JsonGameAnswer `json:"answers,omitempty"`
+}
+
+type JsonDailyChallengeRunResponse struct {
+	Success bool                   `json:"success,omitempty"`
+	Run     *JsonDailyChallengeRun `json:"run,omitempty"`
+}
+
+type JsonDailyChallengeLeaderboardResponse struct {
+	Success bool                     `json:"success,omitempty"`
+	Date    string                   `json:"date"`
+	Runs    []*JsonDailyChallengeRun `json:"runs"`
+}
+
+type JsonDailyChallengeRun struct {
+	Id          int64  `json:"id"`
+	Date        string `json:"date"`
+	UserId      int64  `json:"userId"`
+	UserName    string `json:"userName"`
+	Score       int    `json:"score"`
+	NbCorrect   int    `json:"nbCorrect"`
+	SubmittedAt int64  `json:"submittedAt"`
+	Rank        int    `json:"rank,omitempty"`
+}
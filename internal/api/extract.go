@@ -21,10 +21,10 @@ func extractPathParameter(req *http.Request, name string) string {
 	return strings.Trim(params.ByName(name), " ")
 }
 
-// func toBool(value string) bool {
-// 	value = strings.ToLower(value)
-// 	return value == "true" || value == "1"
-// }
+func toBool(value string) bool {
+	value = strings.ToLower(value)
+	return value == "true" || value == "1"
+}
 
 func toStrings(values string) []string {
 	return util.Convert(
@@ -1,9 +1,15 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/gre-ory/amnezic-go/internal/model"
 	"github.com/gre-ory/amnezic-go/internal/service"
@@ -40,11 +46,14 @@ func (h *fileHandler) RegisterRoutes(router *httprouter.Router) {
 
 	router.ServeFiles("/static/music/*filepath", NewFilteredDirectory(h.musicFilter))
 	router.ServeFiles("/static/image/*filepath", NewFilteredDirectory(h.imageFilter))
+	router.HandlerFunc(http.MethodGet, "/static/excerpt/*filepath", h.handleExcerpt)
 
 	withSessionPermission := WithPermission(h.logger, h.sessionService, model.Permission_File)
 
 	router.HandlerFunc(http.MethodGet, "/file/music", withSessionPermission(h.handleListMusic))
 	router.HandlerFunc(http.MethodGet, "/file/image", withSessionPermission(h.handleListImage))
+	router.HandlerFunc(http.MethodPut, "/file/music", withSessionPermission(h.handleUploadMusic))
+	router.HandlerFunc(http.MethodPut, "/file/image", withSessionPermission(h.handleUploadImage))
 }
 
 // //////////////////////////////////////////////////
@@ -69,6 +78,63 @@ func (fs *FilteredDirectory) Open(name string) (http.File, error) {
 	return fs.Directory.Open(name)
 }
 
+// //////////////////////////////////////////////////
+// excerpt
+
+func (h *fileHandler) handleExcerpt(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var data []byte
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		path := extractPathParameter(req, "filepath")
+		excerpt := model.AudioExcerpt{
+			Start:    time.Duration(toInt64(extractParameter(req, "start_ms"))) * time.Millisecond,
+			Duration: time.Duration(toInt64(extractParameter(req, "duration_ms"))) * time.Millisecond,
+		}
+		h.logger.Info(fmt.Sprintf("[api] excerpt %q", path), zap.Object("excerpt", excerpt))
+
+		//
+		// execute
+		//
+
+		data, err = h.fileService.Excerpt(ctx, h.musicFilter, path, excerpt)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode response
+		//
+
+		resp.Header().Set("Content-Type", "audio/mpeg")
+		resp.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		resp.WriteHeader(http.StatusOK)
+		_, err = resp.Write(data)
+		if err != nil {
+			break
+		}
+		return
+
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
 // //////////////////////////////////////////////////
 // list
 
@@ -124,9 +190,109 @@ func (h *fileHandler) handleList(resp http.ResponseWriter, req *http.Request, fi
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
 
+// //////////////////////////////////////////////////
+// upload
+
+func (h *fileHandler) handleUploadMusic(resp http.ResponseWriter, req *http.Request) {
+	h.handleUpload(resp, req, h.musicFilter, model.MusicUploadMaxSize, h.fileService.UploadMusic)
+}
+
+func (h *fileHandler) handleUploadImage(resp http.ResponseWriter, req *http.Request) {
+	h.handleUpload(resp, req, h.imageFilter, model.ImageUploadMaxSize, h.fileService.UploadImage)
+}
+
+type uploadFunc func(ctx context.Context, filter *model.FileFilter, filename string, data []byte) (model.Url, error)
+
+func (h *fileHandler) handleUpload(resp http.ResponseWriter, req *http.Request, filter *model.FileFilter, maxSize int64, upload uploadFunc) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var url model.Url
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		req.Body = http.MaxBytesReader(resp, req.Body, maxSize+model.UploadRequestOverhead)
+		err = req.ParseMultipartForm(maxSize)
+		if err != nil {
+			var maxBytesErr *http.MaxBytesError
+			if errors.As(err, &maxBytesErr) {
+				encodeError(resp, http.StatusRequestEntityTooLarge, model.ErrFileTooLarge.Error())
+				return
+			}
+			err = model.ErrInvalidBody
+			break
+		}
+		file, header, formErr := req.FormFile("file")
+		if formErr != nil {
+			err = model.ErrInvalidBody
+			break
+		}
+		defer file.Close()
+		h.logger.Info(fmt.Sprintf("[api] upload file %q ( %d bytes )", header.Filename, header.Size))
+
+		var data []byte
+		data, err = io.ReadAll(file)
+		if err != nil {
+			break
+		}
+
+		//
+		// execute
+		//
+
+		url, err = upload(ctx, filter, header.Filename, data)
+		if errors.Is(err, model.ErrFileTooLarge) {
+			encodeError(resp, http.StatusRequestEntityTooLarge, err.Error())
+			return
+		}
+		if err != nil {
+			break
+		}
+
+		//
+		// encode response
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonUrlResponse(url))
+		if err != nil {
+			break
+		}
+		return
+
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
 // //////////////////////////////////////////////////
 // encode
 
+func toJsonUrlResponse(url model.Url) *JsonUrlResponse {
+	return &JsonUrlResponse{
+		Success: true,
+		Url:     string(url),
+	}
+}
+
+type JsonUrlResponse struct {
+	Success bool   `json:"success,omitempty"`
+	Url     string `json:"url"`
+}
+
 func toJsonUrlsResponse(urls []model.Url) *JsonUrlsResponse {
 	return &JsonUrlsResponse{
 		Success: true,
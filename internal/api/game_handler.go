@@ -3,6 +3,7 @@ package api
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"time"
 
@@ -16,26 +17,46 @@ import (
 // //////////////////////////////////////////////////
 // game handler
 
-func NewGamehandler(logger *zap.Logger, service service.GameService) Handler {
+func NewGamehandler(logger *zap.Logger, service service.GameService, gamePresetService service.GamePresetService, sessionService service.SessionService) Handler {
 	return &gameHandler{
-		logger:  logger,
-		service: service,
+		logger:            logger,
+		service:           service,
+		gamePresetService: gamePresetService,
+		sessionService:    sessionService,
 	}
 }
 
 type gameHandler struct {
-	logger  *zap.Logger
-	service service.GameService
+	logger            *zap.Logger
+	service           service.GameService
+	gamePresetService service.GamePresetService
+	sessionService    service.SessionService
 }
 
 // //////////////////////////////////////////////////
 // register
 
 func (h *gameHandler) RegisterRoutes(router *httprouter.Router) {
-	router.HandlerFunc(http.MethodPut, "/api/game/new", h.handleCreateGame)
-	router.HandlerFunc(http.MethodGet, "/api/game/:game_id", h.handleRetrieveGame)
-	router.HandlerFunc(http.MethodPost, "/api/game/:game_id", h.handleUpdateGame)
-	router.HandlerFunc(http.MethodDelete, "/api/game/:game_id", h.handleDeleteGame)
+	withOptionalSession := WithOptionalSession(h.logger, h.sessionService)
+	withGameOwner := WithGameOwner(h.logger, h.sessionService, h.service)
+
+	router.HandlerFunc(http.MethodPut, "/api/game/new", withOptionalSession(h.handleCreateGame))
+	router.HandlerFunc(http.MethodGet, "/api/game-preview", withOptionalSession(h.handlePreviewGame))
+	router.HandlerFunc(http.MethodGet, "/api/game/:game_id", withOptionalSession(h.handleRetrieveGame))
+	router.HandlerFunc(http.MethodPost, "/api/game/:game_id", withGameOwner(h.handleUpdateGame))
+	router.HandlerFunc(http.MethodDelete, "/api/game/:game_id", withGameOwner(h.handleDeleteGame))
+	router.HandlerFunc(http.MethodPut, "/api/game-answer/:game_id/:question_id/:player_id/:answer_id", withGameOwner(h.handleAnswerQuestion))
+	router.HandlerFunc(http.MethodPut, "/api/game-year/:game_id/:question_id/:player_id/:year", withGameOwner(h.handleAnswerYear))
+	router.HandlerFunc(http.MethodPut, "/api/game-slots/:game_id/:question_id/:player_id", withGameOwner(h.handleAnswerSlots))
+	router.HandlerFunc(http.MethodPut, "/api/game-joker/:game_id/:question_id/:player_id/:joker", withGameOwner(h.handleUseJoker))
+	router.HandlerFunc(http.MethodPut, "/api/game-reveal/:game_id/:question_id", withGameOwner(h.handleRevealQuestion))
+	router.HandlerFunc(http.MethodPut, "/api/game-finish/:game_id", withGameOwner(h.handleFinishGame))
+	router.HandlerFunc(http.MethodGet, "/api/game-timeline/:game_id", withGameOwner(h.handleRetrieveTimeline))
+	router.HandlerFunc(http.MethodGet, "/api/game-player/:game_id/:player_id", h.handleRetrievePlayerView)
+	router.HandlerFunc(http.MethodPut, "/api/game-player/:game_id", withGameOwner(h.handleAddPlayer))
+	router.HandlerFunc(http.MethodPost, "/api/game-player/:game_id/:player_id", withGameOwner(h.handleRenamePlayer))
+	router.HandlerFunc(http.MethodPut, "/api/game-player-active/:game_id/:player_id/:active", withGameOwner(h.handleSetPlayerActive))
+	router.HandlerFunc(http.MethodPut, "/api/game-score/:game_id/:player_id", withGameOwner(h.handleAdjustScore))
 }
 
 // //////////////////////////////////////////////////
@@ -56,51 +77,899 @@ func (h *gameHandler) handleCreateGame(resp http.ResponseWriter, req *http.Reque
 		// decode request
 		//
 
-		settings = model.GameSettings{
-			Seed:       time.Now().UnixMilli(),
-			NbQuestion: toInt(extractParameter(req, "nb_question")),
-			NbAnswer:   toInt(extractParameter(req, "nb_answer")),
-			NbPlayer:   toInt(extractParameter(req, "nb_player")),
-			Sources: util.Filter(
-				util.Convert(
-					toStrings(extractParameter(req, "sources")),
-					model.ToSource,
-				),
-				func(s model.Source) bool { return s != "" },
-			),
-			ThemeIds: util.Filter(
-				util.Convert(
-					toStrings(extractParameter(req, "theme_ids")),
-					model.ToThemeId,
-				),
-				func(id model.ThemeId) bool { return id != 0 },
-			),
-			DeezerPlaylistId: model.DeezerPlaylistId(toInt64(extractParameter(req, "deezer_playlist_id"))),
+		settings, err = h.extractGameSettings(req)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] create game with %d question(s), %d answer(s), %d player(s) and %d sources: %#v", settings.NbQuestion, settings.NbAnswer, settings.NbPlayer, len(settings.Sources), settings.Sources))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.CreateGame(ctx, settings)
+		if err != nil {
+			break
+		}
+		if game == nil {
+			err = model.ErrGameNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		// host token is only disclosed to the creator
+		jsonResponse := toJsonGameResponse(game)
+		jsonResponse.Game.HostToken = game.HostToken.String()
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(jsonResponse)
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// preview
+
+// handlePreviewGame takes the same parameters as the game creation, the rounds being sent as json "rounds" parameter.
+func (h *gameHandler) handlePreviewGame(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var settings model.GameSettings
+	var preview *model.GamePreview
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		settings, err = h.extractGameSettings(req)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] preview game with %d question(s), %d answer(s), %d player(s) and %d sources: %#v", settings.NbQuestion, settings.NbAnswer, settings.NbPlayer, len(settings.Sources), settings.Sources))
+
+		//
+		// execute
+		//
+
+		preview, err = h.service.PreviewGame(ctx, settings)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGamePreviewResponse(preview))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (h *gameHandler) handleRetrieveGame(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] retrieve game %d", gameId))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.RetrieveGame(ctx, gameId)
+		if err != nil {
+			break
+		}
+		if game == nil {
+			err = model.ErrGameNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (h *gameHandler) handleUpdateGame(resp http.ResponseWriter, req *http.Request) {
+
+	// ctx := req.Context()
+
+	var gameId model.GameId
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] update game %d", gameId))
+
+		//
+		// execute
+		//
+
+		// TODO
+		err = model.ErrNotImplemented
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// answer
+
+func (h *gameHandler) handleAnswerQuestion(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var questionId model.GameQuestionId
+	var playerId model.GamePlayerId
+	var answerId model.GameAnswerId
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		questionId = model.GameQuestionId(toInt64(extractPathParameter(req, "question_id")))
+		if questionId == 0 || questionId.Split() != gameId {
+			err = model.ErrGameQuestionNotFound
+			break
+		}
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		answerId = model.GameAnswerId(toInt64(extractPathParameter(req, "answer_id")))
+		if answerId == 0 {
+			err = model.ErrGameAnswerNotFound
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] player %d answers %d to question %d of game %d", playerId, answerId, questionId, gameId))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.AnswerQuestion(ctx, gameId, questionId, playerId, answerId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// answer year
+
+func (h *gameHandler) handleAnswerYear(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var questionId model.GameQuestionId
+	var playerId model.GamePlayerId
+	var year int
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		questionId = model.GameQuestionId(toInt64(extractPathParameter(req, "question_id")))
+		if questionId == 0 || questionId.Split() != gameId {
+			err = model.ErrGameQuestionNotFound
+			break
+		}
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		year = toInt(extractPathParameter(req, "year"))
+		if year <= 0 {
+			err = model.ErrInvalidGameYear
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] player %d guesses year %d to question %d of game %d", playerId, year, questionId, gameId))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.AnswerYear(ctx, gameId, questionId, playerId, year)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// answer slots
+
+func (h *gameHandler) handleAnswerSlots(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var questionId model.GameQuestionId
+	var playerId model.GamePlayerId
+	var slotAnswers []*model.GameSlotAnswer
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		questionId = model.GameQuestionId(toInt64(extractPathParameter(req, "question_id")))
+		if questionId == 0 || questionId.Split() != gameId {
+			err = model.ErrGameQuestionNotFound
+			break
+		}
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		slotAnswers, err = extractSlotAnswersFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] player %d answers %d parts to question %d of game %d", playerId, len(slotAnswers), questionId, gameId))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.AnswerSlots(ctx, gameId, questionId, playerId, slotAnswers)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+func extractSlotAnswersFromBody(req *http.Request, logger *zap.Logger) ([]*model.GameSlotAnswer, error) {
+	var jsonBody JsonGameSlotsBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		logger.Info("failed to decode slots body: EOF")
+		return nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		logger.Info("failed to decode slots body", zap.Error(jsonErr))
+		return nil, model.ErrInvalidBody
+	}
+	return util.Convert(jsonBody.Parts, func(jsonPart *JsonGameSlotAnswer) *model.GameSlotAnswer {
+		return &model.GameSlotAnswer{
+			Slot:     jsonPart.Slot,
+			AnswerId: model.GameAnswerId(jsonPart.AnswerId),
+			Text:     jsonPart.Text,
+		}
+	}), nil
+}
+
+type JsonGameSlotsBody struct {
+	Parts []*JsonGameSlotAnswer `json:"parts"`
+}
+
+// //////////////////////////////////////////////////
+// reveal
+
+func (h *gameHandler) handleRevealQuestion(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var questionId model.GameQuestionId
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		questionId = model.GameQuestionId(toInt64(extractPathParameter(req, "question_id")))
+		if questionId == 0 || questionId.Split() != gameId {
+			err = model.ErrGameQuestionNotFound
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] reveal question %d of game %d", questionId, gameId))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.RevealQuestion(ctx, gameId, questionId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// finish
+
+func (h *gameHandler) handleFinishGame(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] finish game %d", gameId))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.FinishGame(ctx, gameId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// player
+
+func (h *gameHandler) handleAddPlayer(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var name string
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		name, err = extractPlayerNameFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] add player %q to game %d", name, gameId))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.AddPlayer(ctx, gameId, name)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+func (h *gameHandler) handleRenamePlayer(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var playerId model.GamePlayerId
+	var name string
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		name, err = extractPlayerNameFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] rename player %d of game %d to %q", playerId, gameId, name))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.RenamePlayer(ctx, gameId, playerId, name)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+func (h *gameHandler) handleSetPlayerActive(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var playerId model.GamePlayerId
+	var active bool
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		active = toBool(extractPathParameter(req, "active"))
+		h.logger.Info(fmt.Sprintf("[api] set player %d of game %d active: %t", playerId, gameId, active))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.SetPlayerActive(ctx, gameId, playerId, active)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// score
+
+func (h *gameHandler) handleAdjustScore(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var playerId model.GamePlayerId
+	var adjustment *JsonGameScoreBody
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		adjustment, err = extractScoreAdjustmentFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] adjust score of player %d of game %d by %d: %q", playerId, gameId, adjustment.Points, adjustment.Reason))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.AdjustScore(ctx, gameId, playerId, adjustment.Points, adjustment.Reason)
+		if err != nil {
+			break
 		}
-		// CLEAN
-		if len(settings.Sources) == 0 {
-			h.logger.Info("[api] missing sources >>> FALLBACK to store")
-			settings.Sources = append(settings.Sources, model.Source_Store)
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		if err != nil {
+			break
 		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
 
-		err = settings.Validate()
-		if err != nil {
+func extractPlayerNameFromBody(req *http.Request, logger *zap.Logger) (string, error) {
+	var jsonBody JsonGamePlayerBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		logger.Info("failed to decode player body: EOF")
+		return "", model.ErrInvalidBody
+	case jsonErr != nil:
+		logger.Info("failed to decode player body", zap.Error(jsonErr))
+		return "", model.ErrInvalidBody
+	}
+	return jsonBody.Name, nil
+}
+
+func extractScoreAdjustmentFromBody(req *http.Request, logger *zap.Logger) (*JsonGameScoreBody, error) {
+	var jsonBody JsonGameScoreBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		logger.Info("failed to decode score body: EOF")
+		return nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		logger.Info("failed to decode score body", zap.Error(jsonErr))
+		return nil, model.ErrInvalidBody
+	}
+	return &jsonBody, nil
+}
+
+type JsonGamePlayerBody struct {
+	Name string `json:"name"`
+}
+
+type JsonGameScoreBody struct {
+	Points int    `json:"points"`
+	Reason string `json:"reason"`
+}
+
+// //////////////////////////////////////////////////
+// timeline
+
+func (h *gameHandler) handleRetrieveTimeline(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var asOf int
+	var timeline *model.GameTimeline
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
 			break
 		}
-		h.logger.Info(fmt.Sprintf("[api] create game with %d question(s), %d answer(s), %d player(s) and %d sources: %#v", settings.NbQuestion, settings.NbAnswer, settings.NbPlayer, len(settings.Sources), settings.Sources))
+		asOf = toInt(extractParameter(req, "as_of"))
+		h.logger.Info(fmt.Sprintf("[api] retrieve timeline of game %d as of %d", gameId, asOf))
 
 		//
 		// execute
 		//
 
-		game, err = h.service.CreateGame(ctx, settings)
+		timeline, err = h.service.RetrieveTimeline(ctx, gameId, asOf)
 		if err != nil {
 			break
 		}
-		if game == nil {
-			err = model.ErrGameNotFound
-			break
-		}
 
 		//
 		// encode success
@@ -108,7 +977,7 @@ func (h *gameHandler) handleCreateGame(resp http.ResponseWriter, req *http.Reque
 
 		resp.Header().Set("Content-Type", "application/json")
 		resp.WriteHeader(http.StatusOK)
-		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		err = json.NewEncoder(resp).Encode(toJsonGameTimelineResponse(timeline))
 		if err != nil {
 			break
 		}
@@ -124,13 +993,16 @@ func (h *gameHandler) handleCreateGame(resp http.ResponseWriter, req *http.Reque
 }
 
 // //////////////////////////////////////////////////
-// retrieve
+// joker
 
-func (h *gameHandler) handleRetrieveGame(resp http.ResponseWriter, req *http.Request) {
+func (h *gameHandler) handleUseJoker(resp http.ResponseWriter, req *http.Request) {
 
 	ctx := req.Context()
 
 	var gameId model.GameId
+	var questionId model.GameQuestionId
+	var playerId model.GamePlayerId
+	var jokerType model.GameJokerType
 	var game *model.Game
 	var err error
 
@@ -146,20 +1018,31 @@ func (h *gameHandler) handleRetrieveGame(resp http.ResponseWriter, req *http.Req
 			err = model.ErrInvalidGameId
 			break
 		}
-		h.logger.Info(fmt.Sprintf("[api] retrieve game %d", gameId))
+		questionId = model.GameQuestionId(toInt64(extractPathParameter(req, "question_id")))
+		if questionId == 0 || questionId.Split() != gameId {
+			err = model.ErrGameQuestionNotFound
+			break
+		}
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		jokerType = model.ToGameJokerType(extractPathParameter(req, "joker"))
+		if jokerType == "" {
+			err = model.ErrInvalidGameJokerType
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] player %d uses joker %s on question %d of game %d", playerId, jokerType, questionId, gameId))
 
 		//
 		// execute
 		//
 
-		game, err = h.service.RetrieveGame(ctx, gameId)
+		game, err = h.service.UseJoker(ctx, gameId, questionId, playerId, jokerType)
 		if err != nil {
 			break
 		}
-		if game == nil {
-			err = model.ErrGameNotFound
-			break
-		}
 
 		//
 		// encode success
@@ -183,14 +1066,15 @@ func (h *gameHandler) handleRetrieveGame(resp http.ResponseWriter, req *http.Req
 }
 
 // //////////////////////////////////////////////////
-// update
+// player view
 
-func (h *gameHandler) handleUpdateGame(resp http.ResponseWriter, req *http.Request) {
+func (h *gameHandler) handleRetrievePlayerView(resp http.ResponseWriter, req *http.Request) {
 
-	// ctx := req.Context()
+	ctx := req.Context()
 
 	var gameId model.GameId
-	var game *model.Game
+	var playerId model.GamePlayerId
+	var view *model.GamePlayerView
 	var err error
 
 	switch {
@@ -205,14 +1089,18 @@ func (h *gameHandler) handleUpdateGame(resp http.ResponseWriter, req *http.Reque
 			err = model.ErrInvalidGameId
 			break
 		}
-		h.logger.Info(fmt.Sprintf("[api] update game %d", gameId))
+		playerId = model.GamePlayerId(toInt64(extractPathParameter(req, "player_id")))
+		if playerId == 0 {
+			err = model.ErrGamePlayerNotFound
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] retrieve view of player %d for game %d", playerId, gameId))
 
 		//
 		// execute
 		//
 
-		// TODO
-		err = model.ErrNotImplemented
+		view, err = h.service.RetrievePlayerView(ctx, gameId, playerId)
 		if err != nil {
 			break
 		}
@@ -223,7 +1111,7 @@ func (h *gameHandler) handleUpdateGame(resp http.ResponseWriter, req *http.Reque
 
 		resp.Header().Set("Content-Type", "application/json")
 		resp.WriteHeader(http.StatusOK)
-		err = json.NewEncoder(resp).Encode(toJsonGameResponse(game))
+		err = json.NewEncoder(resp).Encode(toJsonGamePlayerViewResponse(view))
 		if err != nil {
 			break
 		}
@@ -292,6 +1180,194 @@ func (h *gameHandler) handleDeleteGame(resp http.ResponseWriter, req *http.Reque
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
 
+// //////////////////////////////////////////////////
+// decode
+
+// extractGameSettings starts from the optional preset and lets each provided parameter override it.
+func (h *gameHandler) extractGameSettings(req *http.Request) (model.GameSettings, error) {
+	var settings model.GameSettings
+
+	if presetId := extractParameter(req, "preset"); presetId != "" {
+		id := model.ToGamePresetId(presetId)
+		if id == 0 {
+			return settings, model.ErrInvalidGamePresetId
+		}
+		preset, err := h.gamePresetService.RetrieveGamePreset(req.Context(), id)
+		if err != nil {
+			return settings, err
+		}
+		h.logger.Info(fmt.Sprintf("[api] use game preset %d", id))
+		settings = preset.ToGameSettings()
+	}
+
+	settings.Seed = time.Now().UnixMilli()
+	if value := extractParameter(req, "nb_question"); value != "" {
+		settings.NbQuestion = toInt(value)
+	}
+	if value := extractParameter(req, "nb_answer"); value != "" {
+		settings.NbAnswer = toInt(value)
+	}
+	if value := extractParameter(req, "nb_player"); value != "" {
+		settings.NbPlayer = toInt(value)
+	}
+	if value := extractParameter(req, "sources"); value != "" {
+		settings.Sources = util.Filter(
+			util.Convert(
+				toStrings(value),
+				model.ToSource,
+			),
+			func(s model.Source) bool { return s != "" },
+		)
+	}
+	if value := extractParameter(req, "theme_ids"); value != "" {
+		settings.ThemeIds = util.Filter(
+			util.Convert(
+				toStrings(value),
+				model.ToThemeId,
+			),
+			func(id model.ThemeId) bool { return id != 0 },
+		)
+	}
+	if value := extractParameter(req, "deezer_playlist_id"); value != "" {
+		settings.DeezerPlaylistId = model.DeezerPlaylistId(toInt64(value))
+	}
+	if value := extractParameter(req, "decade"); value != "" {
+		settings.Decade = model.ToGameDecade(value)
+		if settings.Decade == 0 {
+			return settings, model.ErrInvalidGameDecade
+		}
+	}
+	if value := extractParameter(req, "genre"); value != "" {
+		settings.Genre = value
+	}
+
+	if value := extractParameter(req, "jokers"); value != "" {
+		settings.Jokers = util.Convert(toStrings(value), model.ToGameJokerSettings)
+	}
+
+	rounds, err := extractGameRounds(req, h.logger)
+	if err != nil {
+		return settings, err
+	}
+	if len(rounds) > 0 {
+		settings.Rounds = rounds
+	}
+
+	// CLEAN
+	if len(settings.Sources) == 0 {
+		h.logger.Info("[api] missing sources >>> FALLBACK to store")
+		settings.Sources = append(settings.Sources, model.Source_Store)
+	}
+	for _, round := range settings.Rounds {
+		round.ApplyDefaults()
+	}
+	return settings, settings.Validate()
+}
+
+// extractGameRounds decodes the optional rounds sent either as json "rounds" parameter ( e.g. for a GET ) or as json body.
+func extractGameRounds(req *http.Request, logger *zap.Logger) ([]*model.GameRoundSettings, error) {
+	if value := extractParameter(req, "rounds"); value != "" {
+		var jsonRounds []*JsonGameRoundSettings
+		if err := json.Unmarshal([]byte(value), &jsonRounds); err != nil {
+			logger.Info("failed to decode game rounds parameter", zap.Error(err))
+			return nil, model.ErrInvalidGameRounds
+		}
+		return util.Convert(jsonRounds, toGameRoundSettings), nil
+	}
+	return extractGameRoundsFromBody(req, logger)
+}
+
+// extractGameRoundsFromBody decodes the optional rounds sent as json body.
+func extractGameRoundsFromBody(req *http.Request, logger *zap.Logger) ([]*model.GameRoundSettings, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	var jsonBody JsonGameRoundsBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		return nil, nil
+	case jsonErr != nil:
+		logger.Info("failed to decode game rounds body", zap.Error(jsonErr))
+		return nil, model.ErrInvalidBody
+	}
+	return util.Convert(jsonBody.Rounds, toGameRoundSettings), nil
+}
+
+func toGameSettings(jsonSettings *JsonGameSettings) *model.GameSettings {
+	if jsonSettings == nil {
+		return nil
+	}
+	return &model.GameSettings{
+		NbQuestion:       jsonSettings.NbQuestion,
+		NbAnswer:         jsonSettings.NbAnswer,
+		NbPlayer:         jsonSettings.NbPlayer,
+		Sources:          util.Filter(util.Convert(jsonSettings.Sources, model.ToSource), func(s model.Source) bool { return s != "" }),
+		ThemeIds:         util.Convert(jsonSettings.ThemeIds, func(id int64) model.ThemeId { return model.ThemeId(id) }),
+		DeezerPlaylistId: model.DeezerPlaylistId(jsonSettings.DeezerPlaylistId),
+		Decade:           model.ToGameDecade(jsonSettings.Decade),
+		Genre:            jsonSettings.Genre,
+		Rounds:           util.Convert(jsonSettings.Rounds, toGameRoundSettings),
+		Jokers:           util.Convert(jsonSettings.Jokers, toGameJokerSettings),
+	}
+}
+
+func toGameJokerSettings(jsonJoker *JsonGameJokerSettings) *model.GameJokerSettings {
+	return &model.GameJokerSettings{
+		Type:  model.ToGameJokerType(jsonJoker.Type),
+		Count: jsonJoker.Count,
+		Cost:  jsonJoker.Cost,
+	}
+}
+
+func toGameRoundSettings(jsonRound *JsonGameRoundSettings) *model.GameRoundSettings {
+	round := &model.GameRoundSettings{
+		Title:            jsonRound.Title,
+		NbQuestion:       jsonRound.NbQuestion,
+		NbAnswer:         jsonRound.NbAnswer,
+		Sources:          util.Filter(util.Convert(jsonRound.Sources, model.ToSource), func(s model.Source) bool { return s != "" }),
+		ThemeIds:         util.Convert(jsonRound.ThemeIds, func(id int64) model.ThemeId { return model.ThemeId(id) }),
+		DeezerPlaylistId: model.DeezerPlaylistId(jsonRound.DeezerPlaylistId),
+		Decade:           model.ToGameDecade(jsonRound.Decade),
+		Genre:            jsonRound.Genre,
+		QuestionType:     model.GameQuestionType(jsonRound.QuestionType),
+		MediaKind:        model.GameMediaKind(jsonRound.MediaKind),
+		Guess:            model.GameGuess(jsonRound.Guess),
+	}
+	if jsonRound.Scoring != nil {
+		round.Scoring = model.GameScoring{
+			Correct: jsonRound.Scoring.Correct,
+			Wrong:   jsonRound.Scoring.Wrong,
+		}
+	}
+	round.YearScoring = toGameYearScoring(jsonRound.YearScoring)
+	round.Slots = util.Convert(jsonRound.Slots, toGameSlotSettings)
+	return round
+}
+
+func toGameSlotSettings(jsonSlot *JsonGameSlotSettings) *model.GameSlotSettings {
+	return &model.GameSlotSettings{
+		Guess:    model.ToGameGuess(jsonSlot.Guess),
+		Points:   jsonSlot.Points,
+		FreeText: jsonSlot.FreeText,
+	}
+}
+
+func toGameYearScoring(jsonSteps []*JsonGameYearStep) model.GameYearScoring {
+	return model.GameYearScoring{
+		Steps: util.Convert(jsonSteps, func(jsonStep *JsonGameYearStep) model.GameYearStep {
+			return model.GameYearStep{
+				Within: jsonStep.Within,
+				Points: jsonStep.Points,
+			}
+		}),
+	}
+}
+
+type JsonGameRoundsBody struct {
+	Rounds []*JsonGameRoundSettings `json:"rounds,omitempty"`
+}
+
 // //////////////////////////////////////////////////
 // encode
 
@@ -302,12 +1378,123 @@ func toJsonGameResponse(game *model.Game) *JsonGameResponse {
 	}
 }
 
+func toJsonGameTimelineResponse(timeline *model.GameTimeline) *JsonGameTimelineResponse {
+	return &JsonGameTimelineResponse{
+		Success: true,
+		AsOf:    timeline.AsOf,
+		Events:  util.Convert(timeline.Events, toJsonGameEvent),
+		Game:    toJsonGame(timeline.Game),
+	}
+}
+
+func toJsonGameEvent(event *model.GameEvent) *JsonGameEvent {
+	return &JsonGameEvent{
+		Sequence:      event.Sequence,
+		Type:          event.Type.String(),
+		OccurredAt:    event.OccurredAt.Unix(),
+		UserId:        event.UserId.ToInt64(),
+		PlayerId:      int64(event.PlayerId),
+		PlayerName:    event.PlayerName,
+		QuestionId:    int64(event.QuestionId),
+		AnswerId:      int64(event.AnswerId),
+		Joker:         event.JokerType.String(),
+		Year:          event.Year,
+		Parts:         util.Convert(event.Parts, toJsonGameSlotAnswer),
+		Points:        event.Points,
+		Reason:        event.Reason,
+		PreviousPhase: event.PreviousPhase.String(),
+		Phase:         event.Phase.String(),
+	}
+}
+
 func toJsonGame(game *model.Game) *JsonGame {
-	return &JsonGame{
-		Id:        int64(game.Id),
-		Settings:  toJsonGameSettings(game.Settings),
-		Players:   util.Convert(game.Players, toJsonGamePlayer),
-		Questions: util.Convert(game.Questions, toJsonGameQuestion),
+	jsonGame := &JsonGame{
+		Id:          int64(game.Id),
+		OwnerId:     game.OwnerId.ToInt64(),
+		Settings:    toJsonGameSettings(game.Settings),
+		Rounds:      util.Convert(game.Rounds, toJsonGameRound),
+		Players:     util.Convert(game.Players, toJsonGamePlayer),
+		Questions:   util.Convert(game.Questions, toJsonGameQuestion),
+		Result:      toJsonGameResult(game.Result()),
+		Adjustments: util.Convert(game.Adjustments, toJsonGameScoreAdjustment),
+		Finished:    game.Finished,
+	}
+	for _, jsonPlayer := range jsonGame.Players {
+		jsonPlayer.Jokers = toJsonRemainingJokers(game.RemainingJokers(model.GamePlayerId(jsonPlayer.Id)))
+	}
+	return jsonGame
+}
+
+func toJsonGameScoreAdjustment(adjustment *model.GameScoreAdjustment) *JsonGameScoreAdjustment {
+	return &JsonGameScoreAdjustment{
+		PlayerId: int64(adjustment.PlayerId),
+		Points:   adjustment.Points,
+		Reason:   adjustment.Reason,
+	}
+}
+
+func toJsonRemainingJokers(remaining map[model.GameJokerType]int) map[string]int {
+	if len(remaining) == 0 {
+		return nil
+	}
+	jsonRemaining := make(map[string]int, len(remaining))
+	for jokerType, count := range remaining {
+		jsonRemaining[jokerType.String()] = count
+	}
+	return jsonRemaining
+}
+
+func toJsonGameRound(round *model.GameRound) *JsonGameRound {
+	return &JsonGameRound{
+		Number:       round.Number,
+		Title:        round.Title,
+		QuestionType: round.QuestionType.String(),
+		Scoring:      toJsonGameScoring(round.Scoring),
+		MediaKind:    round.MediaKind.String(),
+		Guess:        round.Guess.String(),
+		YearScoring:  toJsonGameYearScoring(round.YearScoring),
+		Slots:        util.Convert(round.Slots, toJsonGameSlotSettings),
+	}
+}
+
+func toJsonGameScoring(scoring model.GameScoring) *JsonGameScoring {
+	return &JsonGameScoring{
+		Correct: scoring.Correct,
+		Wrong:   scoring.Wrong,
+	}
+}
+
+func toJsonGameSlotSettings(slot *model.GameSlotSettings) *JsonGameSlotSettings {
+	return &JsonGameSlotSettings{
+		Guess:    slot.Guess.String(),
+		Points:   slot.Points,
+		FreeText: slot.FreeText,
+	}
+}
+
+func toJsonGameYearScoring(scoring model.GameYearScoring) []*JsonGameYearStep {
+	return util.Convert(scoring.Steps, func(step model.GameYearStep) *JsonGameYearStep {
+		return &JsonGameYearStep{
+			Within: step.Within,
+			Points: step.Points,
+		}
+	})
+}
+
+func toJsonGameResult(result *model.GameResult) *JsonGameResult {
+	return &JsonGameResult{
+		Players: util.Convert(result.Players, toJsonGamePlayerResult),
+	}
+}
+
+func toJsonGamePlayerResult(playerResult *model.GamePlayerResult) *JsonGamePlayerResult {
+	return &JsonGamePlayerResult{
+		PlayerId:    int64(playerResult.PlayerId),
+		Name:        playerResult.Name,
+		RoundScores: playerResult.RoundScores,
+		Score:       playerResult.Score,
+		NbCorrect:   playerResult.NbCorrect,
+		NbPartial:   playerResult.NbPartial,
 	}
 }
 
@@ -320,6 +1507,37 @@ func toJsonGameSettings(settings *model.GameSettings) *JsonGameSettings {
 		Sources:          util.Convert(settings.Sources, model.Source.String),
 		ThemeIds:         util.Convert(settings.ThemeIds, model.ThemeId.ToInt64),
 		DeezerPlaylistId: int64(settings.DeezerPlaylistId),
+		Decade:           settings.Decade.String(),
+		Genre:            settings.Genre,
+		Rounds:           util.Convert(settings.Rounds, toJsonGameRoundSettings),
+		Jokers:           util.Convert(settings.Jokers, toJsonGameJokerSettings),
+	}
+}
+
+func toJsonGameJokerSettings(joker *model.GameJokerSettings) *JsonGameJokerSettings {
+	return &JsonGameJokerSettings{
+		Type:  joker.Type.String(),
+		Count: joker.Count,
+		Cost:  joker.Cost,
+	}
+}
+
+func toJsonGameRoundSettings(round *model.GameRoundSettings) *JsonGameRoundSettings {
+	return &JsonGameRoundSettings{
+		Title:            round.Title,
+		NbQuestion:       round.NbQuestion,
+		NbAnswer:         round.NbAnswer,
+		Sources:          util.Convert(round.Sources, model.Source.String),
+		ThemeIds:         util.Convert(round.ThemeIds, model.ThemeId.ToInt64),
+		DeezerPlaylistId: int64(round.DeezerPlaylistId),
+		Decade:           round.Decade.String(),
+		Genre:            round.Genre,
+		QuestionType:     round.QuestionType.String(),
+		Scoring:          toJsonGameScoring(round.Scoring),
+		MediaKind:        round.MediaKind.String(),
+		Guess:            round.Guess.String(),
+		YearScoring:      toJsonGameYearScoring(round.YearScoring),
+		Slots:            util.Convert(round.Slots, toJsonGameSlotSettings),
 	}
 }
 
@@ -334,10 +1552,103 @@ func toJsonGamePlayer(player *model.GamePlayer) *JsonGamePlayer {
 
 func toJsonGameQuestion(question *model.GameQuestion) *JsonGameQuestion {
 	return &JsonGameQuestion{
+		Id:            int64(question.Id),
+		Round:         question.Round,
+		Theme:         toJsonGameTheme(question.Theme),
+		Music:         toJsonMusic(question.Music),
+		Media:         toJsonGameMedia(question.Media),
+		Excerpt:       toJsonAudioExcerpt(question.Excerpt),
+		Year:          question.Year,
+		Answers:       util.Convert(question.Answers, toJsonGameAnswer),
+		Slots:         util.Convert(question.Slots, toJsonGameAnswerSlot),
+		PlayerAnswers: util.Convert(question.PlayerAnswers, toJsonGamePlayerAnswer),
+		PlayerJokers:  util.Convert(question.PlayerJokers, toJsonGamePlayerJoker),
+		Revealed:      question.Revealed,
+	}
+}
+
+func toJsonGameAnswerSlot(slot *model.GameAnswerSlot) *JsonGameAnswerSlot {
+	return &JsonGameAnswerSlot{
+		Number:   slot.Number,
+		Guess:    slot.Guess.String(),
+		Points:   slot.Points,
+		Answers:  util.Convert(slot.Answers, toJsonGameAnswer),
+		Expected: slot.Expected,
+	}
+}
+
+func toJsonGameMedia(media *model.GameMedia) *JsonGameMedia {
+	if media == nil {
+		return nil
+	}
+	return &JsonGameMedia{
+		Kind:   media.Kind.String(),
+		Mp3Url: string(media.Mp3Url),
+		ImgUrl: string(media.ImgUrl),
+	}
+}
+
+func toJsonGamePlayerJoker(playerJoker *model.GamePlayerJoker) *JsonGamePlayerJoker {
+	return &JsonGamePlayerJoker{
+		PlayerId:         int64(playerJoker.PlayerId),
+		Type:             playerJoker.Type.String(),
+		Cost:             playerJoker.Cost,
+		RemovedAnswerIds: util.Convert(playerJoker.RemovedAnswerIds, func(id model.GameAnswerId) int64 { return int64(id) }),
+	}
+}
+
+func toJsonGamePlayerViewResponse(view *model.GamePlayerView) *JsonGamePlayerViewResponse {
+	return &JsonGamePlayerViewResponse{
+		Success: true,
+		View:    toJsonGamePlayerView(view),
+	}
+}
+
+func toJsonGamePlayerView(view *model.GamePlayerView) *JsonGamePlayerView {
+	return &JsonGamePlayerView{
+		GameId:    int64(view.GameId),
+		Player:    toJsonGamePlayer(view.Player),
+		Rounds:    util.Convert(view.Rounds, toJsonGameRound),
+		Jokers:    toJsonRemainingJokers(view.RemainingJokers),
+		Questions: util.Convert(view.Questions, toJsonGamePlayerQuestionView),
+	}
+}
+
+func toJsonGamePlayerQuestionView(question *model.GamePlayerQuestionView) *JsonGamePlayerQuestionView {
+	jsonQuestion := &JsonGamePlayerQuestionView{
 		Id:      int64(question.Id),
+		Round:   question.Round,
 		Theme:   toJsonGameTheme(question.Theme),
-		Music:   toJsonMusic(question.Music),
 		Answers: util.Convert(question.Answers, toJsonGameAnswer),
+		Slots:   util.Convert(question.Slots, toJsonGameAnswerSlot),
+		Jokers:  util.Convert(question.Jokers, model.GameJokerType.String),
+		Skipped: question.Skipped,
+	}
+	if question.PlayerAnswer != nil {
+		jsonQuestion.PlayerAnswer = toJsonGamePlayerAnswer(question.PlayerAnswer)
+	}
+	return jsonQuestion
+}
+
+func toJsonGamePlayerAnswer(playerAnswer *model.GamePlayerAnswer) *JsonGamePlayerAnswer {
+	return &JsonGamePlayerAnswer{
+		Id:       int64(playerAnswer.Id),
+		PlayerId: int64(playerAnswer.PlayerId),
+		AnswerId: int64(playerAnswer.AnswerId),
+		Year:     playerAnswer.Year,
+		Parts:    util.Convert(playerAnswer.Parts, toJsonGameSlotAnswer),
+		Correct:  playerAnswer.Correct,
+		Points:   playerAnswer.Points,
+	}
+}
+
+func toJsonGameSlotAnswer(part *model.GameSlotAnswer) *JsonGameSlotAnswer {
+	return &JsonGameSlotAnswer{
+		Slot:     part.Slot,
+		AnswerId: int64(part.AnswerId),
+		Text:     part.Text,
+		Correct:  part.Correct,
+		Points:   part.Points,
 	}
 }
 
@@ -357,16 +1668,172 @@ func toJsonGameAnswer(answer *model.GameAnswer) *JsonGameAnswer {
 	}
 }
 
+func toJsonGamePreviewResponse(preview *model.GamePreview) *JsonGamePreviewResponse {
+	return &JsonGamePreviewResponse{
+		Success: true,
+		Preview: toJsonGamePreview(preview),
+	}
+}
+
+func toJsonGamePreview(preview *model.GamePreview) *JsonGamePreview {
+	return &JsonGamePreview{
+		Fillable:   preview.IsFillable(),
+		NbQuestion: preview.NbQuestion,
+		Settings:   toJsonGameSettings(preview.Settings),
+		Rounds:     util.Convert(preview.Rounds, toJsonGameRoundPreview),
+		Warnings:   preview.Warnings,
+	}
+}
+
+func toJsonGameRoundPreview(round *model.GameRoundPreview) *JsonGameRoundPreview {
+	return &JsonGameRoundPreview{
+		Number:     round.Number,
+		Title:      round.Settings.Title,
+		NbQuestion: round.NbQuestion,
+		Sources:    util.Convert(round.Sources, toJsonGameSourcePreview),
+	}
+}
+
+func toJsonGameSourcePreview(source *model.GameSourcePreview) *JsonGameSourcePreview {
+	return &JsonGameSourcePreview{
+		Source:     source.Source.String(),
+		NbQuestion: source.NbQuestion,
+		NbAnswer:   source.NbAnswer,
+		Themes:     util.Convert(source.Themes, toJsonGameThemePreview),
+	}
+}
+
+func toJsonGameThemePreview(theme *model.GameThemePreview) *JsonGameThemePreview {
+	return &JsonGameThemePreview{
+		Id:         theme.Id,
+		Title:      theme.Title,
+		NbQuestion: theme.NbQuestion,
+		NbAnswer:   theme.NbAnswer,
+	}
+}
+
+type JsonGamePreviewResponse struct {
+	Success bool             `json:"success,omitempty"`
+	Preview *JsonGamePreview `json:"preview,omitempty"`
+}
+
+type JsonGamePreview struct {
+	Fillable   bool                    `json:"fillable"`
+	NbQuestion int                     `json:"nbQuestion"`
+	Settings   *JsonGameSettings       `json:"settings,omitempty"`
+	Rounds     []*JsonGameRoundPreview `json:"rounds,omitempty"`
+	Warnings   []string                `json:"warnings,omitempty"`
+}
+
+type JsonGameRoundPreview struct {
+	Number     int                      `json:"number"`
+	Title      string                   `json:"title,omitempty"`
+	NbQuestion int                      `json:"nbQuestion"`
+	Sources    []*JsonGameSourcePreview `json:"sources,omitempty"`
+}
+
+type JsonGameSourcePreview struct {
+	Source     string                  `json:"source"`
+	NbQuestion int                     `json:"nbQuestion"`
+	NbAnswer   int                     `json:"nbAnswer"`
+	Themes     []*JsonGameThemePreview `json:"themes,omitempty"`
+}
+
+type JsonGameThemePreview struct {
+	Id         int64  `json:"id,omitempty"`
+	Title      string `json:"title,omitempty"`
+	NbQuestion int    `json:"nbQuestion"`
+	NbAnswer   int    `json:"nbAnswer"`
+}
+
+type JsonGameTimelineResponse struct {
+	Success bool             `json:"success,omitempty"`
+	AsOf    int              `json:"asOf,omitempty"`
+	Events  []*JsonGameEvent `json:"events,omitempty"`
+	Game    *JsonGame        `json:"game,omitempty"`
+}
+
+type JsonGameEvent struct {
+	Sequence   int                   `json:"sequence"`
+	Type       string                `json:"type"`
+	OccurredAt int64                 `json:"occurredAt"`
+	UserId     int64                 `json:"userId,omitempty"`
+	PlayerId   int64                 `json:"playerId,omitempty"`
+	PlayerName string                `json:"playerName,omitempty"`
+	QuestionId int64                 `json:"questionId,omitempty"`
+	AnswerId   int64                 `json:"answerId,omitempty"`
+	Year       int                   `json:"year,omitempty"`
+	Parts      []*JsonGameSlotAnswer `json:"parts,omitempty"`
+	Joker      string                `json:"joker,omitempty"`
+	Points     int                   `json:"points,omitempty"`
+	Reason     string                `json:"reason,omitempty"`
+
+	PreviousPhase string `json:"previousPhase,omitempty"`
+	Phase         string `json:"phase,omitempty"`
+}
+
 type JsonGameResponse struct {
 	Success bool      `json:"success,omitempty"`
 	Game    *JsonGame `json:"game,omitempty"`
 }
 
 type JsonGame struct {
-	Id        int64               `json:"id,omitempty"`
-	Settings  *JsonGameSettings   `json:"settings,omitempty"`
-	Players   []*JsonGamePlayer   `json:"players,omitempty"`
-	Questions []*JsonGameQuestion `json:"questions,omitempty"`
+	Id          int64                      `json:"id,omitempty"`
+	OwnerId     int64                      `json:"ownerId,omitempty"`
+	HostToken   string                     `json:"hostToken,omitempty"`
+	Settings    *JsonGameSettings          `json:"settings,omitempty"`
+	Rounds      []*JsonGameRound           `json:"rounds,omitempty"`
+	Players     []*JsonGamePlayer          `json:"players,omitempty"`
+	Questions   []*JsonGameQuestion        `json:"questions,omitempty"`
+	Result      *JsonGameResult            `json:"result,omitempty"`
+	Adjustments []*JsonGameScoreAdjustment `json:"adjustments,omitempty"`
+	Finished    bool                       `json:"finished,omitempty"`
+}
+
+type JsonGameScoreAdjustment struct {
+	PlayerId int64  `json:"playerId"`
+	Points   int    `json:"points"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+type JsonGameRound struct {
+	Number       int                     `json:"number"`
+	Title        string                  `json:"title,omitempty"`
+	QuestionType string                  `json:"questionType,omitempty"`
+	Scoring      *JsonGameScoring        `json:"scoring,omitempty"`
+	MediaKind    string                  `json:"mediaKind,omitempty"`
+	Guess        string                  `json:"guess,omitempty"`
+	YearScoring  []*JsonGameYearStep     `json:"yearScoring,omitempty"`
+	Slots        []*JsonGameSlotSettings `json:"slots,omitempty"`
+}
+
+type JsonGameScoring struct {
+	Correct int `json:"correct"`
+	Wrong   int `json:"wrong"`
+}
+
+type JsonGameYearStep struct {
+	Within int `json:"within"`
+	Points int `json:"points"`
+}
+
+type JsonGameSlotSettings struct {
+	Guess    string `json:"guess"`
+	Points   int    `json:"points"`
+	FreeText bool   `json:"freeText,omitempty"`
+}
+
+type JsonGameResult struct {
+	Players []*JsonGamePlayerResult `json:"players,omitempty"`
+}
+
+type JsonGamePlayerResult struct {
+	PlayerId    int64  `json:"playerId"`
+	Name        string `json:"name,omitempty"`
+	RoundScores []int  `json:"roundScores,omitempty"`
+	Score       int    `json:"score"`
+	NbCorrect   int    `json:"nbCorrect"`
+	NbPartial   int    `json:"nbPartial,omitempty"`
 }
 
 type JsonGameSettings struct {
@@ -377,20 +1844,120 @@ type JsonGameSettings struct {
 	Sources          []string `json:"sources,omitempty"`
 	ThemeIds         []int64  `json:"theme_ids,omitempty"`
 	DeezerPlaylistId int64    `json:"deezer_playlist_id,omitempty"`
+	Decade           string   `json:"decade,omitempty"`
+	Genre            string   `json:"genre,omitempty"`
+
+	Rounds []*JsonGameRoundSettings `json:"rounds,omitempty"`
+	Jokers []*JsonGameJokerSettings `json:"jokers,omitempty"`
+}
+
+type JsonGameJokerSettings struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+	Cost  int    `json:"cost,omitempty"`
+}
+
+type JsonGameRoundSettings struct {
+	Title            string                  `json:"title,omitempty"`
+	NbQuestion       int                     `json:"nbQuestion,omitempty"`
+	NbAnswer         int                     `json:"nbAnswer,omitempty"`
+	Sources          []string                `json:"sources,omitempty"`
+	ThemeIds         []int64                 `json:"theme_ids,omitempty"`
+	DeezerPlaylistId int64                   `json:"deezer_playlist_id,omitempty"`
+	Decade           string                  `json:"decade,omitempty"`
+	Genre            string                  `json:"genre,omitempty"`
+	QuestionType     string                  `json:"questionType,omitempty"`
+	Scoring          *JsonGameScoring        `json:"scoring,omitempty"`
+	MediaKind        string                  `json:"mediaKind,omitempty"`
+	Guess            string                  `json:"guess,omitempty"`
+	YearScoring      []*JsonGameYearStep     `json:"yearScoring,omitempty"`
+	Slots            []*JsonGameSlotSettings `json:"slots,omitempty"`
 }
 
 type JsonGamePlayer struct {
-	Id     int64  `json:"id,omitempty"`
-	Name   string `json:"name,omitempty"`
-	Active bool   `json:"active,omitempty"`
-	Score  int    `json:"score,omitempty"`
+	Id     int64          `json:"id,omitempty"`
+	Name   string         `json:"name,omitempty"`
+	Active bool           `json:"active,omitempty"`
+	Score  int            `json:"score,omitempty"`
+	Jokers map[string]int `json:"jokers,omitempty"`
 }
 
 type JsonGameQuestion struct {
-	Id      int64             `json:"id"`
-	Theme   *JsonGameTheme    `json:"theme"`
-	Music   *JsonMusic        `json:"music"`
-	Answers []*JsonGameAnswer `json:"answers,omitempty"`
+	Id            int64                   `json:"id"`
+	Round         int                     `json:"round,omitempty"`
+	Theme         *JsonGameTheme          `json:"theme"`
+	Music         *JsonMusic              `json:"music"`
+	Media         *JsonGameMedia          `json:"media,omitempty"`
+	Excerpt       *JsonAudioExcerpt       `json:"excerpt,omitempty"`
+	Year          int                     `json:"year,omitempty"`
+	Answers       []*JsonGameAnswer       `json:"answers,omitempty"`
+	Slots         []*JsonGameAnswerSlot   `json:"slots,omitempty"`
+	PlayerAnswers []*JsonGamePlayerAnswer `json:"playerAnswers,omitempty"`
+	PlayerJokers  []*JsonGamePlayerJoker  `json:"playerJokers,omitempty"`
+	Revealed      bool                    `json:"revealed,omitempty"`
+}
+
+type JsonGameAnswerSlot struct {
+	Number   int               `json:"number"`
+	Guess    string            `json:"guess"`
+	Points   int               `json:"points"`
+	Answers  []*JsonGameAnswer `json:"answers,omitempty"`
+	Expected string            `json:"expected,omitempty"`
+}
+
+type JsonGameMedia struct {
+	Kind   string `json:"kind"`
+	Mp3Url string `json:"mp3Url,omitempty"`
+	ImgUrl string `json:"imgUrl,omitempty"`
+}
+
+type JsonGamePlayerJoker struct {
+	PlayerId         int64   `json:"playerId"`
+	Type             string  `json:"type"`
+	Cost             int     `json:"cost,omitempty"`
+	RemovedAnswerIds []int64 `json:"removedAnswerIds,omitempty"`
+}
+
+type JsonGamePlayerViewResponse struct {
+	Success bool                `json:"success,omitempty"`
+	View    *JsonGamePlayerView `json:"view,omitempty"`
+}
+
+type JsonGamePlayerView struct {
+	GameId    int64                         `json:"gameId"`
+	Player    *JsonGamePlayer               `json:"player,omitempty"`
+	Rounds    []*JsonGameRound              `json:"rounds,omitempty"`
+	Jokers    map[string]int                `json:"jokers,omitempty"`
+	Questions []*JsonGamePlayerQuestionView `json:"questions,omitempty"`
+}
+
+type JsonGamePlayerQuestionView struct {
+	Id           int64                 `json:"id"`
+	Round        int                   `json:"round,omitempty"`
+	Theme        *JsonGameTheme        `json:"theme"`
+	Answers      []*JsonGameAnswer     `json:"answers,omitempty"`
+	Slots        []*JsonGameAnswerSlot `json:"slots,omitempty"`
+	Jokers       []string              `json:"jokers,omitempty"`
+	Skipped      bool                  `json:"skipped,omitempty"`
+	PlayerAnswer *JsonGamePlayerAnswer `json:"playerAnswer,omitempty"`
+}
+
+type JsonGamePlayerAnswer struct {
+	Id       int64                 `json:"id"`
+	PlayerId int64                 `json:"playerId"`
+	AnswerId int64                 `json:"answerId,omitempty"`
+	Year     int                   `json:"year,omitempty"`
+	Parts    []*JsonGameSlotAnswer `json:"parts,omitempty"`
+	Correct  bool                  `json:"correct,omitempty"`
+	Points   int                   `json:"points"`
+}
+
+type JsonGameSlotAnswer struct {
+	Slot     int    `json:"slot"`
+	AnswerId int64  `json:"answerId,omitempty"`
+	Text     string `json:"text,omitempty"`
+	Correct  bool   `json:"correct,omitempty"`
+	Points   int    `json:"points,omitempty"`
 }
 
 type JsonGameTheme struct {
@@ -0,0 +1,167 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+const (
+	maxGamePackMemory = 32 << 20
+)
+
+// //////////////////////////////////////////////////
+// game pack handler
+
+func NewGamePackHandler(logger *zap.Logger, service service.GamePackService, gameService service.GameService, sessionService service.SessionService) Handler {
+	return &gamePackHandler{
+		logger:         logger,
+		service:        service,
+		gameService:    gameService,
+		sessionService: sessionService,
+	}
+}
+
+type gamePackHandler struct {
+	logger         *zap.Logger
+	service        service.GamePackService
+	gameService    service.GameService
+	sessionService service.SessionService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *gamePackHandler) RegisterRoutes(router *httprouter.Router) {
+	withGameOwner := WithGameOwner(h.logger, h.sessionService, h.gameService)
+	withFilePermission := WithPermission(h.logger, h.sessionService, model.Permission_File)
+
+	router.HandlerFunc(http.MethodGet, "/api/game-pack/:game_id", withGameOwner(h.handleExportGame))
+	router.HandlerFunc(http.MethodPut, "/api/game-pack/import", withFilePermission(h.handleImportGame))
+}
+
+// //////////////////////////////////////////////////
+// export
+
+func (h *gamePackHandler) handleExportGame(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var gameId model.GameId
+	var pack bytes.Buffer
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		gameId = model.GameId(toInt64(extractPathParameter(req, "game_id")))
+		if gameId == 0 {
+			err = model.ErrInvalidGameId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] export game %d", gameId))
+
+		//
+		// execute
+		//
+
+		err = h.service.ExportGame(ctx, gameId, &pack)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/zip")
+		resp.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"game-%d.zip\"", gameId))
+		resp.WriteHeader(http.StatusOK)
+		_, err = pack.WriteTo(resp)
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// import
+
+func (h *gamePackHandler) handleImportGame(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var game *model.Game
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		err = req.ParseMultipartForm(maxGamePackMemory)
+		if err != nil {
+			err = model.ErrInvalidGamePack
+			break
+		}
+		file, header, formErr := req.FormFile("pack")
+		if formErr != nil {
+			err = model.ErrInvalidGamePack
+			break
+		}
+		defer file.Close()
+		h.logger.Info(fmt.Sprintf("[api] import game pack %q ( %d bytes )", header.Filename, header.Size))
+
+		//
+		// execute
+		//
+
+		game, err = h.service.ImportGame(ctx, file, header.Size)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		// host token is only disclosed to the importer
+		jsonResponse := toJsonGameResponse(game)
+		jsonResponse.Game.HostToken = game.HostToken.String()
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(jsonResponse)
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
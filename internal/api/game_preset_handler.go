@@ -0,0 +1,430 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// game preset handler
+
+func NewGamePresetHandler(logger *zap.Logger, gamePresetService service.GamePresetService, sessionService service.SessionService) Handler {
+	return &gamePresetHandler{
+		logger:            logger,
+		gamePresetService: gamePresetService,
+		sessionService:    sessionService,
+	}
+}
+
+type gamePresetHandler struct {
+	logger            *zap.Logger
+	gamePresetService service.GamePresetService
+	sessionService    service.SessionService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *gamePresetHandler) RegisterRoutes(router *httprouter.Router) {
+	withOptionalSession := WithOptionalSession(h.logger, h.sessionService)
+	withSession := WithSession(h.logger, h.sessionService)
+
+	router.HandlerFunc(http.MethodGet, "/api/game-preset", withOptionalSession(h.handleListGamePreset))
+	router.HandlerFunc(http.MethodGet, "/api/game-preset/:preset_id", withOptionalSession(h.handleRetrieveGamePreset))
+	router.HandlerFunc(http.MethodPut, "/api/game-preset/new", withSession(h.handleCreateGamePreset))
+	router.HandlerFunc(http.MethodPost, "/api/game-preset/:preset_id", withSession(h.handleUpdateGamePreset))
+	router.HandlerFunc(http.MethodDelete, "/api/game-preset/:preset_id", withSession(h.handleDeleteGamePreset))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (h *gamePresetHandler) handleListGamePreset(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var presets []*model.GamePreset
+	var err error
+
+	switch {
+	default:
+
+		h.logger.Info("[api] list game presets")
+
+		//
+		// execute
+		//
+
+		presets, err = h.gamePresetService.ListGamePresets(ctx)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode response
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGamePresetsResponse(presets))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (h *gamePresetHandler) handleCreateGamePreset(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var preset *model.GamePreset
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		preset, _, err = extractGamePresetFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] create game preset %q", preset.Name))
+
+		//
+		// execute
+		//
+
+		preset, err = h.gamePresetService.CreateGamePreset(ctx, preset)
+		if err != nil {
+			break
+		}
+		if preset == nil {
+			err = model.ErrGamePresetNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGamePresetResponse(preset))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (h *gamePresetHandler) handleRetrieveGamePreset(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var presetId model.GamePresetId
+	var preset *model.GamePreset
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		presetId = model.ToGamePresetId(extractPathParameter(req, "preset_id"))
+		if presetId == 0 {
+			err = model.ErrInvalidGamePresetId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] retrieve game preset %d", presetId))
+
+		//
+		// execute
+		//
+
+		preset, err = h.gamePresetService.RetrieveGamePreset(ctx, presetId)
+		if err != nil {
+			break
+		}
+		if preset == nil {
+			err = model.ErrGamePresetNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGamePresetResponse(preset))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (h *gamePresetHandler) handleUpdateGamePreset(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var presetId model.GamePresetId
+	var preset *model.GamePreset
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		presetId = model.ToGamePresetId(extractPathParameter(req, "preset_id"))
+		if presetId == 0 {
+			err = model.ErrInvalidGamePresetId
+			break
+		}
+		var public *bool
+		preset, public, err = extractGamePresetFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		if preset.Id == 0 {
+			preset.Id = presetId
+		} else if preset.Id != presetId {
+			err = model.ErrInvalidGamePresetId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] update game preset %d", presetId))
+
+		//
+		// execute
+		//
+
+		preset, err = h.gamePresetService.UpdateGamePreset(ctx, preset, public)
+		if err != nil {
+			break
+		}
+		if preset == nil {
+			err = model.ErrGamePresetNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGamePresetResponse(preset))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (h *gamePresetHandler) handleDeleteGamePreset(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var presetId model.GamePresetId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		presetId = model.ToGamePresetId(extractPathParameter(req, "preset_id"))
+		if presetId == 0 {
+			err = model.ErrInvalidGamePresetId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] delete game preset %d", presetId))
+
+		//
+		// execute
+		//
+
+		err = h.gamePresetService.DeleteGamePreset(ctx, presetId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// decode
+
+// extractGamePresetFromBody also returns the public flag when present in the body.
+func extractGamePresetFromBody(req *http.Request, logger *zap.Logger) (*model.GamePreset, *bool, error) {
+	var jsonBody JsonGamePresetBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		logger.Info("failed to decode game preset body: EOF")
+		return nil, nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		logger.Info("failed to decode game preset body", zap.Error(jsonErr))
+		return nil, nil, model.ErrInvalidBody
+	case jsonBody.Preset == nil:
+		logger.Info("failed to decode game preset body: missing preset")
+		return nil, nil, model.ErrInvalidBody
+	}
+
+	return toGamePreset(jsonBody.Preset), jsonBody.Preset.Public, nil
+}
+
+func toGamePreset(jsonPreset *JsonGamePreset) *model.GamePreset {
+	preset := &model.GamePreset{
+		Id:       model.GamePresetId(jsonPreset.Id),
+		Name:     jsonPreset.Name,
+		Settings: toGameSettings(jsonPreset.Settings),
+	}
+	if jsonPreset.Public != nil {
+		preset.Public = *jsonPreset.Public
+	}
+	if settings := preset.Settings; settings != nil {
+		if len(settings.Sources) == 0 {
+			settings.Sources = append(settings.Sources, model.Source_Store)
+		}
+		for _, round := range settings.Rounds {
+			round.ApplyDefaults()
+		}
+	}
+	return preset
+}
+
+type JsonGamePresetBody struct {
+	Preset *JsonGamePreset `json:"preset,omitempty"`
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func toJsonGamePresetsResponse(presets []*model.GamePreset) *JsonGamePresetsResponse {
+	return &JsonGamePresetsResponse{
+		Success: true,
+		Presets: util.Convert(presets, toJsonGamePreset),
+	}
+}
+
+func toJsonGamePresetResponse(preset *model.GamePreset) *JsonGamePresetResponse {
+	return &JsonGamePresetResponse{
+		Success: true,
+		Preset:  toJsonGamePreset(preset),
+	}
+}
+
+func toJsonGamePreset(preset *model.GamePreset) *JsonGamePreset {
+	jsonPreset := &JsonGamePreset{
+		Id:      preset.Id.ToInt64(),
+		Name:    preset.Name,
+		OwnerId: preset.OwnerId.ToInt64(),
+		Public:  &preset.Public,
+	}
+	if preset.Settings != nil {
+		jsonPreset.Settings = toJsonGameSettings(preset.Settings)
+	}
+	return jsonPreset
+}
+
+type JsonGamePresetsResponse struct {
+	Success bool              `json:"success,omitempty"`
+	Presets []*JsonGamePreset `json:"presets,omitempty"`
+}
+
+type JsonGamePresetResponse struct {
+	Success bool            `json:"success,omitempty"`
+	Preset  *JsonGamePreset `json:"preset,omitempty"`
+}
+
+type JsonGamePreset struct {
+	Id       int64             `json:"id,omitempty"`
+	Name     string            `json:"name,omitempty"`
+	OwnerId  int64             `json:"ownerId,omitempty"`
+	Public   *bool             `json:"public,omitempty"`
+	Settings *JsonGameSettings `json:"settings,omitempty"`
+}
@@ -0,0 +1,694 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// genre handler
+
+func NewGenrehandler(logger *zap.Logger, service service.GenreService, sessionService service.SessionService) Handler {
+	return &genreHandler{
+		logger:         logger,
+		service:        service,
+		sessionService: sessionService,
+	}
+}
+
+type genreHandler struct {
+	logger         *zap.Logger
+	service        service.GenreService
+	sessionService service.SessionService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *genreHandler) RegisterRoutes(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodGet, "/api/genre", h.handleListGenre)
+	router.HandlerFunc(http.MethodGet, "/api/genre/:genre_id", h.handleRetrieveGenre)
+
+	withGenrePermission := WithPermission(h.logger, h.sessionService, model.Permission_Music)
+
+	router.HandlerFunc(http.MethodPut, "/api/genre/new", withGenrePermission(h.handleCreateGenre))
+	router.HandlerFunc(http.MethodPost, "/api/genre/:genre_id", withGenrePermission(h.handleUpdateGenre))
+	router.HandlerFunc(http.MethodDelete, "/api/genre/:genre_id", withGenrePermission(h.handleDeleteGenre))
+	router.HandlerFunc(http.MethodPut, "/api/genre-album/:genre_id/:album_id", withGenrePermission(h.handleLinkAlbum))
+	router.HandlerFunc(http.MethodDelete, "/api/genre-album/:genre_id/:album_id", withGenrePermission(h.handleUnlinkAlbum))
+	router.HandlerFunc(http.MethodPut, "/api/genre-music/:genre_id/:music_id", withGenrePermission(h.handleLinkMusic))
+	router.HandlerFunc(http.MethodDelete, "/api/genre-music/:genre_id/:music_id", withGenrePermission(h.handleUnlinkMusic))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (h *genreHandler) handleListGenre(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var filter *model.MusicGenreFilter
+	var page *model.MusicGenrePage
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		sort := model.ToMusicSort(extractParameter(req, "sort"))
+		if sort == "" && extractParameter(req, "sort") != "" {
+			err = model.ErrInvalidMusicSort
+			break
+		}
+
+		filter = model.NewMusicGenreFilter().
+			WithName(extractParameter(req, "name")).
+			WithSort(sort).
+			WithOffset(toInt(extractParameter(req, "offset"))).
+			WithLimit(model.ToMusicPageLimit(toInt(extractParameter(req, "limit"))))
+
+		h.logger.Info("[api] list genre", zap.Object("filter", filter))
+
+		//
+		// execute
+		//
+
+		page, err = h.service.ListGenre(ctx, filter)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGenrePageResponse(page))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (h *genreHandler) handleCreateGenre(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genre *model.MusicGenre
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genre, err = extractGenreFromBody(req)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] create genre: %#v", genre))
+
+		//
+		// execute
+		//
+
+		genre, err = h.service.CreateGenre(ctx, genre)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGenreResponse(genre))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (h *genreHandler) handleRetrieveGenre(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genreId model.MusicGenreId
+	var genre *model.MusicGenre
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genreId = model.MusicGenreId(toInt64(extractPathParameter(req, "genre_id")))
+		if genreId == 0 {
+			err = model.ErrInvalidMusicGenreId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] retrieve genre %d", genreId))
+
+		//
+		// execute
+		//
+
+		genre, err = h.service.GetGenre(ctx, genreId)
+		if err != nil {
+			break
+		}
+		if genre == nil {
+			err = model.ErrMusicGenreNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGenreResponse(genre))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (h *genreHandler) handleUpdateGenre(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genreId model.MusicGenreId
+	var genre *model.MusicGenre
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genreId = model.MusicGenreId(toInt64(extractPathParameter(req, "genre_id")))
+		if genreId == 0 {
+			err = model.ErrInvalidMusicGenreId
+			break
+		}
+		genre, err = extractGenreFromBody(req)
+		if err != nil {
+			break
+		}
+		genre.Id = genreId
+		h.logger.Info(fmt.Sprintf("[api] update genre %d: %#v", genreId, genre))
+
+		//
+		// execute
+		//
+
+		genre, err = h.service.UpdateGenre(ctx, genre)
+		if err != nil {
+			break
+		}
+		if genre == nil {
+			err = model.ErrMusicGenreNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonGenreResponse(genre))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (h *genreHandler) handleDeleteGenre(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genreId model.MusicGenreId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genreId = model.MusicGenreId(toInt64(extractPathParameter(req, "genre_id")))
+		if genreId == 0 {
+			err = model.ErrInvalidMusicGenreId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] delete genre %d", genreId))
+
+		//
+		// execute
+		//
+
+		err = h.service.DeleteGenre(ctx, genreId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// link album
+
+func (h *genreHandler) handleLinkAlbum(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genreId model.MusicGenreId
+	var albumId model.MusicAlbumId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genreId = model.MusicGenreId(toInt64(extractPathParameter(req, "genre_id")))
+		if genreId == 0 {
+			err = model.ErrInvalidMusicGenreId
+			break
+		}
+		albumId = model.MusicAlbumId(toInt64(extractPathParameter(req, "album_id")))
+		if albumId == 0 {
+			err = model.ErrInvalidMusicAlbumId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] link genre %d to album %d", genreId, albumId))
+
+		//
+		// execute
+		//
+
+		err = h.service.LinkAlbum(ctx, genreId, albumId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// unlink album
+
+func (h *genreHandler) handleUnlinkAlbum(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genreId model.MusicGenreId
+	var albumId model.MusicAlbumId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genreId = model.MusicGenreId(toInt64(extractPathParameter(req, "genre_id")))
+		if genreId == 0 {
+			err = model.ErrInvalidMusicGenreId
+			break
+		}
+		albumId = model.MusicAlbumId(toInt64(extractPathParameter(req, "album_id")))
+		if albumId == 0 {
+			err = model.ErrInvalidMusicAlbumId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] unlink genre %d from album %d", genreId, albumId))
+
+		//
+		// execute
+		//
+
+		err = h.service.UnlinkAlbum(ctx, genreId, albumId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// link music
+
+func (h *genreHandler) handleLinkMusic(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genreId model.MusicGenreId
+	var musicId model.MusicId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genreId = model.MusicGenreId(toInt64(extractPathParameter(req, "genre_id")))
+		if genreId == 0 {
+			err = model.ErrInvalidMusicGenreId
+			break
+		}
+		musicId = model.MusicId(toInt64(extractPathParameter(req, "music_id")))
+		if musicId == 0 {
+			err = model.ErrInvalidMusicId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] link genre %d to music %d", genreId, musicId))
+
+		//
+		// execute
+		//
+
+		err = h.service.LinkMusic(ctx, genreId, musicId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// unlink music
+
+func (h *genreHandler) handleUnlinkMusic(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var genreId model.MusicGenreId
+	var musicId model.MusicId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		genreId = model.MusicGenreId(toInt64(extractPathParameter(req, "genre_id")))
+		if genreId == 0 {
+			err = model.ErrInvalidMusicGenreId
+			break
+		}
+		musicId = model.MusicId(toInt64(extractPathParameter(req, "music_id")))
+		if musicId == 0 {
+			err = model.ErrInvalidMusicId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] unlink genre %d from music %d", genreId, musicId))
+
+		//
+		// execute
+		//
+
+		err = h.service.UnlinkMusic(ctx, genreId, musicId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// decode
+
+func extractGenreFromBody(req *http.Request) (*model.MusicGenre, error) {
+	var jsonBody JsonGenreBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		return nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		return nil, model.ErrInvalidBody
+	}
+	if jsonBody.Genre == nil {
+		return nil, model.ErrMissingGenre
+	}
+
+	return toGenre(jsonBody.Genre), nil
+}
+
+type JsonGenreBody struct {
+	Genre *JsonGenreLite `json:"genre,omitempty"`
+}
+
+func toGenre(jsonGenre *JsonGenreLite) *model.MusicGenre {
+	return &model.MusicGenre{
+		Id:       model.MusicGenreId(jsonGenre.Id),
+		DeezerId: model.DeezerGenreId(jsonGenre.DeezerId),
+		Name:     jsonGenre.Name,
+		ImgUrl:   model.Url(jsonGenre.ImgUrl),
+	}
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func toJsonGenrePageResponse(page *model.MusicGenrePage) *JsonGenrePageResponse {
+	return &JsonGenrePageResponse{
+		Success: true,
+		Genres:  util.Convert(page.Genres, toJsonGenreLite),
+		Total:   page.Total,
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+	}
+}
+
+func toJsonGenreLite(genre *model.MusicGenre) *JsonGenreLite {
+	if genre == nil {
+		return nil
+	}
+	return &JsonGenreLite{
+		Id:       int64(genre.Id),
+		DeezerId: int64(genre.DeezerId),
+		Name:     genre.Name,
+		ImgUrl:   string(genre.ImgUrl),
+		NbAlbum:  genre.NbAlbum,
+		NbMusic:  genre.NbMusic,
+	}
+}
+
+func toJsonGenreResponse(genre *model.MusicGenre) *JsonGenreResponse {
+	return &JsonGenreResponse{
+		Success: true,
+		Genre:   toJsonGenre(genre),
+	}
+}
+
+func toJsonGenre(genre *model.MusicGenre) *JsonGenre {
+	if genre == nil {
+		return nil
+	}
+	return &JsonGenre{
+		Id:       int64(genre.Id),
+		DeezerId: int64(genre.DeezerId),
+		Name:     genre.Name,
+		ImgUrl:   string(genre.ImgUrl),
+		NbAlbum:  genre.NbAlbum,
+		NbMusic:  genre.NbMusic,
+		Albums:   util.Convert(genre.Albums, toJsonAlbumLite),
+	}
+}
+
+type JsonGenrePageResponse struct {
+	Success bool             `json:"success,omitempty"`
+	Genres  []*JsonGenreLite `json:"genres,omitempty"`
+	Total   int              `json:"total"`
+	Offset  int              `json:"offset,omitempty"`
+	Limit   int              `json:"limit,omitempty"`
+}
+
+type JsonGenreLite struct {
+	Id       int64  `json:"id,omitempty"`
+	DeezerId int64  `json:"deezerId,omitempty"`
+	Name     string `json:"name,omitempty"`
+	ImgUrl   string `json:"imgUrl,omitempty"`
+	NbAlbum  int    `json:"nbAlbum,omitempty"`
+	NbMusic  int    `json:"nbMusic,omitempty"`
+}
+
+type JsonGenreResponse struct {
+	Success bool       `json:"success,omitempty"`
+	Genre   *JsonGenre `json:"genre,omitempty"`
+}
+
+type JsonGenre struct {
+	Id       int64            `json:"id,omitempty"`
+	DeezerId int64            `json:"deezerId,omitempty"`
+	Name     string           `json:"name,omitempty"`
+	ImgUrl   string           `json:"imgUrl,omitempty"`
+	NbAlbum  int              `json:"nbAlbum,omitempty"`
+	NbMusic  int              `json:"nbMusic,omitempty"`
+	Albums   []*JsonAlbumLite `json:"albums,omitempty"`
+}
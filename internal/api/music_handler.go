@@ -2,9 +2,11 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gre-ory/amnezic-go/internal/model"
 	"github.com/gre-ory/amnezic-go/internal/service"
@@ -34,6 +36,7 @@ type musicHandler struct {
 // register
 
 func (h *musicHandler) RegisterRoutes(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodGet, "/api/music", h.handleListMusic)
 	router.HandlerFunc(http.MethodGet, "/api/deezer/music", h.handleSearchDeezerMusic)
 	router.HandlerFunc(http.MethodGet, "/api/music/:music_id", h.handleRetrieveMusic)
 
@@ -44,6 +47,84 @@ func (h *musicHandler) RegisterRoutes(router *httprouter.Router) {
 	router.HandlerFunc(http.MethodDelete, "/api/music/:music_id", withMusicPermission(h.handleDeleteMusic))
 }
 
+// //////////////////////////////////////////////////
+// list
+
+func (h *musicHandler) handleListMusic(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var filter *model.MusicFilter
+	var page *model.MusicPage
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		sort := model.ToMusicSort(extractParameter(req, "sort"))
+		if sort == "" && extractParameter(req, "sort") != "" {
+			err = model.ErrInvalidMusicSort
+			break
+		}
+
+		artistId := model.MusicArtistId(toInt64(extractParameter(req, "artist_id")))
+		albumId := model.MusicAlbumId(toInt64(extractParameter(req, "album_id")))
+		genreId := model.MusicGenreId(toInt64(extractParameter(req, "genre_id")))
+		minDuration := time.Duration(toInt(extractParameter(req, "min_duration"))) * time.Second
+		maxDuration := time.Duration(toInt(extractParameter(req, "max_duration"))) * time.Second
+
+		filter = model.NewMusicFilter().
+			WithName(extractParameter(req, "name")).
+			WithArtistId(artistId).
+			WithAlbumId(albumId).
+			WithGenreId(genreId).
+			WithYears(toInt(extractParameter(req, "min_year")), toInt(extractParameter(req, "max_year"))).
+			WithGenre(extractParameter(req, "genre")).
+			WithDurations(minDuration, maxDuration).
+			WithMinRank(toInt(extractParameter(req, "min_rank"))).
+			WithSort(sort).
+			WithOffset(toInt(extractParameter(req, "offset"))).
+			WithLimit(model.ToMusicPageLimit(toInt(extractParameter(req, "limit"))))
+		if explicit := extractParameter(req, "explicit"); explicit != "" {
+			filter.WithExplicit(toBool(explicit))
+		}
+
+		h.logger.Info("[api] list music", zap.Object("filter", filter))
+
+		//
+		// execute
+		//
+
+		page, err = h.service.ListMusic(ctx, filter)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonMusicPageResponse(page))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
 // //////////////////////////////////////////////////
 // search
 
@@ -167,6 +248,10 @@ func (h *musicHandler) handleAddDezzerMusic(resp http.ResponseWriter, req *http.
 	// encode error
 	//
 
+	if encodeMusicConflict(resp, err) {
+		return
+	}
+
 	// TODO status code
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
@@ -220,6 +305,10 @@ func (h *musicHandler) handleCreateMusic(resp http.ResponseWriter, req *http.Req
 	// encode error
 	//
 
+	if encodeMusicConflict(resp, err) {
+		return
+	}
+
 	// TODO status code
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
@@ -342,6 +431,10 @@ func (h *musicHandler) handleUpdateMusic(resp http.ResponseWriter, req *http.Req
 	// encode error
 	//
 
+	if encodeMusicConflict(resp, err) {
+		return
+	}
+
 	// TODO status code
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
@@ -426,18 +519,38 @@ func toMusic(jsonMusic *JsonMusic) *model.Music {
 	album := toAlbum(jsonMusic.Album)
 
 	music := &model.Music{
-		Id:       model.MusicId(jsonMusic.Id),
-		DeezerId: model.DeezerMusicId(jsonMusic.DeezerId),
-		Name:     jsonMusic.Name,
-		Mp3Url:   model.Url(jsonMusic.Mp3Url),
-		ArtistId: artist.Id,
-		AlbumId:  album.Id,
+		Id:          model.MusicId(jsonMusic.Id),
+		DeezerId:    model.DeezerMusicId(jsonMusic.DeezerId),
+		Name:        jsonMusic.Name,
+		Mp3Url:      model.Url(jsonMusic.Mp3Url),
+		ArtistId:    artist.Id,
+		AlbumId:     album.Id,
+		ReleaseDate: jsonMusic.ReleaseDate,
+		Year:        jsonMusic.Year,
+		Duration:    time.Duration(jsonMusic.Duration) * time.Second,
+		Genre:       jsonMusic.Genre,
+		Explicit:    jsonMusic.Explicit,
+		Rank:        jsonMusic.Rank,
 
 		Artist: artist,
 		Album:  album,
 	}
+	if jsonMusic.Contributors != nil {
+		music.Contributors = util.Convert(jsonMusic.Contributors, toMusicContributor)
+	}
 
-	return music
+	return music.WithReleaseYear()
+}
+
+// toMusicContributor only keeps the reference to the artist: contributing does not edit the artist.
+func toMusicContributor(jsonContributor *JsonMusicContributor) *model.MusicContributor {
+	contributor := &model.MusicContributor{
+		Role: model.ToMusicArtistRole(jsonContributor.Role),
+	}
+	if jsonContributor.Artist != nil {
+		contributor.ArtistId = model.MusicArtistId(jsonContributor.Artist.Id)
+	}
+	return contributor
 }
 
 // //////////////////////////////////////////////////
@@ -450,17 +563,34 @@ func toJsonMusicsResponse(musics []*model.Music) *JsonMusicsResponse {
 	}
 }
 
+func toJsonMusicPageResponse(page *model.MusicPage) *JsonMusicPageResponse {
+	return &JsonMusicPageResponse{
+		Success: true,
+		Musics:  util.Convert(page.Musics, toJsonMusicLite),
+		Total:   page.Total,
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+	}
+}
+
 func toJsonMusicLite(music *model.Music) *JsonMusicLite {
 	if music == nil {
 		return nil
 	}
 	return &JsonMusicLite{
-		Id:       int64(music.Id),
-		DeezerId: int64(music.DeezerId),
-		Name:     music.Name,
-		Mp3Url:   string(music.Mp3Url),
-		ArtistId: int64(music.ArtistId),
-		AlbumId:  int64(music.AlbumId),
+		Id:          int64(music.Id),
+		DeezerId:    int64(music.DeezerId),
+		Name:        music.Name,
+		Mp3Url:      string(music.Mp3Url),
+		ArtistId:    int64(music.ArtistId),
+		AlbumId:     int64(music.AlbumId),
+		ReleaseDate: music.ReleaseDate,
+		Year:        music.Year,
+		Duration:    int(music.Duration / time.Second),
+		Genre:       music.Genre,
+		Explicit:    music.Explicit,
+		Rank:        music.Rank,
+		NbTheme:     music.NbTheme,
 	}
 }
 
@@ -476,13 +606,49 @@ func toJsonMusic(music *model.Music) *JsonMusic {
 		return nil
 	}
 	return &JsonMusic{
-		Id:        int64(music.Id),
-		DeezerId:  int64(music.DeezerId),
-		Name:      music.Name,
-		Mp3Url:    string(music.Mp3Url),
-		Artist:    toJsonArtistLite(music.Artist),
-		Album:     toJsonAlbumLite(music.Album),
-		Questions: util.Convert(music.Questions, toJsonThemeQuestion),
+		Id:           int64(music.Id),
+		DeezerId:     int64(music.DeezerId),
+		Name:         music.Name,
+		Mp3Url:       string(music.Mp3Url),
+		ReleaseDate:  music.ReleaseDate,
+		Year:         music.Year,
+		Duration:     int(music.Duration / time.Second),
+		Genre:        music.Genre,
+		Explicit:     music.Explicit,
+		Rank:         music.Rank,
+		Artist:       toJsonArtistLite(music.Artist),
+		Album:        toJsonAlbumLite(music.Album),
+		Genres:       util.Convert(music.Genres, toJsonGenreLite),
+		Contributors: util.Convert(music.Contributors, toJsonMusicContributor),
+		Questions:    util.Convert(music.Questions, toJsonThemeQuestion),
+	}
+}
+
+// encodeMusicConflict encodes a conflict pointing to the existing music, if any.
+func encodeMusicConflict(resp http.ResponseWriter, err error) bool {
+	var conflictErr *model.MusicConflictError
+	if !errors.As(err, &conflictErr) {
+		return false
+	}
+
+	resp.Header().Set("Content-Type", "application/json")
+	resp.WriteHeader(http.StatusConflict)
+
+	// try to encode error >>> no need to check error at encoding
+	json.NewEncoder(resp).Encode(&JsonMusicConflictResponse{
+		JsonErrorResponse: toJsonError(http.StatusConflict, conflictErr.Error()),
+		Existing:          toJsonMusicLite(conflictErr.Existing),
+	})
+	return true
+}
+
+func toJsonMusicContributor(contributor *model.MusicContributor) *JsonMusicContributor {
+	if contributor == nil {
+		return nil
+	}
+	return &JsonMusicContributor{
+		Artist: toJsonArtistLite(contributor.Artist),
+		Role:   contributor.Role.String(),
 	}
 }
 
@@ -491,13 +657,28 @@ type JsonMusicsResponse struct {
 	Musics  []*JsonMusicLite `json:"musics,omitempty"`
 }
 
+type JsonMusicPageResponse struct {
+	Success bool             `json:"success,omitempty"`
+	Musics  []*JsonMusicLite `json:"musics,omitempty"`
+	Total   int              `json:"total"`
+	Offset  int              `json:"offset,omitempty"`
+	Limit   int              `json:"limit,omitempty"`
+}
+
 type JsonMusicLite struct {
-	Id       int64  `json:"id,omitempty"`
-	DeezerId int64  `json:"deezerId,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Mp3Url   string `json:"mp3Url,omitempty"`
-	ArtistId int64  `json:"artistId,omitempty"`
-	AlbumId  int64  `json:"albumId,omitempty"`
+	Id          int64  `json:"id,omitempty"`
+	DeezerId    int64  `json:"deezerId,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Mp3Url      string `json:"mp3Url,omitempty"`
+	ArtistId    int64  `json:"artistId,omitempty"`
+	AlbumId     int64  `json:"albumId,omitempty"`
+	ReleaseDate string `json:"releaseDate,omitempty"`
+	Year        int    `json:"year,omitempty"`
+	Duration    int    `json:"duration,omitempty"`
+	Genre       string `json:"genre,omitempty"`
+	Explicit    bool   `json:"explicit,omitempty"`
+	Rank        int    `json:"rank,omitempty"`
+	NbTheme     int    `json:"nbTheme,omitempty"`
 }
 
 type JsonMusicResponse struct {
@@ -506,11 +687,29 @@ type JsonMusicResponse struct {
 }
 
 type JsonMusic struct {
-	Id        int64                `json:"id,omitempty"`
-	DeezerId  int64                `json:"deezerId,omitempty"`
-	Name      string               `json:"name,omitempty"`
-	Mp3Url    string               `json:"mp3Url,omitempty"`
-	Artist    *JsonArtistLite      `json:"artist,omitempty"`
-	Album     *JsonAlbumLite       `json:"album,omitempty"`
-	Questions []*JsonThemeQuestion `json:"questions,omitempty"`
+	Id           int64                   `json:"id,omitempty"`
+	DeezerId     int64                   `json:"deezerId,omitempty"`
+	Name         string                  `json:"name,omitempty"`
+	Mp3Url       string                  `json:"mp3Url,omitempty"`
+	ReleaseDate  string                  `json:"releaseDate,omitempty"`
+	Year         int                     `json:"year,omitempty"`
+	Duration     int                     `json:"duration,omitempty"`
+	Genre        string                  `json:"genre,omitempty"`
+	Explicit     bool                    `json:"explicit,omitempty"`
+	Rank         int                     `json:"rank,omitempty"`
+	Artist       *JsonArtistLite         `json:"artist,omitempty"`
+	Album        *JsonAlbumLite          `json:"album,omitempty"`
+	Genres       []*JsonGenreLite        `json:"genres,omitempty"`
+	Contributors []*JsonMusicContributor `json:"contributors,omitempty"`
+	Questions    []*JsonThemeQuestion    `json:"questions,omitempty"`
+}
+
+type JsonMusicConflictResponse struct {
+	*JsonErrorResponse
+	Existing *JsonMusicLite `json:"existing,omitempty"`
+}
+
+type JsonMusicContributor struct {
+	Artist *JsonArtistLite `json:"artist,omitempty"`
+	Role   string          `json:"role,omitempty"`
 }
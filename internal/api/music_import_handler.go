@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// music import handler
+
+func NewMusicImportHandler(logger *zap.Logger, service service.MusicImportService, sessionService service.SessionService) Handler {
+	return &musicImportHandler{
+		logger:         logger,
+		service:        service,
+		sessionService: sessionService,
+	}
+}
+
+type musicImportHandler struct {
+	logger         *zap.Logger
+	service        service.MusicImportService
+	sessionService service.SessionService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *musicImportHandler) RegisterRoutes(router *httprouter.Router) {
+
+	withMusicPermission := WithPermission(h.logger, h.sessionService, model.Permission_Music)
+
+	router.HandlerFunc(http.MethodPost, "/api/music-import", withMusicPermission(h.handleImportLocalMusics))
+}
+
+// //////////////////////////////////////////////////
+// import
+
+func (h *musicImportHandler) handleImportLocalMusics(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var dryRun bool
+	var report *model.MusicImportReport
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		dryRun = toBool(extractParameter(req, "dry_run"))
+		h.logger.Info(fmt.Sprintf("[api] import local musics ( dry run: %t )", dryRun))
+
+		//
+		// execute
+		//
+
+		report, err = h.service.ImportLocalMusics(ctx, dryRun)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode response
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonMusicImportResponse(report))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func toJsonMusicImportResponse(report *model.MusicImportReport) *JsonMusicImportResponse {
+	return &JsonMusicImportResponse{
+		Success:    true,
+		DryRun:     report.DryRun,
+		NbNew:      report.Count(model.MusicImportStatus_New),
+		NbExisting: report.Count(model.MusicImportStatus_Existing),
+		NbInvalid:  report.Count(model.MusicImportStatus_Invalid),
+		Items:      util.Convert(report.Items, toJsonMusicImportItem),
+	}
+}
+
+func toJsonMusicImportItem(item *model.MusicImportItem) *JsonMusicImportItem {
+	return &JsonMusicImportItem{
+		Path:     string(item.Path),
+		Status:   string(item.Status),
+		Reason:   item.Reason,
+		Music:    toJsonMusic(item.Music),
+		CoverUrl: string(item.CoverUrl),
+	}
+}
+
+type JsonMusicImportResponse struct {
+	Success    bool                   `json:"success,omitempty"`
+	DryRun     bool                   `json:"dryRun,omitempty"`
+	NbNew      int                    `json:"nbNew"`
+	NbExisting int                    `json:"nbExisting"`
+	NbInvalid  int                    `json:"nbInvalid"`
+	Items      []*JsonMusicImportItem `json:"items"`
+}
+
+type JsonMusicImportItem struct {
+	Path     string     `json:"path"`
+	Status   string     `json:"status"`
+	Reason   string     `json:"reason,omitempty"`
+	Music    *JsonMusic `json:"music,omitempty"`
+	CoverUrl string     `json:"coverUrl,omitempty"`
+}
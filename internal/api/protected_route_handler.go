@@ -76,6 +76,189 @@ func (g *permissionGranter) Grant(req *http.Request) (*http.Request, error) {
 	return req, nil
 }
 
+// //////////////////////////////////////////////////
+// session
+
+func WithSession(logger *zap.Logger, sessionService service.SessionService) func(http.HandlerFunc) http.HandlerFunc {
+	granter := NewSessionGranter(logger, sessionService)
+	return func(nextHanlder http.HandlerFunc) http.HandlerFunc {
+		return Protect(granter, nextHanlder)
+	}
+}
+
+func NewSessionGranter(logger *zap.Logger, sessionService service.SessionService) Granter {
+	return &sessionGranter{
+		logger:         logger,
+		sessionService: sessionService,
+	}
+}
+
+type sessionGranter struct {
+	logger         *zap.Logger
+	sessionService service.SessionService
+}
+
+func (g *sessionGranter) Grant(req *http.Request) (*http.Request, error) {
+
+	//
+	// extract session token
+	//
+
+	token, err := extractSessionToken(req)
+	if err != nil {
+		g.logger.Info("unable to extract session token", zap.Error(err))
+		return req, err
+	}
+
+	//
+	// check session token
+	//
+
+	session, err := g.sessionService.Authenticate(req.Context(), token)
+	if err != nil {
+		g.logger.Info("user not authenticated", zap.Error(err))
+		return req, err
+	}
+	req = req.WithContext(model.WithSession(req.Context(), session))
+
+	g.logger.Info(fmt.Sprintf("user %d authenticated", session.UserId))
+	return req, nil
+}
+
+// //////////////////////////////////////////////////
+// optional session
+
+func WithOptionalSession(logger *zap.Logger, sessionService service.SessionService) func(http.HandlerFunc) http.HandlerFunc {
+	granter := NewOptionalSessionGranter(logger, sessionService)
+	return func(nextHanlder http.HandlerFunc) http.HandlerFunc {
+		return Protect(granter, nextHanlder)
+	}
+}
+
+func NewOptionalSessionGranter(logger *zap.Logger, sessionService service.SessionService) Granter {
+	return &optionalSessionGranter{
+		logger:         logger,
+		sessionService: sessionService,
+	}
+}
+
+type optionalSessionGranter struct {
+	logger         *zap.Logger
+	sessionService service.SessionService
+}
+
+func (g *optionalSessionGranter) Grant(req *http.Request) (*http.Request, error) {
+
+	//
+	// extract session token
+	//
+
+	token, err := extractSessionToken(req)
+	if err != nil {
+		g.logger.Info("no session token >>> anonymous", zap.Error(err))
+		return req, nil
+	}
+
+	//
+	// check session token
+	//
+
+	session, err := g.sessionService.Authenticate(req.Context(), token)
+	if err != nil {
+		g.logger.Info("invalid session token >>> anonymous", zap.Error(err))
+		return req, nil
+	}
+	req = req.WithContext(model.WithSession(req.Context(), session))
+
+	g.logger.Info(fmt.Sprintf("user %d authenticated", session.UserId))
+	return req, nil
+}
+
+// //////////////////////////////////////////////////
+// game owner
+
+func WithGameOwner(logger *zap.Logger, sessionService service.SessionService, gameService service.GameService) func(http.HandlerFunc) http.HandlerFunc {
+	granter := NewGameOwnerGranter(logger, sessionService, gameService)
+	return func(nextHanlder http.HandlerFunc) http.HandlerFunc {
+		return Protect(granter, nextHanlder)
+	}
+}
+
+func NewGameOwnerGranter(logger *zap.Logger, sessionService service.SessionService, gameService service.GameService) Granter {
+	return &gameOwnerGranter{
+		logger:         logger,
+		sessionService: sessionService,
+		gameService:    gameService,
+	}
+}
+
+type gameOwnerGranter struct {
+	logger         *zap.Logger
+	sessionService service.SessionService
+	gameService    service.GameService
+}
+
+func (g *gameOwnerGranter) Grant(req *http.Request) (*http.Request, error) {
+
+	ctx := req.Context()
+
+	//
+	// extract game id
+	//
+
+	gameId := model.GameId(toInt64(extractPathParameter(req, "game_id")))
+	if gameId == 0 {
+		return req, model.ErrInvalidGameId
+	}
+
+	//
+	// extract token ( host token or session token )
+	//
+
+	token, err := extractSessionToken(req)
+	if err != nil {
+		g.logger.Info("unable to extract token", zap.Error(err))
+		return req, err
+	}
+
+	//
+	// retrieve game
+	//
+
+	game, err := g.gameService.RetrieveGame(ctx, gameId)
+	if err != nil {
+		g.logger.Info(fmt.Sprintf("unable to retrieve game %d", gameId), zap.Error(err))
+		return req, err
+	}
+
+	//
+	// check anonymous host token
+	//
+
+	if game.IsHostedWith(model.GameHostToken(token)) {
+		g.logger.Info(fmt.Sprintf("host granted for game %d", gameId))
+		return req, nil
+	}
+
+	//
+	// check session owner
+	//
+
+	session, err := g.sessionService.Authenticate(ctx, token)
+	if err != nil {
+		g.logger.Info(fmt.Sprintf("user not granted for game %d", gameId), zap.Error(err))
+		return req, model.ErrNotGameOwner
+	}
+	if !game.IsOwnedBy(session.UserId) {
+		g.logger.Info(fmt.Sprintf("user %d not owner of game %d", session.UserId, gameId))
+		return req, model.ErrNotGameOwner
+	}
+	req = req.WithContext(model.WithSession(ctx, session))
+
+	g.logger.Info(fmt.Sprintf("user %d granted for game %d", session.UserId, gameId))
+	return req, nil
+}
+
 // //////////////////////////////////////////////////
 // extract session token
 
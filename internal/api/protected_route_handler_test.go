@@ -0,0 +1,158 @@
+package api_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/api"
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// newTestSessionService opens an in-memory sqlite database with the user tables and logs in the given users ( password "password" ).
+func newTestSessionService(t *testing.T, names ...string) (service.SessionService, map[string]*model.Session) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	// a new connection would open another in-memory database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "db", "00003_user.sql"))
+	require.NoError(t, err)
+	up, _, _ := strings.Cut(string(data), "-- +goose Down")
+	_, err = db.Exec(up)
+	require.NoError(t, err)
+
+	userStore := store.NewUserStore(zap.NewNop())
+	sessionService := service.NewSessionService(zap.NewNop(), "secret", db, store.NewSessionStore(zap.NewNop()), userStore)
+
+	sessions := make(map[string]*model.Session)
+	for _, name := range names {
+		user := &model.User{Name: name, Password: "password"}
+		require.NoError(t, user.HashPassword())
+		require.NoError(t, util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+			userStore.Create(ctx, tx, user)
+		}))
+		session, err := sessionService.Login(ctx, &model.LoginRequest{Name: name, Password: "password"})
+		require.NoError(t, err)
+		sessions[name] = session
+	}
+	return sessionService, sessions
+}
+
+type testGameService struct {
+	service.GameService
+	games map[model.GameId]*model.Game
+}
+
+func (s *testGameService) RetrieveGame(ctx context.Context, id model.GameId) (*model.Game, error) {
+	if game, ok := s.games[id]; ok {
+		return game, nil
+	}
+	return nil, model.ErrGameNotFound
+}
+
+func newTestRequest(gameId model.GameId, token string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/game/%d", gameId), nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	params := httprouter.Params{{Key: "game_id", Value: fmt.Sprintf("%d", gameId)}}
+	return req.WithContext(context.WithValue(req.Context(), httprouter.ParamsKey, params))
+}
+
+func TestGameOwnerGranter(t *testing.T) {
+	sessionService, sessions := newTestSessionService(t, "alice", "bob")
+	alice := sessions["alice"]
+	bob := sessions["bob"]
+
+	hostToken := model.NewGameHostToken()
+	gameService := &testGameService{
+		games: map[model.GameId]*model.Game{
+			1: {Id: 1, HostToken: hostToken},
+			2: {Id: 2, OwnerId: alice.UserId},
+		},
+	}
+	granter := api.NewGameOwnerGranter(zap.NewNop(), sessionService, gameService)
+
+	tests := []struct {
+		name        string
+		gameId      model.GameId
+		token       string
+		wantErr     error
+		wantSession *model.Session
+	}{
+		{name: "host token", gameId: 1, token: hostToken.String()},
+		{name: "anonymous game without token", gameId: 1, wantErr: model.ErrMissingAuthorizationHeader},
+		{name: "anonymous game with a session token", gameId: 1, token: alice.Token.String(), wantErr: model.ErrNotGameOwner},
+		{name: "anonymous game with another host token", gameId: 1, token: model.NewGameHostToken().String(), wantErr: model.ErrNotGameOwner},
+		{name: "session owner", gameId: 2, token: alice.Token.String(), wantSession: alice},
+		{name: "another user", gameId: 2, token: bob.Token.String(), wantErr: model.ErrNotGameOwner},
+		{name: "owned game without token", gameId: 2, wantErr: model.ErrMissingAuthorizationHeader},
+		{name: "owned game with a host token", gameId: 2, token: hostToken.String(), wantErr: model.ErrNotGameOwner},
+		{name: "unknown game", gameId: 3, token: alice.Token.String(), wantErr: model.ErrGameNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := granter.Grant(newTestRequest(tt.gameId, tt.token))
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			session := model.GetSession(req.Context())
+			if tt.wantSession == nil {
+				require.Nil(t, session)
+				return
+			}
+			require.NotNil(t, session)
+			require.Equal(t, tt.wantSession.UserId, session.UserId)
+		})
+	}
+}
+
+func TestOptionalSessionGranter(t *testing.T) {
+	sessionService, sessions := newTestSessionService(t, "alice")
+	alice := sessions["alice"]
+
+	granter := api.NewOptionalSessionGranter(zap.NewNop(), sessionService)
+
+	tests := []struct {
+		name        string
+		token       string
+		wantSession *model.Session
+	}{
+		{name: "valid token", token: alice.Token.String(), wantSession: alice},
+		{name: "missing token"},
+		{name: "invalid token", token: "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := granter.Grant(newTestRequest(1, tt.token))
+			require.NoError(t, err)
+			session := model.GetSession(req.Context())
+			if tt.wantSession == nil {
+				require.Nil(t, session)
+				return
+			}
+			require.NotNil(t, session)
+			require.Equal(t, tt.wantSession.UserId, session.UserId)
+		})
+	}
+}
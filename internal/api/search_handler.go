@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// search handler
+
+func NewSearchHandler(logger *zap.Logger, service service.SearchService) Handler {
+	return &searchHandler{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type searchHandler struct {
+	logger  *zap.Logger
+	service service.SearchService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *searchHandler) RegisterRoutes(router *httprouter.Router) {
+	router.HandlerFunc(http.MethodGet, "/api/search", h.handleSearch)
+}
+
+// //////////////////////////////////////////////////
+// search
+
+func (h *searchHandler) handleSearch(resp http.ResponseWriter, req *http.Request) {
+
+	ctx := req.Context()
+
+	var query string
+	var result *model.SearchResult
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		query = extractParameter(req, "q")
+		limit := model.ToSearchLimit(toInt(extractParameter(req, "limit")))
+		h.logger.Info(fmt.Sprintf("[api] search %q ( limit: %d )", query, limit))
+
+		//
+		// execute
+		//
+
+		result, err = h.service.Search(ctx, query, limit)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSearchResponse(result))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func toJsonSearchResponse(result *model.SearchResult) *JsonSearchResponse {
+	return &JsonSearchResponse{
+		Success: true,
+		Query:   result.Query,
+		Musics:  util.Convert(result.Musics, toJsonSearchHit),
+		Artists: util.Convert(result.Artists, toJsonSearchHit),
+		Albums:  util.Convert(result.Albums, toJsonSearchHit),
+		Themes:  util.Convert(result.Themes, toJsonSearchThemeHit),
+	}
+}
+
+func toJsonSearchHit(entry *model.SearchEntry) *JsonSearchHit {
+	if entry == nil {
+		return nil
+	}
+	return &JsonSearchHit{
+		Id:   entry.Id,
+		Text: entry.Text,
+	}
+}
+
+func toJsonSearchThemeHit(hit *model.SearchThemeHit) *JsonSearchThemeHit {
+	if hit == nil {
+		return nil
+	}
+	return &JsonSearchThemeHit{
+		Id:        int64(hit.ThemeId),
+		Title:     hit.Title,
+		Questions: util.Convert(hit.Questions, toJsonSearchHit),
+	}
+}
+
+type JsonSearchResponse struct {
+	Success bool                  `json:"success,omitempty"`
+	Query   string                `json:"query,omitempty"`
+	Musics  []*JsonSearchHit      `json:"musics,omitempty"`
+	Artists []*JsonSearchHit      `json:"artists,omitempty"`
+	Albums  []*JsonSearchHit      `json:"albums,omitempty"`
+	Themes  []*JsonSearchThemeHit `json:"themes,omitempty"`
+}
+
+type JsonSearchHit struct {
+	Id   int64  `json:"id,omitempty"`
+	Text string `json:"text,omitempty"`
+}
+
+type JsonSearchThemeHit struct {
+	Id        int64            `json:"id,omitempty"`
+	Title     string           `json:"title,omitempty"`
+	Questions []*JsonSearchHit `json:"questions,omitempty"`
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gre-ory/amnezic-go/internal/model"
 	"github.com/gre-ory/amnezic-go/internal/service"
@@ -407,6 +408,10 @@ func (h *themeHandler) handleAddQuestion(resp http.ResponseWriter, req *http.Req
 	// encode error
 	//
 
+	if encodeMusicConflict(resp, err) {
+		return
+	}
+
 	// TODO status code
 	encodeError(resp, http.StatusBadRequest, err.Error())
 }
@@ -596,6 +601,7 @@ func toThemeQuestion(themeId model.ThemeId, jsonQuestion *JsonThemeQuestion) *mo
 		ThemeId: themeId,
 		Text:    jsonQuestion.Text,
 		Hint:    jsonQuestion.Hint,
+		Excerpt: toAudioExcerpt(jsonQuestion.Excerpt),
 	}
 
 	if jsonQuestion.Music != nil {
@@ -605,6 +611,16 @@ func toThemeQuestion(themeId model.ThemeId, jsonQuestion *JsonThemeQuestion) *mo
 	return question
 }
 
+func toAudioExcerpt(jsonExcerpt *JsonAudioExcerpt) model.AudioExcerpt {
+	if jsonExcerpt == nil {
+		return model.AudioExcerpt{}
+	}
+	return model.AudioExcerpt{
+		Start:    time.Duration(jsonExcerpt.StartMs) * time.Millisecond,
+		Duration: time.Duration(jsonExcerpt.DurationMs) * time.Millisecond,
+	}
+}
+
 type JsonThemeBody struct {
 	Theme *JsonTheme `json:"theme,omitempty"`
 }
@@ -657,9 +673,10 @@ func toJsonTheme(theme *model.Theme) *JsonTheme {
 
 func toJsonThemeQuestion(question *model.ThemeQuestion) *JsonThemeQuestion {
 	jsonQuestion := &JsonThemeQuestion{
-		Id:   int64(question.Id),
-		Text: question.Text,
-		Hint: question.Hint,
+		Id:      int64(question.Id),
+		Text:    question.Text,
+		Hint:    question.Hint,
+		Excerpt: toJsonAudioExcerpt(question.Excerpt),
 	}
 
 	if question.Music != nil {
@@ -673,6 +690,16 @@ func toJsonThemeQuestion(question *model.ThemeQuestion) *JsonThemeQuestion {
 	return jsonQuestion
 }
 
+func toJsonAudioExcerpt(excerpt model.AudioExcerpt) *JsonAudioExcerpt {
+	if excerpt.IsZero() {
+		return nil
+	}
+	return &JsonAudioExcerpt{
+		StartMs:    excerpt.Start.Milliseconds(),
+		DurationMs: excerpt.Duration.Milliseconds(),
+	}
+}
+
 type JsonThemesResponse struct {
 	Success bool             `json:"success,omitempty"`
 	Themes  []*JsonThemeInfo `json:"themes"`
@@ -700,9 +727,15 @@ type JsonTheme struct {
 }
 
 type JsonThemeQuestion struct {
-	Id    int64      `json:"id,omitempty"`
-	Text  string     `json:"text,omitempty"`
-	Hint  string     `json:"hint,omitempty"`
-	Theme *JsonTheme `json:"theme,omitempty"`
-	Music *JsonMusic `json:"music,omitempty"`
+	Id      int64             `json:"id,omitempty"`
+	Text    string            `json:"text,omitempty"`
+	Hint    string            `json:"hint,omitempty"`
+	Excerpt *JsonAudioExcerpt `json:"excerpt,omitempty"`
+	Theme   *JsonTheme        `json:"theme,omitempty"`
+	Music   *JsonMusic        `json:"music,omitempty"`
+}
+
+type JsonAudioExcerpt struct {
+	StartMs    int64 `json:"startMs"`
+	DurationMs int64 `json:"durationMs,omitempty"`
 }
@@ -0,0 +1,449 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// tournament handler
+
+func NewTournamentHandler(logger *zap.Logger, tournamentService service.TournamentService, sessionService service.SessionService) Handler {
+	return &tournamentHandler{
+		logger:            logger,
+		tournamentService: tournamentService,
+		sessionService:    sessionService,
+	}
+}
+
+type tournamentHandler struct {
+	logger            *zap.Logger
+	tournamentService service.TournamentService
+	sessionService    service.SessionService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *tournamentHandler) RegisterRoutes(router *httprouter.Router) {
+	withSession := WithSession(h.logger, h.sessionService)
+
+	router.HandlerFunc(http.MethodPut, "/api/tournament/new", withSession(h.handleCreateTournament))
+	router.HandlerFunc(http.MethodGet, "/api/tournament/:tournament_id", h.handleRetrieveTournament)
+	router.HandlerFunc(http.MethodDelete, "/api/tournament/:tournament_id", withSession(h.handleDeleteTournament))
+	router.HandlerFunc(http.MethodPut, "/api/tournament-stage/:tournament_id", withSession(h.handleNextStage))
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (h *tournamentHandler) handleCreateTournament(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var tournament *model.Tournament
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		tournament, err = extractTournamentFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] create tournament %q", tournament.Name))
+
+		//
+		// execute
+		//
+
+		tournament, err = h.tournamentService.CreateTournament(ctx, tournament)
+		if err != nil {
+			break
+		}
+		if tournament == nil {
+			err = model.ErrTournamentNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		h.encodeTournament(resp, req, tournament)
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (h *tournamentHandler) handleRetrieveTournament(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var tournamentId model.TournamentId
+	var tournament *model.Tournament
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		tournamentId = model.ToTournamentId(extractPathParameter(req, "tournament_id"))
+		if tournamentId == 0 {
+			err = model.ErrInvalidTournamentId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] retrieve tournament %d", tournamentId))
+
+		//
+		// execute
+		//
+
+		tournament, err = h.tournamentService.RetrieveTournament(ctx, tournamentId)
+		if err != nil {
+			break
+		}
+		if tournament == nil {
+			err = model.ErrTournamentNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		h.encodeTournament(resp, req, tournament)
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// next stage
+
+func (h *tournamentHandler) handleNextStage(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var tournamentId model.TournamentId
+	var tournament *model.Tournament
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		tournamentId = model.ToTournamentId(extractPathParameter(req, "tournament_id"))
+		if tournamentId == 0 {
+			err = model.ErrInvalidTournamentId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] next stage of tournament %d", tournamentId))
+
+		//
+		// execute
+		//
+
+		tournament, err = h.tournamentService.NextStage(ctx, tournamentId)
+		if err != nil {
+			break
+		}
+		if tournament == nil {
+			err = model.ErrTournamentNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		h.encodeTournament(resp, req, tournament)
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (h *tournamentHandler) handleDeleteTournament(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var tournamentId model.TournamentId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		tournamentId = model.ToTournamentId(extractPathParameter(req, "tournament_id"))
+		if tournamentId == 0 {
+			err = model.ErrInvalidTournamentId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] delete tournament %d", tournamentId))
+
+		//
+		// execute
+		//
+
+		err = h.tournamentService.DeleteTournament(ctx, tournamentId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+// encodeTournament encodes the tournament along with its current standings.
+func (h *tournamentHandler) encodeTournament(resp http.ResponseWriter, req *http.Request, tournament *model.Tournament) {
+	standings, err := h.tournamentService.RetrieveStandings(req.Context(), tournament.Id)
+	if err == nil {
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonTournamentResponse(tournament, standings))
+	}
+	if err != nil {
+		// TODO status code
+		encodeError(resp, http.StatusBadRequest, err.Error())
+	}
+}
+
+func toJsonTournamentResponse(tournament *model.Tournament, standings []*model.TournamentStanding) *JsonTournamentResponse {
+	jsonTournament := toJsonTournament(tournament)
+	jsonTournament.Standings = util.Convert(standings, toJsonTournamentStanding)
+	return &JsonTournamentResponse{
+		Success:    true,
+		Tournament: jsonTournament,
+	}
+}
+
+func toJsonTournament(tournament *model.Tournament) *JsonTournament {
+	return &JsonTournament{
+		Id:           int64(tournament.Id),
+		Name:         tournament.Name,
+		TableSize:    tournament.TableSize,
+		PromoteTop:   tournament.PromoteTop,
+		Settings:     toJsonGameSettings(tournament.Settings),
+		Participants: util.Convert(tournament.Participants, toJsonTournamentParticipant),
+		Stages:       util.Convert(tournament.Stages, toJsonTournamentStage),
+		Finished:     tournament.Finished,
+	}
+}
+
+func toJsonTournamentParticipant(participant *model.TournamentParticipant) *JsonTournamentParticipant {
+	return &JsonTournamentParticipant{
+		Id:   int(participant.Id),
+		Name: participant.Name,
+	}
+}
+
+func toJsonTournamentStage(stage *model.TournamentStage) *JsonTournamentStage {
+	return &JsonTournamentStage{
+		Number: stage.Number,
+		Tables: util.Convert(stage.Tables, toJsonTournamentTable),
+	}
+}
+
+func toJsonTournamentTable(table *model.TournamentTable) *JsonTournamentTable {
+	return &JsonTournamentTable{
+		Number: table.Number,
+		GameId: int64(table.GameId),
+		Seats:  util.Convert(table.Seats, toJsonTournamentSeat),
+	}
+}
+
+func toJsonTournamentSeat(seat *model.TournamentSeat) *JsonTournamentSeat {
+	return &JsonTournamentSeat{
+		ParticipantId: int(seat.ParticipantId),
+		PlayerId:      int64(seat.PlayerId),
+	}
+}
+
+func toJsonTournamentStanding(standing *model.TournamentStanding) *JsonTournamentStanding {
+	return &JsonTournamentStanding{
+		ParticipantId: int(standing.ParticipantId),
+		Name:          standing.Name,
+		Stage:         standing.Stage,
+		Position:      standing.Position,
+		NbGame:        standing.NbGame,
+		Score:         standing.Score,
+		Rank:          standing.Rank,
+	}
+}
+
+type JsonTournamentResponse struct {
+	Success    bool            `json:"success,omitempty"`
+	Tournament *JsonTournament `json:"tournament,omitempty"`
+}
+
+type JsonTournament struct {
+	Id           int64                        `json:"id"`
+	Name         string                       `json:"name"`
+	TableSize    int                          `json:"tableSize"`
+	PromoteTop   int                          `json:"promoteTop"`
+	Settings     *JsonGameSettings            `json:"settings,omitempty"`
+	Participants []*JsonTournamentParticipant `json:"participants,omitempty"`
+	Stages       []*JsonTournamentStage       `json:"stages,omitempty"`
+	Standings    []*JsonTournamentStanding    `json:"standings,omitempty"`
+	Finished     bool                         `json:"finished,omitempty"`
+}
+
+type JsonTournamentParticipant struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type JsonTournamentStage struct {
+	Number int                    `json:"number"`
+	Tables []*JsonTournamentTable `json:"tables,omitempty"`
+}
+
+type JsonTournamentTable struct {
+	Number int                   `json:"number"`
+	GameId int64                 `json:"gameId"`
+	Seats  []*JsonTournamentSeat `json:"seats,omitempty"`
+}
+
+type JsonTournamentSeat struct {
+	ParticipantId int   `json:"participantId"`
+	PlayerId      int64 `json:"playerId"`
+}
+
+type JsonTournamentStanding struct {
+	ParticipantId int    `json:"participantId"`
+	Name          string `json:"name"`
+	Stage         int    `json:"stage"`
+	Position      int    `json:"position,omitempty"`
+	NbGame        int    `json:"nbGame"`
+	Score         int    `json:"score"`
+	Rank          int    `json:"rank"`
+}
+
+// //////////////////////////////////////////////////
+// decode
+
+func extractTournamentFromBody(req *http.Request, logger *zap.Logger) (*model.Tournament, error) {
+	var jsonBody JsonTournamentBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		logger.Info("failed to decode tournament body: EOF")
+		return nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		logger.Info("failed to decode tournament body", zap.Error(jsonErr))
+		return nil, model.ErrInvalidBody
+	case jsonBody.Tournament == nil:
+		logger.Info("failed to decode tournament body: missing tournament")
+		return nil, model.ErrInvalidBody
+	}
+
+	return toTournament(jsonBody.Tournament), nil
+}
+
+func toTournament(jsonTournament *JsonTournamentRequest) *model.Tournament {
+	tournament := &model.Tournament{
+		Name:       strings.TrimSpace(jsonTournament.Name),
+		TableSize:  jsonTournament.TableSize,
+		PromoteTop: jsonTournament.PromoteTop,
+		Settings:   toGameSettings(jsonTournament.Settings),
+	}
+	for _, name := range jsonTournament.Participants {
+		if name = strings.TrimSpace(name); name != "" {
+			tournament.Participants = append(tournament.Participants, &model.TournamentParticipant{Name: name})
+		}
+	}
+	if settings := tournament.Settings; settings != nil {
+		settings.Seed = time.Now().UnixMilli()
+		if len(settings.Sources) == 0 {
+			settings.Sources = append(settings.Sources, model.Source_Store)
+		}
+		for _, round := range settings.Rounds {
+			round.ApplyDefaults()
+		}
+	}
+	return tournament
+}
+
+type JsonTournamentBody struct {
+	Tournament *JsonTournamentRequest `json:"tournament,omitempty"`
+}
+
+type JsonTournamentRequest struct {
+	Name         string            `json:"name"`
+	TableSize    int               `json:"tableSize"`
+	PromoteTop   int               `json:"promoteTop"`
+	Participants []string          `json:"participants,omitempty"`
+	Settings     *JsonGameSettings `json:"settings,omitempty"`
+}
@@ -0,0 +1,520 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/julienschmidt/httprouter"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// webhook handler
+
+func NewWebhookHandler(logger *zap.Logger, webhookService service.WebhookService, sessionService service.SessionService) Handler {
+	return &webhookHandler{
+		logger:         logger,
+		webhookService: webhookService,
+		sessionService: sessionService,
+	}
+}
+
+type webhookHandler struct {
+	logger         *zap.Logger
+	webhookService service.WebhookService
+	sessionService service.SessionService
+}
+
+// //////////////////////////////////////////////////
+// register
+
+func (h *webhookHandler) RegisterRoutes(router *httprouter.Router) {
+	withWebhookPermission := WithPermission(h.logger, h.sessionService, model.Permission_Webhook)
+
+	router.HandlerFunc(http.MethodGet, "/api/webhook", withWebhookPermission(h.handleListWebhook))
+	router.HandlerFunc(http.MethodPut, "/api/webhook/new", withWebhookPermission(h.handleCreateWebhook))
+	router.HandlerFunc(http.MethodGet, "/api/webhook/:webhook_id", withWebhookPermission(h.handleRetrieveWebhook))
+	router.HandlerFunc(http.MethodPost, "/api/webhook/:webhook_id", withWebhookPermission(h.handleUpdateWebhook))
+	router.HandlerFunc(http.MethodDelete, "/api/webhook/:webhook_id", withWebhookPermission(h.handleDeleteWebhook))
+	router.HandlerFunc(http.MethodGet, "/api/webhook-delivery/:webhook_id", withWebhookPermission(h.handleListWebhookDelivery))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (h *webhookHandler) handleListWebhook(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var webhooks []*model.Webhook
+	var err error
+
+	switch {
+	default:
+
+		h.logger.Info("[api] list webhooks")
+
+		//
+		// execute
+		//
+
+		webhooks, err = h.webhookService.ListWebhooks(ctx)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode response
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonWebhooksResponse(webhooks))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (h *webhookHandler) handleCreateWebhook(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var webhook *model.Webhook
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		webhook, err = extractWebhookFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] create webhook %q", webhook.Url))
+
+		//
+		// execute
+		//
+
+		webhook, err = h.webhookService.CreateWebhook(ctx, webhook)
+		if err != nil {
+			break
+		}
+		if webhook == nil {
+			err = model.ErrWebhookNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonWebhookResponse(webhook))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (h *webhookHandler) handleRetrieveWebhook(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var webhookId model.WebhookId
+	var webhook *model.Webhook
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		webhookId = model.ToWebhookId(extractPathParameter(req, "webhook_id"))
+		if webhookId == 0 {
+			err = model.ErrInvalidWebhookId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] retrieve webhook %d", webhookId))
+
+		//
+		// execute
+		//
+
+		webhook, err = h.webhookService.RetrieveWebhook(ctx, webhookId)
+		if err != nil {
+			break
+		}
+		if webhook == nil {
+			err = model.ErrWebhookNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonWebhookResponse(webhook))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (h *webhookHandler) handleUpdateWebhook(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var webhookId model.WebhookId
+	var webhook *model.Webhook
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		webhookId = model.ToWebhookId(extractPathParameter(req, "webhook_id"))
+		if webhookId == 0 {
+			err = model.ErrInvalidWebhookId
+			break
+		}
+		webhook, err = extractWebhookFromBody(req, h.logger)
+		if err != nil {
+			break
+		}
+		if webhook.Id == 0 {
+			webhook.Id = webhookId
+		} else if webhook.Id != webhookId {
+			err = model.ErrInvalidWebhookId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] update webhook %d", webhookId))
+
+		//
+		// execute
+		//
+
+		webhook, err = h.webhookService.UpdateWebhook(ctx, webhook)
+		if err != nil {
+			break
+		}
+		if webhook == nil {
+			err = model.ErrWebhookNotFound
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonWebhookResponse(webhook))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (h *webhookHandler) handleDeleteWebhook(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var webhookId model.WebhookId
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		webhookId = model.ToWebhookId(extractPathParameter(req, "webhook_id"))
+		if webhookId == 0 {
+			err = model.ErrInvalidWebhookId
+			break
+		}
+		h.logger.Info(fmt.Sprintf("[api] delete webhook %d", webhookId))
+
+		//
+		// execute
+		//
+
+		err = h.webhookService.DeleteWebhook(ctx, webhookId)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonSuccess())
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// deliveries
+
+func (h *webhookHandler) handleListWebhookDelivery(resp http.ResponseWriter, req *http.Request) {
+	defer onPanic(resp)()
+
+	ctx := req.Context()
+
+	var filter *model.WebhookDeliveryFilter
+	var deliveries []*model.WebhookDelivery
+	var err error
+
+	switch {
+	default:
+
+		//
+		// decode request
+		//
+
+		filter = &model.WebhookDeliveryFilter{
+			WebhookId: model.ToWebhookId(extractPathParameter(req, "webhook_id")),
+			Limit:     100,
+		}
+		if filter.WebhookId == 0 {
+			err = model.ErrInvalidWebhookId
+			break
+		}
+		if value := extractParameter(req, "limit"); value != "" {
+			filter.Limit = toInt(value)
+		}
+		h.logger.Info(fmt.Sprintf("[api] list deliveries of webhook %d", filter.WebhookId))
+
+		//
+		// execute
+		//
+
+		deliveries, err = h.webhookService.ListDeliveries(ctx, filter)
+		if err != nil {
+			break
+		}
+
+		//
+		// encode success
+		//
+
+		resp.Header().Set("Content-Type", "application/json")
+		resp.WriteHeader(http.StatusOK)
+		err = json.NewEncoder(resp).Encode(toJsonWebhookDeliveriesResponse(deliveries))
+		if err != nil {
+			break
+		}
+		return
+	}
+
+	//
+	// encode error
+	//
+
+	// TODO status code
+	encodeError(resp, http.StatusBadRequest, err.Error())
+}
+
+// //////////////////////////////////////////////////
+// decode
+
+func extractWebhookFromBody(req *http.Request, logger *zap.Logger) (*model.Webhook, error) {
+	var jsonBody JsonWebhookBody
+	jsonErr := json.NewDecoder(req.Body).Decode(&jsonBody)
+	switch {
+	case jsonErr == io.EOF:
+		logger.Info("failed to decode webhook body: EOF")
+		return nil, model.ErrInvalidBody
+	case jsonErr != nil:
+		logger.Info("failed to decode webhook body", zap.Error(jsonErr))
+		return nil, model.ErrInvalidBody
+	case jsonBody.Webhook == nil:
+		logger.Info("failed to decode webhook body: missing webhook")
+		return nil, model.ErrInvalidBody
+	}
+
+	return toWebhook(jsonBody.Webhook), nil
+}
+
+func toWebhook(jsonWebhook *JsonWebhook) *model.Webhook {
+	webhook := &model.Webhook{
+		Id:     model.WebhookId(jsonWebhook.Id),
+		Url:    strings.TrimSpace(jsonWebhook.Url),
+		Secret: jsonWebhook.Secret,
+		Events: util.Convert(jsonWebhook.Events, model.ToWebhookEventType),
+		Active: true,
+	}
+	if jsonWebhook.Active != nil {
+		webhook.Active = *jsonWebhook.Active
+	}
+	return webhook
+}
+
+type JsonWebhookBody struct {
+	Webhook *JsonWebhook `json:"webhook,omitempty"`
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func toJsonWebhooksResponse(webhooks []*model.Webhook) *JsonWebhooksResponse {
+	return &JsonWebhooksResponse{
+		Success:  true,
+		Webhooks: util.Convert(webhooks, toJsonWebhook),
+	}
+}
+
+func toJsonWebhookResponse(webhook *model.Webhook) *JsonWebhookResponse {
+	return &JsonWebhookResponse{
+		Success: true,
+		Webhook: toJsonWebhook(webhook),
+	}
+}
+
+func toJsonWebhook(webhook *model.Webhook) *JsonWebhook {
+	return &JsonWebhook{
+		Id:     int64(webhook.Id),
+		Url:    webhook.Url,
+		Secret: webhook.Secret,
+		Events: util.Convert(webhook.Events, model.WebhookEventType.String),
+		Active: &webhook.Active,
+	}
+}
+
+func toJsonWebhookDeliveriesResponse(deliveries []*model.WebhookDelivery) *JsonWebhookDeliveriesResponse {
+	return &JsonWebhookDeliveriesResponse{
+		Success:    true,
+		Deliveries: util.Convert(deliveries, toJsonWebhookDelivery),
+	}
+}
+
+func toJsonWebhookDelivery(delivery *model.WebhookDelivery) *JsonWebhookDelivery {
+	return &JsonWebhookDelivery{
+		Id:          int64(delivery.Id),
+		WebhookId:   int64(delivery.WebhookId),
+		Event:       delivery.Event.String(),
+		GameId:      int64(delivery.GameId),
+		Attempt:     delivery.Attempt,
+		StatusCode:  delivery.StatusCode,
+		Error:       delivery.Error,
+		Success:     delivery.Success,
+		DeliveredAt: delivery.DeliveredAt.Unix(),
+	}
+}
+
+type JsonWebhooksResponse struct {
+	Success  bool           `json:"success,omitempty"`
+	Webhooks []*JsonWebhook `json:"webhooks"`
+}
+
+type JsonWebhookResponse struct {
+	Success bool         `json:"success,omitempty"`
+	Webhook *JsonWebhook `json:"webhook,omitempty"`
+}
+
+type JsonWebhook struct {
+	Id     int64    `json:"id,omitempty"`
+	Url    string   `json:"url"`
+	Secret string   `json:"secret,omitempty"`
+	Events []string `json:"events,omitempty"`
+	Active *bool    `json:"active,omitempty"`
+}
+
+type JsonWebhookDeliveriesResponse struct {
+	Success    bool                   `json:"success,omitempty"`
+	Deliveries []*JsonWebhookDelivery `json:"deliveries"`
+}
+
+type JsonWebhookDelivery struct {
+	Id          int64  `json:"id"`
+	WebhookId   int64  `json:"webhookId"`
+	Event       string `json:"event"`
+	GameId      int64  `json:"gameId,omitempty"`
+	Attempt     int    `json:"attempt"`
+	StatusCode  int    `json:"statusCode,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Success     bool   `json:"success"`
+	DeliveredAt int64  `json:"deliveredAt"`
+}
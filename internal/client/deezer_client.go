@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gre-ory/amnezic-go/internal/model"
 	"github.com/gre-ory/amnezic-go/internal/util"
@@ -78,9 +79,48 @@ func (c *deezerClient) GetMusic(trackId model.DeezerMusicId) (*model.Music, erro
 		return nil, err
 	}
 
+	// the release date and the genres are only provided by the album
+	if jsonTrack.Album != nil && jsonTrack.Album.Id != 0 {
+		jsonAlbum, err := c.getAlbum(jsonTrack.Album.Id)
+		if err != nil {
+			c.logger.Info(fmt.Sprintf("[client] get-music: not able to fetch album %d >>> no release metadata", jsonTrack.Album.Id), zap.Error(err))
+		} else {
+			jsonTrack.Album.ReleaseDate = jsonAlbum.ReleaseDate
+			jsonTrack.Album.Genres = jsonAlbum.Genres
+		}
+	}
+
 	return toMusic(&jsonTrack), nil
 }
 
+// //////////////////////////////////////////////////
+// get album
+
+func (c *deezerClient) getAlbum(albumId int64) (*JsonDeezerAlbum, error) {
+
+	url := fmt.Sprintf("https://api.deezer.com/album/%d", albumId)
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad status: %s", resp.Status)
+	}
+
+	var jsonAlbum JsonDeezerAlbum
+	err = json.NewDecoder(resp.Body).Decode(&jsonAlbum)
+	if err != nil {
+		return nil, err
+	}
+	// deezer reports a missing album as an error object with a 200 status
+	if jsonAlbum.Id != albumId {
+		return nil, fmt.Errorf("album %d not found", albumId)
+	}
+	return &jsonAlbum, nil
+}
+
 // //////////////////////////////////////////////////
 // search playlists
 
@@ -156,13 +196,31 @@ func (c *deezerClient) GetPlaylist(id model.DeezerPlaylistId, withTracks bool) (
 // adapter
 
 func toMusic(jsonTrack *JsonDeezerTrack) *model.Music {
-	return &model.Music{
-		DeezerId: model.DeezerMusicId(jsonTrack.Id),
-		Name:     jsonTrack.Title,
-		Mp3Url:   model.Url(jsonTrack.Preview),
-		Artist:   toArtist(jsonTrack.Artist),
-		Album:    toAlbum(jsonTrack.Album),
+	music := &model.Music{
+		DeezerId:     model.DeezerMusicId(jsonTrack.Id),
+		Name:         jsonTrack.Title,
+		Mp3Url:       model.Url(jsonTrack.Preview),
+		ReleaseDate:  jsonTrack.ReleaseDate,
+		Duration:     time.Duration(jsonTrack.Duration) * time.Second,
+		Explicit:     jsonTrack.ExplicitLyrics,
+		Rank:         int(jsonTrack.Rank),
+		Artist:       toArtist(jsonTrack.Artist),
+		Album:        toAlbum(jsonTrack.Album),
+		Contributors: toContributors(jsonTrack.Contributors),
 	}
+	if jsonTrack.Album != nil {
+		if music.ReleaseDate == "" {
+			music.ReleaseDate = jsonTrack.Album.ReleaseDate
+		}
+		if jsonTrack.Album.Genres != nil && len(jsonTrack.Album.Genres.Genres) > 0 {
+			music.Genre = jsonTrack.Album.Genres.Genres[0].Name
+		}
+	}
+	if model.ToMusicYear(music.ReleaseDate) == 0 {
+		// deezer uses 0000-00-00 for unknown dates
+		music.ReleaseDate = ""
+	}
+	return music.WithReleaseYear()
 }
 
 func toArtist(jsonArtist *JsonDeezerArtist) *model.MusicArtist {
@@ -176,15 +234,47 @@ func toArtist(jsonArtist *JsonDeezerArtist) *model.MusicArtist {
 	}
 }
 
+// toContributors keeps the contributors having a known role ( deezer uses "Main" and "Featured" ).
+func toContributors(jsonContributors []*JsonDeezerContributor) []*model.MusicContributor {
+	contributors := make([]*model.MusicContributor, 0, len(jsonContributors))
+	for _, jsonContributor := range jsonContributors {
+		role := model.ToMusicArtistRole(jsonContributor.Role)
+		if role == "" {
+			continue
+		}
+		contributors = append(contributors, &model.MusicContributor{
+			Role: role,
+			Artist: &model.MusicArtist{
+				DeezerId: model.DeezerArtistId(jsonContributor.Id),
+				Name:     jsonContributor.Name,
+				ImgUrl:   model.Url(jsonContributor.Picture),
+			},
+		})
+	}
+	return contributors
+}
+
 func toAlbum(jsonAlbum *JsonDeezerAlbum) *model.MusicAlbum {
 	if jsonAlbum == nil {
 		return nil
 	}
-	return &model.MusicAlbum{
+	album := &model.MusicAlbum{
 		DeezerId: model.DeezerAlbumId(jsonAlbum.Id),
 		Name:     jsonAlbum.Title,
 		ImgUrl:   model.Url(jsonAlbum.Cover),
 	}
+	if jsonAlbum.Genres != nil {
+		album.Genres = util.Convert(jsonAlbum.Genres.Genres, toGenre)
+	}
+	return album
+}
+
+func toGenre(jsonGenre *JsonDeezerGenre) *model.MusicGenre {
+	return &model.MusicGenre{
+		DeezerId: model.DeezerGenreId(jsonGenre.Id),
+		Name:     jsonGenre.Name,
+		ImgUrl:   model.Url(jsonGenre.Picture),
+	}
 }
 
 func toPlaylist(jsonPlaylist *JsonDeezerPlaylist) *model.Playlist {
@@ -217,6 +307,9 @@ type JsonDeezerTrack struct {
 	Title              string                   `json:"title"`
 	TitleShort         string                   `json:"title_short"`
 	Duration           int64                    `json:"duration"`
+	ReleaseDate        string                   `json:"release_date"`
+	ExplicitLyrics     bool                     `json:"explicit_lyrics"`
+	Rank               int64                    `json:"rank"`
 	Preview            string                   `json:"preview"`
 	AvailableCountries []string                 `json:"available_countries"`
 	Contributors       []*JsonDeezerContributor `json:"contributors"`
@@ -241,11 +334,23 @@ type JsonDeezerArtist struct {
 }
 
 type JsonDeezerAlbum struct {
-	Id    int64  `json:"id"`
-	Title string `json:"title"`
-	Cover string `json:"cover"`
-	Type  string `json:"type"`
-	Role  string `json:"role"`
+	Id          int64             `json:"id"`
+	Title       string            `json:"title"`
+	Cover       string            `json:"cover"`
+	Type        string            `json:"type"`
+	Role        string            `json:"role"`
+	ReleaseDate string            `json:"release_date"`
+	Genres      *JsonDeezerGenres `json:"genres"`
+}
+
+type JsonDeezerGenres struct {
+	Genres []*JsonDeezerGenre `json:"data"`
+}
+
+type JsonDeezerGenre struct {
+	Id      int64  `json:"id"`
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
 }
 
 type JsonDeezerSearchPlaylists struct {
@@ -17,6 +17,7 @@ import (
 type DownloadClient interface {
 	DownloadMusic(url model.Url, fileName model.Url) (err error)
 	DownloadImage(url model.Url, fileName model.Url) (err error)
+	Download(url model.Url, writer io.Writer) (err error)
 }
 
 func NewDownloadClient(logger *zap.Logger, musicFilter *model.FileFilter, imageFilter *model.FileFilter) DownloadClient {
@@ -44,6 +45,11 @@ func (c *downloadClient) DownloadImage(url model.Url, fileName model.Url) error
 	return c.downloadFile(url, fileName, c.imageFilter)
 }
 
+// Download writes the remote file to the writer ( e.g. an entry of a game pack ) instead of a local file.
+func (c *downloadClient) Download(url model.Url, writer io.Writer) error {
+	return c.download(url, writer)
+}
+
 func (c *downloadClient) downloadFile(url model.Url, fileName model.Url, filter *model.FileFilter) error {
 
 	filePath := filepath.Join(filter.Directory, string(fileName))
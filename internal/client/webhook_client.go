@@ -0,0 +1,235 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+const (
+	WebhookEventHeader     = "X-Amnezic-Event"
+	WebhookSignatureHeader = "X-Amnezic-Signature"
+	WebhookAttemptHeader   = "X-Amnezic-Attempt"
+)
+
+// //////////////////////////////////////////////////
+// webhook client
+
+type WebhookClient interface {
+	Deliver(ctx context.Context, webhook *model.Webhook, event *model.WebhookEvent) []*model.WebhookDelivery
+}
+
+// NewWebhookClient returns a client posting events up to maxAttempt times, waiting backoff, then twice as long, ... between attempts.
+func NewWebhookClient(logger *zap.Logger, maxAttempt int, backoff time.Duration, timeout time.Duration) WebhookClient {
+	return &webhookClient{
+		logger:     logger,
+		maxAttempt: maxAttempt,
+		backoff:    backoff,
+		httpClient: &http.Client{Timeout: timeout},
+	}
+}
+
+type webhookClient struct {
+	logger     *zap.Logger
+	maxAttempt int
+	backoff    time.Duration
+	httpClient *http.Client
+}
+
+// //////////////////////////////////////////////////
+// deliver
+
+func (c *webhookClient) Deliver(ctx context.Context, webhook *model.Webhook, event *model.WebhookEvent) []*model.WebhookDelivery {
+
+	payload, err := json.Marshal(toJsonWebhookPayload(event))
+	if err != nil {
+		c.logger.Info(fmt.Sprintf("[client] webhook %d: >>> error: %s", webhook.Id, err.Error()), zap.Error(err))
+		return []*model.WebhookDelivery{c.newDelivery(webhook, event, 1, 0, err)}
+	}
+
+	deliveries := make([]*model.WebhookDelivery, 0, c.maxAttempt)
+	backoff := c.backoff
+	for attempt := 1; attempt <= c.maxAttempt; attempt++ {
+		if attempt > 1 {
+			select {
+			case <-ctx.Done():
+				return deliveries
+			case <-time.After(backoff):
+			}
+			backoff *= 2
+		}
+
+		c.logger.Info(fmt.Sprintf("[client] webhook %d: post %s to %s ( attempt %d )", webhook.Id, event.Type, webhook.Url, attempt))
+		statusCode, err := c.post(ctx, webhook, event, payload, attempt)
+		delivery := c.newDelivery(webhook, event, attempt, statusCode, err)
+		deliveries = append(deliveries, delivery)
+		if delivery.Success {
+			return deliveries
+		}
+		c.logger.Info(fmt.Sprintf("[client] webhook %d: >>> failed ( attempt %d )", webhook.Id, attempt), zap.Object("delivery", delivery))
+	}
+	return deliveries
+}
+
+func (c *webhookClient) post(ctx context.Context, webhook *model.Webhook, event *model.WebhookEvent, payload []byte, attempt int) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.Url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, event.Type.String())
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(webhook.Secret, payload))
+	req.Header.Set(WebhookAttemptHeader, fmt.Sprintf("%d", attempt))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (c *webhookClient) newDelivery(webhook *model.Webhook, event *model.WebhookEvent, attempt int, statusCode int, err error) *model.WebhookDelivery {
+	delivery := &model.WebhookDelivery{
+		WebhookId:   webhook.Id,
+		Event:       event.Type,
+		Attempt:     attempt,
+		StatusCode:  statusCode,
+		Success:     err == nil,
+		DeliveredAt: time.Now(),
+	}
+	if event.Game != nil {
+		delivery.GameId = event.Game.Id
+	}
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	return delivery
+}
+
+// //////////////////////////////////////////////////
+// signature
+
+// SignWebhookPayload returns the hex encoded HMAC-SHA256 of the payload, as sent in the signature header.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// //////////////////////////////////////////////////
+// payload
+
+func toJsonWebhookPayload(event *model.WebhookEvent) *JsonWebhookPayload {
+	payload := &JsonWebhookPayload{
+		Event:      event.Type.String(),
+		OccurredAt: event.OccurredAt.Unix(),
+	}
+	if event.Game != nil {
+		payload.Game = toJsonWebhookGame(event.Game)
+		if event.Type == model.WebhookEventType_GameFinished {
+			payload.Result = util.Convert(event.Game.Result().Players, toJsonWebhookPlayer)
+		}
+	}
+	if event.Question != nil {
+		payload.Question = toJsonWebhookQuestion(event.Question)
+	}
+	return payload
+}
+
+func toJsonWebhookGame(game *model.Game) *JsonWebhookGame {
+	return &JsonWebhookGame{
+		Id:         int64(game.Id),
+		NbRound:    len(game.Rounds),
+		NbQuestion: len(game.Questions),
+		NbPlayer:   len(game.Players),
+	}
+}
+
+func toJsonWebhookQuestion(question *model.GameQuestion) *JsonWebhookQuestion {
+	jsonQuestion := &JsonWebhookQuestion{
+		Id:    int64(question.Id),
+		Round: question.Round,
+	}
+	if question.Theme != nil {
+		jsonQuestion.Theme = question.Theme.Title
+	}
+	for _, answer := range question.Answers {
+		if answer.Correct {
+			jsonQuestion.Answer = answer.Text
+		}
+	}
+	if question.IsYearQuestion() {
+		jsonQuestion.Answer = strconv.Itoa(question.Year)
+	}
+	if question.IsSlotQuestion() {
+		parts := make([]string, 0, len(question.Slots))
+		for _, slot := range question.Slots {
+			parts = append(parts, model.GuessValue(slot.Guess, question.Music))
+		}
+		jsonQuestion.Answer = strings.Join(parts, " / ")
+	}
+	if question.Music != nil {
+		jsonQuestion.Music = question.Music.Name
+		if question.Music.Artist != nil {
+			jsonQuestion.Artist = question.Music.Artist.Name
+		}
+	}
+	return jsonQuestion
+}
+
+func toJsonWebhookPlayer(player *model.GamePlayerResult) *JsonWebhookPlayer {
+	return &JsonWebhookPlayer{
+		Id:    int64(player.PlayerId),
+		Name:  player.Name,
+		Score: player.Score,
+	}
+}
+
+type JsonWebhookPayload struct {
+	Event      string               `json:"event"`
+	OccurredAt int64                `json:"occurredAt"`
+	Game       *JsonWebhookGame     `json:"game,omitempty"`
+	Question   *JsonWebhookQuestion `json:"question,omitempty"`
+	Result     []*JsonWebhookPlayer `json:"result,omitempty"`
+}
+
+type JsonWebhookGame struct {
+	Id         int64 `json:"id"`
+	NbRound    int   `json:"nbRound"`
+	NbQuestion int   `json:"nbQuestion"`
+	NbPlayer   int   `json:"nbPlayer"`
+}
+
+type JsonWebhookQuestion struct {
+	Id     int64  `json:"id"`
+	Round  int    `json:"round,omitempty"`
+	Theme  string `json:"theme,omitempty"`
+	Answer string `json:"answer,omitempty"`
+	Music  string `json:"music,omitempty"`
+	Artist string `json:"artist,omitempty"`
+}
+
+type JsonWebhookPlayer struct {
+	Id    int64  `json:"id"`
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
@@ -0,0 +1,78 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/client"
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWebhookDeliver(t *testing.T) {
+
+	var attempts int
+	var payload client.JsonWebhookPayload
+	receiver := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		attempts++
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.Equal(t, client.SignWebhookPayload("my-secret", body), req.Header.Get(client.WebhookSignatureHeader), "wrong signature")
+		require.Equal(t, "game-created", req.Header.Get(client.WebhookEventHeader), "wrong event")
+		require.NoError(t, json.Unmarshal(body, &payload))
+
+		// first attempt fails
+		if attempts == 1 {
+			resp.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		resp.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	webhookClient := client.NewWebhookClient(zap.L(), 3, time.Millisecond, time.Second)
+	webhook := &model.Webhook{Id: 1, Url: receiver.URL, Secret: "my-secret", Active: true}
+	game := &model.Game{Id: model.NewGameId(1), Players: []*model.GamePlayer{{Id: model.NewGamePlayerId(1)}}}
+
+	deliveries := webhookClient.Deliver(context.Background(), webhook, model.NewGameWebhookEvent(model.WebhookEventType_GameCreated, game))
+
+	require.Len(t, deliveries, 2, "wrong number of attempts")
+	require.False(t, deliveries[0].Success)
+	require.Equal(t, http.StatusInternalServerError, deliveries[0].StatusCode)
+	require.True(t, deliveries[1].Success)
+	require.Equal(t, 2, deliveries[1].Attempt)
+	require.Equal(t, http.StatusNoContent, deliveries[1].StatusCode)
+	require.Equal(t, model.NewGameId(1), deliveries[1].GameId)
+
+	require.Equal(t, "game-created", payload.Event)
+	require.Equal(t, int64(model.NewGameId(1)), payload.Game.Id)
+	require.Equal(t, 1, payload.Game.NbPlayer)
+}
+
+func TestWebhookDeliverGiveUp(t *testing.T) {
+
+	var attempts int
+	receiver := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		attempts++
+		resp.WriteHeader(http.StatusBadGateway)
+	}))
+	defer receiver.Close()
+
+	webhookClient := client.NewWebhookClient(zap.L(), 3, time.Millisecond, time.Second)
+	webhook := &model.Webhook{Id: 1, Url: receiver.URL, Secret: "my-secret", Active: true}
+
+	deliveries := webhookClient.Deliver(context.Background(), webhook, model.NewGameWebhookEvent(model.WebhookEventType_GameFinished, &model.Game{}))
+
+	require.Equal(t, 3, attempts)
+	require.Len(t, deliveries, 3)
+	for _, delivery := range deliveries {
+		require.False(t, delivery.Success)
+		require.NotEmpty(t, delivery.Error)
+	}
+}
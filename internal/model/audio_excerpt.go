@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// audio excerpt
+
+// AudioExcerpt is the part of the mp3 played for a question: from the start offset, for the given duration ( 0 means until the end ).
+type AudioExcerpt struct {
+	Start    time.Duration
+	Duration time.Duration
+}
+
+func (o AudioExcerpt) IsZero() bool {
+	return o.Start == 0 && o.Duration == 0
+}
+
+func (o AudioExcerpt) Validate() error {
+	if o.Start < 0 || o.Duration < 0 {
+		return ErrInvalidAudioExcerpt
+	}
+	return nil
+}
+
+func (o AudioExcerpt) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddDuration("start", o.Start)
+	if o.Duration != 0 {
+		enc.AddDuration("duration", o.Duration)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// daily challenge date
+
+const (
+	DailyChallengeDateLayout = "2006-01-02"
+
+	// runs started before midnight can still be submitted for the previous day
+	DailyChallengeGracePeriod = 30 * time.Minute
+
+	// the game numbers of the challenges are far beyond the ones of the regular games
+	DailyChallengeGameNumberStart = 100000000
+)
+
+type DailyChallengeDate string
+
+func NewDailyChallengeDate(t time.Time) DailyChallengeDate {
+	return DailyChallengeDate(t.UTC().Format(DailyChallengeDateLayout))
+}
+
+func ToDailyChallengeDate(value string) DailyChallengeDate {
+	t, err := time.Parse(DailyChallengeDateLayout, value)
+	if err != nil {
+		return ""
+	}
+	return NewDailyChallengeDate(t)
+}
+
+func (o DailyChallengeDate) String() string {
+	return string(o)
+}
+
+func (o DailyChallengeDate) Time() time.Time {
+	t, _ := time.Parse(DailyChallengeDateLayout, string(o))
+	return t
+}
+
+func (o DailyChallengeDate) Next() DailyChallengeDate {
+	return NewDailyChallengeDate(o.Time().AddDate(0, 0, 1))
+}
+
+// GameId is the id of the game of the challenge, taken out of the id space of the regular games.
+func (o DailyChallengeDate) GameId() GameId {
+	return NewGameId(DailyChallengeGameNumberStart + int(o.Seed()))
+}
+
+// CanSubmitAt tells whether a run of the challenge can be submitted at the given time:
+// the challenge of the day, or the one of the day before during the grace period following midnight.
+func (o DailyChallengeDate) CanSubmitAt(t time.Time) bool {
+	return o == NewDailyChallengeDate(t) || o == NewDailyChallengeDate(t.Add(-DailyChallengeGracePeriod))
+}
+
+// Seed derives the game seed from the date ( e.g. 20240131 ) so that everyone gets the same questions.
+func (o DailyChallengeDate) Seed() int64 {
+	t := o.Time()
+	return int64(t.Year()*10000 + int(t.Month())*100 + t.Day())
+}
+
+// //////////////////////////////////////////////////
+// daily challenge settings
+
+type DailyChallengeSettings struct {
+	Sources    []Source
+	ThemeIds   []ThemeId
+	NbQuestion int
+	NbAnswer   int
+}
+
+func (o *DailyChallengeSettings) ToGameSettings(date DailyChallengeDate) *GameSettings {
+	sources := o.Sources
+	if len(sources) == 0 {
+		sources = []Source{Source_Store}
+	}
+	return &GameSettings{
+		Seed:       date.Seed(),
+		NbQuestion: o.NbQuestion,
+		NbAnswer:   o.NbAnswer,
+		// a run is played solo by the user submitting it, the generated players are not used
+		NbPlayer: MinNbPlayer,
+		Sources:  sources,
+		ThemeIds: o.ThemeIds,
+	}
+}
+
+// //////////////////////////////////////////////////
+// daily challenge
+
+type DailyChallenge struct {
+	Date     DailyChallengeDate
+	Settings *GameSettings
+
+	// consolidated data
+	Game *Game
+}
+
+func (o *DailyChallenge) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("date", o.Date.String())
+	if o.Settings != nil {
+		enc.AddObject("settings", o.Settings)
+	}
+	if o.Game != nil {
+		enc.AddInt("nb-questions", len(o.Game.Questions))
+	}
+	return nil
+}
+
+// Play scores a solo run made of one answer per question on a copy of the challenge game.
+func (o *DailyChallenge) Play(answers map[GameQuestionId]GameAnswerId) (*DailyChallengeRun, error) {
+	if o.Game == nil {
+		return nil, ErrDailyChallengeNotFound
+	}
+	game := o.Game.Copy()
+	playerId := NewGamePlayerId(1)
+	game.Players = []*GamePlayer{
+		{Id: playerId, Active: true},
+	}
+
+	run := &DailyChallengeRun{
+		Date: o.Date,
+	}
+	for questionId, answerId := range answers {
+		playerAnswer, err := game.AnswerQuestion(questionId, playerId, answerId)
+		if err != nil {
+			return nil, err
+		}
+		if playerAnswer.Correct {
+			run.NbCorrect++
+		}
+	}
+	run.Score = game.FindPlayer(playerId).Score
+	return run, nil
+}
+
+// //////////////////////////////////////////////////
+// daily challenge run
+
+type DailyChallengeRunId int64
+
+type DailyChallengeRun struct {
+	Id          DailyChallengeRunId
+	Date        DailyChallengeDate
+	UserId      UserId
+	UserName    string
+	Score       int
+	NbCorrect   int
+	SubmittedAt time.Time
+
+	// consolidated data
+	Rank int
+}
+
+func (o *DailyChallengeRun) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("id", int64(o.Id))
+	enc.AddString("date", o.Date.String())
+	enc.AddInt64("user-id", int64(o.UserId))
+	enc.AddInt("score", o.Score)
+	enc.AddInt("nb-correct", o.NbCorrect)
+	return nil
+}
@@ -0,0 +1,65 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestDailyChallengeDate(t *testing.T) {
+	date := model.NewDailyChallengeDate(time.Date(2024, 12, 31, 23, 30, 0, 0, time.UTC))
+	require.Equal(t, model.DailyChallengeDate("2024-12-31"), date)
+	require.Equal(t, int64(20241231), date.Seed())
+	require.Equal(t, model.DailyChallengeDate("2025-01-01"), date.Next())
+
+	require.Equal(t, date, model.ToDailyChallengeDate("2024-12-31"))
+	require.Equal(t, model.DailyChallengeDate(""), model.ToDailyChallengeDate("31/12/2024"))
+}
+
+func TestDailyChallengeCanSubmitAt(t *testing.T) {
+	date := model.DailyChallengeDate("2024-12-31")
+	require.True(t, date.CanSubmitAt(time.Date(2024, 12, 31, 23, 59, 0, 0, time.UTC)))
+	require.True(t, date.CanSubmitAt(time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC)))
+	require.False(t, date.CanSubmitAt(time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)))
+	require.False(t, date.CanSubmitAt(time.Date(2024, 12, 30, 23, 59, 0, 0, time.UTC)))
+	require.False(t, model.DailyChallengeDate("").CanSubmitAt(time.Now()))
+}
+
+func TestDailyChallengeGameId(t *testing.T) {
+	gameId := model.DailyChallengeDate("2024-12-31").GameId()
+	require.NotEqual(t, gameId, model.DailyChallengeDate("2025-01-01").GameId())
+	require.Greater(t, gameId, model.NewGameId(model.DailyChallengeGameNumberStart))
+
+	questionId := model.NewGameQuestionId(gameId, 12)
+	require.Equal(t, gameId, questionId.Split())
+}
+
+func TestDailyChallengePlay(t *testing.T) {
+	game := newTestGame()
+	game.Players = game.Players[:1]
+	challenge := &model.DailyChallenge{
+		Date: "2024-12-31",
+		Game: game,
+	}
+	q1, q2 := game.Questions[0], game.Questions[1]
+
+	run, err := challenge.Play(map[model.GameQuestionId]model.GameAnswerId{
+		q1.Id: q1.Answers[0].Id,
+		q2.Id: q2.Answers[1].Id,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, run.NbCorrect)
+	require.Equal(t, 0, run.Score)
+
+	// the challenge game is left untouched
+	require.Empty(t, q1.PlayerAnswers)
+	require.Equal(t, 0, game.Players[0].Score)
+
+	_, err = challenge.Play(map[model.GameQuestionId]model.GameAnswerId{
+		q1.Id: q2.Answers[0].Id,
+	})
+	require.Error(t, err)
+}
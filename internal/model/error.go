@@ -10,25 +10,101 @@ var (
 	ErrGameNotFound                = fmt.Errorf("game not found")
 	ErrConcurrentUpdate            = fmt.Errorf("concurrent update")
 	ErrInvalidGameId               = fmt.Errorf("invalid game id")
+	ErrNotGameOwner                = fmt.Errorf("not game owner")
+	ErrGamePresetNotFound          = fmt.Errorf("game preset not found")
+	ErrInvalidGamePresetId         = fmt.Errorf("invalid game preset id")
+	ErrInvalidGamePresetName       = fmt.Errorf("invalid game preset name")
+	ErrMissingGamePresetSettings   = fmt.Errorf("missing game preset settings")
+	ErrNotGamePresetOwner          = fmt.Errorf("not game preset owner")
 	ErrInvalidMusicId              = fmt.Errorf("invalid music id")
 	ErrInvalidMusicArtistId        = fmt.Errorf("invalid music artist id")
 	ErrInvalidMusicAlbumId         = fmt.Errorf("invalid music album id")
+	ErrInvalidMusicGenreId         = fmt.Errorf("invalid music genre id")
 	ErrInvalidThemeId              = fmt.Errorf("invalid theme id")
 	ErrInvalidThemeQuestionId      = fmt.Errorf("invalid theme question id")
 	ErrInvalidDeezerId             = fmt.Errorf("invalid deezer id")
 	ErrInvalidMusicName            = fmt.Errorf("invalid music name")
 	ErrInvalidArtistName           = fmt.Errorf("invalid artist name")
 	ErrInvalidAlbumName            = fmt.Errorf("invalid album name")
+	ErrInvalidGenreName            = fmt.Errorf("invalid genre name")
 	ErrInvalidMusicUrl             = fmt.Errorf("invalid music url")
+	ErrInvalidMusicYear            = fmt.Errorf("invalid music year")
+	ErrInvalidMusicReleaseDate     = fmt.Errorf("invalid music release date")
+	ErrInvalidMusicDuration        = fmt.Errorf("invalid music duration")
+	ErrInvalidMusicRank            = fmt.Errorf("invalid music rank")
+	ErrInvalidMusicSort            = fmt.Errorf("invalid music sort")
+	ErrInvalidMusicArtistRole      = fmt.Errorf("invalid music artist role")
+	ErrInvalidSearchQuery          = fmt.Errorf("invalid search query")
+	ErrSearchEntryNotFound         = fmt.Errorf("search entry not found")
+	ErrSearchNotSupported          = fmt.Errorf("sqlite fts5 not available: build with -tags sqlite_fts5")
 	ErrInvalidImageUrl             = fmt.Errorf("invalid image url")
 	ErrMissingMusic                = fmt.Errorf("missing music")
 	ErrMissingArtist               = fmt.Errorf("missing artist")
 	ErrMissingAlbum                = fmt.Errorf("missing album")
+	ErrMissingGenre                = fmt.Errorf("missing genre")
 	ErrInvalidMusicLocalUrl        = fmt.Errorf("invalid music local url")
 	ErrInvalidNbPlayer             = fmt.Errorf("invalid number of player")
 	ErrInvalidNbQuestion           = fmt.Errorf("invalid number of question")
 	ErrInvalidNbAnswer             = fmt.Errorf("invalid number of answer")
 	ErrMissingSource               = fmt.Errorf("missing source")
+	ErrInvalidGameQuestionType     = fmt.Errorf("invalid game question type")
+	ErrInvalidGameMediaKind        = fmt.Errorf("invalid game media kind")
+	ErrInvalidGameGuess            = fmt.Errorf("invalid game guess")
+	ErrInvalidGameDecade           = fmt.Errorf("invalid game decade")
+	ErrInvalidGameRounds           = fmt.Errorf("invalid game rounds")
+	ErrEmptyMusicBucket            = fmt.Errorf("empty music bucket")
+	ErrInvalidGameYearScoring      = fmt.Errorf("invalid game year scoring")
+	ErrInvalidGameSlot             = fmt.Errorf("invalid game slot")
+	ErrDuplicateGameSlot           = fmt.Errorf("duplicate game slot")
+	ErrGameQuestionNotFound        = fmt.Errorf("game question not found")
+	ErrGameAnswerNotFound          = fmt.Errorf("game answer not found")
+	ErrInvalidGameYear             = fmt.Errorf("invalid game year")
+	ErrNotYearQuestion             = fmt.Errorf("not a year question")
+	ErrNotSlotQuestion             = fmt.Errorf("not a multi-part question")
+	ErrGameSlotNotFound            = fmt.Errorf("game slot not found")
+	ErrEmptyGameSlotAnswer         = fmt.Errorf("empty game slot answer")
+	ErrIncompleteGameSlotAnswer    = fmt.Errorf("incomplete multi-part answer")
+	ErrGamePlayerNotFound          = fmt.Errorf("game player not found")
+	ErrAlreadyAnswered             = fmt.Errorf("already answered")
+	ErrQuestionClosed              = fmt.Errorf("question closed")
+	ErrQuestionSkipped             = fmt.Errorf("question skipped")
+	ErrQuestionAlreadyRevealed     = fmt.Errorf("question already revealed")
+	ErrGameFinished                = fmt.Errorf("game finished")
+	ErrInvalidPlayerName           = fmt.Errorf("invalid player name")
+	ErrInactivePlayer              = fmt.Errorf("inactive player")
+	ErrInvalidScoreAdjustment      = fmt.Errorf("invalid score adjustment")
+	ErrMissingAdjustmentReason     = fmt.Errorf("missing score adjustment reason")
+	ErrInvalidGameEvent            = fmt.Errorf("invalid game event")
+	ErrGameEventNotFound           = fmt.Errorf("game event not found")
+	ErrInvalidGameJokerType        = fmt.Errorf("invalid game joker type")
+	ErrInvalidNbJoker              = fmt.Errorf("invalid number of joker")
+	ErrInvalidGameJokerCost        = fmt.Errorf("invalid game joker cost")
+	ErrDuplicateGameJoker          = fmt.Errorf("duplicate game joker")
+	ErrGameJokerNotAvailable       = fmt.Errorf("game joker not available")
+	ErrGameJokerAlreadyUsed        = fmt.Errorf("game joker already used")
+	ErrGameJokerNotApplicable      = fmt.Errorf("game joker not applicable")
+	ErrTournamentNotFound          = fmt.Errorf("tournament not found")
+	ErrInvalidTournamentId         = fmt.Errorf("invalid tournament id")
+	ErrInvalidTournamentName       = fmt.Errorf("invalid tournament name")
+	ErrInvalidNbParticipant        = fmt.Errorf("invalid number of participant")
+	ErrInvalidTournamentTableSize  = fmt.Errorf("invalid tournament table size")
+	ErrInvalidTournamentPromotion  = fmt.Errorf("invalid tournament promotion")
+	ErrMissingTournamentSettings   = fmt.Errorf("missing tournament settings")
+	ErrNotTournamentOwner          = fmt.Errorf("not tournament owner")
+	ErrTournamentFinished          = fmt.Errorf("tournament finished")
+	ErrWebhookNotFound             = fmt.Errorf("webhook not found")
+	ErrInvalidWebhookId            = fmt.Errorf("invalid webhook id")
+	ErrInvalidWebhookUrl           = fmt.Errorf("invalid webhook url")
+	ErrInvalidWebhookEvent         = fmt.Errorf("invalid webhook event")
+	ErrWebhookDeliveryNotFound     = fmt.Errorf("webhook delivery not found")
+	ErrMissingGameMedia            = func(music string, media string) error { return fmt.Errorf("missing %s of music %q", media, music) }
+	ErrInvalidGamePack             = fmt.Errorf("invalid game pack")
+	ErrGamePackTooLarge            = fmt.Errorf("game pack too large")
+	ErrMissingGamePackFile         = func(path string) error { return fmt.Errorf("missing game pack file %q", path) }
+	ErrDailyChallengeNotFound      = fmt.Errorf("daily challenge not found")
+	ErrInvalidDailyChallengeDate   = fmt.Errorf("invalid daily challenge date")
+	ErrDailyChallengeAlreadyPlayed = fmt.Errorf("daily challenge already played")
+	ErrDailyChallengeRunNotFound   = fmt.Errorf("daily challenge run not found")
 	ErrMusicNotFound               = fmt.Errorf("music not found")
 	ErrMusicAlbumNotFound          = fmt.Errorf("music album not found")
 	ErrMusicArtistNotFound         = fmt.Errorf("music artist not found")
@@ -45,6 +121,7 @@ var (
 	ErrInvalidThemeQuestion        = fmt.Errorf("invalid theme question")
 	ErrInvalidThemeQuestionText    = fmt.Errorf("invalid theme question text")
 	ErrInvalidThemeQuestionHint    = fmt.Errorf("invalid theme question hint")
+	ErrInvalidAudioExcerpt         = fmt.Errorf("invalid audio excerpt")
 	ErrCouldNotUpdateThemeId       = fmt.Errorf("could not update theme id")
 	ErrCouldNotUpdateMusicId       = fmt.Errorf("could not update music id")
 	ErrEmptyPlaylist               = fmt.Errorf("empty playlist")
@@ -65,7 +142,15 @@ var (
 	ErrExistingMusic               = fmt.Errorf("existing music")
 	ErrExistingArtist              = fmt.Errorf("existing artist")
 	ErrExistingAlbum               = fmt.Errorf("existing album")
+	ErrMissingDuplicate            = fmt.Errorf("missing duplicate")
+	ErrInvalidDuplicate            = fmt.Errorf("invalid duplicate")
+	ErrInvalidSimilarity           = fmt.Errorf("invalid similarity")
+	ErrExistingGenre               = fmt.Errorf("existing genre")
 	ErrFileAlreadyExists           = func(path string) error { return fmt.Errorf("file %q already exists", path) }
 	ErrInvalidExtension            = fmt.Errorf("invalid extension")
+	ErrInvalidFileName             = fmt.Errorf("invalid file name")
+	ErrInvalidContentType          = func(contentType string) error { return fmt.Errorf("invalid content type %q", contentType) }
+	ErrMismatchingContentType      = func(contentType string, name Url) error { return fmt.Errorf("%s content in %q", contentType, name) }
+	ErrFileTooLarge                = fmt.Errorf("file too large")
 	ErrPathNotFound                = func(path string) error { return fmt.Errorf("path %q not found", path) }
 )
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"path/filepath"
 	"strings"
 
 	"github.com/gre-ory/amnezic-go/internal/util"
@@ -10,6 +11,15 @@ import (
 // //////////////////////////////////////////////////
 // file filter
 
+const (
+	// size limits of an uploaded file
+	MusicUploadMaxSize = 32 << 20
+	ImageUploadMaxSize = 8 << 20
+
+	// headroom of an upload request for the multipart boundaries and headers, the file size itself is checked by the service
+	UploadRequestOverhead = 1 << 20
+)
+
 type FileFilter struct {
 	Directory  string
 	Extensions []string
@@ -47,6 +57,20 @@ func (o *FileFilter) MatchExtension(filename string) bool {
 	return false
 }
 
+// ToFileName sanitizes the name of an uploaded file, keeping its extension when allowed by the filter.
+func (o *FileFilter) ToFileName(filename string) (Url, error) {
+	base := filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	extension := strings.ToLower(filepath.Ext(base))
+	if extension == "" || extension != "."+util.SanitizeAlphaLower(extension) || !o.MatchExtension(extension) {
+		return "", ErrInvalidExtension
+	}
+	name := util.SanitizeAlphaLower(strings.TrimSuffix(base, filepath.Ext(base)))
+	if name == "" {
+		return "", ErrInvalidFileName
+	}
+	return Url(name + extension), nil
+}
+
 func (o *FileFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if o.Directory != "" {
 		enc.AddString("directory", o.Directory)
@@ -0,0 +1,35 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestFileFilterToFileName(t *testing.T) {
+
+	filter := &model.FileFilter{Directory: "music", Extensions: []string{".mp3"}}
+
+	tests := []struct {
+		filename     string
+		wantFileName model.Url
+		wantErr      error
+	}{
+		{"Smalltown Boy.MP3", "smalltown-boy.mp3", nil},
+		{"../../Bronski Beat - Why?.mp3", "bronski-beat-why.mp3", nil},
+		{"C:\\Music\\Hurt.mp3", "hurt.mp3", nil},
+		{"cover.jpg", "", model.ErrInvalidExtension},
+		{"no-extension", "", model.ErrInvalidExtension},
+		{"???.mp3", "", model.ErrInvalidFileName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			gotFileName, gotErr := filter.ToFileName(tt.filename)
+			require.Equal(t, tt.wantErr, gotErr)
+			require.Equal(t, tt.wantFileName, gotFileName)
+		})
+	}
+}
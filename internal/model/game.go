@@ -1,12 +1,462 @@
 package model
 
+import (
+	"crypto/rand"
+	"encoding/hex"
+	mathrand "math/rand"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// game host token
+
+type GameHostToken string
+
+func NewGameHostToken() GameHostToken {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+	return GameHostToken(hex.EncodeToString(bytes))
+}
+
+func (o GameHostToken) String() string {
+	return string(o)
+}
+
 // //////////////////////////////////////////////////
 // game
 
 type Game struct {
-	Id        GameId
-	Version   int
-	Settings  *GameSettings
-	Players   []*GamePlayer
-	Questions []*GameQuestion
+	Id          GameId
+	OwnerId     UserId
+	HostToken   GameHostToken
+	Settings    *GameSettings
+	Rounds      []*GameRound
+	Players     []*GamePlayer
+	Questions   []*GameQuestion
+	Adjustments []*GameScoreAdjustment
+	Finished    bool
+}
+
+func (o *Game) IsOwnedBy(userId UserId) bool {
+	return o.OwnerId != 0 && o.OwnerId == userId
+}
+
+func (o *Game) IsHostedWith(token GameHostToken) bool {
+	return o.HostToken != "" && o.HostToken == token
+}
+
+// Copy returns a deep copy to be modified without altering the original.
+func (o *Game) Copy() *Game {
+	if o == nil {
+		return nil
+	}
+	var settings *GameSettings
+	if o.Settings != nil {
+		copied := *o.Settings
+		copied.Rounds = util.Convert(o.Settings.Rounds, (*GameRoundSettings).Copy)
+		copied.Jokers = util.Convert(o.Settings.Jokers, (*GameJokerSettings).Copy)
+		settings = &copied
+	}
+	return &Game{
+		Id:          o.Id,
+		OwnerId:     o.OwnerId,
+		HostToken:   o.HostToken,
+		Settings:    settings,
+		Rounds:      util.Convert(o.Rounds, (*GameRound).Copy),
+		Players:     util.Convert(o.Players, (*GamePlayer).Copy),
+		Questions:   util.Convert(o.Questions, (*GameQuestion).Copy),
+		Adjustments: util.Convert(o.Adjustments, (*GameScoreAdjustment).Copy),
+		Finished:    o.Finished,
+	}
+}
+
+// Restart returns a copy of the game without any progress: no answer, joker, adjustment nor score.
+func (o *Game) Restart() *Game {
+	restarted := o.Copy()
+	restarted.Adjustments = nil
+	restarted.Finished = false
+	for _, player := range restarted.Players {
+		player.Score = 0
+	}
+	for _, question := range restarted.Questions {
+		question.PlayerAnswers = nil
+		question.PlayerJokers = nil
+		question.Revealed = false
+	}
+	return restarted
+}
+
+func (o *Game) FindRound(number int) *GameRound {
+	round, _ := util.FindIf(o.Rounds, func(round *GameRound) bool { return round.Number == number })
+	return round
+}
+
+func (o *Game) FindPlayer(id GamePlayerId) *GamePlayer {
+	player, _ := util.FindIf(o.Players, func(player *GamePlayer) bool { return player.Id == id })
+	return player
+}
+
+func (o *Game) FindQuestion(id GameQuestionId) *GameQuestion {
+	question, _ := util.FindIf(o.Questions, func(question *GameQuestion) bool { return question.Id == id })
+	return question
+}
+
+// AnswerQuestion records the answer of a player and scores it with the rules of the question round.
+// A player answers each question once, and a buzzer question is closed by its first correct answer.
+func (o *Game) AnswerQuestion(questionId GameQuestionId, playerId GamePlayerId, answerId GameAnswerId) (*GamePlayerAnswer, error) {
+	if o.Finished {
+		return nil, ErrGameFinished
+	}
+	question := o.FindQuestion(questionId)
+	if question == nil {
+		return nil, ErrGameQuestionNotFound
+	}
+	if question.Revealed {
+		return nil, ErrQuestionClosed
+	}
+	answer := question.FindAnswer(answerId)
+	if answer == nil {
+		return nil, ErrGameAnswerNotFound
+	}
+	player, round, err := o.checkPlayerAnswer(question, playerId)
+	if err != nil {
+		return nil, err
+	}
+
+	scoring := DefaultGameScoring(GameQuestionType_Choice)
+	if round != nil {
+		scoring = round.Scoring
+	}
+
+	playerAnswer := &GamePlayerAnswer{
+		Id:       NewGamePlayerAnswerId(answer.Id, player.Id),
+		PlayerId: player.Id,
+		AnswerId: answer.Id,
+		Correct:  answer.Correct,
+		Points:   scoring.Points(answer.Correct),
+	}
+	question.PlayerAnswers = append(question.PlayerAnswers, playerAnswer)
+	player.Score += playerAnswer.Points
+	return playerAnswer, nil
+}
+
+// AnswerYear records the release year guessed by a player: points depend on how close the guess is, only the exact year is correct.
+func (o *Game) AnswerYear(questionId GameQuestionId, playerId GamePlayerId, year int) (*GamePlayerAnswer, error) {
+	if o.Finished {
+		return nil, ErrGameFinished
+	}
+	question := o.FindQuestion(questionId)
+	if question == nil {
+		return nil, ErrGameQuestionNotFound
+	}
+	if question.Revealed {
+		return nil, ErrQuestionClosed
+	}
+	if !question.IsYearQuestion() {
+		return nil, ErrNotYearQuestion
+	}
+	if year <= 0 || year > MaxMusicYear {
+		return nil, ErrInvalidGameYear
+	}
+	player, round, err := o.checkPlayerAnswer(question, playerId)
+	if err != nil {
+		return nil, err
+	}
+
+	scoring := DefaultGameYearScoring()
+	if round != nil && !round.YearScoring.IsZero() {
+		scoring = round.YearScoring
+	}
+
+	playerAnswer := &GamePlayerAnswer{
+		// no answer to choose from: the id only refers to the question
+		Id:       NewGamePlayerAnswerId(GameAnswerId(question.Id), player.Id),
+		PlayerId: player.Id,
+		Year:     year,
+		Correct:  year == question.Year,
+		Points:   scoring.Points(year, question.Year),
+	}
+	question.PlayerAnswers = append(question.PlayerAnswers, playerAnswer)
+	player.Score += playerAnswer.Points
+	return playerAnswer, nil
+}
+
+// AnswerSlots records the parts answered by a player to a multi-part question: each correct part earns the points of its slot.
+// the answer is only correct when every part is correct.
+func (o *Game) AnswerSlots(questionId GameQuestionId, playerId GamePlayerId, slotAnswers []*GameSlotAnswer) (*GamePlayerAnswer, error) {
+	if o.Finished {
+		return nil, ErrGameFinished
+	}
+	question := o.FindQuestion(questionId)
+	if question == nil {
+		return nil, ErrGameQuestionNotFound
+	}
+	if question.Revealed {
+		return nil, ErrQuestionClosed
+	}
+	if !question.IsSlotQuestion() {
+		return nil, ErrNotSlotQuestion
+	}
+	player, _, err := o.checkPlayerAnswer(question, playerId)
+	if err != nil {
+		return nil, err
+	}
+
+	// every part is answered, once
+	if len(slotAnswers) != len(question.Slots) {
+		return nil, ErrIncompleteGameSlotAnswer
+	}
+
+	playerAnswer := &GamePlayerAnswer{
+		// no single answer: the id only refers to the question
+		Id:       NewGamePlayerAnswerId(GameAnswerId(question.Id), player.Id),
+		PlayerId: player.Id,
+	}
+	nbCorrect := 0
+	for _, slotAnswer := range slotAnswers {
+		if slotAnswer == nil {
+			return nil, ErrEmptyGameSlotAnswer
+		}
+		slot := question.FindSlot(slotAnswer.Slot)
+		if slot == nil {
+			return nil, ErrGameSlotNotFound
+		}
+		if _, found := util.FindIf(playerAnswer.Parts, func(part *GameSlotAnswer) bool { return part.Slot == slot.Number }); found {
+			return nil, ErrDuplicateGameSlot
+		}
+		correct, err := slot.Check(slotAnswer)
+		if err != nil {
+			return nil, err
+		}
+		part := &GameSlotAnswer{
+			Slot:     slot.Number,
+			AnswerId: slotAnswer.AnswerId,
+			Text:     slotAnswer.Text,
+			Correct:  correct,
+		}
+		if correct {
+			part.Points = slot.Points
+			nbCorrect++
+		}
+		playerAnswer.Parts = append(playerAnswer.Parts, part)
+		playerAnswer.Points += part.Points
+	}
+	playerAnswer.Correct = nbCorrect == len(question.Slots)
+
+	question.PlayerAnswers = append(question.PlayerAnswers, playerAnswer)
+	player.Score += playerAnswer.Points
+	return playerAnswer, nil
+}
+
+// checkPlayerAnswer ensures that the player is still allowed to answer the question and returns the round of the question, if any.
+func (o *Game) checkPlayerAnswer(question *GameQuestion, playerId GamePlayerId) (*GamePlayer, *GameRound, error) {
+	player := o.FindPlayer(playerId)
+	if player == nil {
+		return nil, nil, ErrGamePlayerNotFound
+	}
+	if !player.Active {
+		return nil, nil, ErrInactivePlayer
+	}
+	if question.FindPlayerAnswer(playerId) != nil {
+		return nil, nil, ErrAlreadyAnswered
+	}
+	if question.FindPlayerJoker(playerId, GameJokerType_Skip) != nil {
+		return nil, nil, ErrQuestionSkipped
+	}
+	round := o.FindRound(question.Round)
+	if round != nil && round.QuestionType.IsBuzzer() && question.HasCorrectPlayerAnswer() {
+		return nil, nil, ErrQuestionClosed
+	}
+	return player, round, nil
+}
+
+// RevealQuestion discloses the correct answer of a question: no more answer is accepted afterwards.
+func (o *Game) RevealQuestion(questionId GameQuestionId) (*GameQuestion, error) {
+	if o.Finished {
+		return nil, ErrGameFinished
+	}
+	question := o.FindQuestion(questionId)
+	if question == nil {
+		return nil, ErrGameQuestionNotFound
+	}
+	if question.Revealed {
+		return nil, ErrQuestionAlreadyRevealed
+	}
+	question.Revealed = true
+	return question, nil
+}
+
+// Finish ends the game: the result is final.
+func (o *Game) Finish() error {
+	if o.Finished {
+		return ErrGameFinished
+	}
+	o.Finished = true
+	return nil
+}
+
+// AdjustScore manually adds ( or removes ) points to a player, the reason is mandatory to keep the game auditable.
+func (o *Game) AdjustScore(playerId GamePlayerId, points int, reason string) (*GameScoreAdjustment, error) {
+	player := o.FindPlayer(playerId)
+	if player == nil {
+		return nil, ErrGamePlayerNotFound
+	}
+	if !player.Active {
+		return nil, ErrInactivePlayer
+	}
+	if points == 0 {
+		return nil, ErrInvalidScoreAdjustment
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, ErrMissingAdjustmentReason
+	}
+	adjustment := &GameScoreAdjustment{
+		PlayerId: playerId,
+		Points:   points,
+		Reason:   strings.TrimSpace(reason),
+	}
+	o.Adjustments = append(o.Adjustments, adjustment)
+	player.Score += points
+	return adjustment, nil
+}
+
+// AddPlayer adds a new player during the game, starting with no score.
+func (o *Game) AddPlayer(name string) (*GamePlayer, error) {
+	if o.Finished {
+		return nil, ErrGameFinished
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidPlayerName
+	}
+	number := len(o.Players) + 1
+	for o.FindPlayer(NewGamePlayerId(number)) != nil {
+		number++
+	}
+	player := &GamePlayer{
+		Id:     NewGamePlayerId(number),
+		Name:   name,
+		Active: true,
+	}
+	o.Players = append(o.Players, player)
+	return player, nil
+}
+
+func (o *Game) RenamePlayer(playerId GamePlayerId, name string) (*GamePlayer, error) {
+	player := o.FindPlayer(playerId)
+	if player == nil {
+		return nil, ErrGamePlayerNotFound
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrInvalidPlayerName
+	}
+	player.Name = name
+	return player, nil
+}
+
+// SetPlayerActive (de)activates a player: an inactive player can not play and is excluded from the result.
+func (o *Game) SetPlayerActive(playerId GamePlayerId, active bool) (*GamePlayer, error) {
+	if o.Finished {
+		return nil, ErrGameFinished
+	}
+	player := o.FindPlayer(playerId)
+	if player == nil {
+		return nil, ErrGamePlayerNotFound
+	}
+	player.Active = active
+	return player, nil
+}
+
+// RemainingJokers returns, per joker type, how many jokers the player can still use.
+func (o *Game) RemainingJokers(playerId GamePlayerId) map[GameJokerType]int {
+	remaining := make(map[GameJokerType]int)
+	if o.Settings == nil {
+		return remaining
+	}
+	for _, joker := range o.Settings.Jokers {
+		remaining[joker.Type] = joker.Count
+	}
+	for _, question := range o.Questions {
+		for _, playerJoker := range question.PlayerJokers {
+			if playerJoker.PlayerId == playerId {
+				remaining[playerJoker.Type]--
+			}
+		}
+	}
+	return remaining
+}
+
+// UseJoker records a joker played by a player on a question before answering it and charges its cost.
+// The answers removed by a "fifty-fifty" only depend on the game seed, the question and the player.
+func (o *Game) UseJoker(questionId GameQuestionId, playerId GamePlayerId, jokerType GameJokerType) (*GamePlayerJoker, error) {
+	if o.Finished {
+		return nil, ErrGameFinished
+	}
+	question := o.FindQuestion(questionId)
+	if question == nil {
+		return nil, ErrGameQuestionNotFound
+	}
+	if question.Revealed {
+		return nil, ErrQuestionClosed
+	}
+	player := o.FindPlayer(playerId)
+	if player == nil {
+		return nil, ErrGamePlayerNotFound
+	}
+	if !player.Active {
+		return nil, ErrInactivePlayer
+	}
+	if o.Settings == nil || o.Settings.FindJoker(jokerType) == nil || o.RemainingJokers(playerId)[jokerType] <= 0 {
+		return nil, ErrGameJokerNotAvailable
+	}
+	if question.FindPlayerAnswer(playerId) != nil {
+		return nil, ErrAlreadyAnswered
+	}
+	if question.FindPlayerJoker(playerId, GameJokerType_Skip) != nil {
+		return nil, ErrQuestionSkipped
+	}
+	if question.FindPlayerJoker(playerId, jokerType) != nil {
+		return nil, ErrGameJokerAlreadyUsed
+	}
+
+	playerJoker := &GamePlayerJoker{
+		PlayerId: playerId,
+		Type:     jokerType,
+		Cost:     o.Settings.FindJoker(jokerType).Cost,
+	}
+
+	switch jokerType {
+	case GameJokerType_FiftyFifty:
+		wrongAnswerIds := make([]GameAnswerId, 0, len(question.Answers))
+		for _, answer := range question.Answers {
+			if !answer.Correct {
+				wrongAnswerIds = append(wrongAnswerIds, answer.Id)
+			}
+		}
+		nbRemoved := (len(wrongAnswerIds) + 1) / 2
+		if nbRemoved >= len(wrongAnswerIds) {
+			nbRemoved = len(wrongAnswerIds) - 1
+		}
+		if nbRemoved <= 0 {
+			return nil, ErrGameJokerNotApplicable
+		}
+		random := mathrand.New(mathrand.NewSource(o.Settings.Seed + int64(questionId) + int64(playerId)))
+		random.Shuffle(len(wrongAnswerIds), func(i, j int) {
+			wrongAnswerIds[i], wrongAnswerIds[j] = wrongAnswerIds[j], wrongAnswerIds[i]
+		})
+		playerJoker.RemovedAnswerIds = wrongAnswerIds[:nbRemoved]
+	case GameJokerType_Hint:
+		if _, found := util.FindIf(question.Answers, func(answer *GameAnswer) bool { return answer.Hint != "" }); !found {
+			return nil, ErrGameJokerNotApplicable
+		}
+	}
+
+	question.PlayerJokers = append(question.PlayerJokers, playerJoker)
+	player.Score -= playerJoker.Cost
+	return playerJoker, nil
 }
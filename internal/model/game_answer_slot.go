@@ -0,0 +1,183 @@
+package model
+
+import (
+	"math/rand"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game slot settings
+
+// GameSlotSettings configures a part of a multi-part question ( e.g. one point for the artist, one for the title ).
+type GameSlotSettings struct {
+	Guess    GameGuess
+	Points   int
+	FreeText bool
+}
+
+func (o *GameSlotSettings) Validate() error {
+	switch o.Guess {
+	case GameGuess_Artist, GameGuess_Title, GameGuess_Album:
+	default:
+		return ErrInvalidGameSlot
+	}
+	if o.Points <= 0 {
+		return ErrInvalidGameSlot
+	}
+	return nil
+}
+
+func (o *GameSlotSettings) Copy() *GameSlotSettings {
+	if o == nil {
+		return nil
+	}
+	return &GameSlotSettings{
+		Guess:    o.Guess,
+		Points:   o.Points,
+		FreeText: o.FreeText,
+	}
+}
+
+func (o *GameSlotSettings) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("guess", o.Guess.String())
+	enc.AddInt("points", o.Points)
+	if o.FreeText {
+		enc.AddBool("free-text", o.FreeText)
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game answer slot
+
+// GameAnswerSlot is a part of a multi-part question: either answers to choose from, or a free text matching the expected one.
+type GameAnswerSlot struct {
+	Number   int
+	Guess    GameGuess
+	Points   int
+	Answers  []*GameAnswer
+	Expected string
+}
+
+func (o *GameAnswerSlot) Copy() *GameAnswerSlot {
+	if o == nil {
+		return nil
+	}
+	return &GameAnswerSlot{
+		Number:   o.Number,
+		Guess:    o.Guess,
+		Points:   o.Points,
+		Answers:  util.Convert(o.Answers, (*GameAnswer).Copy),
+		Expected: o.Expected,
+	}
+}
+
+func (o *GameAnswerSlot) IsFreeText() bool {
+	return len(o.Answers) == 0
+}
+
+func (o *GameAnswerSlot) FindAnswer(id GameAnswerId) *GameAnswer {
+	answer, _ := util.FindIf(o.Answers, func(answer *GameAnswer) bool { return answer.Id == id })
+	return answer
+}
+
+// Check tells whether the part answered by a player is correct.
+// a free text is compared to the expected text regardless of case, accents and punctuation.
+func (o *GameAnswerSlot) Check(slotAnswer *GameSlotAnswer) (bool, error) {
+	if o.IsFreeText() {
+		text := util.SanitizeAlphaLower(slotAnswer.Text)
+		if text == "" {
+			return false, ErrEmptyGameSlotAnswer
+		}
+		return text == util.SanitizeAlphaLower(o.Expected), nil
+	}
+	if slotAnswer.AnswerId == 0 {
+		return false, ErrEmptyGameSlotAnswer
+	}
+	answer := o.FindAnswer(slotAnswer.AnswerId)
+	if answer == nil {
+		return false, ErrGameAnswerNotFound
+	}
+	return answer.Correct, nil
+}
+
+func (o *GameAnswerSlot) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("number", o.Number)
+	enc.AddString("guess", o.Guess.String())
+	enc.AddInt("points", o.Points)
+	if o.IsFreeText() {
+		enc.AddString("expected", o.Expected)
+	} else {
+		enc.AddInt("nb-answers", len(o.Answers))
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game slot answer
+
+// GameSlotAnswer is the part of a player answer for a slot: the chosen answer or the free text.
+// correct and points are computed when the answer is submitted.
+type GameSlotAnswer struct {
+	Slot     int
+	AnswerId GameAnswerId
+	Text     string
+	Correct  bool
+	Points   int
+}
+
+func (o *GameSlotAnswer) Copy() *GameSlotAnswer {
+	if o == nil {
+		return nil
+	}
+	return &GameSlotAnswer{
+		Slot:     o.Slot,
+		AnswerId: o.AnswerId,
+		Text:     o.Text,
+		Correct:  o.Correct,
+		Points:   o.Points,
+	}
+}
+
+func (o *GameSlotAnswer) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("slot", o.Slot)
+	if o.AnswerId != 0 {
+		enc.AddInt64("answer-id", int64(o.AnswerId))
+	}
+	if o.Text != "" {
+		enc.AddString("text", o.Text)
+	}
+	enc.AddBool("correct", o.Correct)
+	enc.AddInt("points", o.Points)
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// apply slots
+
+// ApplySlots replaces the answers of the round questions by one slot per configured part.
+// the answers of a slot are picked among the values of the round questions for the guessed part.
+func (o *GameRoundSettings) ApplySlots(random *rand.Rand, questions []*GameQuestion) {
+	for _, question := range questions {
+		question.Answers = nil
+		question.Slots = nil
+	}
+	for index, slotSettings := range o.Slots {
+		values := toGuessValues(questions, slotSettings.Guess)
+		for _, question := range questions {
+			slot := &GameAnswerSlot{
+				Number: index + 1,
+				Guess:  slotSettings.Guess,
+				Points: slotSettings.Points,
+			}
+			if slotSettings.FreeText {
+				slot.Expected = GuessValue(slotSettings.Guess, question.Music)
+			} else {
+				slot.Answers = toGuessAnswers(random, slotSettings.Guess, question.Music, values, o.NbAnswer)
+			}
+			question.Slots = append(question.Slots, slot)
+		}
+	}
+}
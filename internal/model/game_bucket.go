@@ -0,0 +1,97 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// game decade
+
+// GameDecade is the first year of a decade ( e.g. 1980 for the "1980s" ), 0 when not set.
+type GameDecade int
+
+// ToGameDecade parses a decade such as "1980s" or "1980", 0 when invalid.
+func ToGameDecade(value string) GameDecade {
+	value = strings.Trim(value, " ")
+	value = strings.ToLower(value)
+	value = strings.TrimSuffix(value, "s")
+	decade := GameDecade(util.StrToInt64(value))
+	if !decade.IsValid() {
+		return 0
+	}
+	return decade
+}
+
+func (o GameDecade) IsValid() bool {
+	return o > 0 && o%10 == 0 && int(o)+9 <= MaxMusicYear
+}
+
+func (o GameDecade) String() string {
+	if o == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%ds", o)
+}
+
+// //////////////////////////////////////////////////
+// music bucket
+
+// musicBucket selects the musics of the store catalog released in a decade and / or of a genre.
+// the decade and the genre only apply when their source is selected, the legacy questions being used otherwise.
+type musicBucket struct {
+	sources []Source
+	decade  GameDecade
+	genre   string
+}
+
+func (o musicBucket) useDecade() bool {
+	return o.decade != 0 && util.Contains(o.sources, Source_Decade)
+}
+
+func (o musicBucket) useGenre() bool {
+	return o.genre != "" && util.Contains(o.sources, Source_Genre)
+}
+
+func (o musicBucket) isUsed() bool {
+	return o.useDecade() || o.useGenre()
+}
+
+func (o musicBucket) toMusicFilter() *MusicFilter {
+	filter := NewMusicFilter()
+	if o.useDecade() {
+		filter.WithYears(int(o.decade), int(o.decade)+9)
+	}
+	if o.useGenre() {
+		filter.WithGenre(o.genre)
+	}
+	return filter
+}
+
+// source is the decade source when used, the genre source otherwise.
+func (o musicBucket) source() Source {
+	if o.useDecade() {
+		return Source_Decade
+	}
+	return Source_Genre
+}
+
+func (o musicBucket) title() string {
+	parts := make([]string, 0, 2)
+	if o.useDecade() {
+		parts = append(parts, o.decade.String())
+	}
+	if o.useGenre() {
+		parts = append(parts, o.genre)
+	}
+	return strings.Join(parts, " - ")
+}
+
+func (o musicBucket) validate() error {
+	if o.decade != 0 && !o.decade.IsValid() {
+		return ErrInvalidGameDecade
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestToGameDecade(t *testing.T) {
+
+	tests := []struct {
+		value      string
+		wantDecade model.GameDecade
+	}{
+		{"1980s", 1980},
+		{" 1990S ", 1990},
+		{"2000", 2000},
+		{"1985", 0},
+		{"eighties", 0},
+		{"", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			require.Equal(t, tt.wantDecade, model.ToGameDecade(tt.value))
+		})
+	}
+}
+
+func TestGameSettingsMusicBucket(t *testing.T) {
+
+	music := &model.Music{
+		Name:  "Smalltown Boy",
+		Year:  1984,
+		Genre: "Pop",
+	}
+
+	tests := []struct {
+		name         string
+		settings     model.GameSettings
+		wantUsed     bool
+		wantTitle    string
+		wantMatching bool
+	}{
+		{"legacy", model.GameSettings{Sources: []model.Source{model.Source_Legacy}, Decade: 1980}, false, "", true},
+		{"decade", model.GameSettings{Sources: []model.Source{model.Source_Decade}, Decade: 1980}, true, "1980s", true},
+		{"other-decade", model.GameSettings{Sources: []model.Source{model.Source_Decade}, Decade: 1990}, true, "1990s", false},
+		{"genre", model.GameSettings{Sources: []model.Source{model.Source_Genre}, Genre: "pop"}, true, "pop", true},
+		{"both", model.GameSettings{Sources: []model.Source{model.Source_Decade, model.Source_Genre}, Decade: 1980, Genre: "Rock"}, true, "1980s - Rock", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantUsed, tt.settings.UseMusicBucket())
+			require.Equal(t, tt.wantTitle, tt.settings.BucketTitle())
+			require.Equal(t, tt.wantMatching, tt.settings.ToMusicFilter().IsMatching(0, music))
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package model
+
+import (
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game event type
+
+type GameEventType string
+
+const (
+	GameEventType_GameCreated       GameEventType = "game-created"
+	GameEventType_PlayerJoined      GameEventType = "player-joined"
+	GameEventType_PlayerRenamed     GameEventType = "player-renamed"
+	GameEventType_PlayerActivated   GameEventType = "player-activated"
+	GameEventType_PlayerDeactivated GameEventType = "player-deactivated"
+	GameEventType_AnswerSubmitted   GameEventType = "answer-submitted"
+	GameEventType_YearSubmitted     GameEventType = "year-submitted"
+	GameEventType_SlotsSubmitted    GameEventType = "slots-submitted"
+	GameEventType_JokerUsed         GameEventType = "joker-used"
+	GameEventType_ScoreAdjusted     GameEventType = "score-adjusted"
+	GameEventType_QuestionRevealed  GameEventType = "question-revealed"
+	GameEventType_GameFinished      GameEventType = "game-finished"
+	GameEventType_GameDeleted       GameEventType = "game-deleted"
+	GameEventType_PhaseChanged      GameEventType = "phase-changed"
+)
+
+func (o GameEventType) String() string {
+	return string(o)
+}
+
+// //////////////////////////////////////////////////
+// game event
+
+// GameEvent is an entry of the append-only log of a game: replaying the events in sequence rebuilds the game.
+// only the fields related to the type of event are set.
+type GameEvent struct {
+	Sequence   int
+	Type       GameEventType
+	OccurredAt time.Time
+	UserId     UserId
+
+	// game-created: initial game, without players nor answers
+	Game *Game
+
+	PlayerId   GamePlayerId
+	PlayerName string
+	QuestionId GameQuestionId
+	AnswerId   GameAnswerId
+	Year       int
+	Parts      []*GameSlotAnswer
+	JokerType  GameJokerType
+	Points     int
+	Reason     string
+
+	// phase-changed: logged after the event moving the game into another phase
+	PreviousPhase GamePhase
+	Phase         GamePhase
+}
+
+func newGameEvent(eventType GameEventType) *GameEvent {
+	return &GameEvent{
+		Type:       eventType,
+		OccurredAt: time.Now(),
+	}
+}
+
+func NewGameCreatedEvent(game *Game) *GameEvent {
+	initial := game.Restart()
+	initial.Players = nil
+	event := newGameEvent(GameEventType_GameCreated)
+	event.Game = initial
+	return event
+}
+
+func NewPlayerJoinedEvent(player *GamePlayer) *GameEvent {
+	event := newGameEvent(GameEventType_PlayerJoined)
+	event.PlayerId = player.Id
+	event.PlayerName = player.Name
+	return event
+}
+
+func NewPlayerRenamedEvent(player *GamePlayer) *GameEvent {
+	event := newGameEvent(GameEventType_PlayerRenamed)
+	event.PlayerId = player.Id
+	event.PlayerName = player.Name
+	return event
+}
+
+func NewPlayerActiveEvent(player *GamePlayer) *GameEvent {
+	eventType := GameEventType_PlayerDeactivated
+	if player.Active {
+		eventType = GameEventType_PlayerActivated
+	}
+	event := newGameEvent(eventType)
+	event.PlayerId = player.Id
+	return event
+}
+
+func NewAnswerSubmittedEvent(questionId GameQuestionId, playerId GamePlayerId, answerId GameAnswerId) *GameEvent {
+	event := newGameEvent(GameEventType_AnswerSubmitted)
+	event.QuestionId = questionId
+	event.PlayerId = playerId
+	event.AnswerId = answerId
+	return event
+}
+
+func NewYearSubmittedEvent(questionId GameQuestionId, playerId GamePlayerId, year int) *GameEvent {
+	event := newGameEvent(GameEventType_YearSubmitted)
+	event.QuestionId = questionId
+	event.PlayerId = playerId
+	event.Year = year
+	return event
+}
+
+func NewSlotsSubmittedEvent(questionId GameQuestionId, playerId GamePlayerId, slotAnswers []*GameSlotAnswer) *GameEvent {
+	event := newGameEvent(GameEventType_SlotsSubmitted)
+	event.QuestionId = questionId
+	event.PlayerId = playerId
+	event.Parts = util.Convert(slotAnswers, func(slotAnswer *GameSlotAnswer) *GameSlotAnswer {
+		return &GameSlotAnswer{
+			Slot:     slotAnswer.Slot,
+			AnswerId: slotAnswer.AnswerId,
+			Text:     slotAnswer.Text,
+		}
+	})
+	return event
+}
+
+func NewJokerUsedEvent(questionId GameQuestionId, playerId GamePlayerId, jokerType GameJokerType) *GameEvent {
+	event := newGameEvent(GameEventType_JokerUsed)
+	event.QuestionId = questionId
+	event.PlayerId = playerId
+	event.JokerType = jokerType
+	return event
+}
+
+func NewScoreAdjustedEvent(playerId GamePlayerId, points int, reason string) *GameEvent {
+	event := newGameEvent(GameEventType_ScoreAdjusted)
+	event.PlayerId = playerId
+	event.Points = points
+	event.Reason = reason
+	return event
+}
+
+func NewQuestionRevealedEvent(questionId GameQuestionId) *GameEvent {
+	event := newGameEvent(GameEventType_QuestionRevealed)
+	event.QuestionId = questionId
+	return event
+}
+
+func NewGameFinishedEvent() *GameEvent {
+	return newGameEvent(GameEventType_GameFinished)
+}
+
+func NewGameDeletedEvent() *GameEvent {
+	return newGameEvent(GameEventType_GameDeleted)
+}
+
+func NewPhaseChangedEvent(previous GamePhase, phase GamePhase) *GameEvent {
+	event := newGameEvent(GameEventType_PhaseChanged)
+	event.PreviousPhase = previous
+	event.Phase = phase
+	return event
+}
+
+func (o *GameEvent) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("sequence", o.Sequence)
+	enc.AddString("type", o.Type.String())
+	if o.UserId != 0 {
+		enc.AddInt64("user-id", int64(o.UserId))
+	}
+	if o.PlayerId != 0 {
+		enc.AddInt64("player-id", int64(o.PlayerId))
+	}
+	if o.QuestionId != 0 {
+		enc.AddInt64("question-id", int64(o.QuestionId))
+	}
+	if o.AnswerId != 0 {
+		enc.AddInt64("answer-id", int64(o.AnswerId))
+	}
+	if o.Year != 0 {
+		enc.AddInt("year", o.Year)
+	}
+	if len(o.Parts) > 0 {
+		enc.AddInt("nb-parts", len(o.Parts))
+	}
+	if o.JokerType != "" {
+		enc.AddString("joker", o.JokerType.String())
+	}
+	if o.Points != 0 {
+		enc.AddInt("points", o.Points)
+	}
+	if o.Reason != "" {
+		enc.AddString("reason", o.Reason)
+	}
+	if o.Phase != "" {
+		enc.AddString("previous-phase", o.PreviousPhase.String())
+		enc.AddString("phase", o.Phase.String())
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// apply
+
+// Apply replays an event on the game with the same rules as the original mutation.
+func (o *Game) Apply(event *GameEvent) error {
+	var err error
+	switch event.Type {
+	case GameEventType_PlayerJoined:
+		if o.FindPlayer(event.PlayerId) != nil {
+			return ErrInvalidGameEvent
+		}
+		o.Players = append(o.Players, &GamePlayer{
+			Id:     event.PlayerId,
+			Name:   event.PlayerName,
+			Active: true,
+		})
+	case GameEventType_PlayerRenamed:
+		_, err = o.RenamePlayer(event.PlayerId, event.PlayerName)
+	case GameEventType_PlayerActivated:
+		_, err = o.SetPlayerActive(event.PlayerId, true)
+	case GameEventType_PlayerDeactivated:
+		_, err = o.SetPlayerActive(event.PlayerId, false)
+	case GameEventType_AnswerSubmitted:
+		_, err = o.AnswerQuestion(event.QuestionId, event.PlayerId, event.AnswerId)
+	case GameEventType_YearSubmitted:
+		_, err = o.AnswerYear(event.QuestionId, event.PlayerId, event.Year)
+	case GameEventType_SlotsSubmitted:
+		_, err = o.AnswerSlots(event.QuestionId, event.PlayerId, event.Parts)
+	case GameEventType_JokerUsed:
+		_, err = o.UseJoker(event.QuestionId, event.PlayerId, event.JokerType)
+	case GameEventType_ScoreAdjusted:
+		_, err = o.AdjustScore(event.PlayerId, event.Points, event.Reason)
+	case GameEventType_QuestionRevealed:
+		_, err = o.RevealQuestion(event.QuestionId)
+	case GameEventType_GameFinished:
+		err = o.Finish()
+	case GameEventType_GameDeleted:
+		return ErrGameNotFound
+	case GameEventType_PhaseChanged:
+		// the phase is derived from the game: the replayed game must already be in the logged phase
+		if o.Phase() != event.Phase {
+			return ErrInvalidGameEvent
+		}
+	default:
+		return ErrInvalidGameEvent
+	}
+	return err
+}
+
+// ReplayGame rebuilds the game from its events up to the given sequence ( included ), or all the events when asOf is 0.
+func ReplayGame(events []*GameEvent, asOf int) (*Game, error) {
+	if len(events) == 0 || events[0].Type != GameEventType_GameCreated || events[0].Game == nil {
+		return nil, ErrInvalidGameEvent
+	}
+	game := events[0].Game.Copy()
+	for _, event := range events[1:] {
+		if asOf != 0 && event.Sequence > asOf {
+			break
+		}
+		if err := game.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return game, nil
+}
+
+// //////////////////////////////////////////////////
+// game timeline
+
+type GameTimeline struct {
+	Events []*GameEvent
+	AsOf   int
+	Game   *Game
+}
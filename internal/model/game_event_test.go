@@ -0,0 +1,49 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestReplayGame(t *testing.T) {
+	game := newTestGame()
+	question := game.Questions[0]
+	alice := game.Players[0].Id
+	bob := game.Players[1].Id
+
+	events := []*model.GameEvent{
+		model.NewGameCreatedEvent(game),
+		model.NewPlayerJoinedEvent(game.Players[0]),
+		model.NewPlayerJoinedEvent(game.Players[1]),
+		model.NewAnswerSubmittedEvent(question.Id, alice, question.Answers[0].Id),
+		model.NewAnswerSubmittedEvent(question.Id, bob, question.Answers[1].Id),
+		model.NewScoreAdjustedEvent(bob, 2, "bonus"),
+		model.NewQuestionRevealedEvent(question.Id),
+		model.NewGameFinishedEvent(),
+	}
+	for index, event := range events {
+		event.Sequence = index + 1
+	}
+
+	replayed, err := model.ReplayGame(events, 0)
+	require.NoError(t, err)
+	require.Len(t, replayed.Players, 2)
+	require.True(t, replayed.Questions[0].Revealed)
+	require.True(t, replayed.Finished)
+	require.Equal(t, 1, replayed.FindPlayer(alice).Score)
+	require.Equal(t, 2, replayed.FindPlayer(bob).Score)
+
+	replayed, err = model.ReplayGame(events, 4)
+	require.NoError(t, err)
+	require.Equal(t, 1, replayed.FindPlayer(alice).Score)
+	require.Equal(t, 0, replayed.FindPlayer(bob).Score)
+	require.Len(t, replayed.Questions[0].PlayerAnswers, 1)
+	require.False(t, replayed.Questions[0].Revealed)
+	require.False(t, replayed.Finished)
+
+	_, err = model.ReplayGame(events[1:], 0)
+	require.ErrorIs(t, err, model.ErrInvalidGameEvent)
+}
@@ -0,0 +1,126 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game joker type
+
+type GameJokerType string
+
+var (
+	GameJokerType_FiftyFifty GameJokerType = "fifty-fifty"
+	GameJokerType_Hint       GameJokerType = "hint"
+	GameJokerType_Skip       GameJokerType = "skip"
+)
+
+func ToGameJokerType(value string) GameJokerType {
+	value = strings.Trim(value, " ")
+	value = strings.ToLower(value)
+	switch value {
+	case string(GameJokerType_FiftyFifty), "50/50", "50-50":
+		return GameJokerType_FiftyFifty
+	case string(GameJokerType_Hint):
+		return GameJokerType_Hint
+	case string(GameJokerType_Skip):
+		return GameJokerType_Skip
+	default:
+		return ""
+	}
+}
+
+func (o GameJokerType) String() string {
+	return string(o)
+}
+
+// //////////////////////////////////////////////////
+// game joker settings
+
+type GameJokerSettings struct {
+	Type  GameJokerType
+	Count int
+	Cost  int
+}
+
+// ToGameJokerSettings decodes "<type>[:<count>[:<cost>]]", count defaults to 1 and cost to 0.
+func ToGameJokerSettings(value string) *GameJokerSettings {
+	parts := strings.Split(value, ":")
+	joker := &GameJokerSettings{
+		Type:  ToGameJokerType(parts[0]),
+		Count: 1,
+	}
+	if len(parts) > 1 {
+		joker.Count = int(util.StrToInt64(strings.Trim(parts[1], " ")))
+	}
+	if len(parts) > 2 {
+		joker.Cost = int(util.StrToInt64(strings.Trim(parts[2], " ")))
+	}
+	return joker
+}
+
+func (o *GameJokerSettings) Validate() error {
+	if o.Type == "" {
+		return ErrInvalidGameJokerType
+	}
+	if o.Count < 1 || o.Count > MaxNbJoker {
+		return ErrInvalidNbJoker
+	}
+	if o.Cost < 0 {
+		return ErrInvalidGameJokerCost
+	}
+	return nil
+}
+
+func (o *GameJokerSettings) Copy() *GameJokerSettings {
+	if o == nil {
+		return nil
+	}
+	return &GameJokerSettings{
+		Type:  o.Type,
+		Count: o.Count,
+		Cost:  o.Cost,
+	}
+}
+
+func (o *GameJokerSettings) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", o.Type.String())
+	enc.AddInt("count", o.Count)
+	enc.AddInt("cost", o.Cost)
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game player joker
+
+type GamePlayerJoker struct {
+	PlayerId         GamePlayerId
+	Type             GameJokerType
+	Cost             int
+	RemovedAnswerIds []GameAnswerId
+}
+
+func (o *GamePlayerJoker) Copy() *GamePlayerJoker {
+	if o == nil {
+		return nil
+	}
+	return &GamePlayerJoker{
+		PlayerId:         o.PlayerId,
+		Type:             o.Type,
+		Cost:             o.Cost,
+		RemovedAnswerIds: append([]GameAnswerId(nil), o.RemovedAnswerIds...),
+	}
+}
+
+func (o *GamePlayerJoker) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("player-id", int64(o.PlayerId))
+	enc.AddString("type", o.Type.String())
+	enc.AddInt("cost", o.Cost)
+	if len(o.RemovedAnswerIds) > 0 {
+		enc.AddInt("nb-removed-answers", len(o.RemovedAnswerIds))
+	}
+	return nil
+}
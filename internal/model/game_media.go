@@ -0,0 +1,266 @@
+package model
+
+import (
+	"math/rand"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game media kind
+
+type GameMediaKind string
+
+var (
+	GameMediaKind_Audio GameMediaKind = "audio"
+	GameMediaKind_Image GameMediaKind = "image"
+	GameMediaKind_Both  GameMediaKind = "both"
+)
+
+func ToGameMediaKind(value string) GameMediaKind {
+	value = strings.Trim(value, " ")
+	value = strings.ToLower(value)
+	switch value {
+	case string(GameMediaKind_Audio):
+		return GameMediaKind_Audio
+	case string(GameMediaKind_Image):
+		return GameMediaKind_Image
+	case string(GameMediaKind_Both):
+		return GameMediaKind_Both
+	default:
+		return ""
+	}
+}
+
+func (o GameMediaKind) String() string {
+	return string(o)
+}
+
+func (o GameMediaKind) HasAudio() bool {
+	return o == GameMediaKind_Audio || o == GameMediaKind_Both
+}
+
+func (o GameMediaKind) HasImage() bool {
+	return o == GameMediaKind_Image || o == GameMediaKind_Both
+}
+
+// //////////////////////////////////////////////////
+// game guess
+
+// GameGuess is what the answers of a question name: the artist ( default ), the title or the album of the music.
+// the release year of the music is guessed without answers to choose from.
+type GameGuess string
+
+var (
+	GameGuess_Artist GameGuess = "artist"
+	GameGuess_Title  GameGuess = "title"
+	GameGuess_Album  GameGuess = "album"
+	GameGuess_Year   GameGuess = "year"
+)
+
+func ToGameGuess(value string) GameGuess {
+	value = strings.Trim(value, " ")
+	value = strings.ToLower(value)
+	switch value {
+	case string(GameGuess_Artist):
+		return GameGuess_Artist
+	case string(GameGuess_Title):
+		return GameGuess_Title
+	case string(GameGuess_Album):
+		return GameGuess_Album
+	case string(GameGuess_Year):
+		return GameGuess_Year
+	default:
+		return ""
+	}
+}
+
+func (o GameGuess) String() string {
+	return string(o)
+}
+
+// GuessValue is the expected answer of the guess for the music, empty when unknown.
+func GuessValue(guess GameGuess, music *Music) string {
+	if music == nil {
+		return ""
+	}
+	switch guess {
+	case GameGuess_Artist:
+		if music.Artist != nil {
+			return music.Artist.Name
+		}
+	case GameGuess_Title:
+		return music.Name
+	case GameGuess_Album:
+		if music.Album != nil {
+			return music.Album.Name
+		}
+	}
+	return ""
+}
+
+// //////////////////////////////////////////////////
+// game media
+
+// GameMedia describes what is played and shown for a question.
+// the picture is the album cover when guessing the album or the year, the artist picture otherwise.
+type GameMedia struct {
+	Kind   GameMediaKind
+	Mp3Url Url
+	ImgUrl Url
+}
+
+func NewGameMedia(kind GameMediaKind, guess GameGuess, music *Music) *GameMedia {
+	if music == nil {
+		return nil
+	}
+	if kind == "" {
+		kind = GameMediaKind_Audio
+	}
+	media := &GameMedia{
+		Kind: kind,
+	}
+	if kind.HasAudio() {
+		media.Mp3Url = music.Mp3Url
+	}
+	if kind.HasImage() {
+		withCover := guess == GameGuess_Album || guess == GameGuess_Year
+		switch {
+		case withCover && music.Album != nil:
+			media.ImgUrl = music.Album.ImgUrl
+		case !withCover && music.Artist != nil:
+			media.ImgUrl = music.Artist.ImgUrl
+		}
+	}
+	return media
+}
+
+// Missing names the media expected by the kind but not available, empty when complete.
+func (o *GameMedia) Missing() string {
+	switch {
+	case o.Kind.HasAudio() && o.Mp3Url.IsEmpty():
+		return "mp3"
+	case o.Kind.HasImage() && o.ImgUrl.IsEmpty():
+		return "image"
+	}
+	return ""
+}
+
+func (o *GameMedia) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("kind", o.Kind.String())
+	if o.Mp3Url != "" {
+		enc.AddString("mp3-url", string(o.Mp3Url))
+	}
+	if o.ImgUrl != "" {
+		enc.AddString("img-url", string(o.ImgUrl))
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// apply media
+
+// ApplyMedia sets the media of the questions of the round.
+// it fails naming the first music missing a media or a guessed value, so that a round never silently loses questions.
+// when guessing the title or the album, the answers are replaced by the titles or album names of the round questions.
+// when guessing the year, the answers are removed.
+// multi-part questions get one slot per part instead of answers.
+func (o *GameRoundSettings) ApplyMedia(random *rand.Rand, questions []*GameQuestion) error {
+	for _, question := range questions {
+		question.Media = NewGameMedia(o.MediaKind, o.Guess, question.Music)
+		if question.Music == nil {
+			return ErrMissingMusic
+		}
+		if missing := o.missingMedia(question); missing != "" {
+			return ErrMissingGameMedia(question.Music.Name, missing)
+		}
+		if o.Guess == GameGuess_Year {
+			question.Year = question.Music.Year
+			question.Answers = nil
+		}
+	}
+	switch {
+	case len(o.Slots) > 0:
+		o.ApplySlots(random, questions)
+	case o.Guess == GameGuess_Title || o.Guess == GameGuess_Album:
+		values := toGuessValues(questions, o.Guess)
+		for _, question := range questions {
+			question.Answers = toGuessAnswers(random, o.Guess, question.Music, values, o.NbAnswer)
+		}
+	}
+	return nil
+}
+
+// missingMedia names what the music of the question lacks for the round, empty when nothing is missing.
+func (o *GameRoundSettings) missingMedia(question *GameQuestion) string {
+	if missing := question.Media.Missing(); missing != "" {
+		return missing
+	}
+	if !o.hasGuessValues(question.Music) {
+		return "guessed value"
+	}
+	if o.Guess == GameGuess_Year && question.Music.Year == 0 {
+		return "release year"
+	}
+	return ""
+}
+
+// hasGuessValues tells whether the music provides the values guessed from the music itself.
+// artists guessed by the round are not checked as their answers come from the theme.
+func (o *GameRoundSettings) hasGuessValues(music *Music) bool {
+	if len(o.Slots) > 0 {
+		for _, slot := range o.Slots {
+			if GuessValue(slot.Guess, music) == "" {
+				return false
+			}
+		}
+		return true
+	}
+	if o.Guess == GameGuess_Title || o.Guess == GameGuess_Album {
+		return GuessValue(o.Guess, music) != ""
+	}
+	return true
+}
+
+// toGuessValues lists the distinct guessed values of the questions, each with the artists of its music as hint.
+func toGuessValues(questions []*GameQuestion, guess GameGuess) []*GameAnswer {
+	values := make([]*GameAnswer, 0, len(questions))
+	for _, question := range questions {
+		value := GuessValue(guess, question.Music)
+		if value == "" {
+			continue
+		}
+		if _, found := util.FindIf(values, func(other *GameAnswer) bool { return other.Text == value }); !found {
+			values = append(values, toGuessAnswer(guess, question.Music, value))
+		}
+	}
+	return values
+}
+
+// toGuessAnswer hints the artists of the music, so that the hint joker does not single out the correct answer.
+func toGuessAnswer(guess GameGuess, music *Music, value string) *GameAnswer {
+	answer := &GameAnswer{
+		Text: value,
+	}
+	if guess != GameGuess_Artist {
+		answer.Hint = music.GetDefaultAnswerText()
+	}
+	return answer
+}
+
+func toGuessAnswers(random *rand.Rand, guess GameGuess, music *Music, values []*GameAnswer, nbAnswer int) []*GameAnswer {
+	value := GuessValue(guess, music)
+	others := util.Filter(values, func(other *GameAnswer) bool { return other.Text != value })
+	util.Shuffle(random, others)
+	if len(others) > nbAnswer-1 {
+		others = others[:nbAnswer-1]
+	}
+	answers := util.Convert(others, (*GameAnswer).Copy)
+	correct := toGuessAnswer(guess, music, value)
+	correct.Correct = true
+	answers = append(answers, correct)
+	util.Shuffle(random, answers)
+	return answers
+}
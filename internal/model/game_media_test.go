@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestGameRoundApplyMedia(t *testing.T) {
+	newQuestion := func(artist string, album string, albumImg model.Url) *model.GameQuestion {
+		return &model.GameQuestion{
+			Music: &model.Music{
+				Name:   artist + " - " + album,
+				Mp3Url: "music.mp3",
+				Artist: &model.MusicArtist{Name: artist, ImgUrl: "artist.jpg"},
+				Album:  &model.MusicAlbum{Name: album, ImgUrl: albumImg},
+			},
+			Answers: []*model.GameAnswer{{Text: artist, Correct: true}},
+		}
+	}
+
+	round := &model.GameRoundSettings{NbAnswer: 3}
+	round.ApplyDefaults()
+	questions := []*model.GameQuestion{newQuestion("abba", "arrival", "arrival.jpg")}
+	require.NoError(t, round.ApplyMedia(rand.New(rand.NewSource(1)), questions))
+	require.Equal(t, &model.GameMedia{Kind: model.GameMediaKind_Audio, Mp3Url: "music.mp3"}, questions[0].Media)
+
+	round = &model.GameRoundSettings{NbAnswer: 3, MediaKind: model.GameMediaKind_Image, Guess: model.GameGuess_Album}
+	err := round.ApplyMedia(rand.New(rand.NewSource(1)), []*model.GameQuestion{
+		newQuestion("abba", "arrival", "arrival.jpg"),
+		newQuestion("queen", "jazz", ""),
+	})
+	require.EqualError(t, err, `missing image of music "queen - jazz"`, "question without cover is not dropped silently")
+
+	questions = []*model.GameQuestion{
+		newQuestion("abba", "arrival", "arrival.jpg"),
+		newQuestion("blondie", "parallel lines", "parallel.jpg"),
+		newQuestion("toto", "IV", "iv.jpg"),
+	}
+	require.NoError(t, round.ApplyMedia(rand.New(rand.NewSource(1)), questions))
+	artists := map[string]string{"arrival": "abba", "parallel lines": "blondie", "IV": "toto"}
+	for _, question := range questions {
+		require.Equal(t, model.GameMediaKind_Image, question.Media.Kind)
+		require.Empty(t, question.Media.Mp3Url)
+		require.Equal(t, question.Music.Album.ImgUrl, question.Media.ImgUrl)
+		require.Len(t, question.Answers, 3)
+		correct := 0
+		for _, answer := range question.Answers {
+			// every answer is hinted, the hint joker does not single out the correct one
+			require.Equal(t, artists[answer.Text], answer.Hint)
+			if answer.Correct {
+				correct++
+				require.Equal(t, question.Music.Album.Name, answer.Text)
+			}
+		}
+		require.Equal(t, 1, correct)
+	}
+
+	round = &model.GameRoundSettings{NbAnswer: 3, MediaKind: model.GameMediaKind_Both, Guess: model.GameGuess_Artist}
+	questions = []*model.GameQuestion{newQuestion("abba", "arrival", "")}
+	require.NoError(t, round.ApplyMedia(rand.New(rand.NewSource(1)), questions))
+	require.Equal(t, model.Url("artist.jpg"), questions[0].Media.ImgUrl)
+	require.Equal(t, model.Url("music.mp3"), questions[0].Media.Mp3Url)
+	require.Equal(t, "abba", questions[0].Answers[0].Text)
+
+	round = &model.GameRoundSettings{NbAnswer: 3, Guess: model.GameGuess_Year}
+	err = round.ApplyMedia(rand.New(rand.NewSource(1)), []*model.GameQuestion{newQuestion("abba", "arrival", "")})
+	require.EqualError(t, err, `missing release year of music "abba - arrival"`)
+}
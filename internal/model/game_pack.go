@@ -0,0 +1,131 @@
+package model
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game pack
+
+// a game pack is a zip holding everything needed to host a game offline:
+//
+//	manifest.json
+//	game.json
+//	music/<file>.mp3
+//	image/<file>.jpg
+const (
+	GamePackFormat       = 1
+	GamePackManifestPath = "manifest.json"
+	GamePackGamePath     = "game.json"
+
+	// limits of an imported pack, checked before extracting anything
+	GamePackMaxNbEntry = 1000
+	GamePackMaxSize    = 1 << 30
+)
+
+type GamePackFileKind string
+
+const (
+	GamePackFileKind_Music GamePackFileKind = "music"
+	GamePackFileKind_Image GamePackFileKind = "image"
+)
+
+func (o GamePackFileKind) String() string {
+	return string(o)
+}
+
+func ToGamePackFileKind(value string) GamePackFileKind {
+	switch value {
+	case string(GamePackFileKind_Music):
+		return GamePackFileKind_Music
+	case string(GamePackFileKind_Image):
+		return GamePackFileKind_Image
+	}
+	return ""
+}
+
+// //////////////////////////////////////////////////
+// game pack manifest
+
+type GamePackManifest struct {
+	Format     int
+	GameId     GameId
+	ExportedAt time.Time
+	Files      []*GamePackFile
+}
+
+func (o *GamePackManifest) Validate() error {
+	if o.Format != GamePackFormat {
+		return ErrInvalidGamePack
+	}
+	for _, file := range o.Files {
+		if err := file.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateGamePackEntries checks the number of entries of the zip and their total uncompressed size.
+func ValidateGamePackEntries(sizes []uint64) error {
+	if len(sizes) > GamePackMaxNbEntry {
+		return ErrGamePackTooLarge
+	}
+	total := uint64(0)
+	for _, size := range sizes {
+		if size > GamePackMaxSize {
+			return ErrGamePackTooLarge
+		}
+		total += size
+		if total > GamePackMaxSize {
+			return ErrGamePackTooLarge
+		}
+	}
+	return nil
+}
+
+func (o *GamePackManifest) FindFile(kind GamePackFileKind, name Url) *GamePackFile {
+	file, _ := util.FindIf(o.Files, func(file *GamePackFile) bool { return file.Kind == kind && file.Name == name })
+	return file
+}
+
+func (o *GamePackManifest) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("format", o.Format)
+	enc.AddInt64("game-id", int64(o.GameId))
+	enc.AddInt("nb-file", len(o.Files))
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game pack file
+
+// GamePackFile is a media of the game, stored in the directory of its kind with the name used by the game urls.
+type GamePackFile struct {
+	Kind GamePackFileKind
+	Name Url
+	Size int64
+}
+
+func (o *GamePackFile) Validate() error {
+	if ToGamePackFileKind(o.Kind.String()) == "" {
+		return ErrInvalidGamePack
+	}
+	if cleaned, err := util.CleanLocalPath(string(o.Name)); err != nil || cleaned != string(o.Name) || path.IsAbs(cleaned) || cleaned == "." {
+		return ErrInvalidGamePack
+	}
+	return nil
+}
+
+// Path is the location of the file inside the zip.
+func (o *GamePackFile) Path() string {
+	return path.Join(o.Kind.String(), string(o.Name))
+}
+
+func (o *GamePackFile) String() string {
+	return fmt.Sprintf("%s ( %d bytes )", o.Path(), o.Size)
+}
@@ -0,0 +1,41 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestGamePackManifestValidate(t *testing.T) {
+	manifest := &model.GamePackManifest{
+		Format: model.GamePackFormat,
+		Files: []*model.GamePackFile{
+			{Kind: model.GamePackFileKind_Music, Name: "music_abba_sos.mp3"},
+			{Kind: model.GamePackFileKind_Image, Name: "covers/album_abba.jpg"},
+		},
+	}
+	require.NoError(t, manifest.Validate())
+	require.Equal(t, "image/covers/album_abba.jpg", manifest.Files[1].Path())
+	require.NotNil(t, manifest.FindFile(model.GamePackFileKind_Music, "music_abba_sos.mp3"))
+	require.Nil(t, manifest.FindFile(model.GamePackFileKind_Image, "music_abba_sos.mp3"))
+
+	for _, file := range []*model.GamePackFile{
+		{Kind: model.GamePackFileKind_Music, Name: "../escape.mp3"},
+		{Kind: model.GamePackFileKind_Music, Name: "/etc/escape.mp3"},
+		{Kind: model.GamePackFileKind_Music, Name: ""},
+		{Kind: "video", Name: "clip.mp4"},
+	} {
+		require.ErrorIs(t, file.Validate(), model.ErrInvalidGamePack, file.Name)
+	}
+
+	manifest.Format = 0
+	require.ErrorIs(t, manifest.Validate(), model.ErrInvalidGamePack)
+}
+
+func TestValidateGamePackEntries(t *testing.T) {
+	require.NoError(t, model.ValidateGamePackEntries([]uint64{1, model.GamePackMaxSize - 1}))
+	require.ErrorIs(t, model.ValidateGamePackEntries([]uint64{1, model.GamePackMaxSize}), model.ErrGamePackTooLarge)
+	require.ErrorIs(t, model.ValidateGamePackEntries(make([]uint64, model.GamePackMaxNbEntry+1)), model.ErrGamePackTooLarge)
+}
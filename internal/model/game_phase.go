@@ -0,0 +1,42 @@
+package model
+
+import (
+	"fmt"
+)
+
+// //////////////////////////////////////////////////
+// game phase
+
+// GamePhase is the progress of a game: not started, playing a round ( e.g. "round-2" ) or finished.
+type GamePhase string
+
+const (
+	GamePhase_NotStarted GamePhase = "not-started"
+	GamePhase_Finished   GamePhase = "finished"
+)
+
+func NewGameRoundPhase(round int) GamePhase {
+	return GamePhase(fmt.Sprintf("round-%d", round))
+}
+
+func (o GamePhase) String() string {
+	return string(o)
+}
+
+// Phase is derived from the questions played so far: the latest round with an answered, revealed or joked question.
+func (o *Game) Phase() GamePhase {
+	if o.Finished {
+		return GamePhase_Finished
+	}
+	round := 0
+	for _, question := range o.Questions {
+		played := question.Revealed || len(question.PlayerAnswers) > 0 || len(question.PlayerJokers) > 0
+		if played && question.Round > round {
+			round = question.Round
+		}
+	}
+	if round == 0 {
+		return GamePhase_NotStarted
+	}
+	return NewGameRoundPhase(round)
+}
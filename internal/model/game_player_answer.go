@@ -0,0 +1,60 @@
+package model
+
+import (
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game player answer
+
+type GamePlayerAnswer struct {
+	Id       GamePlayerAnswerId
+	PlayerId GamePlayerId
+	AnswerId GameAnswerId
+	Year     int
+	Parts    []*GameSlotAnswer
+	Correct  bool
+	Points   int
+}
+
+func (o *GamePlayerAnswer) Copy() *GamePlayerAnswer {
+	if o == nil {
+		return nil
+	}
+	return &GamePlayerAnswer{
+		Id:       o.Id,
+		PlayerId: o.PlayerId,
+		AnswerId: o.AnswerId,
+		Year:     o.Year,
+		Parts:    util.Convert(o.Parts, (*GameSlotAnswer).Copy),
+		Correct:  o.Correct,
+		Points:   o.Points,
+	}
+}
+
+// IsPartial tells whether only some parts of a multi-part question are correct.
+func (o *GamePlayerAnswer) IsPartial() bool {
+	if o.Correct {
+		return false
+	}
+	_, found := util.FindIf(o.Parts, func(part *GameSlotAnswer) bool { return part.Correct })
+	return found
+}
+
+func (o *GamePlayerAnswer) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("id", int64(o.Id))
+	enc.AddInt64("player-id", int64(o.PlayerId))
+	if o.AnswerId != 0 {
+		enc.AddInt64("answer-id", int64(o.AnswerId))
+	}
+	if o.Year != 0 {
+		enc.AddInt("year", o.Year)
+	}
+	if len(o.Parts) > 0 {
+		enc.AddInt("nb-parts", len(o.Parts))
+	}
+	enc.AddBool("correct", o.Correct)
+	enc.AddInt("points", o.Points)
+	return nil
+}
@@ -0,0 +1,97 @@
+package model
+
+import "github.com/gre-ory/amnezic-go/internal/util"
+
+// //////////////////////////////////////////////////
+// game player view
+
+// GamePlayerView is what a player is allowed to see of a game:
+// no music nor correct flag ( nor expected text of slots ), answers removed by a "fifty-fifty" are hidden
+// and hints are only disclosed once a "hint" joker has been played.
+type GamePlayerView struct {
+	GameId          GameId
+	Player          *GamePlayer
+	Rounds          []*GameRound
+	RemainingJokers map[GameJokerType]int
+	Questions       []*GamePlayerQuestionView
+}
+
+type GamePlayerQuestionView struct {
+	Id           GameQuestionId
+	Round        int
+	Theme        *GameTheme
+	Answers      []*GameAnswer
+	Slots        []*GameAnswerSlot
+	Jokers       []GameJokerType
+	Skipped      bool
+	PlayerAnswer *GamePlayerAnswer
+}
+
+func (o *Game) PlayerView(playerId GamePlayerId) (*GamePlayerView, error) {
+	player := o.FindPlayer(playerId)
+	if player == nil {
+		return nil, ErrGamePlayerNotFound
+	}
+
+	view := &GamePlayerView{
+		GameId:          o.Id,
+		Player:          player.Copy(),
+		Rounds:          o.Rounds,
+		RemainingJokers: o.RemainingJokers(playerId),
+	}
+	for _, question := range o.Questions {
+		questionView := &GamePlayerQuestionView{
+			Id:           question.Id,
+			Round:        question.Round,
+			Theme:        question.Theme.Copy(),
+			PlayerAnswer: question.FindPlayerAnswer(playerId).Copy(),
+		}
+
+		removedAnswerIds := make(map[GameAnswerId]bool)
+		withHint := false
+		for _, playerJoker := range question.PlayerJokers {
+			if playerJoker.PlayerId != playerId {
+				continue
+			}
+			questionView.Jokers = append(questionView.Jokers, playerJoker.Type)
+			switch playerJoker.Type {
+			case GameJokerType_FiftyFifty:
+				for _, answerId := range playerJoker.RemovedAnswerIds {
+					removedAnswerIds[answerId] = true
+				}
+			case GameJokerType_Hint:
+				withHint = true
+			case GameJokerType_Skip:
+				questionView.Skipped = true
+			}
+		}
+
+		for _, answer := range question.Answers {
+			if removedAnswerIds[answer.Id] {
+				continue
+			}
+			answerView := &GameAnswer{
+				Id:   answer.Id,
+				Text: answer.Text,
+			}
+			if withHint {
+				answerView.Hint = answer.Hint
+			}
+			questionView.Answers = append(questionView.Answers, answerView)
+		}
+
+		for _, slot := range question.Slots {
+			questionView.Slots = append(questionView.Slots, &GameAnswerSlot{
+				Number: slot.Number,
+				Guess:  slot.Guess,
+				Points: slot.Points,
+				Answers: util.Convert(slot.Answers, func(answer *GameAnswer) *GameAnswer {
+					return &GameAnswer{Id: answer.Id, Text: answer.Text}
+				}),
+			})
+		}
+
+		view.Questions = append(view.Questions, questionView)
+	}
+	return view, nil
+}
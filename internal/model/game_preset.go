@@ -0,0 +1,95 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game preset id
+
+type GamePresetId int64
+
+func (i GamePresetId) String() string {
+	return fmt.Sprintf("%d", i)
+}
+
+func (i GamePresetId) ToInt64() int64 {
+	return int64(i)
+}
+
+func ToGamePresetId(value string) GamePresetId {
+	return GamePresetId(util.StrToInt64(value))
+}
+
+// //////////////////////////////////////////////////
+// game preset
+
+type GamePreset struct {
+	Id       GamePresetId
+	Name     string
+	OwnerId  UserId
+	Public   bool
+	Settings *GameSettings
+}
+
+func (o *GamePreset) IsOwnedBy(userId UserId) bool {
+	return userId != 0 && o.OwnerId == userId
+}
+
+func (o *GamePreset) IsVisibleTo(userId UserId) bool {
+	return o.Public || o.IsOwnedBy(userId)
+}
+
+// ToGameSettings returns a copy of the preset settings ( the seed is never stored ).
+func (o *GamePreset) ToGameSettings() GameSettings {
+	if o.Settings == nil {
+		return GameSettings{}
+	}
+	return GameSettings{
+		NbQuestion:       o.Settings.NbQuestion,
+		NbAnswer:         o.Settings.NbAnswer,
+		NbPlayer:         o.Settings.NbPlayer,
+		Sources:          append([]Source(nil), o.Settings.Sources...),
+		ThemeIds:         append([]ThemeId(nil), o.Settings.ThemeIds...),
+		DeezerPlaylistId: o.Settings.DeezerPlaylistId,
+		Decade:           o.Settings.Decade,
+		Genre:            o.Settings.Genre,
+		Rounds:           util.Convert(o.Settings.Rounds, (*GameRoundSettings).Copy),
+		Jokers:           util.Convert(o.Settings.Jokers, (*GameJokerSettings).Copy),
+	}
+}
+
+func (o *GamePreset) Validate() error {
+	if o.Name == "" {
+		return ErrInvalidGamePresetName
+	}
+	if o.Settings == nil {
+		return ErrMissingGamePresetSettings
+	}
+	return o.Settings.Validate()
+}
+
+func (o *GamePreset) Copy() *GamePreset {
+	settings := o.ToGameSettings()
+	return &GamePreset{
+		Id:       o.Id,
+		Name:     o.Name,
+		OwnerId:  o.OwnerId,
+		Public:   o.Public,
+		Settings: &settings,
+	}
+}
+
+func (o *GamePreset) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("id", int64(o.Id))
+	enc.AddString("name", o.Name)
+	enc.AddInt64("owner-id", int64(o.OwnerId))
+	enc.AddBool("public", o.Public)
+	if o.Settings != nil {
+		enc.AddObject("settings", o.Settings)
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package model
+
+import "go.uber.org/zap/zapcore"
+
+// //////////////////////////////////////////////////
+// game preset filter
+
+type GamePresetFilter struct {
+	GamePresetId GamePresetId
+	OwnerId      UserId
+	VisibleTo    UserId
+	PublicOnly   bool
+}
+
+func (o *GamePresetFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.GamePresetId != 0 {
+		enc.AddInt64("game-preset-id", int64(o.GamePresetId))
+	}
+	if o.OwnerId != 0 {
+		enc.AddInt64("owner-id", int64(o.OwnerId))
+	}
+	if o.VisibleTo != 0 {
+		enc.AddInt64("visible-to", int64(o.VisibleTo))
+	}
+	if o.PublicOnly {
+		enc.AddBool("public-only", o.PublicOnly)
+	}
+	return nil
+}
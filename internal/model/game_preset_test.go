@@ -0,0 +1,63 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func newTestGamePreset() *model.GamePreset {
+	return &model.GamePreset{
+		Name:     "evening",
+		Settings: &model.GameSettings{NbQuestion: 10, NbAnswer: 4, NbPlayer: 2, Sources: []model.Source{model.Source_Store}},
+	}
+}
+
+func TestGamePresetValidate(t *testing.T) {
+	require.NoError(t, newTestGamePreset().Validate())
+
+	preset := newTestGamePreset()
+	preset.Name = ""
+	require.ErrorIs(t, preset.Validate(), model.ErrInvalidGamePresetName)
+
+	preset = newTestGamePreset()
+	preset.Settings = nil
+	require.ErrorIs(t, preset.Validate(), model.ErrMissingGamePresetSettings)
+
+	// the settings are validated as for a game
+	preset = newTestGamePreset()
+	preset.Settings.NbQuestion = 0
+	require.ErrorIs(t, preset.Validate(), model.ErrInvalidNbQuestion)
+
+	preset = newTestGamePreset()
+	preset.Settings.NbAnswer = 1
+	require.ErrorIs(t, preset.Validate(), model.ErrInvalidNbAnswer)
+
+	preset = newTestGamePreset()
+	preset.Settings.NbPlayer = 0
+	require.ErrorIs(t, preset.Validate(), model.ErrInvalidNbPlayer)
+
+	preset = newTestGamePreset()
+	preset.Settings.Sources = nil
+	require.ErrorIs(t, preset.Validate(), model.ErrMissingSource)
+}
+
+func TestGamePresetVisibility(t *testing.T) {
+	preset := newTestGamePreset()
+	preset.OwnerId = 1
+
+	require.True(t, preset.IsOwnedBy(1))
+	require.False(t, preset.IsOwnedBy(2))
+	require.False(t, preset.IsOwnedBy(0))
+
+	require.True(t, preset.IsVisibleTo(1))
+	require.False(t, preset.IsVisibleTo(2))
+	require.False(t, preset.IsVisibleTo(0))
+
+	preset.Public = true
+	require.True(t, preset.IsVisibleTo(2))
+	require.True(t, preset.IsVisibleTo(0))
+	require.False(t, preset.IsOwnedBy(2))
+}
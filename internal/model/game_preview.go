@@ -0,0 +1,80 @@
+package model
+
+import (
+	"fmt"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game preview
+
+type GamePreview struct {
+	Settings   *GameSettings
+	NbQuestion int
+	Rounds     []*GameRoundPreview
+	Warnings   []string
+}
+
+func (o *GamePreview) IsFillable() bool {
+	return len(o.Warnings) == 0
+}
+
+// Check computes the number of available questions and warns about
+// any round, source or theme that could not fill the requested settings.
+func (o *GamePreview) Check() {
+	o.NbQuestion = 0
+	o.Warnings = nil
+	for _, round := range o.Rounds {
+		round.NbQuestion = 0
+		for _, source := range round.Sources {
+			round.NbQuestion += source.NbQuestion
+			for _, theme := range source.Themes {
+				if theme.NbQuestion > 0 && theme.NbAnswer < round.Settings.NbAnswer {
+					o.Warnings = append(o.Warnings, fmt.Sprintf("round %d: theme %q from source %s only provides %d distinct answer(s) out of %d", round.Number, theme.Title, source.Source, theme.NbAnswer, round.Settings.NbAnswer))
+				}
+			}
+		}
+		if round.NbQuestion < round.Settings.NbQuestion {
+			o.Warnings = append(o.Warnings, fmt.Sprintf("round %d: only %d question(s) available out of %d", round.Number, round.NbQuestion, round.Settings.NbQuestion))
+		}
+		o.NbQuestion += round.NbQuestion
+	}
+}
+
+func (o *GamePreview) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("nb-question", o.NbQuestion)
+	enc.AddInt("nb-round", len(o.Rounds))
+	enc.AddInt("nb-warning", len(o.Warnings))
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game round preview
+
+type GameRoundPreview struct {
+	Number     int
+	Settings   *GameRoundSettings
+	NbQuestion int
+	Sources    []*GameSourcePreview
+}
+
+// //////////////////////////////////////////////////
+// game source preview
+
+type GameSourcePreview struct {
+	Source     Source
+	NbQuestion int
+	NbAnswer   int
+	Themes     []*GameThemePreview
+}
+
+// //////////////////////////////////////////////////
+// game theme preview
+
+type GameThemePreview struct {
+	Id         int64
+	Title      string
+	NbQuestion int
+	NbAnswer   int
+}
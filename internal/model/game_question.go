@@ -9,10 +9,18 @@ import (
 // game question
 
 type GameQuestion struct {
-	Id      GameQuestionId
-	Theme   *GameTheme
-	Music   *Music
-	Answers []*GameAnswer
+	Id            GameQuestionId
+	Round         int
+	Theme         *GameTheme
+	Music         *Music
+	Media         *GameMedia
+	Excerpt       AudioExcerpt
+	Year          int
+	Answers       []*GameAnswer
+	Slots         []*GameAnswerSlot
+	PlayerAnswers []*GamePlayerAnswer
+	PlayerJokers  []*GamePlayerJoker
+	Revealed      bool
 }
 
 func (o *GameQuestion) Copy() *GameQuestion {
@@ -20,17 +28,82 @@ func (o *GameQuestion) Copy() *GameQuestion {
 		return nil
 	}
 	return &GameQuestion{
-		Id:      o.Id,
-		Theme:   o.Theme.Copy(),
-		Music:   o.Music.Copy(),
-		Answers: util.Convert(o.Answers, (*GameAnswer).Copy),
+		Id:    o.Id,
+		Round: o.Round,
+		Theme: o.Theme.Copy(),
+		// music ( with its artist and album ) is never modified during a game
+		Music:         o.Music,
+		Media:         o.Media,
+		Excerpt:       o.Excerpt,
+		Year:          o.Year,
+		Answers:       util.Convert(o.Answers, (*GameAnswer).Copy),
+		Slots:         util.Convert(o.Slots, (*GameAnswerSlot).Copy),
+		PlayerAnswers: util.Convert(o.PlayerAnswers, (*GamePlayerAnswer).Copy),
+		PlayerJokers:  util.Convert(o.PlayerJokers, (*GamePlayerJoker).Copy),
+		Revealed:      o.Revealed,
 	}
 }
 
+// IsYearQuestion tells whether the release year is guessed instead of choosing an answer.
+func (o *GameQuestion) IsYearQuestion() bool {
+	return o.Year != 0
+}
+
+// IsSlotQuestion tells whether the question is answered in several parts, each scored separately.
+func (o *GameQuestion) IsSlotQuestion() bool {
+	return len(o.Slots) > 0
+}
+
+func (o *GameQuestion) FindSlot(number int) *GameAnswerSlot {
+	slot, _ := util.FindIf(o.Slots, func(slot *GameAnswerSlot) bool { return slot.Number == number })
+	return slot
+}
+
+func (o *GameQuestion) FindAnswer(id GameAnswerId) *GameAnswer {
+	answer, _ := util.FindIf(o.Answers, func(answer *GameAnswer) bool { return answer.Id == id })
+	return answer
+}
+
+func (o *GameQuestion) FindPlayerAnswer(playerId GamePlayerId) *GamePlayerAnswer {
+	playerAnswer, _ := util.FindIf(o.PlayerAnswers, func(playerAnswer *GamePlayerAnswer) bool { return playerAnswer.PlayerId == playerId })
+	return playerAnswer
+}
+
+func (o *GameQuestion) FindPlayerJoker(playerId GamePlayerId, jokerType GameJokerType) *GamePlayerJoker {
+	playerJoker, _ := util.FindIf(o.PlayerJokers, func(playerJoker *GamePlayerJoker) bool {
+		return playerJoker.PlayerId == playerId && playerJoker.Type == jokerType
+	})
+	return playerJoker
+}
+
+func (o *GameQuestion) HasCorrectPlayerAnswer() bool {
+	_, found := util.FindIf(o.PlayerAnswers, func(playerAnswer *GamePlayerAnswer) bool { return playerAnswer.Correct })
+	return found
+}
+
 func (o *GameQuestion) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt64("id", int64(o.Id))
+	enc.AddInt("round", o.Round)
 	enc.AddObject("theme", o.Theme)
 	enc.AddObject("music", o.Music)
+	if o.Media != nil {
+		enc.AddObject("media", o.Media)
+	}
+	if !o.Excerpt.IsZero() {
+		enc.AddObject("excerpt", o.Excerpt)
+	}
+	if o.Year != 0 {
+		enc.AddInt("year", o.Year)
+	}
 	enc.AddInt("nb-answers", len(o.Answers))
+	if len(o.Slots) > 0 {
+		enc.AddInt("nb-slots", len(o.Slots))
+	}
+	if len(o.PlayerAnswers) > 0 {
+		enc.AddInt("nb-player-answers", len(o.PlayerAnswers))
+	}
+	if len(o.PlayerJokers) > 0 {
+		enc.AddInt("nb-player-jokers", len(o.PlayerJokers))
+	}
 	return nil
 }
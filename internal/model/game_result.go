@@ -0,0 +1,92 @@
+package model
+
+import (
+	"sort"
+)
+
+// //////////////////////////////////////////////////
+// game result
+
+type GameResult struct {
+	Rounds  []*GameRound
+	Players []*GamePlayerResult
+}
+
+type GamePlayerResult struct {
+	PlayerId    GamePlayerId
+	Name        string
+	RoundScores []int
+	Score       int
+
+	// answers fully correct, or only correct for some parts of multi-part questions
+	NbCorrect int
+	NbPartial int
+}
+
+// Result computes the score of each active player with a subtotal per round, best score first.
+func (o *Game) Result() *GameResult {
+	roundIndexes := make(map[int]int, len(o.Rounds))
+	for index, round := range o.Rounds {
+		roundIndexes[round.Number] = index
+	}
+
+	players := make([]*GamePlayerResult, 0, len(o.Players))
+	playerResults := make(map[GamePlayerId]*GamePlayerResult, len(o.Players))
+	for _, player := range o.Players {
+		if !player.Active {
+			continue
+		}
+		playerResult := &GamePlayerResult{
+			PlayerId:    player.Id,
+			Name:        player.Name,
+			RoundScores: make([]int, len(o.Rounds)),
+		}
+		players = append(players, playerResult)
+		playerResults[player.Id] = playerResult
+	}
+
+	for _, question := range o.Questions {
+		roundIndex, found := roundIndexes[question.Round]
+		for _, playerAnswer := range question.PlayerAnswers {
+			playerResult, ok := playerResults[playerAnswer.PlayerId]
+			if !ok {
+				continue
+			}
+			if found {
+				playerResult.RoundScores[roundIndex] += playerAnswer.Points
+			}
+			playerResult.Score += playerAnswer.Points
+			switch {
+			case playerAnswer.Correct:
+				playerResult.NbCorrect++
+			case playerAnswer.IsPartial():
+				playerResult.NbPartial++
+			}
+		}
+		for _, playerJoker := range question.PlayerJokers {
+			playerResult, ok := playerResults[playerJoker.PlayerId]
+			if !ok {
+				continue
+			}
+			if found {
+				playerResult.RoundScores[roundIndex] -= playerJoker.Cost
+			}
+			playerResult.Score -= playerJoker.Cost
+		}
+	}
+
+	for _, adjustment := range o.Adjustments {
+		if playerResult, ok := playerResults[adjustment.PlayerId]; ok {
+			playerResult.Score += adjustment.Points
+		}
+	}
+
+	sort.SliceStable(players, func(i, j int) bool {
+		return players[i].Score > players[j].Score
+	})
+
+	return &GameResult{
+		Rounds:  o.Rounds,
+		Players: players,
+	}
+}
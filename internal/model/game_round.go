@@ -0,0 +1,338 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// game question type
+
+type GameQuestionType string
+
+var (
+	GameQuestionType_Choice GameQuestionType = "choice"
+	GameQuestionType_Buzzer GameQuestionType = "buzzer"
+)
+
+func ToGameQuestionType(value string) GameQuestionType {
+	value = strings.Trim(value, " ")
+	value = strings.ToLower(value)
+	switch value {
+	case string(GameQuestionType_Choice):
+		return GameQuestionType_Choice
+	case string(GameQuestionType_Buzzer):
+		return GameQuestionType_Buzzer
+	default:
+		return ""
+	}
+}
+
+func (o GameQuestionType) String() string {
+	return string(o)
+}
+
+// IsBuzzer tells whether the question is closed by the first correct answer.
+func (o GameQuestionType) IsBuzzer() bool {
+	return o == GameQuestionType_Buzzer
+}
+
+// //////////////////////////////////////////////////
+// game scoring
+
+type GameScoring struct {
+	Correct int
+	Wrong   int
+}
+
+func DefaultGameScoring(questionType GameQuestionType) GameScoring {
+	if questionType.IsBuzzer() {
+		return GameScoring{Correct: 2, Wrong: -1}
+	}
+	return GameScoring{Correct: 1, Wrong: 0}
+}
+
+func (o GameScoring) IsZero() bool {
+	return o.Correct == 0 && o.Wrong == 0
+}
+
+func (o GameScoring) Points(correct bool) int {
+	if correct {
+		return o.Correct
+	}
+	return o.Wrong
+}
+
+func (o GameScoring) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("correct", o.Correct)
+	enc.AddInt("wrong", o.Wrong)
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game year scoring
+
+// GameYearStep awards points to a guessed year at most Within years away from the release year.
+type GameYearStep struct {
+	Within int
+	Points int
+}
+
+// GameYearScoring scores the year questions by closeness: the best step covering the gap applies, no point otherwise.
+type GameYearScoring struct {
+	Steps []GameYearStep
+}
+
+const (
+	MaxGameYearWithin = 100
+)
+
+func DefaultGameYearScoring() GameYearScoring {
+	return GameYearScoring{
+		Steps: []GameYearStep{
+			{Within: 0, Points: 5},
+			{Within: 2, Points: 3},
+			{Within: 5, Points: 1},
+		},
+	}
+}
+
+func (o GameYearScoring) IsZero() bool {
+	return len(o.Steps) == 0
+}
+
+func (o GameYearScoring) Points(guess int, year int) int {
+	gap := guess - year
+	if gap < 0 {
+		gap = -gap
+	}
+	points := 0
+	for _, step := range o.Steps {
+		if gap <= step.Within && step.Points > points {
+			points = step.Points
+		}
+	}
+	return points
+}
+
+func (o GameYearScoring) Validate() error {
+	for _, step := range o.Steps {
+		if step.Within < 0 || step.Within > MaxGameYearWithin || step.Points <= 0 {
+			return ErrInvalidGameYearScoring
+		}
+	}
+	return nil
+}
+
+func (o GameYearScoring) Copy() GameYearScoring {
+	return GameYearScoring{
+		Steps: append([]GameYearStep(nil), o.Steps...),
+	}
+}
+
+func (o GameYearScoring) MarshalLogArray(enc zapcore.ArrayEncoder) error {
+	for _, step := range o.Steps {
+		enc.AppendString(fmt.Sprintf("%d:%d", step.Within, step.Points))
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game round settings
+
+type GameRoundSettings struct {
+	Title            string
+	NbQuestion       int
+	NbAnswer         int
+	Sources          []Source
+	ThemeIds         []ThemeId
+	DeezerPlaylistId DeezerPlaylistId
+	Decade           GameDecade
+	Genre            string
+	QuestionType     GameQuestionType
+	Scoring          GameScoring
+	MediaKind        GameMediaKind
+	Guess            GameGuess
+	YearScoring      GameYearScoring
+
+	// optional parts of multi-part questions, scored separately
+	Slots []*GameSlotSettings
+}
+
+// ApplyDefaults falls back to the store source, multiple choice audio questions on the artist and the default scoring of the question type.
+func (o *GameRoundSettings) ApplyDefaults() {
+	if len(o.Sources) == 0 {
+		o.Sources = append(o.Sources, Source_Store)
+	}
+	if o.QuestionType == "" {
+		o.QuestionType = GameQuestionType_Choice
+	}
+	if o.Scoring.IsZero() {
+		o.Scoring = DefaultGameScoring(o.QuestionType)
+	}
+	if o.MediaKind == "" {
+		o.MediaKind = GameMediaKind_Audio
+	}
+	if o.Guess == "" {
+		o.Guess = GameGuess_Artist
+	}
+	if o.Guess == GameGuess_Year && o.YearScoring.IsZero() {
+		o.YearScoring = DefaultGameYearScoring()
+	}
+}
+
+func (o *GameRoundSettings) Validate() error {
+	if o.NbQuestion < MinNbQuestion || o.NbQuestion > MaxNbQuestion {
+		return ErrInvalidNbQuestion
+	}
+	if o.Guess != GameGuess_Year && (o.NbAnswer < MinNbAnswer || o.NbAnswer > MaxNbAnswer) {
+		return ErrInvalidNbAnswer
+	}
+	if len(o.Sources) == 0 {
+		return ErrMissingSource
+	}
+	if o.Decade != 0 && !o.Decade.IsValid() {
+		return ErrInvalidGameDecade
+	}
+	if ToGameQuestionType(o.QuestionType.String()) == "" {
+		return ErrInvalidGameQuestionType
+	}
+	if ToGameMediaKind(o.MediaKind.String()) == "" {
+		return ErrInvalidGameMediaKind
+	}
+	if ToGameGuess(o.Guess.String()) == "" {
+		return ErrInvalidGameGuess
+	}
+	if err := o.YearScoring.Validate(); err != nil {
+		return err
+	}
+	if len(o.Slots) > 0 && o.Guess == GameGuess_Year {
+		return ErrInvalidGameSlot
+	}
+	guesses := make(map[GameGuess]bool, len(o.Slots))
+	for _, slot := range o.Slots {
+		if err := slot.Validate(); err != nil {
+			return err
+		}
+		if guesses[slot.Guess] {
+			return ErrDuplicateGameSlot
+		}
+		guesses[slot.Guess] = true
+	}
+	return nil
+}
+
+func (o *GameRoundSettings) Copy() *GameRoundSettings {
+	if o == nil {
+		return nil
+	}
+	return &GameRoundSettings{
+		Title:            o.Title,
+		NbQuestion:       o.NbQuestion,
+		NbAnswer:         o.NbAnswer,
+		Sources:          append([]Source(nil), o.Sources...),
+		ThemeIds:         append([]ThemeId(nil), o.ThemeIds...),
+		DeezerPlaylistId: o.DeezerPlaylistId,
+		Decade:           o.Decade,
+		Genre:            o.Genre,
+		QuestionType:     o.QuestionType,
+		Scoring:          o.Scoring,
+		MediaKind:        o.MediaKind,
+		Guess:            o.Guess,
+		YearScoring:      o.YearScoring.Copy(),
+		Slots:            util.Convert(o.Slots, (*GameSlotSettings).Copy),
+	}
+}
+
+func (o *GameRoundSettings) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.Title != "" {
+		enc.AddString("title", o.Title)
+	}
+	enc.AddInt("nb-question", o.NbQuestion)
+	enc.AddInt("nb-answer", o.NbAnswer)
+	if len(o.Sources) > 0 {
+		enc.AddString("sources", util.Join(o.Sources, ","))
+	}
+	if len(o.ThemeIds) > 0 {
+		enc.AddString("theme-ids", util.Join(o.ThemeIds, ","))
+	}
+	if o.DeezerPlaylistId != 0 {
+		enc.AddInt64("deezer-playlist-id", int64(o.DeezerPlaylistId))
+	}
+	if o.Decade != 0 {
+		enc.AddString("decade", o.Decade.String())
+	}
+	if o.Genre != "" {
+		enc.AddString("genre", o.Genre)
+	}
+	enc.AddString("question-type", o.QuestionType.String())
+	enc.AddObject("scoring", o.Scoring)
+	enc.AddString("media-kind", o.MediaKind.String())
+	enc.AddString("guess", o.Guess.String())
+	if !o.YearScoring.IsZero() {
+		enc.AddArray("year-scoring", o.YearScoring)
+	}
+	if len(o.Slots) > 0 {
+		enc.AddArray("slots", zapcore.ArrayMarshalerFunc(o.MarshalLogSlots))
+	}
+	return nil
+}
+
+func (o *GameRoundSettings) MarshalLogSlots(enc zapcore.ArrayEncoder) error {
+	for _, slot := range o.Slots {
+		enc.AppendObject(slot)
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// game round
+
+type GameRound struct {
+	Number       int
+	Title        string
+	QuestionType GameQuestionType
+	Scoring      GameScoring
+	MediaKind    GameMediaKind
+	Guess        GameGuess
+	YearScoring  GameYearScoring
+	Slots        []*GameSlotSettings
+}
+
+func (o *GameRound) Copy() *GameRound {
+	if o == nil {
+		return nil
+	}
+	return &GameRound{
+		Number:       o.Number,
+		Title:        o.Title,
+		QuestionType: o.QuestionType,
+		Scoring:      o.Scoring,
+		MediaKind:    o.MediaKind,
+		Guess:        o.Guess,
+		YearScoring:  o.YearScoring.Copy(),
+		Slots:        util.Convert(o.Slots, (*GameSlotSettings).Copy),
+	}
+}
+
+func (o *GameRound) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("number", o.Number)
+	if o.Title != "" {
+		enc.AddString("title", o.Title)
+	}
+	enc.AddString("question-type", o.QuestionType.String())
+	enc.AddObject("scoring", o.Scoring)
+	if o.MediaKind != "" {
+		enc.AddString("media-kind", o.MediaKind.String())
+	}
+	if o.Guess != "" {
+		enc.AddString("guess", o.Guess.String())
+	}
+	if !o.YearScoring.IsZero() {
+		enc.AddArray("year-scoring", o.YearScoring)
+	}
+	return nil
+}
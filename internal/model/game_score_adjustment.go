@@ -0,0 +1,31 @@
+package model
+
+import "go.uber.org/zap/zapcore"
+
+// //////////////////////////////////////////////////
+// game score adjustment
+
+// GameScoreAdjustment is a manual correction of the score of a player by the host.
+type GameScoreAdjustment struct {
+	PlayerId GamePlayerId
+	Points   int
+	Reason   string
+}
+
+func (o *GameScoreAdjustment) Copy() *GameScoreAdjustment {
+	if o == nil {
+		return nil
+	}
+	return &GameScoreAdjustment{
+		PlayerId: o.PlayerId,
+		Points:   o.Points,
+		Reason:   o.Reason,
+	}
+}
+
+func (o *GameScoreAdjustment) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("player-id", int64(o.PlayerId))
+	enc.AddInt("points", o.Points)
+	enc.AddString("reason", o.Reason)
+	return nil
+}
@@ -16,6 +16,56 @@ type GameSettings struct {
 	Sources          []Source
 	ThemeIds         []ThemeId
 	DeezerPlaylistId DeezerPlaylistId
+	Decade           GameDecade
+	Genre            string
+	Rounds           []*GameRoundSettings
+	Jokers           []*GameJokerSettings
+}
+
+func (o *GameSettings) FindJoker(jokerType GameJokerType) *GameJokerSettings {
+	joker, _ := util.FindIf(o.Jokers, func(joker *GameJokerSettings) bool { return joker.Type == jokerType })
+	return joker
+}
+
+// GetRounds returns the configured rounds or a single round built from the flat settings.
+func (o *GameSettings) GetRounds() []*GameRoundSettings {
+	if len(o.Rounds) > 0 {
+		return o.Rounds
+	}
+	round := &GameRoundSettings{
+		NbQuestion:       o.NbQuestion,
+		NbAnswer:         o.NbAnswer,
+		Sources:          o.Sources,
+		ThemeIds:         o.ThemeIds,
+		DeezerPlaylistId: o.DeezerPlaylistId,
+		Decade:           o.Decade,
+		Genre:            o.Genre,
+	}
+	round.ApplyDefaults()
+	return []*GameRoundSettings{round}
+}
+
+// ForRound returns the flat settings used to select the questions of the given round ( numbered from 1 ).
+func (o *GameSettings) ForRound(number int, round *GameRoundSettings) GameSettings {
+	return GameSettings{
+		Seed:             o.Seed + int64(number-1),
+		NbQuestion:       round.NbQuestion,
+		NbAnswer:         round.NbAnswer,
+		NbPlayer:         o.NbPlayer,
+		Sources:          round.Sources,
+		ThemeIds:         round.ThemeIds,
+		DeezerPlaylistId: round.DeezerPlaylistId,
+		Decade:           round.Decade,
+		Genre:            round.Genre,
+	}
+}
+
+func (o *GameSettings) GetNbQuestion() int {
+	nbQuestion := 0
+	for _, round := range o.GetRounds() {
+		nbQuestion += round.NbQuestion
+	}
+	return nbQuestion
 }
 
 func (o *GameSettings) UseDeezerPlaylist() bool {
@@ -31,6 +81,34 @@ func (o *GameSettings) UseStore() bool {
 	return ok
 }
 
+// UseMusicBucket tells whether the questions are musics of the store catalog picked by decade and / or genre.
+func (o *GameSettings) UseMusicBucket() bool {
+	return o.bucket().isUsed()
+}
+
+// ToMusicFilter selects the musics of the decade and / or genre bucket.
+func (o *GameSettings) ToMusicFilter() *MusicFilter {
+	return o.bucket().toMusicFilter()
+}
+
+// BucketTitle names the decade and / or genre bucket ( e.g. "1980s - Rock" ).
+func (o *GameSettings) BucketTitle() string {
+	return o.bucket().title()
+}
+
+// BucketSource is the source previewed for the bucket.
+func (o *GameSettings) BucketSource() Source {
+	return o.bucket().source()
+}
+
+func (o *GameSettings) bucket() musicBucket {
+	return musicBucket{
+		sources: o.Sources,
+		decade:  o.Decade,
+		genre:   o.Genre,
+	}
+}
+
 func (o *GameSettings) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	enc.AddInt64("seed", o.Seed)
 	enc.AddInt("nb-question", o.NbQuestion)
@@ -45,6 +123,32 @@ func (o *GameSettings) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if o.DeezerPlaylistId != 0 {
 		enc.AddInt64("deezer-playlist-id", int64(o.DeezerPlaylistId))
 	}
+	if o.Decade != 0 {
+		enc.AddString("decade", o.Decade.String())
+	}
+	if o.Genre != "" {
+		enc.AddString("genre", o.Genre)
+	}
+	if len(o.Rounds) > 0 {
+		enc.AddArray("rounds", zapcore.ArrayMarshalerFunc(o.MarshalLogRounds))
+	}
+	if len(o.Jokers) > 0 {
+		enc.AddArray("jokers", zapcore.ArrayMarshalerFunc(o.MarshalLogJokers))
+	}
+	return nil
+}
+
+func (o *GameSettings) MarshalLogJokers(enc zapcore.ArrayEncoder) error {
+	for _, joker := range o.Jokers {
+		enc.AppendObject(joker)
+	}
+	return nil
+}
+
+func (o *GameSettings) MarshalLogRounds(enc zapcore.ArrayEncoder) error {
+	for _, round := range o.Rounds {
+		enc.AppendObject(round)
+	}
 	return nil
 }
 
@@ -60,12 +164,38 @@ const (
 
 	MinNbAnswer = 2
 	MaxNbAnswer = 99
+
+	MaxNbJoker = 99
 )
 
 func (o *GameSettings) Validate() error {
 	if o.NbPlayer < MinNbPlayer || o.NbPlayer > MaxNbPlayer {
 		return ErrInvalidNbPlayer
 	}
+	jokerTypes := make(map[GameJokerType]bool, len(o.Jokers))
+	for _, joker := range o.Jokers {
+		if err := joker.Validate(); err != nil {
+			return err
+		}
+		if jokerTypes[joker.Type] {
+			return ErrDuplicateGameJoker
+		}
+		jokerTypes[joker.Type] = true
+	}
+	if err := o.bucket().validate(); err != nil {
+		return err
+	}
+	if len(o.Rounds) > 0 {
+		for _, round := range o.Rounds {
+			if err := round.Validate(); err != nil {
+				return err
+			}
+		}
+		if o.GetNbQuestion() > MaxNbQuestion {
+			return ErrInvalidNbQuestion
+		}
+		return nil
+	}
 	if o.NbQuestion < MinNbQuestion || o.NbQuestion > MaxNbQuestion {
 		return ErrInvalidNbQuestion
 	}
@@ -0,0 +1,321 @@
+package model_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func newTestGame() *model.Game {
+	gameId := model.NewGameId(1)
+	newQuestion := func(number int, round int) *model.GameQuestion {
+		questionId := model.NewGameQuestionId(gameId, number)
+		return &model.GameQuestion{
+			Id:    questionId,
+			Round: round,
+			Answers: []*model.GameAnswer{
+				{Id: model.NewGameAnswerId(questionId, 1), Text: "right", Correct: true},
+				{Id: model.NewGameAnswerId(questionId, 2), Text: "wrong"},
+			},
+		}
+	}
+	return &model.Game{
+		Id: gameId,
+		Rounds: []*model.GameRound{
+			{Number: 1, Title: "80s", QuestionType: model.GameQuestionType_Choice, Scoring: model.GameScoring{Correct: 1}},
+			{Number: 2, Title: "final", QuestionType: model.GameQuestionType_Buzzer, Scoring: model.GameScoring{Correct: 3, Wrong: -1}},
+		},
+		Players: []*model.GamePlayer{
+			{Id: model.NewGamePlayerId(1), Name: "alice", Active: true},
+			{Id: model.NewGamePlayerId(2), Name: "bob", Active: true},
+		},
+		Questions: []*model.GameQuestion{
+			newQuestion(1, 1),
+			newQuestion(2, 2),
+		},
+	}
+}
+
+func TestGameAnswerQuestion(t *testing.T) {
+	game := newTestGame()
+	alice, bob := model.NewGamePlayerId(1), model.NewGamePlayerId(2)
+	q1, q2 := game.Questions[0], game.Questions[1]
+
+	// choice: every player answers once
+	_, err := game.AnswerQuestion(q1.Id, alice, q1.Answers[0].Id)
+	require.NoError(t, err)
+	_, err = game.AnswerQuestion(q1.Id, bob, q1.Answers[1].Id)
+	require.NoError(t, err)
+	_, err = game.AnswerQuestion(q1.Id, bob, q1.Answers[0].Id)
+	require.ErrorIs(t, err, model.ErrAlreadyAnswered)
+
+	// buzzer: wrong answer is penalized, first correct answer closes the question
+	_, err = game.AnswerQuestion(q2.Id, alice, q2.Answers[1].Id)
+	require.NoError(t, err)
+	_, err = game.AnswerQuestion(q2.Id, bob, q2.Answers[0].Id)
+	require.NoError(t, err)
+
+	_, err = game.AnswerQuestion(q2.Id, model.NewGamePlayerId(3), q2.Answers[0].Id)
+	require.ErrorIs(t, err, model.ErrGamePlayerNotFound)
+	_, err = game.AnswerQuestion(model.NewGameQuestionId(game.Id, 9), alice, q2.Answers[0].Id)
+	require.ErrorIs(t, err, model.ErrGameQuestionNotFound)
+
+	require.Equal(t, 0, game.FindPlayer(alice).Score)
+	require.Equal(t, 3, game.FindPlayer(bob).Score)
+}
+
+func TestGameAnswerYear(t *testing.T) {
+	game := newTestGame()
+	alice, bob := model.NewGamePlayerId(1), model.NewGamePlayerId(2)
+	q1, q2 := game.Questions[0], game.Questions[1]
+	q2.Year, q2.Answers = 1979, nil
+	game.Rounds[1].YearScoring = model.DefaultGameYearScoring()
+
+	_, err := game.AnswerYear(q1.Id, alice, 1979)
+	require.ErrorIs(t, err, model.ErrNotYearQuestion)
+	_, err = game.AnswerYear(q2.Id, alice, 0)
+	require.ErrorIs(t, err, model.ErrInvalidGameYear)
+
+	// closeness: 2 years away
+	playerAnswer, err := game.AnswerYear(q2.Id, alice, 1981)
+	require.NoError(t, err)
+	require.False(t, playerAnswer.Correct)
+	require.Equal(t, 3, playerAnswer.Points)
+
+	// exact year
+	playerAnswer, err = game.AnswerYear(q2.Id, bob, 1979)
+	require.NoError(t, err)
+	require.True(t, playerAnswer.Correct)
+	require.Equal(t, 5, playerAnswer.Points)
+	_, err = game.AnswerYear(q2.Id, bob, 1979)
+	require.ErrorIs(t, err, model.ErrAlreadyAnswered)
+
+	require.Equal(t, 3, game.FindPlayer(alice).Score)
+	require.Equal(t, 5, game.FindPlayer(bob).Score)
+
+	scoring := model.DefaultGameYearScoring()
+	require.Equal(t, 1, scoring.Points(1974, 1979))
+	require.Equal(t, 0, scoring.Points(1973, 1979))
+}
+
+func TestGameAnswerSlots(t *testing.T) {
+	game := newTestGame()
+	alice, bob := model.NewGamePlayerId(1), model.NewGamePlayerId(2)
+	q1, q2 := game.Questions[0], game.Questions[1]
+	newMusic := func(artist string, title string) *model.Music {
+		return &model.Music{Name: title, Mp3Url: "music.mp3", Artist: &model.MusicArtist{Name: artist}}
+	}
+	q1.Music = newMusic("Beyoncé", "Halo")
+	q2.Music = newMusic("Queen", "Bohemian Rhapsody")
+
+	round := &model.GameRoundSettings{
+		NbQuestion: 1,
+		NbAnswer:   2,
+		Slots: []*model.GameSlotSettings{
+			{Guess: model.GameGuess_Artist, Points: 1},
+			{Guess: model.GameGuess_Title, Points: 2, FreeText: true},
+		},
+	}
+	round.ApplyDefaults()
+	require.NoError(t, round.Validate())
+	require.NoError(t, round.ApplyMedia(rand.New(rand.NewSource(1)), []*model.GameQuestion{q1}))
+	require.Empty(t, q1.Answers)
+	require.Len(t, q1.Slots, 2)
+	require.Len(t, q1.Slots[0].Answers, 1)
+	require.Equal(t, "Halo", q1.Slots[1].Expected)
+	q1.Slots[0].Answers[0].Id = model.NewGameAnswerId(q1.Id, 1)
+
+	_, err := game.AnswerSlots(q2.Id, alice, nil)
+	require.ErrorIs(t, err, model.ErrNotSlotQuestion)
+	_, err = game.AnswerSlots(q1.Id, alice, []*model.GameSlotAnswer{{Slot: 1, AnswerId: q1.Slots[0].Answers[0].Id}, {Slot: 3, Text: "halo"}})
+	require.ErrorIs(t, err, model.ErrGameSlotNotFound)
+
+	// every part must be answered
+	_, err = game.AnswerSlots(q1.Id, alice, nil)
+	require.ErrorIs(t, err, model.ErrIncompleteGameSlotAnswer)
+	_, err = game.AnswerSlots(q1.Id, alice, []*model.GameSlotAnswer{{Slot: 2, Text: "halo"}})
+	require.ErrorIs(t, err, model.ErrIncompleteGameSlotAnswer)
+	_, err = game.AnswerSlots(q1.Id, alice, []*model.GameSlotAnswer{{Slot: 1, AnswerId: q1.Slots[0].Answers[0].Id}, {Slot: 2, Text: " ! "}})
+	require.ErrorIs(t, err, model.ErrEmptyGameSlotAnswer)
+	_, err = game.AnswerSlots(q1.Id, alice, []*model.GameSlotAnswer{{Slot: 1}, {Slot: 2, Text: "halo"}})
+	require.ErrorIs(t, err, model.ErrEmptyGameSlotAnswer)
+	_, err = game.AnswerSlots(q1.Id, alice, []*model.GameSlotAnswer{{Slot: 2, Text: "halo"}, {Slot: 2, Text: "halo"}})
+	require.ErrorIs(t, err, model.ErrDuplicateGameSlot)
+	require.Empty(t, q1.PlayerAnswers)
+
+	// free text ignores case and accents, each part earns its own points
+	playerAnswer, err := game.AnswerSlots(q1.Id, alice, []*model.GameSlotAnswer{
+		{Slot: 1, AnswerId: q1.Slots[0].Answers[0].Id},
+		{Slot: 2, Text: " HALO "},
+	})
+	require.NoError(t, err)
+	require.True(t, playerAnswer.Correct)
+	require.Equal(t, 3, playerAnswer.Points)
+
+	playerAnswer, err = game.AnswerSlots(q1.Id, bob, []*model.GameSlotAnswer{
+		{Slot: 1, AnswerId: q1.Slots[0].Answers[0].Id},
+		{Slot: 2, Text: "hello"},
+	})
+	require.NoError(t, err)
+	require.False(t, playerAnswer.Correct)
+	require.True(t, playerAnswer.IsPartial())
+	require.Equal(t, 1, playerAnswer.Points)
+
+	result := game.Result()
+	require.Equal(t, alice, result.Players[0].PlayerId)
+	require.Equal(t, 1, result.Players[0].NbCorrect)
+	require.Equal(t, 1, result.Players[1].NbPartial)
+}
+
+func TestGameResult(t *testing.T) {
+	game := newTestGame()
+	alice, bob := model.NewGamePlayerId(1), model.NewGamePlayerId(2)
+	q1, q2 := game.Questions[0], game.Questions[1]
+
+	_, _ = game.AnswerQuestion(q1.Id, alice, q1.Answers[0].Id)
+	_, _ = game.AnswerQuestion(q2.Id, alice, q2.Answers[1].Id)
+	_, _ = game.AnswerQuestion(q2.Id, bob, q2.Answers[0].Id)
+
+	result := game.Result()
+	require.Len(t, result.Players, 2)
+
+	require.Equal(t, bob, result.Players[0].PlayerId)
+	require.Equal(t, []int{0, 3}, result.Players[0].RoundScores)
+	require.Equal(t, 3, result.Players[0].Score)
+
+	require.Equal(t, alice, result.Players[1].PlayerId)
+	require.Equal(t, []int{1, -1}, result.Players[1].RoundScores)
+	require.Equal(t, 0, result.Players[1].Score)
+}
+
+func TestGameCopy(t *testing.T) {
+	game := newTestGame()
+	copied := game.Copy()
+
+	_, err := copied.AnswerQuestion(copied.Questions[0].Id, model.NewGamePlayerId(1), copied.Questions[0].Answers[0].Id)
+	require.NoError(t, err)
+
+	require.Empty(t, game.Questions[0].PlayerAnswers)
+	require.Equal(t, 0, game.Players[0].Score)
+	require.Len(t, copied.Questions[0].PlayerAnswers, 1)
+	require.Equal(t, 1, copied.Players[0].Score)
+}
+
+func TestGameUseJoker(t *testing.T) {
+	game := newTestGame()
+	game.Settings = &model.GameSettings{
+		Seed: 42,
+		Jokers: []*model.GameJokerSettings{
+			{Type: model.GameJokerType_FiftyFifty, Count: 1, Cost: 1},
+			{Type: model.GameJokerType_Skip, Count: 1},
+		},
+	}
+	alice := model.NewGamePlayerId(1)
+	q1, q2 := game.Questions[0], game.Questions[1]
+	q1.Answers = append(q1.Answers,
+		&model.GameAnswer{Id: model.NewGameAnswerId(q1.Id, 3), Text: "wrong-3"},
+		&model.GameAnswer{Id: model.NewGameAnswerId(q1.Id, 4), Text: "wrong-4"},
+	)
+
+	// fifty-fifty: removes 2 of the 3 wrong answers
+	playerJoker, err := game.UseJoker(q1.Id, alice, model.GameJokerType_FiftyFifty)
+	require.NoError(t, err)
+	require.Len(t, playerJoker.RemovedAnswerIds, 2)
+	require.NotContains(t, playerJoker.RemovedAnswerIds, q1.Answers[0].Id)
+	require.Equal(t, -1, game.FindPlayer(alice).Score)
+
+	_, err = game.UseJoker(q2.Id, alice, model.GameJokerType_FiftyFifty)
+	require.ErrorIs(t, err, model.ErrGameJokerNotAvailable)
+	_, err = game.UseJoker(q2.Id, alice, model.GameJokerType_Hint)
+	require.ErrorIs(t, err, model.ErrGameJokerNotAvailable)
+
+	// skip: the question can no longer be answered
+	_, err = game.UseJoker(q2.Id, alice, model.GameJokerType_Skip)
+	require.NoError(t, err)
+	_, err = game.AnswerQuestion(q2.Id, alice, q2.Answers[0].Id)
+	require.ErrorIs(t, err, model.ErrQuestionSkipped)
+
+	require.Equal(t, map[model.GameJokerType]int{
+		model.GameJokerType_FiftyFifty: 0,
+		model.GameJokerType_Skip:       0,
+	}, game.RemainingJokers(alice))
+
+	result := game.Result()
+	require.Equal(t, []int{-1, 0}, result.Players[1].RoundScores)
+
+	view, err := game.PlayerView(alice)
+	require.NoError(t, err)
+	require.Len(t, view.Questions[0].Answers, 2)
+	require.True(t, view.Questions[1].Skipped)
+}
+
+func TestGameRevealAndFinish(t *testing.T) {
+	game := newTestGame()
+	question := game.Questions[0]
+	alice := game.Players[0].Id
+
+	revealed, err := game.RevealQuestion(question.Id)
+	require.NoError(t, err)
+	require.True(t, revealed.Revealed)
+
+	_, err = game.RevealQuestion(question.Id)
+	require.ErrorIs(t, err, model.ErrQuestionAlreadyRevealed)
+
+	_, err = game.AnswerQuestion(question.Id, alice, question.Answers[0].Id)
+	require.ErrorIs(t, err, model.ErrQuestionClosed)
+
+	require.NoError(t, game.Finish())
+	require.ErrorIs(t, game.Finish(), model.ErrGameFinished)
+
+	next := game.Questions[1]
+	_, err = game.AnswerQuestion(next.Id, alice, next.Answers[0].Id)
+	require.ErrorIs(t, err, model.ErrGameFinished)
+
+	copied := game.Copy()
+	require.True(t, copied.Finished)
+	require.True(t, copied.Questions[0].Revealed)
+}
+
+func TestGamePlayerManagement(t *testing.T) {
+	game := newTestGame()
+	question := game.Questions[0]
+	alice := game.Players[0].Id
+	bob := game.Players[1].Id
+
+	carol, err := game.AddPlayer(" carol ")
+	require.NoError(t, err)
+	require.Equal(t, model.NewGamePlayerId(3), carol.Id)
+	require.Equal(t, "carol", carol.Name)
+	require.True(t, carol.Active)
+
+	_, err = game.AddPlayer(" ")
+	require.ErrorIs(t, err, model.ErrInvalidPlayerName)
+
+	renamed, err := game.RenamePlayer(bob, "robert")
+	require.NoError(t, err)
+	require.Equal(t, "robert", renamed.Name)
+
+	_, err = game.SetPlayerActive(bob, false)
+	require.NoError(t, err)
+	_, err = game.AnswerQuestion(question.Id, bob, question.Answers[0].Id)
+	require.ErrorIs(t, err, model.ErrInactivePlayer)
+	_, err = game.AdjustScore(bob, 1, "late answer")
+	require.ErrorIs(t, err, model.ErrInactivePlayer)
+
+	_, err = game.AdjustScore(alice, -2, " ")
+	require.ErrorIs(t, err, model.ErrMissingAdjustmentReason)
+	_, err = game.AdjustScore(alice, -2, "noise")
+	require.NoError(t, err)
+	_, err = game.AnswerQuestion(question.Id, carol.Id, question.Answers[0].Id)
+	require.NoError(t, err)
+
+	result := game.Result()
+	require.Len(t, result.Players, 2)
+	require.Equal(t, carol.Id, result.Players[0].PlayerId)
+	require.Equal(t, 1, result.Players[0].Score)
+	require.Equal(t, alice, result.Players[1].PlayerId)
+	require.Equal(t, -2, result.Players[1].Score)
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gre-ory/amnezic-go/internal/util"
 	"go.uber.org/zap/zapcore"
@@ -13,6 +14,13 @@ import (
 
 type MusicId int64
 
+const (
+	MaxMusicYear = 9999
+
+	// release date layout ( e.g. 2003-06-24 )
+	MusicReleaseDateLayout = "2006-01-02"
+)
+
 type DeezerMusicId int64
 
 type Music struct {
@@ -24,10 +32,21 @@ type Music struct {
 	ArtistId MusicArtistId
 	AlbumId  MusicAlbumId
 
+	// release metadata, zero when unknown
+	ReleaseDate string
+	Year        int
+	Duration    time.Duration
+	Genre       string
+	Explicit    bool
+	Rank        int
+
 	// consolidated data
-	Artist    *MusicArtist
-	Album     *MusicAlbum
-	Questions []*ThemeQuestion
+	Artist       *MusicArtist
+	Album        *MusicAlbum
+	Genres       []*MusicGenre
+	Contributors []*MusicContributor
+	Questions    []*ThemeQuestion
+	NbTheme      int
 }
 
 func (o *Music) Validate(musicPathValidator, imagePathValidator PathValidator) error {
@@ -40,6 +59,24 @@ func (o *Music) Validate(musicPathValidator, imagePathValidator PathValidator) e
 	if o.Mp3Url == "" || !o.Mp3Url.IsValid(musicPathValidator) {
 		return ErrInvalidMusicUrl
 	}
+	if o.Year < 0 || o.Year > MaxMusicYear {
+		return ErrInvalidMusicYear
+	}
+	if o.ReleaseDate != "" {
+		year := ToMusicYear(o.ReleaseDate)
+		if year == 0 {
+			return ErrInvalidMusicReleaseDate
+		}
+		if o.Year != 0 && o.Year != year {
+			return ErrInvalidMusicYear
+		}
+	}
+	if o.Duration < 0 {
+		return ErrInvalidMusicDuration
+	}
+	if o.Rank < 0 {
+		return ErrInvalidMusicRank
+	}
 	if o.Artist == nil {
 		return ErrMissingArtist
 	} else {
@@ -52,6 +89,11 @@ func (o *Music) Validate(musicPathValidator, imagePathValidator PathValidator) e
 			return err
 		}
 	}
+	for _, contributor := range o.Contributors {
+		if err := contributor.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -60,13 +102,50 @@ func (o *Music) Copy() *Music {
 		return nil
 	}
 	return &Music{
-		Id:       o.Id,
-		DeezerId: o.DeezerId,
-		Name:     o.Name,
-		Mp3Url:   o.Mp3Url,
-		ArtistId: o.ArtistId,
-		AlbumId:  o.AlbumId,
+		Id:          o.Id,
+		DeezerId:    o.DeezerId,
+		Name:        o.Name,
+		Mp3Url:      o.Mp3Url,
+		ArtistId:    o.ArtistId,
+		AlbumId:     o.AlbumId,
+		ReleaseDate: o.ReleaseDate,
+		Year:        o.Year,
+		Duration:    o.Duration,
+		Genre:       o.Genre,
+		Explicit:    o.Explicit,
+		Rank:        o.Rank,
+	}
+}
+
+// ToMusicYear returns the year of a release date, 0 when the date is invalid.
+func ToMusicYear(releaseDate string) int {
+	date, err := time.Parse(MusicReleaseDateLayout, releaseDate)
+	if err != nil {
+		return 0
+	}
+	return date.Year()
+}
+
+// WithReleaseYear sets the release year from the release date when unknown.
+func (o *Music) WithReleaseYear() *Music {
+	if o.Year == 0 && o.ReleaseDate != "" {
+		o.Year = ToMusicYear(o.ReleaseDate)
+	}
+	return o
+}
+
+// WithEditedRelease keeps the year and the release date consistent with the field edited from the existing music:
+// a new release date sets the year, a new year drops a release date of another year.
+func (o *Music) WithEditedRelease(existing *Music) *Music {
+	switch {
+	case o.ReleaseDate != "" && o.ReleaseDate != existing.ReleaseDate:
+		if year := ToMusicYear(o.ReleaseDate); year != 0 {
+			o.Year = year
+		}
+	case o.Year != existing.Year && o.ReleaseDate != "" && ToMusicYear(o.ReleaseDate) != o.Year:
+		o.ReleaseDate = ""
 	}
+	return o
 }
 
 func (o *Music) GetMp3FileName() Url {
@@ -84,11 +163,17 @@ func (o *Music) GetMp3FileName() Url {
 	return Url(strings.Join(util.Convert(parts, util.SanitizeAlphaLower), "_") + ".mp3")
 }
 
+// GetDefaultAnswerText names the main artists along with the featured ones ( e.g. "Beyoncé & Shakira feat. Jay-Z" ).
 func (o *Music) GetDefaultAnswerText() string {
-	if o.Artist != nil {
-		return o.Artist.Name
+	mains := o.GetArtistNames(MusicArtistRole_Main)
+	if len(mains) == 0 {
+		return ""
+	}
+	text := strings.Join(mains, " & ")
+	if featured := o.GetArtistNames(MusicArtistRole_Featured); len(featured) > 0 {
+		text += " feat. " + strings.Join(featured, " & ")
 	}
-	return ""
+	return text
 }
 
 func (o *Music) GetDefaultAnswerHint() string {
@@ -127,11 +212,39 @@ func (o *Music) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if o.Mp3Url != "" {
 		enc.AddString("mp3-url", string(o.Mp3Url))
 	}
+	if o.ReleaseDate != "" {
+		enc.AddString("release-date", o.ReleaseDate)
+	}
+	if o.Year != 0 {
+		enc.AddInt("year", o.Year)
+	}
+	if o.Duration != 0 {
+		enc.AddDuration("duration", o.Duration)
+	}
+	if o.Genre != "" {
+		enc.AddString("genre", o.Genre)
+	}
+	if o.Explicit {
+		enc.AddBool("explicit", o.Explicit)
+	}
+	if o.Rank != 0 {
+		enc.AddInt("rank", o.Rank)
+	}
 	if o.Artist != nil {
 		enc.AddObject("artist", o.Artist)
 	}
 	if o.Album != nil {
 		enc.AddObject("album", o.Album)
 	}
+	if len(o.Contributors) > 0 {
+		enc.AddArray("contributors", zapcore.ArrayMarshalerFunc(o.MarshalLogContributors))
+	}
+	return nil
+}
+
+func (o *Music) MarshalLogContributors(enc zapcore.ArrayEncoder) error {
+	for _, contributor := range o.Contributors {
+		enc.AppendObject(contributor)
+	}
 	return nil
 }
@@ -22,7 +22,9 @@ type MusicAlbum struct {
 	ImgUrl   Url
 
 	// consolidated data
-	Musics []*Music
+	Genres  []*MusicGenre
+	Musics  []*Music
+	NbMusic int
 }
 
 func (o *MusicAlbum) Validate(imagePathValidator PathValidator) error {
@@ -1,8 +1,7 @@
 package model
 
 import (
-	"strings"
-
+	"github.com/gre-ory/amnezic-go/internal/util"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -10,14 +9,26 @@ import (
 // music album filter
 
 type MusicAlbumFilter struct {
-	Name  string
-	Limit int
+	Name    string
+	GenreId MusicGenreId
+	Sort    MusicSort
+	Offset  int
+	Limit   int
 }
 
 func (o *MusicAlbumFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if o.Name != "" {
 		enc.AddString("name", o.Name)
 	}
+	if o.GenreId != 0 {
+		enc.AddInt64("genre-id", int64(o.GenreId))
+	}
+	if o.Sort != "" {
+		enc.AddString("sort", o.Sort.String())
+	}
+	if o.Offset != 0 {
+		enc.AddInt("offset", o.Offset)
+	}
 	if o.Limit != 0 {
 		enc.AddInt("limit", o.Limit)
 	}
@@ -28,7 +39,10 @@ func (o *MusicAlbumFilter) IsMatching(count int, candidate *MusicAlbum) bool {
 	if o.Limit != 0 && count > o.Limit {
 		return false
 	}
-	if o.Name != "" && !strings.Contains(candidate.Name, o.Name) {
+	if o.Name != "" && !util.MatchFts(candidate.Name, o.Name) {
+		return false
+	}
+	if o.GenreId != 0 && !HasGenre(candidate.Genres, o.GenreId) {
 		return false
 	}
 	return true
@@ -46,6 +60,21 @@ func (r *MusicAlbumFilter) WithName(name string) *MusicAlbumFilter {
 	return r
 }
 
+func (r *MusicAlbumFilter) WithGenreId(genreId MusicGenreId) *MusicAlbumFilter {
+	r.GenreId = genreId
+	return r
+}
+
+func (r *MusicAlbumFilter) WithSort(sort MusicSort) *MusicAlbumFilter {
+	r.Sort = sort
+	return r
+}
+
+func (r *MusicAlbumFilter) WithOffset(offset int) *MusicAlbumFilter {
+	r.Offset = offset
+	return r
+}
+
 func (r *MusicAlbumFilter) WithLimit(limit int) *MusicAlbumFilter {
 	r.Limit = limit
 	return r
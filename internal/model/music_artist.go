@@ -22,7 +22,8 @@ type MusicArtist struct {
 	ImgUrl   Url
 
 	// consolidated data
-	Musics []*Music
+	Musics  []*Music
+	NbMusic int
 }
 
 func (o *MusicArtist) Validate(imagePathValidator PathValidator) error {
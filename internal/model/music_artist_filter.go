@@ -1,8 +1,7 @@
 package model
 
 import (
-	"strings"
-
+	"github.com/gre-ory/amnezic-go/internal/util"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -10,14 +9,22 @@ import (
 // music artist filter
 
 type MusicArtistFilter struct {
-	Name  string
-	Limit int
+	Name   string
+	Sort   MusicSort
+	Offset int
+	Limit  int
 }
 
 func (o *MusicArtistFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if o.Name != "" {
 		enc.AddString("name", o.Name)
 	}
+	if o.Sort != "" {
+		enc.AddString("sort", o.Sort.String())
+	}
+	if o.Offset != 0 {
+		enc.AddInt("offset", o.Offset)
+	}
 	if o.Limit != 0 {
 		enc.AddInt("limit", o.Limit)
 	}
@@ -28,7 +35,7 @@ func (o *MusicArtistFilter) IsMatching(count int, candidate *MusicArtist) bool {
 	if o.Limit != 0 && count > o.Limit {
 		return false
 	}
-	if o.Name != "" && !strings.Contains(candidate.Name, o.Name) {
+	if o.Name != "" && !util.MatchFts(candidate.Name, o.Name) {
 		return false
 	}
 	return true
@@ -46,6 +53,16 @@ func (r *MusicArtistFilter) WithName(name string) *MusicArtistFilter {
 	return r
 }
 
+func (r *MusicArtistFilter) WithSort(sort MusicSort) *MusicArtistFilter {
+	r.Sort = sort
+	return r
+}
+
+func (r *MusicArtistFilter) WithOffset(offset int) *MusicArtistFilter {
+	r.Offset = offset
+	return r
+}
+
 func (r *MusicArtistFilter) WithLimit(limit int) *MusicArtistFilter {
 	r.Limit = limit
 	return r
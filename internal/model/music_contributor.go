@@ -0,0 +1,176 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// music artist role
+
+// MusicArtistRole is the part taken by an artist in a music.
+type MusicArtistRole string
+
+var (
+	MusicArtistRole_Main     MusicArtistRole = "main"
+	MusicArtistRole_Featured MusicArtistRole = "featured"
+	MusicArtistRole_Composer MusicArtistRole = "composer"
+)
+
+func ToMusicArtistRole(value string) MusicArtistRole {
+	value = strings.Trim(value, " ")
+	value = strings.ToLower(value)
+	switch value {
+	case string(MusicArtistRole_Main):
+		return MusicArtistRole_Main
+	case string(MusicArtistRole_Featured):
+		return MusicArtistRole_Featured
+	case string(MusicArtistRole_Composer):
+		return MusicArtistRole_Composer
+	default:
+		return ""
+	}
+}
+
+func (o MusicArtistRole) String() string {
+	return string(o)
+}
+
+// IsCredited tells whether the artist is named in the answer ( composers are not ).
+func (o MusicArtistRole) IsCredited() bool {
+	return o == MusicArtistRole_Main || o == MusicArtistRole_Featured
+}
+
+// //////////////////////////////////////////////////
+// music contributor
+
+// MusicContributor is an artist taking part in a music along with its role.
+// the artist of the music is its first main artist.
+type MusicContributor struct {
+	ArtistId MusicArtistId
+	Role     MusicArtistRole
+
+	// consolidated data
+	Artist *MusicArtist
+}
+
+func (o *MusicContributor) Validate() error {
+	if ToMusicArtistRole(o.Role.String()) == "" {
+		return ErrInvalidMusicArtistRole
+	}
+	return nil
+}
+
+func (o *MusicContributor) Copy() *MusicContributor {
+	if o == nil {
+		return nil
+	}
+	return &MusicContributor{
+		ArtistId: o.ArtistId,
+		Role:     o.Role,
+		Artist:   o.Artist.Copy(),
+	}
+}
+
+func (o *MusicContributor) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.ArtistId != 0 {
+		enc.AddInt64("artist-id", int64(o.ArtistId))
+	}
+	enc.AddString("role", o.Role.String())
+	if o.Artist != nil {
+		enc.AddString("artist", o.Artist.Name)
+	}
+	return nil
+}
+
+// SyncMainContributor puts the artist of the music first among its main contributors, in place of its former artist.
+// the other contributors are kept once per artist and role.
+func SyncMainContributor(contributors []*MusicContributor, formerArtistId MusicArtistId, artistId MusicArtistId) []*MusicContributor {
+	synced := make([]*MusicContributor, 0, len(contributors)+1)
+	if artistId != 0 {
+		synced = append(synced, &MusicContributor{ArtistId: artistId, Role: MusicArtistRole_Main})
+	}
+	for _, contributor := range contributors {
+		if contributor.Role == MusicArtistRole_Main && (contributor.ArtistId == artistId || contributor.ArtistId == formerArtistId) {
+			continue
+		}
+		if _, found := util.FindIf(synced, func(existing *MusicContributor) bool {
+			return existing.ArtistId == contributor.ArtistId && existing.Role == contributor.Role
+		}); found {
+			continue
+		}
+		synced = append(synced, contributor)
+	}
+	return synced
+}
+
+// //////////////////////////////////////////////////
+// artist names
+
+// GetArtistNames lists the names of the artists having the role, the artist of the music being the first main artist.
+func (o *Music) GetArtistNames(role MusicArtistRole) []string {
+	names := make([]string, 0)
+	add := func(artist *MusicArtist) {
+		if artist == nil || artist.Name == "" {
+			return
+		}
+		for _, name := range names {
+			if util.SanitizeAlphaLower(name) == util.SanitizeAlphaLower(artist.Name) {
+				return
+			}
+		}
+		names = append(names, artist.Name)
+	}
+	if role == MusicArtistRole_Main {
+		add(o.Artist)
+	}
+	for _, contributor := range o.Contributors {
+		if contributor.Role == role {
+			add(contributor.Artist)
+		}
+	}
+	return names
+}
+
+// IsDuplicateAnswer tells whether the answers of both musics could be mistaken for each other:
+// same answer text or an artist credited in both.
+func (o *Music) IsDuplicateAnswer(other *Music) bool {
+	if util.SanitizeAlphaLower(o.GetDefaultAnswerText()) == util.SanitizeAlphaLower(other.GetDefaultAnswerText()) {
+		return true
+	}
+	credited := o.creditedArtists()
+	for key := range other.creditedArtists() {
+		if credited[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// creditedArtists returns the sanitized names of the main and featured artists.
+func (o *Music) creditedArtists() map[string]bool {
+	credited := make(map[string]bool)
+	for _, role := range []MusicArtistRole{MusicArtistRole_Main, MusicArtistRole_Featured} {
+		for _, name := range o.GetArtistNames(role) {
+			credited[util.SanitizeAlphaLower(name)] = true
+		}
+	}
+	return credited
+}
+
+// DistinctAnswerMusics keeps the candidates whose answers cannot be mistaken for the one of the music nor for each other.
+func DistinctAnswerMusics(music *Music, candidates []*Music) []*Music {
+	kept := make([]*Music, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.GetDefaultAnswerText() == "" || candidate.IsDuplicateAnswer(music) {
+			continue
+		}
+		if _, found := util.FindIf(kept, candidate.IsDuplicateAnswer); found {
+			continue
+		}
+		kept = append(kept, candidate)
+	}
+	return kept
+}
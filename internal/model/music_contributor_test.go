@@ -0,0 +1,76 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func newContributor(id model.MusicArtistId, name string, role model.MusicArtistRole) *model.MusicContributor {
+	return &model.MusicContributor{
+		ArtistId: id,
+		Role:     role,
+		Artist:   &model.MusicArtist{Id: id, Name: name},
+	}
+}
+
+func TestMusicGetDefaultAnswerText(t *testing.T) {
+
+	beyonce := &model.MusicArtist{Id: 1, Name: "Beyoncé"}
+
+	tests := []struct {
+		name     string
+		music    *model.Music
+		wantText string
+	}{
+		{"no-artist", &model.Music{}, ""},
+		{"artist", &model.Music{Artist: beyonce}, "Beyoncé"},
+		{"duet", &model.Music{
+			Artist: beyonce,
+			Contributors: []*model.MusicContributor{
+				newContributor(1, "Beyoncé", model.MusicArtistRole_Main),
+				newContributor(2, "Shakira", model.MusicArtistRole_Main),
+			},
+		}, "Beyoncé & Shakira"},
+		{"featuring", &model.Music{
+			Artist: beyonce,
+			Contributors: []*model.MusicContributor{
+				newContributor(3, "Jay-Z", model.MusicArtistRole_Featured),
+				newContributor(4, "Rich Harrison", model.MusicArtistRole_Composer),
+			},
+		}, "Beyoncé feat. Jay-Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantText, tt.music.GetDefaultAnswerText())
+		})
+	}
+}
+
+func TestDistinctAnswerMusics(t *testing.T) {
+
+	music := &model.Music{
+		Id:     1,
+		Artist: &model.MusicArtist{Id: 1, Name: "Beyoncé"},
+		Contributors: []*model.MusicContributor{
+			newContributor(2, "Jay-Z", model.MusicArtistRole_Featured),
+		},
+	}
+	jayZ := &model.Music{Id: 2, Artist: &model.MusicArtist{Id: 2, Name: "JAY-Z"}}
+	shakira := &model.Music{Id: 3, Artist: &model.MusicArtist{Id: 3, Name: "Shakira"}}
+	shakiraDuet := &model.Music{
+		Id:     4,
+		Artist: &model.MusicArtist{Id: 3, Name: "Shakira"},
+		Contributors: []*model.MusicContributor{
+			newContributor(5, "Wyclef Jean", model.MusicArtistRole_Featured),
+		},
+	}
+	madonna := &model.Music{Id: 5, Artist: &model.MusicArtist{Id: 6, Name: "Madonna"}}
+	unknown := &model.Music{Id: 6}
+
+	got := model.DistinctAnswerMusics(music, []*model.Music{jayZ, shakira, shakiraDuet, madonna, unknown})
+	require.Equal(t, []*model.Music{shakira, madonna}, got)
+}
@@ -0,0 +1,146 @@
+package model
+
+import (
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// similarity
+
+const (
+	DefaultDuplicateSimilarity = 85
+	MinDuplicateSimilarity     = 50
+)
+
+// ToDuplicateSimilarity returns the minimum similarity percentage of duplicate names, 0 when invalid.
+func ToDuplicateSimilarity(value int) int {
+	switch {
+	case value == 0:
+		return DefaultDuplicateSimilarity
+	case value < MinDuplicateSimilarity || value > 100:
+		return 0
+	default:
+		return value
+	}
+}
+
+// //////////////////////////////////////////////////
+// artist duplicate
+
+// MusicArtistDuplicate is a group of artists whose normalized names are similar.
+type MusicArtistDuplicate struct {
+	Artists    []*MusicArtist
+	Similarity int
+}
+
+// FindArtistDuplicates groups the artists whose normalized names are at least similar to the given percentage.
+func FindArtistDuplicates(artists []*MusicArtist, minSimilarity int) []*MusicArtistDuplicate {
+	groups := util.GroupSimilar(artists, func(artist *MusicArtist) string { return artist.Name }, minSimilarity)
+	return util.Convert(groups, func(group []*MusicArtist) *MusicArtistDuplicate {
+		return &MusicArtistDuplicate{
+			Artists:    group,
+			Similarity: groupSimilarity(util.Convert(group, func(artist *MusicArtist) string { return artist.Name })),
+		}
+	})
+}
+
+func (o *MusicArtistDuplicate) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("similarity", o.Similarity)
+	enc.AddArray("artists", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		for _, artist := range o.Artists {
+			enc.AppendObject(artist)
+		}
+		return nil
+	}))
+	return nil
+}
+
+// MergeWith completes the artist with the deezer id and image of a duplicate.
+func (o *MusicArtist) MergeWith(duplicate *MusicArtist) {
+	if o.DeezerId == 0 {
+		o.DeezerId = duplicate.DeezerId
+	}
+	if o.ImgUrl == "" {
+		o.ImgUrl = duplicate.ImgUrl
+	}
+}
+
+// MergeWith completes the music with the deezer id, mp3, album and release metadata of a duplicate.
+func (o *Music) MergeWith(duplicate *Music) {
+	if o.DeezerId == 0 {
+		o.DeezerId = duplicate.DeezerId
+	}
+	if o.Mp3Url == "" {
+		o.Mp3Url = duplicate.Mp3Url
+	}
+	if o.AlbumId == 0 {
+		o.AlbumId = duplicate.AlbumId
+	}
+	if o.Year == 0 {
+		o.Year = duplicate.Year
+		o.ReleaseDate = duplicate.ReleaseDate
+	}
+	if o.Duration == 0 {
+		o.Duration = duplicate.Duration
+	}
+	if o.Genre == "" {
+		o.Genre = duplicate.Genre
+	}
+	if o.Rank == 0 {
+		o.Rank = duplicate.Rank
+	}
+}
+
+// //////////////////////////////////////////////////
+// album duplicate
+
+// MusicAlbumDuplicate is a group of albums whose normalized names are similar.
+type MusicAlbumDuplicate struct {
+	Albums     []*MusicAlbum
+	Similarity int
+}
+
+// FindAlbumDuplicates groups the albums whose normalized names are at least similar to the given percentage.
+func FindAlbumDuplicates(albums []*MusicAlbum, minSimilarity int) []*MusicAlbumDuplicate {
+	groups := util.GroupSimilar(albums, func(album *MusicAlbum) string { return album.Name }, minSimilarity)
+	return util.Convert(groups, func(group []*MusicAlbum) *MusicAlbumDuplicate {
+		return &MusicAlbumDuplicate{
+			Albums:     group,
+			Similarity: groupSimilarity(util.Convert(group, func(album *MusicAlbum) string { return album.Name })),
+		}
+	})
+}
+
+func (o *MusicAlbumDuplicate) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt("similarity", o.Similarity)
+	enc.AddArray("albums", zapcore.ArrayMarshalerFunc(func(enc zapcore.ArrayEncoder) error {
+		for _, album := range o.Albums {
+			enc.AppendObject(album)
+		}
+		return nil
+	}))
+	return nil
+}
+
+// MergeWith completes the album with the deezer id and cover of a duplicate.
+func (o *MusicAlbum) MergeWith(duplicate *MusicAlbum) {
+	if o.DeezerId == 0 {
+		o.DeezerId = duplicate.DeezerId
+	}
+	if o.ImgUrl == "" {
+		o.ImgUrl = duplicate.ImgUrl
+	}
+}
+
+// //////////////////////////////////////////////////
+// helper
+
+// groupSimilarity returns the lowest similarity between the first name of a group and the others.
+func groupSimilarity(names []string) int {
+	similarity := 100
+	for _, name := range names[1:] {
+		similarity = min(similarity, util.Similarity(util.NormalizeName(names[0]), util.NormalizeName(name)))
+	}
+	return similarity
+}
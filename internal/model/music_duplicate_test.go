@@ -0,0 +1,36 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestToDuplicateSimilarity(t *testing.T) {
+	require.Equal(t, model.DefaultDuplicateSimilarity, model.ToDuplicateSimilarity(0))
+	require.Equal(t, 90, model.ToDuplicateSimilarity(90))
+	require.Equal(t, 0, model.ToDuplicateSimilarity(20))
+	require.Equal(t, 0, model.ToDuplicateSimilarity(101))
+}
+
+func TestFindArtistDuplicates(t *testing.T) {
+
+	beatles := &model.MusicArtist{Id: 1, Name: "The Beatles"}
+	madonna := &model.MusicArtist{Id: 2, Name: "Madonna"}
+	beatlesDuplicate := &model.MusicArtist{Id: 3, Name: "Beatles", DeezerId: 42, ImgUrl: "artist_deezer-42_beatles.jpg"}
+	madonnaTypo := &model.MusicArtist{Id: 4, Name: "Madona"}
+
+	duplicates := model.FindArtistDuplicates([]*model.MusicArtist{beatles, madonna, beatlesDuplicate, madonnaTypo}, model.DefaultDuplicateSimilarity)
+
+	require.Len(t, duplicates, 2)
+	require.Equal(t, []*model.MusicArtist{beatles, beatlesDuplicate}, duplicates[0].Artists)
+	require.Equal(t, 100, duplicates[0].Similarity)
+	require.Equal(t, []*model.MusicArtist{madonna, madonnaTypo}, duplicates[1].Artists)
+	require.Equal(t, 85, duplicates[1].Similarity)
+
+	beatles.MergeWith(beatlesDuplicate)
+	require.Equal(t, model.DeezerArtistId(42), beatles.DeezerId)
+	require.Equal(t, model.Url("artist_deezer-42_beatles.jpg"), beatles.ImgUrl)
+}
@@ -2,7 +2,9 @@ package model
 
 import (
 	"strings"
+	"time"
 
+	"github.com/gre-ory/amnezic-go/internal/util"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -13,7 +15,20 @@ type MusicFilter struct {
 	Name     string
 	ArtistId MusicArtistId
 	AlbumId  MusicAlbumId
-	Limit    int
+	GenreId  MusicGenreId
+
+	// metadata criteria, ignored when zero
+	MinYear     int
+	MaxYear     int
+	Genre       string
+	Explicit    *bool
+	MinDuration time.Duration
+	MaxDuration time.Duration
+	MinRank     int
+
+	Sort   MusicSort
+	Offset int
+	Limit  int
 }
 
 func (o *MusicFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
@@ -26,6 +41,36 @@ func (o *MusicFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if o.AlbumId != 0 {
 		enc.AddInt64("album-id", int64(o.AlbumId))
 	}
+	if o.GenreId != 0 {
+		enc.AddInt64("genre-id", int64(o.GenreId))
+	}
+	if o.MinYear != 0 {
+		enc.AddInt("min-year", o.MinYear)
+	}
+	if o.MaxYear != 0 {
+		enc.AddInt("max-year", o.MaxYear)
+	}
+	if o.Genre != "" {
+		enc.AddString("genre", o.Genre)
+	}
+	if o.Explicit != nil {
+		enc.AddBool("explicit", *o.Explicit)
+	}
+	if o.MinDuration != 0 {
+		enc.AddDuration("min-duration", o.MinDuration)
+	}
+	if o.MaxDuration != 0 {
+		enc.AddDuration("max-duration", o.MaxDuration)
+	}
+	if o.MinRank != 0 {
+		enc.AddInt("min-rank", o.MinRank)
+	}
+	if o.Sort != "" {
+		enc.AddString("sort", o.Sort.String())
+	}
+	if o.Offset != 0 {
+		enc.AddInt("offset", o.Offset)
+	}
 	if o.Limit != 0 {
 		enc.AddInt("limit", o.Limit)
 	}
@@ -36,12 +81,51 @@ func (o *MusicFilter) IsMatching(count int, candidate *Music) bool {
 	if o.Limit != 0 && count > o.Limit {
 		return false
 	}
-	if o.Name != "" && !strings.Contains(candidate.Name, o.Name) {
+	if o.Name != "" && !util.MatchFts(candidate.Name, o.Name) {
+		return false
+	}
+	if o.ArtistId != 0 && candidate.ArtistId != o.ArtistId {
+		return false
+	}
+	if o.AlbumId != 0 && candidate.AlbumId != o.AlbumId {
+		return false
+	}
+	if o.GenreId != 0 && !HasGenre(candidate.Genres, o.GenreId) {
+		return false
+	}
+	if o.MinYear != 0 && candidate.Year < o.MinYear {
+		return false
+	}
+	if o.MaxYear != 0 && (candidate.Year == 0 || candidate.Year > o.MaxYear) {
+		return false
+	}
+	if o.Genre != "" && !strings.EqualFold(candidate.Genre, o.Genre) && !hasGenreName(candidate.Genres, o.Genre) {
+		return false
+	}
+	if o.Explicit != nil && candidate.Explicit != *o.Explicit {
+		return false
+	}
+	if o.MinDuration != 0 && candidate.Duration < o.MinDuration {
+		return false
+	}
+	if o.MaxDuration != 0 && (candidate.Duration == 0 || candidate.Duration > o.MaxDuration) {
+		return false
+	}
+	if o.MinRank != 0 && candidate.Rank < o.MinRank {
 		return false
 	}
 	return true
 }
 
+func hasGenreName(genres []*MusicGenre, name string) bool {
+	for _, genre := range genres {
+		if strings.EqualFold(genre.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // //////////////////////////////////////////////////
 // builder
 
@@ -64,6 +148,51 @@ func (r *MusicFilter) WithAlbumId(albumId MusicAlbumId) *MusicFilter {
 	return r
 }
 
+func (r *MusicFilter) WithGenreId(genreId MusicGenreId) *MusicFilter {
+	r.GenreId = genreId
+	return r
+}
+
+// WithYears keeps the musics released between both years ( included ).
+func (r *MusicFilter) WithYears(minYear int, maxYear int) *MusicFilter {
+	r.MinYear = minYear
+	r.MaxYear = maxYear
+	return r
+}
+
+// WithGenre keeps the musics of the genre, either as free text or as linked genre.
+func (r *MusicFilter) WithGenre(genre string) *MusicFilter {
+	r.Genre = genre
+	return r
+}
+
+func (r *MusicFilter) WithExplicit(explicit bool) *MusicFilter {
+	r.Explicit = &explicit
+	return r
+}
+
+// WithDurations keeps the musics lasting between both durations ( included ).
+func (r *MusicFilter) WithDurations(minDuration time.Duration, maxDuration time.Duration) *MusicFilter {
+	r.MinDuration = minDuration
+	r.MaxDuration = maxDuration
+	return r
+}
+
+func (r *MusicFilter) WithMinRank(minRank int) *MusicFilter {
+	r.MinRank = minRank
+	return r
+}
+
+func (r *MusicFilter) WithSort(sort MusicSort) *MusicFilter {
+	r.Sort = sort
+	return r
+}
+
+func (r *MusicFilter) WithOffset(offset int) *MusicFilter {
+	r.Offset = offset
+	return r
+}
+
 func (r *MusicFilter) WithLimit(limit int) *MusicFilter {
 	r.Limit = limit
 	return r
@@ -0,0 +1,105 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestMusicFilterIsMatching(t *testing.T) {
+
+	music := &model.Music{
+		Name:        "Crazy in Love",
+		ReleaseDate: "2003-05-18",
+		Year:        2003,
+		Duration:    236 * time.Second,
+		Genre:       "R&B",
+		Rank:        800000,
+	}
+
+	tests := []struct {
+		name         string
+		filter       *model.MusicFilter
+		wantMatching bool
+	}{
+		{"empty", model.NewMusicFilter(), true},
+		{"years", model.NewMusicFilter().WithYears(2000, 2009), true},
+		{"before", model.NewMusicFilter().WithYears(0, 1999), false},
+		{"after", model.NewMusicFilter().WithYears(2010, 0), false},
+		{"genre", model.NewMusicFilter().WithGenre("r&b"), true},
+		{"other-genre", model.NewMusicFilter().WithGenre("Rock"), false},
+		{"not-explicit", model.NewMusicFilter().WithExplicit(false), true},
+		{"explicit", model.NewMusicFilter().WithExplicit(true), false},
+		{"durations", model.NewMusicFilter().WithDurations(3*time.Minute, 4*time.Minute), true},
+		{"too-long", model.NewMusicFilter().WithDurations(0, 3*time.Minute), false},
+		{"rank", model.NewMusicFilter().WithMinRank(500000), true},
+		{"too-rare", model.NewMusicFilter().WithMinRank(900000), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantMatching, tt.filter.IsMatching(0, music))
+		})
+	}
+}
+
+func TestMusicValidateReleaseDate(t *testing.T) {
+
+	tests := []struct {
+		releaseDate string
+		year        int
+		wantErr     error
+	}{
+		{"", 0, nil},
+		{"2003-05-18", 0, nil},
+		{"2003-05-18", 2003, nil},
+		{"2003-05-18", 2004, model.ErrInvalidMusicYear},
+		{"2003", 2003, model.ErrInvalidMusicReleaseDate},
+	}
+
+	for _, tt := range tests {
+		t.Run("date["+tt.releaseDate+"]", func(t *testing.T) {
+			music := &model.Music{
+				Name:        "Crazy in Love",
+				Mp3Url:      "https://cdn/crazy.mp3",
+				ReleaseDate: tt.releaseDate,
+				Year:        tt.year,
+				Artist:      &model.MusicArtist{Name: "Beyoncé"},
+			}
+			require.Equal(t, tt.wantErr, music.Validate(nil, nil))
+		})
+	}
+}
+
+func TestMusicFilterIsMatchingLinkedGenres(t *testing.T) {
+
+	music := &model.Music{
+		Name:  "Paranoid",
+		Genre: "Rock",
+		Genres: []*model.MusicGenre{
+			{Id: 1, Name: "Rock"},
+			{Id: 2, Name: "Metal"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		filter       *model.MusicFilter
+		wantMatching bool
+	}{
+		{"genre-id", model.NewMusicFilter().WithGenreId(2), true},
+		{"other-genre-id", model.NewMusicFilter().WithGenreId(3), false},
+		{"free-text-genre", model.NewMusicFilter().WithGenre("rock"), true},
+		{"linked-genre", model.NewMusicFilter().WithGenre("metal"), true},
+		{"other-genre", model.NewMusicFilter().WithGenre("Pop"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantMatching, tt.filter.IsMatching(0, music))
+		})
+	}
+}
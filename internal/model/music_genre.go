@@ -0,0 +1,83 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// music genre
+
+type MusicGenreId int64
+
+type DeezerGenreId int64
+
+// MusicGenre is linked to albums and musics, the deezer genres of an album being linked on import.
+type MusicGenre struct {
+	Id       MusicGenreId
+	DeezerId DeezerGenreId
+	Name     string
+	ImgUrl   Url
+
+	// consolidated data
+	Albums  []*MusicAlbum
+	NbAlbum int
+	NbMusic int
+}
+
+func (o *MusicGenre) Validate(imagePathValidator PathValidator) error {
+	if o.Name == "" {
+		return ErrInvalidGenreName
+	}
+	if o.ImgUrl != "" && !o.ImgUrl.IsValid(imagePathValidator) {
+		return ErrInvalidImageUrl
+	}
+	return nil
+}
+
+func (o *MusicGenre) Copy() *MusicGenre {
+	if o == nil {
+		return nil
+	}
+	return &MusicGenre{
+		Id:       o.Id,
+		DeezerId: o.DeezerId,
+		Name:     o.Name,
+		ImgUrl:   o.ImgUrl,
+	}
+}
+
+func (o *MusicGenre) GetImageFileName() Url {
+	parts := make([]string, 0)
+	parts = append(parts, "genre")
+	if o.DeezerId != 0 {
+		parts = append(parts, fmt.Sprintf("deezer-%d", o.DeezerId))
+	}
+	if o.Name != "" {
+		parts = append(parts, o.Name)
+	}
+	return Url(strings.Join(util.Convert(parts, util.SanitizeAlphaLower), "_") + ".jpg")
+}
+
+func (o *MusicGenre) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.Id != 0 {
+		enc.AddInt64("id", int64(o.Id))
+	}
+	if o.DeezerId != 0 {
+		enc.AddInt64("deezer-id", int64(o.DeezerId))
+	}
+	enc.AddString("name", o.Name)
+	if o.ImgUrl != "" {
+		enc.AddString("img-url", string(o.ImgUrl))
+	}
+	return nil
+}
+
+// HasGenre tells whether the genre is part of the genres.
+func HasGenre(genres []*MusicGenre, id MusicGenreId) bool {
+	_, found := util.FindIf(genres, func(genre *MusicGenre) bool { return genre.Id == id })
+	return found
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// music genre filter
+
+type MusicGenreFilter struct {
+	Name   string
+	Sort   MusicSort
+	Offset int
+	Limit  int
+}
+
+func (o *MusicGenreFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.Name != "" {
+		enc.AddString("name", o.Name)
+	}
+	if o.Sort != "" {
+		enc.AddString("sort", o.Sort.String())
+	}
+	if o.Offset != 0 {
+		enc.AddInt("offset", o.Offset)
+	}
+	if o.Limit != 0 {
+		enc.AddInt("limit", o.Limit)
+	}
+	return nil
+}
+
+func (o *MusicGenreFilter) IsMatching(count int, candidate *MusicGenre) bool {
+	if o.Limit != 0 && count > o.Limit {
+		return false
+	}
+	if o.Name != "" && !strings.Contains(strings.ToLower(candidate.Name), strings.ToLower(o.Name)) {
+		return false
+	}
+	return true
+}
+
+// //////////////////////////////////////////////////
+// builder
+
+func NewMusicGenreFilter() *MusicGenreFilter {
+	return &MusicGenreFilter{}
+}
+
+func (r *MusicGenreFilter) WithName(name string) *MusicGenreFilter {
+	r.Name = name
+	return r
+}
+
+func (r *MusicGenreFilter) WithSort(sort MusicSort) *MusicGenreFilter {
+	r.Sort = sort
+	return r
+}
+
+func (r *MusicGenreFilter) WithOffset(offset int) *MusicGenreFilter {
+	r.Offset = offset
+	return r
+}
+
+func (r *MusicGenreFilter) WithLimit(limit int) *MusicGenreFilter {
+	r.Limit = limit
+	return r
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// music identity
+
+// ToMusicTitleKey normalizes a title to compare musics without case, accents nor punctuation.
+func ToMusicTitleKey(name string) string {
+	key := util.SanitizeAlphaLower(name)
+	if key == "" {
+		// e.g. non latin titles
+		key = strings.ToLower(strings.Trim(name, " "))
+	}
+	return key
+}
+
+// IsSameIdentity tells whether the music is the one of the artist with the given title.
+func (o *Music) IsSameIdentity(artistId MusicArtistId, name string) bool {
+	return o.ArtistId == artistId && ToMusicTitleKey(o.Name) == ToMusicTitleKey(name)
+}
+
+// //////////////////////////////////////////////////
+// music conflict
+
+// MusicConflictError is returned when a music has the identity of an existing music.
+type MusicConflictError struct {
+	Existing *Music
+}
+
+func NewMusicConflictError(existing *Music) *MusicConflictError {
+	return &MusicConflictError{
+		Existing: existing,
+	}
+}
+
+func (e *MusicConflictError) Error() string {
+	return fmt.Sprintf("%s %d - %s", ErrExistingMusic, e.Existing.Id, e.Existing.Name)
+}
+
+func (e *MusicConflictError) Unwrap() error {
+	return ErrExistingMusic
+}
@@ -0,0 +1,42 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestMusicIsSameIdentity(t *testing.T) {
+
+	hurt := &model.Music{Id: 1, Name: "Hurt", ArtistId: 1}
+
+	tests := []struct {
+		name     string
+		artistId model.MusicArtistId
+		title    string
+		wantSame bool
+	}{
+		{"same", 1, "Hurt", true},
+		{"case", 1, "HURT", true},
+		{"punctuation", 1, " Hurt! ", true},
+		{"other-artist", 2, "Hurt", false},
+		{"other-title", 1, "Closer", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.wantSame, hurt.IsSameIdentity(tt.artistId, tt.title))
+		})
+	}
+}
+
+func TestMusicConflictError(t *testing.T) {
+
+	var err error = model.NewMusicConflictError(&model.Music{Id: 42, Name: "Hurt"})
+
+	require.True(t, errors.Is(err, model.ErrExistingMusic))
+	require.Equal(t, "existing music 42 - Hurt", err.Error())
+}
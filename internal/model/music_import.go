@@ -0,0 +1,113 @@
+package model
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// music import
+
+type MusicImportStatus string
+
+const (
+	MusicImportStatus_New      MusicImportStatus = "new"
+	MusicImportStatus_Existing MusicImportStatus = "existing"
+	MusicImportStatus_Invalid  MusicImportStatus = "invalid"
+)
+
+// MusicImportItem is the outcome of the import of a local mp3.
+type MusicImportItem struct {
+	Path     Url
+	Status   MusicImportStatus
+	Reason   string
+	Music    *Music
+	CoverUrl Url
+}
+
+// ToImportedMusic builds the music of a local mp3 from its ID3 tags, the title falling back to the file name.
+func ToImportedMusic(path Url, tag *util.Id3Tag) *Music {
+	music := &Music{
+		Name:    tag.Title,
+		IsLocal: true,
+		Mp3Url:  path,
+		Year:    tag.Year,
+	}
+	if music.Name == "" {
+		base := filepath.Base(string(path))
+		music.Name = strings.Trim(strings.TrimSuffix(base, filepath.Ext(base)), " ")
+	}
+	if tag.Artist != "" {
+		music.Artist = &MusicArtist{
+			Name: tag.Artist,
+		}
+	}
+	if tag.Album != "" {
+		music.Album = &MusicAlbum{
+			Name: tag.Album,
+		}
+	}
+	return music
+}
+
+// GetCoverFileName returns the image file name of the album cover embedded in the mp3, named after the artist and the album
+// since two artists may release albums of the same name.
+func (o *Music) GetCoverFileName(tag *util.Id3Tag) Url {
+	parts := []string{"album"}
+	if o.Artist != nil && o.Artist.Name != "" {
+		parts = append(parts, o.Artist.Name)
+	}
+	if o.Album != nil && o.Album.Name != "" {
+		parts = append(parts, o.Album.Name)
+	}
+	return Url(strings.Join(util.Convert(parts, util.SanitizeAlphaLower), "_") + tag.PictureExtension())
+}
+
+func (o *MusicImportItem) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("path", string(o.Path))
+	enc.AddString("status", string(o.Status))
+	if o.Reason != "" {
+		enc.AddString("reason", o.Reason)
+	}
+	if o.Music != nil {
+		enc.AddObject("music", o.Music)
+	}
+	if o.CoverUrl != "" {
+		enc.AddString("cover-url", string(o.CoverUrl))
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// music import report
+
+// MusicImportReport lists the outcome of the import of each local mp3, nothing being written on dry run.
+type MusicImportReport struct {
+	DryRun bool
+	Items  []*MusicImportItem
+}
+
+func (o *MusicImportReport) Add(item *MusicImportItem) {
+	o.Items = append(o.Items, item)
+}
+
+func (o *MusicImportReport) Count(status MusicImportStatus) int {
+	count := 0
+	for _, item := range o.Items {
+		if item.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+func (o *MusicImportReport) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddBool("dry-run", o.DryRun)
+	enc.AddInt("nb-new", o.Count(MusicImportStatus_New))
+	enc.AddInt("nb-existing", o.Count(MusicImportStatus_Existing))
+	enc.AddInt("nb-invalid", o.Count(MusicImportStatus_Invalid))
+	return nil
+}
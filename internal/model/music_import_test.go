@@ -0,0 +1,30 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+func TestToImportedMusic(t *testing.T) {
+
+	music := model.ToImportedMusic("80s/bronski-beat - smalltown boy.mp3", &util.Id3Tag{Artist: "Bronski Beat", Album: "The Age of Consent", Year: 1984})
+	require.Equal(t, "bronski-beat - smalltown boy", music.Name)
+	require.Equal(t, model.Url("80s/bronski-beat - smalltown boy.mp3"), music.Mp3Url)
+	require.True(t, music.IsLocal)
+	require.Equal(t, 1984, music.Year)
+	require.Equal(t, "Bronski Beat", music.Artist.Name)
+	require.Equal(t, "The Age of Consent", music.Album.Name)
+
+	music = model.ToImportedMusic("hurt.mp3", &util.Id3Tag{Title: "Hurt"})
+	require.Equal(t, "Hurt", music.Name)
+	require.Nil(t, music.Artist)
+	require.Nil(t, music.Album)
+
+	music = model.ToImportedMusic("hurt.mp3", &util.Id3Tag{Title: "Hurt", Artist: "Johnny Cash", Album: "American IV"})
+	require.Equal(t, model.Url("album_johnny-cash_american-iv.png"), music.GetCoverFileName(&util.Id3Tag{PictureMime: "image/png"}))
+	require.Equal(t, model.Url("album_johnny-cash_american-iv.jpg"), music.GetCoverFileName(&util.Id3Tag{PictureMime: "image/jpeg"}))
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"strings"
+)
+
+// //////////////////////////////////////////////////
+// music sort
+
+// MusicSort orders a catalog listing by a key, in descending order when prefixed by "-" ( e.g. "-usage" ).
+// the usage of a music is the number of themes using it, the one of an artist, an album or a genre its number of musics.
+type MusicSort string
+
+const (
+	MusicSortKey_Id    = "id"
+	MusicSortKey_Name  = "name"
+	MusicSortKey_Usage = "usage"
+
+	musicSortDescPrefix = "-"
+)
+
+func ToMusicSort(value string) MusicSort {
+	value = strings.Trim(value, " ")
+	value = strings.ToLower(value)
+	switch strings.TrimPrefix(value, musicSortDescPrefix) {
+	case MusicSortKey_Id, MusicSortKey_Name, MusicSortKey_Usage:
+		return MusicSort(value)
+	default:
+		return ""
+	}
+}
+
+func (o MusicSort) String() string {
+	return string(o)
+}
+
+// Key is the sort key, by name when not set.
+func (o MusicSort) Key() string {
+	key := strings.TrimPrefix(string(o), musicSortDescPrefix)
+	if key == "" {
+		return MusicSortKey_Name
+	}
+	return key
+}
+
+func (o MusicSort) IsDesc() bool {
+	return strings.HasPrefix(string(o), musicSortDescPrefix)
+}
+
+// //////////////////////////////////////////////////
+// pagination
+
+const (
+	DefaultMusicPageLimit = 50
+	MaxMusicPageLimit     = 500
+)
+
+// ToMusicPageLimit falls back to the default limit and caps the requested one.
+func ToMusicPageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultMusicPageLimit
+	}
+	if limit > MaxMusicPageLimit {
+		return MaxMusicPageLimit
+	}
+	return limit
+}
+
+// //////////////////////////////////////////////////
+// music pages
+
+type MusicPage struct {
+	Musics []*Music
+	Total  int
+	Offset int
+	Limit  int
+}
+
+type MusicArtistPage struct {
+	Artists []*MusicArtist
+	Total   int
+	Offset  int
+	Limit   int
+}
+
+type MusicAlbumPage struct {
+	Albums []*MusicAlbum
+	Total  int
+	Offset int
+	Limit  int
+}
+
+type MusicGenrePage struct {
+	Genres []*MusicGenre
+	Total  int
+	Offset int
+	Limit  int
+}
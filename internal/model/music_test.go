@@ -0,0 +1,38 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestMusicWithEditedRelease(t *testing.T) {
+
+	existing := &model.Music{ReleaseDate: "1977-10-10", Year: 1977}
+
+	tests := []struct {
+		name            string
+		releaseDate     string
+		year            int
+		wantReleaseDate string
+		wantYear        int
+	}{
+		{"unchanged", "1977-10-10", 1977, "1977-10-10", 1977},
+		{"year", "1977-10-10", 1978, "", 1978},
+		{"release-date", "1978-01-01", 1977, "1978-01-01", 1978},
+		{"both", "1979-01-01", 1979, "1979-01-01", 1979},
+		{"both-inconsistent", "1979-01-01", 1980, "1979-01-01", 1979},
+		{"cleared-release-date", "", 1977, "", 1977},
+		{"invalid-release-date", "1978", 1977, "1978", 1977},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			music := (&model.Music{ReleaseDate: tt.releaseDate, Year: tt.year}).WithEditedRelease(existing)
+			require.Equal(t, tt.wantReleaseDate, music.ReleaseDate)
+			require.Equal(t, tt.wantYear, music.Year)
+		})
+	}
+}
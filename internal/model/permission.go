@@ -10,6 +10,7 @@ const (
 	Permission_User
 	Permission_Session
 	Permission_File
+	Permission_Webhook
 
 	__Permission_last
 )
@@ -34,6 +35,8 @@ func (p Permission) String() string {
 		return "session"
 	case Permission_File:
 		return "file"
+	case Permission_Webhook:
+		return "webhook"
 	}
 	return ""
 }
@@ -0,0 +1,138 @@
+package model
+
+import (
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// search kind
+
+// SearchKind is what an entry of the full-text search index refers to.
+type SearchKind string
+
+var (
+	SearchKind_Music         SearchKind = "music"
+	SearchKind_Artist        SearchKind = "artist"
+	SearchKind_Album         SearchKind = "album"
+	SearchKind_ThemeQuestion SearchKind = "theme-question"
+)
+
+func (o SearchKind) String() string {
+	return string(o)
+}
+
+// //////////////////////////////////////////////////
+// search entry
+
+// SearchEntry is an indexed text: the name of a music, an artist or an album, or the text of a theme question.
+// the parent of a theme question is its theme.
+type SearchEntry struct {
+	Kind     SearchKind
+	Id       int64
+	ParentId int64
+	Text     string
+}
+
+func NewMusicSearchEntry(music *Music) *SearchEntry {
+	return &SearchEntry{
+		Kind: SearchKind_Music,
+		Id:   int64(music.Id),
+		Text: music.Name,
+	}
+}
+
+func NewArtistSearchEntry(artist *MusicArtist) *SearchEntry {
+	return &SearchEntry{
+		Kind: SearchKind_Artist,
+		Id:   int64(artist.Id),
+		Text: artist.Name,
+	}
+}
+
+func NewAlbumSearchEntry(album *MusicAlbum) *SearchEntry {
+	return &SearchEntry{
+		Kind: SearchKind_Album,
+		Id:   int64(album.Id),
+		Text: album.Name,
+	}
+}
+
+func NewThemeQuestionSearchEntry(question *ThemeQuestion) *SearchEntry {
+	return &SearchEntry{
+		Kind:     SearchKind_ThemeQuestion,
+		Id:       int64(question.Id),
+		ParentId: int64(question.ThemeId),
+		Text:     question.Text,
+	}
+}
+
+func (o *SearchEntry) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("kind", o.Kind.String())
+	enc.AddInt64("id", o.Id)
+	if o.ParentId != 0 {
+		enc.AddInt64("parent-id", o.ParentId)
+	}
+	enc.AddString("text", o.Text)
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// search result
+
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 100
+)
+
+// ToSearchLimit falls back to the default limit and caps the requested one.
+func ToSearchLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		return MaxSearchLimit
+	}
+	return limit
+}
+
+// SearchResult groups the best ranked entries matching a query by kind.
+// the matching theme questions are grouped by theme, the themes being ordered by their best ranked question.
+type SearchResult struct {
+	Query   string
+	Musics  []*SearchEntry
+	Artists []*SearchEntry
+	Albums  []*SearchEntry
+	Themes  []*SearchThemeHit
+}
+
+func (o *SearchResult) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("query", o.Query)
+	enc.AddInt("nb-musics", len(o.Musics))
+	enc.AddInt("nb-artists", len(o.Artists))
+	enc.AddInt("nb-albums", len(o.Albums))
+	enc.AddInt("nb-themes", len(o.Themes))
+	return nil
+}
+
+type SearchThemeHit struct {
+	ThemeId   ThemeId
+	Title     string
+	Questions []*SearchEntry
+}
+
+// ToSearchThemeHits groups theme question entries by theme, keeping their order.
+func ToSearchThemeHits(entries []*SearchEntry) []*SearchThemeHit {
+	hits := make([]*SearchThemeHit, 0)
+	byTheme := make(map[ThemeId]*SearchThemeHit)
+	for _, entry := range entries {
+		themeId := ThemeId(entry.ParentId)
+		hit, found := byTheme[themeId]
+		if !found {
+			hit = &SearchThemeHit{ThemeId: themeId}
+			byTheme[themeId] = hit
+			hits = append(hits, hit)
+		}
+		hit.Questions = append(hit.Questions, entry)
+	}
+	return hits
+}
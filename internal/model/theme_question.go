@@ -13,6 +13,7 @@ type ThemeQuestion struct {
 	MusicId MusicId
 	Text    string
 	Hint    string
+	Excerpt AudioExcerpt
 
 	// consolidated data
 	Theme *Theme
@@ -29,6 +30,9 @@ func (o *ThemeQuestion) Validate() error {
 	if o.Text == "" {
 		return ErrInvalidThemeQuestion
 	}
+	if err := o.Excerpt.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -39,6 +43,7 @@ func (o *ThemeQuestion) Copy() *ThemeQuestion {
 		MusicId: o.MusicId,
 		Text:    o.Text,
 		Hint:    o.Hint,
+		Excerpt: o.Excerpt,
 	}
 }
 
@@ -50,6 +55,9 @@ func (o *ThemeQuestion) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 	if o.Hint != "" {
 		enc.AddString("hint", o.Hint)
 	}
+	if !o.Excerpt.IsZero() {
+		enc.AddObject("excerpt", o.Excerpt)
+	}
 	if o.Music != nil {
 		enc.AddObject("music", o.Music)
 	}
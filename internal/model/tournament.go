@@ -0,0 +1,314 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// tournament id
+
+type TournamentId int64
+
+func (i TournamentId) String() string {
+	return fmt.Sprintf("%d", i)
+}
+
+func ToTournamentId(value string) TournamentId {
+	return TournamentId(util.StrToInt64(value))
+}
+
+type TournamentParticipantId int
+
+// //////////////////////////////////////////////////
+// tournament
+
+// Tournament groups games played in parallel tables: each stage seeds the remaining participants into tables
+// and the top participants of each table are promoted to the next stage until a single final table remains.
+type Tournament struct {
+	Id           TournamentId
+	Version      int
+	OwnerId      UserId
+	Name         string
+	TableSize    int
+	PromoteTop   int
+	Settings     *GameSettings
+	Participants []*TournamentParticipant
+	Stages       []*TournamentStage
+	Finished     bool
+}
+
+type TournamentParticipant struct {
+	Id   TournamentParticipantId
+	Name string
+}
+
+type TournamentStage struct {
+	Number int
+	Tables []*TournamentTable
+}
+
+type TournamentTable struct {
+	Number int
+	GameId GameId
+	Seats  []*TournamentSeat
+}
+
+// TournamentSeat links a participant to its player in the game of the table.
+type TournamentSeat struct {
+	ParticipantId TournamentParticipantId
+	PlayerId      GamePlayerId
+}
+
+func (o *Tournament) IsOwnedBy(userId UserId) bool {
+	return userId != 0 && o.OwnerId == userId
+}
+
+func (o *Tournament) Validate() error {
+	if strings.TrimSpace(o.Name) == "" {
+		return ErrInvalidTournamentName
+	}
+	if len(o.Participants) < 2 {
+		return ErrInvalidNbParticipant
+	}
+	if o.TableSize < 2 || o.TableSize > MaxNbPlayer {
+		return ErrInvalidTournamentTableSize
+	}
+	// promoting at most half of a table guarantees that each stage shrinks
+	if o.PromoteTop < 1 || 2*o.PromoteTop > o.TableSize {
+		return ErrInvalidTournamentPromotion
+	}
+	if o.Settings == nil {
+		return ErrMissingTournamentSettings
+	}
+	return nil
+}
+
+func (o *Tournament) CurrentStage() *TournamentStage {
+	if len(o.Stages) == 0 {
+		return nil
+	}
+	return o.Stages[len(o.Stages)-1]
+}
+
+func (o *Tournament) FindParticipant(id TournamentParticipantId) *TournamentParticipant {
+	participant, _ := util.FindIf(o.Participants, func(participant *TournamentParticipant) bool { return participant.Id == id })
+	return participant
+}
+
+// IsFinal tells whether the current stage is played on a single table.
+func (o *Tournament) IsFinal() bool {
+	stage := o.CurrentStage()
+	return stage != nil && len(stage.Tables) == 1
+}
+
+// NewStage seeds the given participants ( best seed first ) into the tables of a new stage.
+// participants are dealt in a snake order ( 1 2 3 3 2 1 ... ) so that the best seeds are spread across tables.
+func (o *Tournament) NewStage(participantIds []TournamentParticipantId) *TournamentStage {
+	nbTable := (len(participantIds) + o.TableSize - 1) / o.TableSize
+	stage := &TournamentStage{
+		Number: len(o.Stages) + 1,
+		Tables: make([]*TournamentTable, nbTable),
+	}
+	for index := range stage.Tables {
+		stage.Tables[index] = &TournamentTable{
+			Number: index + 1,
+		}
+	}
+	for index, participantId := range participantIds {
+		tableIndex := index % nbTable
+		if (index/nbTable)%2 == 1 {
+			tableIndex = nbTable - 1 - tableIndex
+		}
+		table := stage.Tables[tableIndex]
+		table.Seats = append(table.Seats, &TournamentSeat{
+			ParticipantId: participantId,
+			PlayerId:      NewGamePlayerId(len(table.Seats) + 1),
+		})
+	}
+	o.Stages = append(o.Stages, stage)
+	return stage
+}
+
+// GameSettings returns the settings of the game played on the given table.
+// every table of a stage shares the same seed ( i.e. the same questions ) while each stage gets new ones.
+func (o *Tournament) GameSettings(stage *TournamentStage, table *TournamentTable) GameSettings {
+	settings := *o.Settings
+	settings.Rounds = util.Convert(o.Settings.Rounds, (*GameRoundSettings).Copy)
+	settings.Jokers = util.Convert(o.Settings.Jokers, (*GameJokerSettings).Copy)
+	settings.Seed = o.Settings.Seed + int64(stage.Number-1)
+	settings.NbPlayer = len(table.Seats)
+	return settings
+}
+
+// Promote returns the top participants of each table of the current stage:
+// all the winners first, then all the second places, ... to be seeded into the next stage.
+func (o *Tournament) Promote(results map[GameId]*GameResult) []TournamentParticipantId {
+	stage := o.CurrentStage()
+	if stage == nil {
+		return nil
+	}
+	ranked := make([][]TournamentParticipantId, 0, len(stage.Tables))
+	for _, table := range stage.Tables {
+		ranked = append(ranked, table.Ranking(results[table.GameId]))
+	}
+	promoted := make([]TournamentParticipantId, 0, len(stage.Tables)*o.PromoteTop)
+	for rank := 0; rank < o.PromoteTop; rank++ {
+		for _, participantIds := range ranked {
+			if rank < len(participantIds) {
+				promoted = append(promoted, participantIds[rank])
+			}
+		}
+	}
+	return promoted
+}
+
+// Ranking returns the participants of the table, best score first.
+func (o *TournamentTable) Ranking(result *GameResult) []TournamentParticipantId {
+	participantIds := make(map[GamePlayerId]TournamentParticipantId, len(o.Seats))
+	for _, seat := range o.Seats {
+		participantIds[seat.PlayerId] = seat.ParticipantId
+	}
+	ranking := make([]TournamentParticipantId, 0, len(o.Seats))
+	if result != nil {
+		for _, playerResult := range result.Players {
+			if participantId, found := participantIds[playerResult.PlayerId]; found {
+				ranking = append(ranking, participantId)
+				delete(participantIds, playerResult.PlayerId)
+			}
+		}
+	}
+	for _, seat := range o.Seats {
+		if _, found := participantIds[seat.PlayerId]; found {
+			ranking = append(ranking, seat.ParticipantId)
+		}
+	}
+	return ranking
+}
+
+func (o *Tournament) Copy() *Tournament {
+	if o == nil {
+		return nil
+	}
+	copied := *o
+	if o.Settings != nil {
+		settings := *o.Settings
+		settings.Rounds = util.Convert(o.Settings.Rounds, (*GameRoundSettings).Copy)
+		settings.Jokers = util.Convert(o.Settings.Jokers, (*GameJokerSettings).Copy)
+		copied.Settings = &settings
+	}
+	copied.Participants = util.Convert(o.Participants, func(participant *TournamentParticipant) *TournamentParticipant {
+		participantCopy := *participant
+		return &participantCopy
+	})
+	copied.Stages = util.Convert(o.Stages, func(stage *TournamentStage) *TournamentStage {
+		return &TournamentStage{
+			Number: stage.Number,
+			Tables: util.Convert(stage.Tables, func(table *TournamentTable) *TournamentTable {
+				return &TournamentTable{
+					Number: table.Number,
+					GameId: table.GameId,
+					Seats: util.Convert(table.Seats, func(seat *TournamentSeat) *TournamentSeat {
+						seatCopy := *seat
+						return &seatCopy
+					}),
+				}
+			}),
+		}
+	})
+	return &copied
+}
+
+func (o *Tournament) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.Id != 0 {
+		enc.AddInt64("id", int64(o.Id))
+	}
+	enc.AddString("name", o.Name)
+	enc.AddInt("nb-participants", len(o.Participants))
+	enc.AddInt("table-size", o.TableSize)
+	enc.AddInt("promote-top", o.PromoteTop)
+	enc.AddInt("nb-stages", len(o.Stages))
+	if o.Finished {
+		enc.AddBool("finished", o.Finished)
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// tournament standing
+
+type TournamentStanding struct {
+	ParticipantId TournamentParticipantId
+	Name          string
+	Stage         int
+	Position      int
+	NbGame        int
+	Score         int
+	Rank          int
+}
+
+// Standings ranks participants by the last stage they reached, then by their position at their last table
+// and finally by the sum of their scores.
+func (o *Tournament) Standings(results map[GameId]*GameResult) []*TournamentStanding {
+	standings := make([]*TournamentStanding, 0, len(o.Participants))
+	byParticipant := make(map[TournamentParticipantId]*TournamentStanding, len(o.Participants))
+	for _, participant := range o.Participants {
+		standing := &TournamentStanding{
+			ParticipantId: participant.Id,
+			Name:          participant.Name,
+		}
+		standings = append(standings, standing)
+		byParticipant[participant.Id] = standing
+	}
+
+	for _, stage := range o.Stages {
+		for _, table := range stage.Tables {
+			result := results[table.GameId]
+			scores := make(map[GamePlayerId]int)
+			if result != nil {
+				for _, playerResult := range result.Players {
+					scores[playerResult.PlayerId] = playerResult.Score
+				}
+			}
+			positions := make(map[TournamentParticipantId]int)
+			for index, participantId := range table.Ranking(result) {
+				positions[participantId] = index + 1
+			}
+			for _, seat := range table.Seats {
+				standing, found := byParticipant[seat.ParticipantId]
+				if !found {
+					continue
+				}
+				standing.Stage = stage.Number
+				standing.Position = positions[seat.ParticipantId]
+				standing.NbGame++
+				standing.Score += scores[seat.PlayerId]
+			}
+		}
+	}
+
+	before := func(a, b *TournamentStanding) bool {
+		if a.Stage != b.Stage {
+			return a.Stage > b.Stage
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.Score > b.Score
+	}
+	sort.SliceStable(standings, func(i, j int) bool {
+		return before(standings[i], standings[j])
+	})
+	for index, standing := range standings {
+		if index > 0 && !before(standings[index-1], standing) {
+			standing.Rank = standings[index-1].Rank
+		} else {
+			standing.Rank = index + 1
+		}
+	}
+	return standings
+}
@@ -0,0 +1,94 @@
+package model_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func newTestTournament(nbParticipant int) *model.Tournament {
+	tournament := &model.Tournament{
+		Name:       "yearly",
+		TableSize:  4,
+		PromoteTop: 2,
+		Settings:   &model.GameSettings{NbQuestion: 10, NbAnswer: 4, Sources: []model.Source{model.Source_Store}},
+	}
+	for number := 1; number <= nbParticipant; number++ {
+		tournament.Participants = append(tournament.Participants, &model.TournamentParticipant{Id: model.TournamentParticipantId(number)})
+	}
+	return tournament
+}
+
+func seatedParticipants(table *model.TournamentTable) []model.TournamentParticipantId {
+	ids := []model.TournamentParticipantId{}
+	for _, seat := range table.Seats {
+		ids = append(ids, seat.ParticipantId)
+	}
+	return ids
+}
+
+func TestTournamentValidate(t *testing.T) {
+	require.NoError(t, newTestTournament(8).Validate())
+
+	tournament := newTestTournament(8)
+	tournament.PromoteTop = 3
+	require.ErrorIs(t, tournament.Validate(), model.ErrInvalidTournamentPromotion)
+
+	require.ErrorIs(t, newTestTournament(1).Validate(), model.ErrInvalidNbParticipant)
+}
+
+func TestTournamentStages(t *testing.T) {
+	tournament := newTestTournament(8)
+
+	stage := tournament.NewStage([]model.TournamentParticipantId{1, 2, 3, 4, 5, 6, 7, 8})
+	require.Equal(t, 1, stage.Number)
+	require.Len(t, stage.Tables, 2)
+	require.Equal(t, []model.TournamentParticipantId{1, 4, 5, 8}, seatedParticipants(stage.Tables[0]))
+	require.Equal(t, []model.TournamentParticipantId{2, 3, 6, 7}, seatedParticipants(stage.Tables[1]))
+	require.False(t, tournament.IsFinal())
+
+	stage.Tables[0].GameId = model.NewGameId(1)
+	stage.Tables[1].GameId = model.NewGameId(2)
+	settings := tournament.GameSettings(stage, stage.Tables[0])
+	require.Equal(t, 4, settings.NbPlayer)
+
+	// table 1: participant 8 then 5 / table 2: participant 2 then 3
+	results := map[model.GameId]*model.GameResult{
+		stage.Tables[0].GameId: {Players: []*model.GamePlayerResult{
+			{PlayerId: model.NewGamePlayerId(4), Score: 5},
+			{PlayerId: model.NewGamePlayerId(3), Score: 4},
+			{PlayerId: model.NewGamePlayerId(1), Score: 2},
+			{PlayerId: model.NewGamePlayerId(2), Score: 1},
+		}},
+		stage.Tables[1].GameId: {Players: []*model.GamePlayerResult{
+			{PlayerId: model.NewGamePlayerId(1), Score: 6},
+			{PlayerId: model.NewGamePlayerId(2), Score: 3},
+			{PlayerId: model.NewGamePlayerId(3), Score: 2},
+			{PlayerId: model.NewGamePlayerId(4), Score: 0},
+		}},
+	}
+	promoted := tournament.Promote(results)
+	require.Equal(t, []model.TournamentParticipantId{8, 2, 5, 3}, promoted)
+
+	final := tournament.NewStage(promoted)
+	require.Equal(t, 2, final.Number)
+	require.True(t, tournament.IsFinal())
+	final.Tables[0].GameId = model.NewGameId(3)
+	results[final.Tables[0].GameId] = &model.GameResult{Players: []*model.GamePlayerResult{
+		{PlayerId: model.NewGamePlayerId(3), Score: 7}, // participant 5
+		{PlayerId: model.NewGamePlayerId(1), Score: 3}, // participant 8
+	}}
+
+	standings := tournament.Standings(results)
+	require.Len(t, standings, 8)
+	require.Equal(t, model.TournamentParticipantId(5), standings[0].ParticipantId)
+	require.Equal(t, 1, standings[0].Rank)
+	require.Equal(t, 2, standings[0].Stage)
+	require.Equal(t, 11, standings[0].Score)
+	require.Equal(t, 2, standings[0].NbGame)
+	require.Equal(t, model.TournamentParticipantId(8), standings[1].ParticipantId)
+	require.Equal(t, 2, standings[1].Stage)
+	require.Equal(t, 1, standings[4].Stage)
+}
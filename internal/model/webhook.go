@@ -0,0 +1,177 @@
+package model
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap/zapcore"
+)
+
+// //////////////////////////////////////////////////
+// webhook event type
+
+type WebhookEventType string
+
+const (
+	WebhookEventType_GameCreated      WebhookEventType = "game-created"
+	WebhookEventType_QuestionRevealed WebhookEventType = "question-revealed"
+	WebhookEventType_GameFinished     WebhookEventType = "game-finished"
+)
+
+func ToWebhookEventType(value string) WebhookEventType {
+	switch WebhookEventType(value) {
+	case WebhookEventType_GameCreated:
+		return WebhookEventType_GameCreated
+	case WebhookEventType_QuestionRevealed:
+		return WebhookEventType_QuestionRevealed
+	case WebhookEventType_GameFinished:
+		return WebhookEventType_GameFinished
+	default:
+		return ""
+	}
+}
+
+func (o WebhookEventType) String() string {
+	return string(o)
+}
+
+// //////////////////////////////////////////////////
+// webhook id
+
+type WebhookId int64
+
+func (i WebhookId) String() string {
+	return fmt.Sprintf("%d", i)
+}
+
+func (i WebhookId) ToInt64() int64 {
+	return int64(i)
+}
+
+func ToWebhookId(value string) WebhookId {
+	return WebhookId(util.StrToInt64(value))
+}
+
+// //////////////////////////////////////////////////
+// webhook
+
+// Webhook is an external url notified of game events.
+// Payloads are signed with the secret so that the receiver can authenticate them.
+type Webhook struct {
+	Id     WebhookId
+	Url    string
+	Secret string
+	Events []WebhookEventType
+	Active bool
+}
+
+func NewWebhookSecret() string {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(bytes)
+}
+
+func (o *Webhook) Subscribes(eventType WebhookEventType) bool {
+	return o.Active && util.Contains(o.Events, eventType)
+}
+
+func (o *Webhook) Validate() error {
+	parsed, err := url.Parse(o.Url)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return ErrInvalidWebhookUrl
+	}
+	if len(o.Events) == 0 {
+		return ErrInvalidWebhookEvent
+	}
+	for _, event := range o.Events {
+		if ToWebhookEventType(string(event)) == "" {
+			return ErrInvalidWebhookEvent
+		}
+	}
+	return nil
+}
+
+func (o *Webhook) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.Id != 0 {
+		enc.AddInt64("id", int64(o.Id))
+	}
+	enc.AddString("url", o.Url)
+	enc.AddString("events", util.ConvertAndJoin(o.Events, WebhookEventType.String, ","))
+	enc.AddBool("active", o.Active)
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// webhook event
+
+type WebhookEvent struct {
+	Type       WebhookEventType
+	OccurredAt time.Time
+	Game       *Game
+	Question   *GameQuestion
+}
+
+func NewGameWebhookEvent(eventType WebhookEventType, game *Game) *WebhookEvent {
+	return &WebhookEvent{
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		Game:       game,
+	}
+}
+
+func NewQuestionWebhookEvent(eventType WebhookEventType, game *Game, question *GameQuestion) *WebhookEvent {
+	return &WebhookEvent{
+		Type:       eventType,
+		OccurredAt: time.Now(),
+		Game:       game,
+		Question:   question,
+	}
+}
+
+func (o *WebhookEvent) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("type", o.Type.String())
+	if o.Game != nil {
+		enc.AddInt64("game-id", int64(o.Game.Id))
+	}
+	if o.Question != nil {
+		enc.AddInt64("question-id", int64(o.Question.Id))
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// webhook delivery
+
+type WebhookDeliveryId int64
+
+// WebhookDelivery logs one attempt to deliver an event to a webhook.
+type WebhookDelivery struct {
+	Id          WebhookDeliveryId
+	WebhookId   WebhookId
+	Event       WebhookEventType
+	GameId      GameId
+	Attempt     int
+	StatusCode  int
+	Error       string
+	Success     bool
+	DeliveredAt time.Time
+}
+
+func (o *WebhookDelivery) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt64("webhook-id", int64(o.WebhookId))
+	enc.AddString("event", o.Event.String())
+	enc.AddInt64("game-id", int64(o.GameId))
+	enc.AddInt("attempt", o.Attempt)
+	enc.AddInt("status-code", o.StatusCode)
+	if o.Error != "" {
+		enc.AddString("error", o.Error)
+	}
+	enc.AddBool("success", o.Success)
+	return nil
+}
@@ -0,0 +1,35 @@
+package model
+
+import "go.uber.org/zap/zapcore"
+
+// //////////////////////////////////////////////////
+// webhook filter
+
+type WebhookFilter struct {
+	ActiveOnly bool
+}
+
+func (o *WebhookFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.ActiveOnly {
+		enc.AddBool("active-only", o.ActiveOnly)
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// webhook delivery filter
+
+type WebhookDeliveryFilter struct {
+	WebhookId WebhookId
+	Limit     int
+}
+
+func (o *WebhookDeliveryFilter) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	if o.WebhookId != 0 {
+		enc.AddInt64("webhook-id", int64(o.WebhookId))
+	}
+	if o.Limit != 0 {
+		enc.AddInt("limit", o.Limit)
+	}
+	return nil
+}
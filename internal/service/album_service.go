@@ -16,20 +16,23 @@ import (
 // album service
 
 type AlbumService interface {
-	ListAlbum(ctx context.Context, search *model.MusicAlbumFilter) ([]*model.MusicAlbum, error)
+	ListAlbum(ctx context.Context, search *model.MusicAlbumFilter) (*model.MusicAlbumPage, error)
 	GetAlbum(ctx context.Context, id model.MusicAlbumId) (*model.MusicAlbum, error)
 	CreateAlbum(ctx context.Context, music *model.MusicAlbum) (*model.MusicAlbum, error)
 	UpdateAlbum(ctx context.Context, music *model.MusicAlbum) (*model.MusicAlbum, error)
 	DeleteAlbum(ctx context.Context, id model.MusicAlbumId) error
+	SuggestAlbumDuplicates(ctx context.Context, minSimilarity int) ([]*model.MusicAlbumDuplicate, error)
+	MergeAlbums(ctx context.Context, id model.MusicAlbumId, duplicateIds []model.MusicAlbumId) (*model.MusicAlbum, error)
 }
 
-func NewAlbumService(logger *zap.Logger, downloadClient client.DownloadClient, db *sql.DB, albumStore store.MusicAlbumStore, musicStore store.MusicStore, imageFileValidator model.PathValidator) AlbumService {
+func NewAlbumService(logger *zap.Logger, downloadClient client.DownloadClient, db *sql.DB, albumStore store.MusicAlbumStore, musicStore store.MusicStore, genreStore store.MusicGenreStore, imageFileValidator model.PathValidator) AlbumService {
 	return &albumService{
 		logger:             logger,
 		downloadClient:     downloadClient,
 		db:                 db,
 		albumStore:         albumStore,
 		musicStore:         musicStore,
+		genreStore:         genreStore,
 		imageFileValidator: imageFileValidator,
 	}
 }
@@ -40,23 +43,33 @@ type albumService struct {
 	db                 *sql.DB
 	albumStore         store.MusicAlbumStore
 	musicStore         store.MusicStore
+	genreStore         store.MusicGenreStore
 	imageFileValidator model.PathValidator
 }
 
 // //////////////////////////////////////////////////
 // list album
 
-func (s *albumService) ListAlbum(ctx context.Context, filter *model.MusicAlbumFilter) ([]*model.MusicAlbum, error) {
-	var albums []*model.MusicAlbum
+// ListAlbum returns a page of albums along with the total number of matching albums and the number of musics of each album.
+func (s *albumService) ListAlbum(ctx context.Context, filter *model.MusicAlbumFilter) (*model.MusicAlbumPage, error) {
+	page := &model.MusicAlbumPage{
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	}
 	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
-		albums = s.albumStore.List(ctx, tx, filter)
+		page.Albums = s.albumStore.List(ctx, tx, filter)
+		page.Total = s.albumStore.Count(ctx, tx, filter)
+		nbMusics := s.musicStore.CountByAlbum(ctx, tx)
+		for _, album := range page.Albums {
+			album.NbMusic = nbMusics[album.Id]
+		}
 	})
 	if err != nil {
-		s.logger.Info("[ KO ] list album", zap.Error(err))
+		s.logger.Info("[ KO ] list album", zap.Object("filter", filter), zap.Error(err))
 		return nil, err
 	}
-	s.logger.Info("[ OK ] list album")
-	return albums, nil
+	s.logger.Info(fmt.Sprintf("[ OK ] list %d / %d album", len(page.Albums), page.Total), zap.Object("filter", filter))
+	return page, nil
 }
 
 // //////////////////////////////////////////////////
@@ -130,6 +143,12 @@ func (s *albumService) GetAlbum(ctx context.Context, id model.MusicAlbumId) (*mo
 		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve album %d", id))
 		album = s.albumStore.Retrieve(ctx, tx, id)
 
+		//
+		// retrieve genres
+		//
+
+		album.Genres = s.genreStore.ListByAlbum(ctx, tx, album.Id)
+
 		//
 		// retrieve related musics
 		//
@@ -176,6 +195,7 @@ func (s *albumService) UpdateAlbum(ctx context.Context, album *model.MusicAlbum)
 			panic(err)
 		}
 
+		updated.Genres = s.genreStore.ListByAlbum(ctx, tx, album.Id)
 		updated.Musics = s.musicStore.List(ctx, tx, &model.MusicFilter{AlbumId: album.Id})
 	})
 
@@ -208,6 +228,7 @@ func (s *albumService) DeleteAlbum(ctx context.Context, id model.MusicAlbumId) e
 
 		s.logger.Info(fmt.Sprintf("[DEBUG] delete album %d", id))
 		s.albumStore.Delete(ctx, tx, id)
+		s.genreStore.UnlinkAllAlbum(ctx, tx, id)
 	})
 
 	if err != nil {
@@ -218,6 +239,83 @@ func (s *albumService) DeleteAlbum(ctx context.Context, id model.MusicAlbumId) e
 	return nil
 }
 
+// //////////////////////////////////////////////////
+// suggest duplicates
+
+// SuggestAlbumDuplicates groups the albums whose normalized names are at least similar to the given percentage.
+func (s *albumService) SuggestAlbumDuplicates(ctx context.Context, minSimilarity int) ([]*model.MusicAlbumDuplicate, error) {
+
+	var duplicates []*model.MusicAlbumDuplicate
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		albums := s.albumStore.List(ctx, tx, nil)
+		nbMusics := s.musicStore.CountByAlbum(ctx, tx)
+		for _, album := range albums {
+			album.NbMusic = nbMusics[album.Id]
+		}
+		duplicates = model.FindAlbumDuplicates(albums, minSimilarity)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] suggest album duplicates ( similarity: %d%% )", minSimilarity), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] suggest %d album duplicates ( similarity: %d%% )", len(duplicates), minSimilarity))
+	return duplicates, nil
+}
+
+// //////////////////////////////////////////////////
+// merge albums
+
+// MergeAlbums moves the musics and genres of the duplicates to the surviving album, completes its deezer id and cover, then deletes the duplicates.
+func (s *albumService) MergeAlbums(ctx context.Context, id model.MusicAlbumId, duplicateIds []model.MusicAlbumId) (*model.MusicAlbum, error) {
+
+	//
+	// validate
+	//
+
+	if len(duplicateIds) == 0 {
+		return nil, model.ErrMissingDuplicate
+	}
+	if util.Contains(duplicateIds, id) {
+		return nil, model.ErrInvalidDuplicate
+	}
+
+	//
+	// merge
+	//
+
+	var album *model.MusicAlbum
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve album %d", id))
+		album = s.albumStore.Retrieve(ctx, tx, id)
+
+		for _, duplicateId := range duplicateIds {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve duplicate album %d", duplicateId))
+			duplicate := s.albumStore.Retrieve(ctx, tx, duplicateId)
+			album.MergeWith(duplicate)
+
+			nb := s.musicStore.ReassignAlbum(ctx, tx, duplicateId, id)
+			s.logger.Info(fmt.Sprintf("[DEBUG] reassign %d musics of album %d to album %d", nb, duplicateId, id))
+			s.genreStore.ReassignAlbum(ctx, tx, duplicateId, id)
+
+			s.logger.Info(fmt.Sprintf("[DEBUG] delete duplicate album %d", duplicateId))
+			s.albumStore.Delete(ctx, tx, duplicateId)
+		}
+
+		album = s.albumStore.Update(ctx, tx, album)
+		album.Genres = s.genreStore.ListByAlbum(ctx, tx, album.Id)
+		album.Musics = s.musicStore.List(ctx, tx, &model.MusicFilter{AlbumId: album.Id})
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] merge albums %v into album %d", duplicateIds, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] merge albums %v into album %d - %s", duplicateIds, album.Id, album.Name), zap.Object("album", album))
+	return album, nil
+}
+
 // //////////////////////////////////////////////////
 // download remote files
 
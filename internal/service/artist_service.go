@@ -16,20 +16,25 @@ import (
 // artist service
 
 type ArtistService interface {
-	ListArtist(ctx context.Context, search *model.MusicArtistFilter) ([]*model.MusicArtist, error)
+	ListArtist(ctx context.Context, search *model.MusicArtistFilter) (*model.MusicArtistPage, error)
 	GetArtist(ctx context.Context, id model.MusicArtistId) (*model.MusicArtist, error)
 	CreateArtist(ctx context.Context, music *model.MusicArtist) (*model.MusicArtist, error)
 	UpdateArtist(ctx context.Context, music *model.MusicArtist) (*model.MusicArtist, error)
 	DeleteArtist(ctx context.Context, id model.MusicArtistId) error
+	SuggestArtistDuplicates(ctx context.Context, minSimilarity int) ([]*model.MusicArtistDuplicate, error)
+	MergeArtists(ctx context.Context, id model.MusicArtistId, duplicateIds []model.MusicArtistId) (*model.MusicArtist, error)
 }
 
-func NewArtistService(logger *zap.Logger, downloadClient client.DownloadClient, db *sql.DB, artistStore store.MusicArtistStore, musicStore store.MusicStore, imageFileValidator model.PathValidator) ArtistService {
+func NewArtistService(logger *zap.Logger, downloadClient client.DownloadClient, db *sql.DB, artistStore store.MusicArtistStore, musicStore store.MusicStore, contributorStore store.MusicContributorStore, genreStore store.MusicGenreStore, themeQuestionStore store.ThemeQuestionStore, imageFileValidator model.PathValidator) ArtistService {
 	return &artistService{
 		logger:             logger,
 		downloadClient:     downloadClient,
 		db:                 db,
 		artistStore:        artistStore,
 		musicStore:         musicStore,
+		contributorStore:   contributorStore,
+		genreStore:         genreStore,
+		themeQuestionStore: themeQuestionStore,
 		imageFileValidator: imageFileValidator,
 	}
 }
@@ -40,23 +45,35 @@ type artistService struct {
 	db                 *sql.DB
 	artistStore        store.MusicArtistStore
 	musicStore         store.MusicStore
+	contributorStore   store.MusicContributorStore
+	genreStore         store.MusicGenreStore
+	themeQuestionStore store.ThemeQuestionStore
 	imageFileValidator model.PathValidator
 }
 
 // //////////////////////////////////////////////////
 // list artist
 
-func (s *artistService) ListArtist(ctx context.Context, filter *model.MusicArtistFilter) ([]*model.MusicArtist, error) {
-	var artists []*model.MusicArtist
+// ListArtist returns a page of artists along with the total number of matching artists and the number of musics of each artist.
+func (s *artistService) ListArtist(ctx context.Context, filter *model.MusicArtistFilter) (*model.MusicArtistPage, error) {
+	page := &model.MusicArtistPage{
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	}
 	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
-		artists = s.artistStore.List(ctx, tx, filter)
+		page.Artists = s.artistStore.List(ctx, tx, filter)
+		page.Total = s.artistStore.Count(ctx, tx, filter)
+		nbMusics := s.musicStore.CountByArtist(ctx, tx)
+		for _, artist := range page.Artists {
+			artist.NbMusic = nbMusics[artist.Id]
+		}
 	})
 	if err != nil {
-		s.logger.Info("[ KO ] list artist", zap.Error(err))
+		s.logger.Info("[ KO ] list artist", zap.Object("filter", filter), zap.Error(err))
 		return nil, err
 	}
-	s.logger.Info("[ OK ] list artist")
-	return artists, nil
+	s.logger.Info(fmt.Sprintf("[ OK ] list %d / %d artist", len(page.Artists), page.Total), zap.Object("filter", filter))
+	return page, nil
 }
 
 // //////////////////////////////////////////////////
@@ -218,6 +235,88 @@ func (s *artistService) DeleteArtist(ctx context.Context, id model.MusicArtistId
 	return nil
 }
 
+// //////////////////////////////////////////////////
+// suggest duplicates
+
+// SuggestArtistDuplicates groups the artists whose normalized names are at least similar to the given percentage.
+func (s *artistService) SuggestArtistDuplicates(ctx context.Context, minSimilarity int) ([]*model.MusicArtistDuplicate, error) {
+
+	var duplicates []*model.MusicArtistDuplicate
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		artists := s.artistStore.List(ctx, tx, nil)
+		nbMusics := s.musicStore.CountByArtist(ctx, tx)
+		for _, artist := range artists {
+			artist.NbMusic = nbMusics[artist.Id]
+		}
+		duplicates = model.FindArtistDuplicates(artists, minSimilarity)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] suggest artist duplicates ( similarity: %d%% )", minSimilarity), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] suggest %d artist duplicates ( similarity: %d%% )", len(duplicates), minSimilarity))
+	return duplicates, nil
+}
+
+// //////////////////////////////////////////////////
+// merge artists
+
+// MergeArtists moves the musics and contributions of the duplicates to the surviving artist, completes its deezer id and image, then deletes the duplicates.
+// a music of a duplicate with the title of a music of the surviving artist is merged into the latter.
+func (s *artistService) MergeArtists(ctx context.Context, id model.MusicArtistId, duplicateIds []model.MusicArtistId) (*model.MusicArtist, error) {
+
+	//
+	// validate
+	//
+
+	if len(duplicateIds) == 0 {
+		return nil, model.ErrMissingDuplicate
+	}
+	if util.Contains(duplicateIds, id) {
+		return nil, model.ErrInvalidDuplicate
+	}
+
+	//
+	// merge
+	//
+
+	var artist *model.MusicArtist
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve artist %d", id))
+		artist = s.artistStore.Retrieve(ctx, tx, id)
+
+		for _, duplicateId := range duplicateIds {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve duplicate artist %d", duplicateId))
+			duplicate := s.artistStore.Retrieve(ctx, tx, duplicateId)
+			artist.MergeWith(duplicate)
+
+			for _, music := range s.musicStore.List(ctx, tx, &model.MusicFilter{ArtistId: duplicateId}) {
+				if other := s.musicStore.SearchByIdentity(ctx, tx, id, music.Name); other != nil {
+					mergeMusic(ctx, tx, s.logger, s.musicStore, s.contributorStore, s.genreStore, s.themeQuestionStore, other, music)
+				}
+			}
+			nb := s.musicStore.ReassignArtist(ctx, tx, duplicateId, id)
+			s.logger.Info(fmt.Sprintf("[DEBUG] reassign %d musics of artist %d to artist %d", nb, duplicateId, id))
+			s.contributorStore.ReassignArtist(ctx, tx, duplicateId, id)
+
+			s.logger.Info(fmt.Sprintf("[DEBUG] delete duplicate artist %d", duplicateId))
+			s.artistStore.Delete(ctx, tx, duplicateId)
+		}
+
+		artist = s.artistStore.Update(ctx, tx, artist)
+		artist.Musics = s.musicStore.List(ctx, tx, &model.MusicFilter{ArtistId: artist.Id})
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] merge artists %v into artist %d", duplicateIds, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] merge artists %v into artist %d - %s", duplicateIds, artist.Id, artist.Name), zap.Object("artist", artist))
+	return artist, nil
+}
+
 // //////////////////////////////////////////////////
 // download remote files
 
@@ -0,0 +1,69 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store/memory"
+)
+
+func TestMergeArtistsWithClashingTitles(t *testing.T) {
+	db := newTestDb(t)
+	stores := newTestGameStores()
+	genreStore := memory.NewMusicGenreMemoryStore()
+	artistService := service.NewArtistService(zap.NewNop(), nil, db, stores.artist, stores.music, stores.contributor, genreStore, stores.themeQuestion, nil)
+	ctx := context.Background()
+
+	beatles := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: "The Beatles"})
+	duplicate := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: "Beatles", DeezerId: 42})
+	lennon := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: "John Lennon"})
+	rock := genreStore.Create(ctx, nil, &model.MusicGenre{Name: "Rock"})
+
+	help := stores.music.Create(ctx, nil, &model.Music{Name: "Help!", Mp3Url: "help.mp3", ArtistId: beatles.Id})
+	helpDuplicate := stores.music.Create(ctx, nil, &model.Music{Name: "help", Mp3Url: "help-2.mp3", ArtistId: duplicate.Id, DeezerId: 7, Year: 1965})
+	yesterday := stores.music.Create(ctx, nil, &model.Music{Name: "Yesterday", Mp3Url: "yesterday.mp3", ArtistId: duplicate.Id})
+	stores.contributor.Link(ctx, nil, help.Id, &model.MusicContributor{ArtistId: beatles.Id, Role: model.MusicArtistRole_Main})
+	stores.contributor.Link(ctx, nil, helpDuplicate.Id, &model.MusicContributor{ArtistId: duplicate.Id, Role: model.MusicArtistRole_Main})
+	stores.contributor.Link(ctx, nil, helpDuplicate.Id, &model.MusicContributor{ArtistId: lennon.Id, Role: model.MusicArtistRole_Composer})
+	genreStore.LinkMusic(ctx, nil, rock.Id, helpDuplicate.Id)
+
+	sixties := stores.theme.Create(ctx, nil, &model.Theme{Title: "60s"})
+	movies := stores.theme.Create(ctx, nil, &model.Theme{Title: "movies"})
+	stores.themeQuestion.Create(ctx, nil, &model.ThemeQuestion{ThemeId: sixties.Id, MusicId: help.Id, Text: "The Beatles"})
+	stores.themeQuestion.Create(ctx, nil, &model.ThemeQuestion{ThemeId: sixties.Id, MusicId: helpDuplicate.Id, Text: "Beatles"})
+	moviesQuestion := stores.themeQuestion.Create(ctx, nil, &model.ThemeQuestion{ThemeId: movies.Id, MusicId: helpDuplicate.Id, Text: "Beatles"})
+
+	merged, err := artistService.MergeArtists(ctx, beatles.Id, []model.MusicArtistId{duplicate.Id})
+	require.NoError(t, err)
+	require.Equal(t, model.DeezerArtistId(42), merged.DeezerId)
+	require.ElementsMatch(t, []model.MusicId{help.Id, yesterday.Id}, []model.MusicId{merged.Musics[0].Id, merged.Musics[1].Id})
+
+	// the clashing music is merged into the music of the surviving artist
+	require.Panics(t, func() { stores.music.Retrieve(ctx, nil, helpDuplicate.Id) })
+	survivor := stores.music.Retrieve(ctx, nil, help.Id)
+	require.Equal(t, model.Url("help.mp3"), survivor.Mp3Url)
+	require.Equal(t, model.DeezerMusicId(7), survivor.DeezerId)
+	require.Equal(t, 1965, survivor.Year)
+
+	// its questions, contributors and genres now refer to the surviving music
+	require.Len(t, stores.themeQuestion.List(ctx, nil, &model.ThemeQuestionFilter{ThemeIds: []model.ThemeId{sixties.Id}}), 1, "theme already asking the surviving music")
+	require.Equal(t, help.Id, stores.themeQuestion.Retrieve(ctx, nil, moviesQuestion.Id).MusicId)
+	require.Empty(t, stores.themeQuestion.List(ctx, nil, &model.ThemeQuestionFilter{MusicId: helpDuplicate.Id}))
+	roles := map[model.MusicArtistId]model.MusicArtistRole{}
+	for _, contributor := range stores.contributor.ListByMusic(ctx, nil, help.Id) {
+		roles[contributor.ArtistId] = contributor.Role
+	}
+	require.Equal(t, map[model.MusicArtistId]model.MusicArtistRole{
+		beatles.Id: model.MusicArtistRole_Main,
+		lennon.Id:  model.MusicArtistRole_Composer,
+	}, roles)
+	require.Empty(t, stores.contributor.ListByMusic(ctx, nil, helpDuplicate.Id))
+	require.Len(t, genreStore.ListByMusic(ctx, nil, help.Id), 1)
+	require.Empty(t, genreStore.ListByMusic(ctx, nil, helpDuplicate.Id))
+	require.Equal(t, beatles.Id, stores.music.Retrieve(ctx, nil, yesterday.Id).ArtistId)
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// daily challenge service
+
+type DailyChallengeService interface {
+	RetrieveChallenge(ctx context.Context, date model.DailyChallengeDate) (*model.DailyChallenge, error)
+	SubmitRun(ctx context.Context, date model.DailyChallengeDate, answers map[model.GameQuestionId]model.GameAnswerId) (*model.DailyChallengeRun, error)
+	ListLeaderboard(ctx context.Context, date model.DailyChallengeDate) ([]*model.DailyChallengeRun, error)
+	StartScheduler(ctx context.Context)
+}
+
+func NewDailyChallengeService(logger *zap.Logger, db *sql.DB, settings *model.DailyChallengeSettings, gameService GameService, dailyChallengeStore store.DailyChallengeStore, dailyChallengeRunStore store.DailyChallengeRunStore) DailyChallengeService {
+	return &dailyChallengeService{
+		logger:                 logger,
+		db:                     db,
+		settings:               settings,
+		gameService:            gameService,
+		dailyChallengeStore:    dailyChallengeStore,
+		dailyChallengeRunStore: dailyChallengeRunStore,
+		challenges:             make(map[model.DailyChallengeDate]*model.DailyChallenge),
+		now:                    time.Now,
+	}
+}
+
+type dailyChallengeService struct {
+	logger                 *zap.Logger
+	db                     *sql.DB
+	settings               *model.DailyChallengeSettings
+	gameService            GameService
+	dailyChallengeStore    store.DailyChallengeStore
+	dailyChallengeRunStore store.DailyChallengeRunStore
+
+	mutex      sync.Mutex
+	challenges map[model.DailyChallengeDate]*model.DailyChallenge
+	now        func() time.Time
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (s *dailyChallengeService) RetrieveChallenge(ctx context.Context, date model.DailyChallengeDate) (*model.DailyChallenge, error) {
+
+	today := model.NewDailyChallengeDate(s.now())
+	if date == "" {
+		date = today
+	} else if date > today {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve daily challenge %s", date), zap.Error(model.ErrInvalidDailyChallengeDate))
+		return nil, model.ErrInvalidDailyChallengeDate
+	}
+
+	challenge, err := s.prepareChallenge(ctx, date)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve daily challenge %s", date), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve daily challenge %s", date), zap.Object("challenge", challenge))
+	return challenge, nil
+}
+
+// prepareChallenge returns the challenge of the day from the cache or the store, generating it from the stored ( or configured ) settings when missing.
+// the generated game is stored along with the settings so that the questions of a day never change, even when the catalog does.
+func (s *dailyChallengeService) prepareChallenge(ctx context.Context, date model.DailyChallengeDate) (*model.DailyChallenge, error) {
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if challenge, found := s.challenges[date]; found {
+		return challenge, nil
+	}
+
+	//
+	// stored challenge
+	//
+
+	var challenge *model.DailyChallenge
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.logger.Info(fmt.Sprintf("[DEBUG] search daily challenge %s", date))
+		challenge = s.dailyChallengeStore.SearchByDate(ctx, tx, date)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if challenge != nil && challenge.Game != nil {
+		s.challenges[date] = challenge
+		return challenge, nil
+	}
+
+	//
+	// generate game: stored or configured settings
+	//
+
+	if challenge == nil {
+		challenge = &model.DailyChallenge{
+			Date:     date,
+			Settings: s.settings.ToGameSettings(date),
+		}
+		if err := challenge.Settings.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	game, err := s.gameService.GenerateGame(ctx, date.GameId(), *challenge.Settings)
+	if err != nil {
+		return nil, err
+	}
+	challenge.Game = game
+
+	//
+	// store: the first generated game wins
+	//
+
+	err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		stored := s.dailyChallengeStore.SearchByDate(ctx, tx, date)
+		switch {
+		case stored == nil:
+			s.logger.Info(fmt.Sprintf("[DEBUG] create daily challenge %s", date), zap.Object("challenge", challenge))
+			s.dailyChallengeStore.Create(ctx, tx, challenge)
+		case stored.Game == nil:
+			s.logger.Info(fmt.Sprintf("[DEBUG] update daily challenge %s", date), zap.Object("challenge", challenge))
+			s.dailyChallengeStore.Update(ctx, tx, challenge)
+		default:
+			challenge = stored
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.challenges[date] = challenge
+	return challenge, nil
+}
+
+// //////////////////////////////////////////////////
+// submit
+
+func (s *dailyChallengeService) SubmitRun(ctx context.Context, date model.DailyChallengeDate, answers map[model.GameQuestionId]model.GameAnswerId) (*model.DailyChallengeRun, error) {
+
+	//
+	// date: today or yesterday within the grace period
+	//
+
+	now := s.now()
+	if !date.CanSubmitAt(now) {
+		s.logger.Info(fmt.Sprintf("[ KO ] submit daily challenge run %s", date), zap.Error(model.ErrInvalidDailyChallengeDate))
+		return nil, model.ErrInvalidDailyChallengeDate
+	}
+
+	//
+	// current user
+	//
+
+	currentUser := model.GetCurrentUser(ctx)
+	if currentUser == nil {
+		return nil, model.ErrSessionNotFound
+	}
+
+	//
+	// score run
+	//
+
+	challenge, err := s.prepareChallenge(ctx, date)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] submit daily challenge run %s", date), zap.Error(err))
+		return nil, err
+	}
+
+	run, err := challenge.Play(answers)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] submit daily challenge run %s", date), zap.Error(err))
+		return nil, err
+	}
+	run.UserId = currentUser.Id
+	run.UserName = currentUser.Name
+	run.SubmittedAt = now
+
+	//
+	// store run: one per user and per day
+	//
+
+	var created *model.DailyChallengeRun
+	err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		if s.dailyChallengeRunStore.Exists(ctx, tx, date, currentUser.Id) {
+			panic(model.ErrDailyChallengeAlreadyPlayed)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] create daily challenge run %s", date), zap.Object("run", run))
+		created = s.dailyChallengeRunStore.Create(ctx, tx, run)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] submit daily challenge run %s", date), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] submit daily challenge run %s", date), zap.Object("run", created))
+	return created, nil
+}
+
+// //////////////////////////////////////////////////
+// leaderboard
+
+func (s *dailyChallengeService) ListLeaderboard(ctx context.Context, date model.DailyChallengeDate) ([]*model.DailyChallengeRun, error) {
+
+	if date == "" {
+		date = model.NewDailyChallengeDate(s.now())
+	}
+
+	var runs []*model.DailyChallengeRun
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.logger.Info(fmt.Sprintf("[DEBUG] list daily challenge runs %s", date))
+		runs = s.dailyChallengeRunStore.ListByDate(ctx, tx, date)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] list daily challenge leaderboard %s", date), zap.Error(err))
+		return nil, err
+	}
+
+	//
+	// rank: same score => same rank
+	//
+
+	for index, run := range runs {
+		if index > 0 && run.Score == runs[index-1].Score {
+			run.Rank = runs[index-1].Rank
+		} else {
+			run.Rank = index + 1
+		}
+	}
+
+	s.logger.Info(fmt.Sprintf("[ OK ] list daily challenge leaderboard %s", date), zap.Int("nb-runs", len(runs)))
+	return runs, nil
+}
+
+// //////////////////////////////////////////////////
+// scheduler
+
+// StartScheduler prepares the challenges of today and tomorrow in the background so that they are ready at midnight.
+func (s *dailyChallengeService) StartScheduler(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			today := model.NewDailyChallengeDate(s.now())
+			for _, date := range []model.DailyChallengeDate{today, today.Next()} {
+				if _, err := s.prepareChallenge(ctx, date); err != nil {
+					s.logger.Info(fmt.Sprintf("[ KO ] prepare daily challenge %s", date), zap.Error(err))
+				}
+			}
+			s.cleanup(today)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// cleanup removes past challenges from the cache.
+func (s *dailyChallengeService) cleanup(today model.DailyChallengeDate) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for date := range s.challenges {
+		if date < today {
+			delete(s.challenges, date)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+func newTestDailyChallengeService(t *testing.T) (service.DailyChallengeService, *sql.DB, *testGameStores) {
+	db := newTestDb(t, "00005_daily_challenge", "00014_daily_challenge_game")
+	stores := newTestGameStores()
+	theme := seedTestTheme(t, stores, "daily", 10)
+	settings := &model.DailyChallengeSettings{
+		ThemeIds:   []model.ThemeId{theme.Id},
+		NbQuestion: 5,
+		NbAnswer:   4,
+	}
+	challengeService := service.NewDailyChallengeService(zap.NewNop(), db, settings, newTestGameService(db, stores), store.NewDailyChallengeStore(zap.NewNop()), store.NewDailyChallengeRunStore(zap.NewNop()))
+	return challengeService, db, stores
+}
+
+func correctAnswers(challenge *model.DailyChallenge) map[model.GameQuestionId]model.GameAnswerId {
+	answers := make(map[model.GameQuestionId]model.GameAnswerId)
+	for _, question := range challenge.Game.Questions {
+		for _, answer := range question.Answers {
+			if answer.Correct {
+				answers[question.Id] = answer.Id
+			}
+		}
+	}
+	return answers
+}
+
+func TestDailyChallengeSubmitAcrossMidnight(t *testing.T) {
+	challengeService, _, _ := newTestDailyChallengeService(t)
+	ctx := context.Background()
+
+	// the run is started before midnight...
+	beforeMidnight := time.Date(2024, 12, 31, 23, 55, 0, 0, time.UTC)
+	service.SetDailyChallengeClock(challengeService, func() time.Time { return beforeMidnight })
+	challenge, err := challengeService.RetrieveChallenge(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, model.DailyChallengeDate("2024-12-31"), challenge.Date)
+	require.Len(t, challenge.Game.Questions, 5)
+	answers := correctAnswers(challenge)
+
+	// ... and submitted after midnight, within the grace period
+	afterMidnight := beforeMidnight.Add(10 * time.Minute)
+	service.SetDailyChallengeClock(challengeService, func() time.Time { return afterMidnight })
+	run, err := challengeService.SubmitRun(withTestUser(ctx, 1, "alice"), challenge.Date, answers)
+	require.NoError(t, err)
+	require.Equal(t, challenge.Date, run.Date)
+	require.Equal(t, 5, run.NbCorrect)
+
+	// too late for the previous day
+	tooLate := beforeMidnight.Add(2 * time.Hour)
+	service.SetDailyChallengeClock(challengeService, func() time.Time { return tooLate })
+	_, err = challengeService.SubmitRun(withTestUser(ctx, 2, "bob"), challenge.Date, answers)
+	require.ErrorIs(t, err, model.ErrInvalidDailyChallengeDate)
+
+	// never in advance
+	service.SetDailyChallengeClock(challengeService, func() time.Time { return beforeMidnight })
+	_, err = challengeService.SubmitRun(withTestUser(ctx, 2, "bob"), challenge.Date.Next(), answers)
+	require.ErrorIs(t, err, model.ErrInvalidDailyChallengeDate)
+
+	// the answers of a challenge do not score another one
+	service.SetDailyChallengeClock(challengeService, func() time.Time { return afterMidnight })
+	_, err = challengeService.SubmitRun(withTestUser(ctx, 2, "bob"), challenge.Date.Next(), answers)
+	require.Error(t, err)
+}
+
+func TestDailyChallengeStoredGame(t *testing.T) {
+	challengeService, db, stores := newTestDailyChallengeService(t)
+	ctx := context.Background()
+
+	now := time.Date(2024, 12, 31, 12, 0, 0, 0, time.UTC)
+	service.SetDailyChallengeClock(challengeService, func() time.Time { return now })
+	challenge, err := challengeService.RetrieveChallenge(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, challenge.Date.GameId(), challenge.Game.Id)
+
+	// another server ( without cache ) serves the stored questions even if the catalog changed
+	seedTestTheme(t, stores, "later", 10)
+	other := service.NewDailyChallengeService(zap.NewNop(), db, &model.DailyChallengeSettings{NbQuestion: 5, NbAnswer: 4}, nil, store.NewDailyChallengeStore(zap.NewNop()), store.NewDailyChallengeRunStore(zap.NewNop()))
+	service.SetDailyChallengeClock(other, func() time.Time { return now })
+	stored, err := other.RetrieveChallenge(ctx, challenge.Date)
+	require.NoError(t, err)
+	require.Equal(t, len(challenge.Game.Questions), len(stored.Game.Questions))
+	for index, question := range challenge.Game.Questions {
+		require.Equal(t, question.Id, stored.Game.Questions[index].Id)
+		require.Equal(t, question.Music.Name, stored.Game.Questions[index].Music.Name)
+		require.Equal(t, len(question.Answers), len(stored.Game.Questions[index].Answers))
+	}
+
+	run, err := other.SubmitRun(withTestUser(ctx, 1, "alice"), challenge.Date, correctAnswers(challenge))
+	require.NoError(t, err)
+	require.Equal(t, 5, run.NbCorrect)
+}
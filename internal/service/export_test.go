@@ -0,0 +1,8 @@
+package service
+
+import "time"
+
+// SetDailyChallengeClock replaces the current time of the daily challenge service.
+func SetDailyChallengeClock(s DailyChallengeService, now func() time.Time) {
+	s.(*dailyChallengeService).now = now
+}
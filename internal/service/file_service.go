@@ -2,9 +2,12 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/gre-ory/amnezic-go/internal/model"
 	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
 	"go.uber.org/zap"
 )
 
@@ -13,6 +16,9 @@ import (
 
 type FileService interface {
 	List(ctx context.Context, filter *model.FileFilter) ([]model.Url, error)
+	Excerpt(ctx context.Context, filter *model.FileFilter, path string, excerpt model.AudioExcerpt) ([]byte, error)
+	UploadMusic(ctx context.Context, filter *model.FileFilter, filename string, data []byte) (model.Url, error)
+	UploadImage(ctx context.Context, filter *model.FileFilter, filename string, data []byte) (model.Url, error)
 }
 
 func NewFileService(logger *zap.Logger, fileStore store.FileStore) FileService {
@@ -33,3 +39,77 @@ type fileService struct {
 func (s *fileService) List(ctx context.Context, filter *model.FileFilter) ([]model.Url, error) {
 	return s.fileStore.List(ctx, filter)
 }
+
+// //////////////////////////////////////////////////
+// excerpt
+
+// Excerpt cuts the local mp3 on frame boundaries.
+func (s *fileService) Excerpt(ctx context.Context, filter *model.FileFilter, path string, excerpt model.AudioExcerpt) ([]byte, error) {
+	if err := excerpt.Validate(); err != nil {
+		return nil, err
+	}
+	cleaned, err := util.CleanLocalPath(path)
+	if err != nil {
+		return nil, err
+	}
+	data, err := s.fileStore.Read(ctx, filter, cleaned)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] excerpt %q", path), zap.Error(err))
+		return nil, err
+	}
+	trimmed, err := util.TrimMp3(data, excerpt.Start, excerpt.Duration)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] excerpt %q", path), zap.Object("excerpt", excerpt), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] excerpt %q: %d / %d bytes", path, len(trimmed), len(data)), zap.Object("excerpt", excerpt))
+	return trimmed, nil
+}
+
+// //////////////////////////////////////////////////
+// upload
+
+// UploadMusic writes an uploaded mp3 into the music directory and returns its url.
+func (s *fileService) UploadMusic(ctx context.Context, filter *model.FileFilter, filename string, data []byte) (model.Url, error) {
+	return s.upload(ctx, filter, filename, data, model.MusicUploadMaxSize, func(contentType string) bool {
+		return contentType == util.Mp3ContentType
+	})
+}
+
+// UploadImage writes an uploaded image into the image directory and returns its url.
+func (s *fileService) UploadImage(ctx context.Context, filter *model.FileFilter, filename string, data []byte) (model.Url, error) {
+	return s.upload(ctx, filter, filename, data, model.ImageUploadMaxSize, func(contentType string) bool {
+		return strings.HasPrefix(contentType, "image/")
+	})
+}
+
+func (s *fileService) upload(ctx context.Context, filter *model.FileFilter, filename string, data []byte, maxSize int, isValidContentType func(contentType string) bool) (model.Url, error) {
+	fileName, err := filter.ToFileName(filename)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] upload %q", filename), zap.Error(err))
+		return "", err
+	}
+	if len(data) > maxSize {
+		err = model.ErrFileTooLarge
+		s.logger.Info(fmt.Sprintf("[ KO ] upload %q ( %d bytes )", filename, len(data)), zap.Error(err))
+		return "", err
+	}
+	// the extension is not trusted: the content must match
+	contentType := util.DetectContentType(data)
+	if !isValidContentType(contentType) {
+		err = model.ErrInvalidContentType(contentType)
+	} else if !util.MatchContentType(string(fileName), contentType) {
+		err = model.ErrMismatchingContentType(contentType, fileName)
+	}
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] upload %q", filename), zap.Error(err))
+		return "", err
+	}
+	err = s.fileStore.Write(ctx, filter, string(fileName), data)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] upload %q", filename), zap.Error(err))
+		return "", err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] upload %q to %s ( %s, %d bytes )", filename, fileName, contentType, len(data)))
+	return fileName, nil
+}
@@ -0,0 +1,60 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+func TestUploadFiles(t *testing.T) {
+	fileStore := store.NewFileStore(zap.NewNop())
+	fileService := service.NewFileService(zap.NewNop(), fileStore)
+	musicFilter := &model.FileFilter{Directory: t.TempDir(), Extensions: []string{".mp3"}}
+	imageFilter := &model.FileFilter{Directory: t.TempDir(), Extensions: []string{".jpg", ".png"}}
+	ctx := context.Background()
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR")
+	jpg := []byte("\xFF\xD8\xFF\xE0\x00\x10JFIF\x00")
+	mp3 := newTestMp3("Hurt", "Johnny Cash", "American IV", nil)
+
+	// the sniffed content must match the sanitized extension
+	url, err := fileService.UploadImage(ctx, imageFilter, "My Cover.PNG", png)
+	require.NoError(t, err)
+	require.Equal(t, model.Url("my-cover.png"), url)
+	_, err = fileService.UploadImage(ctx, imageFilter, "cover.jpg", png)
+	require.EqualError(t, err, model.ErrMismatchingContentType("image/png", "cover.jpg").Error())
+	url, err = fileService.UploadImage(ctx, imageFilter, "cover.jpg", jpg)
+	require.NoError(t, err)
+	require.Equal(t, model.Url("cover.jpg"), url)
+	_, err = fileService.UploadImage(ctx, imageFilter, "hurt.png", mp3)
+	require.EqualError(t, err, model.ErrInvalidContentType("audio/mpeg").Error())
+	_, err = fileService.UploadImage(ctx, imageFilter, "cover.gif", []byte("GIF89a"))
+	require.ErrorIs(t, err, model.ErrInvalidExtension)
+
+	url, err = fileService.UploadMusic(ctx, musicFilter, "hurt.mp3", mp3)
+	require.NoError(t, err)
+	require.Equal(t, model.Url("hurt.mp3"), url)
+	_, err = fileService.UploadMusic(ctx, musicFilter, "cover.mp3", png)
+	require.EqualError(t, err, model.ErrInvalidContentType("image/png").Error())
+
+	// the size is limited
+	_, err = fileService.UploadImage(ctx, imageFilter, "large.png", append(png, bytes.Repeat([]byte{0}, model.ImageUploadMaxSize)...))
+	require.ErrorIs(t, err, model.ErrFileTooLarge)
+	_, err = fileService.UploadMusic(ctx, musicFilter, "large.mp3", append(mp3, bytes.Repeat([]byte{0}, model.MusicUploadMaxSize)...))
+	require.ErrorIs(t, err, model.ErrFileTooLarge)
+
+	// only the accepted files are written
+	images, err := fileStore.List(ctx, imageFilter)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []model.Url{"my-cover.png", "cover.jpg"}, images)
+	musics, err := fileStore.List(ctx, musicFilter)
+	require.NoError(t, err)
+	require.Equal(t, []model.Url{"hurt.mp3"}, musics)
+}
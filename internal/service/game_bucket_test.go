@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+// countingContributorStore counts the musics whose contributors are loaded.
+type countingContributorStore struct {
+	store.MusicContributorStore
+	musicIds map[model.MusicId]bool
+}
+
+func (s *countingContributorStore) ListByMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId) []*model.MusicContributor {
+	s.musicIds[musicId] = true
+	return s.MusicContributorStore.ListByMusic(ctx, tx, musicId)
+}
+
+func TestBucketQuestionsLoadSelectedMusics(t *testing.T) {
+	db := newTestDb(t)
+	stores := newTestGameStores()
+	contributorStore := &countingContributorStore{MusicContributorStore: stores.contributor, musicIds: map[model.MusicId]bool{}}
+	stores.contributor = contributorStore
+
+	ctx := context.Background()
+	for number := 1; number <= 50; number++ {
+		artist := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: fmt.Sprintf("artist %d", number)})
+		stores.music.Create(ctx, nil, &model.Music{
+			Name:     fmt.Sprintf("music %d", number),
+			Mp3Url:   model.Url(fmt.Sprintf("music-%d.mp3", number)),
+			ArtistId: artist.Id,
+			Year:     1980 + number%10,
+		})
+	}
+
+	game, err := newTestGameService(db, stores).CreateGame(ctx, model.GameSettings{
+		Seed:       1,
+		NbQuestion: 3,
+		NbAnswer:   4,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Decade},
+		Decade:     1980,
+	})
+	require.NoError(t, err)
+	require.Len(t, game.Questions, 3)
+
+	// the selected musics and, at most, 3 wrong answers per question
+	loaded := len(contributorStore.musicIds)
+	require.GreaterOrEqual(t, loaded, 3)
+	require.LessOrEqual(t, loaded, 3+3*3)
+	for _, question := range game.Questions {
+		require.Equal(t, "1980s", question.Theme.Title)
+		require.Len(t, question.Answers, 4)
+		for _, answer := range question.Answers {
+			require.NotEmpty(t, answer.Text)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+func TestGamePhaseEvents(t *testing.T) {
+	db := newTestDb(t, "00015_game_event", "00016_game_sequence")
+	stores := newTestGameStores()
+	stores.gameEvent = store.NewGameEventStore(zap.NewNop())
+	theme := seedTestTheme(t, stores, "phase", 6)
+	gameService := newTestGameService(db, stores)
+	ctx := context.Background()
+
+	round := func() *model.GameRoundSettings {
+		return &model.GameRoundSettings{NbQuestion: 2, NbAnswer: 2, Sources: []model.Source{model.Source_Store}, ThemeIds: []model.ThemeId{theme.Id}}
+	}
+	game, err := gameService.CreateGame(ctx, model.GameSettings{
+		Seed:     1,
+		NbPlayer: 2,
+		Rounds:   []*model.GameRoundSettings{round(), round()},
+	})
+	require.NoError(t, err)
+	require.Len(t, game.Questions, 4)
+	require.Equal(t, model.GamePhase_NotStarted, game.Phase())
+
+	// each transition is logged once, right after the event causing it
+	_, err = gameService.RevealQuestion(ctx, game.Id, game.Questions[0].Id)
+	require.NoError(t, err)
+	_, err = gameService.RevealQuestion(ctx, game.Id, game.Questions[1].Id)
+	require.NoError(t, err)
+	_, err = gameService.RevealQuestion(ctx, game.Id, game.Questions[2].Id)
+	require.NoError(t, err)
+	finished, err := gameService.FinishGame(ctx, game.Id)
+	require.NoError(t, err)
+
+	timeline, err := gameService.RetrieveTimeline(ctx, game.Id, 0)
+	require.NoError(t, err)
+	var phases []model.GamePhase
+	for index, event := range timeline.Events {
+		require.Equal(t, index+1, event.Sequence)
+		if event.Type == model.GameEventType_PhaseChanged {
+			require.NotEqual(t, model.GameEventType_PhaseChanged, timeline.Events[index-1].Type)
+			phases = append(phases, event.PreviousPhase, event.Phase)
+		}
+	}
+	require.Equal(t, []model.GamePhase{
+		model.GamePhase_NotStarted, model.NewGameRoundPhase(1),
+		model.NewGameRoundPhase(1), model.NewGameRoundPhase(2),
+		model.NewGameRoundPhase(2), model.GamePhase_Finished,
+	}, phases)
+
+	// the stored events replay the game
+	require.Equal(t, model.GamePhase_Finished, timeline.Game.Phase())
+	require.Equal(t, len(finished.Questions), len(timeline.Game.Questions))
+	for index, question := range finished.Questions {
+		require.Equal(t, question.Revealed, timeline.Game.Questions[index].Revealed)
+		require.Equal(t, question.Music.Name, timeline.Game.Questions[index].Music.Name)
+	}
+	require.Len(t, timeline.Game.Players, 2)
+
+	// the events of a deleted game are kept, but the game is no longer found
+	require.NoError(t, gameService.DeleteGame(ctx, game.Id))
+	_, err = gameService.RetrieveTimeline(ctx, game.Id, 0)
+	require.ErrorIs(t, err, model.ErrGameNotFound)
+	var nbEvents int
+	require.NoError(t, db.QueryRow("SELECT count(*) FROM game_event WHERE game_id = $1", int64(game.Id)).Scan(&nbEvents))
+	require.Equal(t, len(timeline.Events)+1, nbEvents)
+
+	// the id of a deleted game is never reused
+	other, err := gameService.CreateGame(ctx, model.GameSettings{
+		Seed:     1,
+		NbPlayer: 2,
+		Rounds:   []*model.GameRoundSettings{round()},
+	})
+	require.NoError(t, err)
+	require.Greater(t, other.Id, game.Id)
+}
+
+func TestRetrieveGameReplaysEvents(t *testing.T) {
+	db := newTestDb(t, "00015_game_event", "00016_game_sequence")
+	stores := newTestGameStores()
+	stores.gameEvent = store.NewGameEventStore(zap.NewNop())
+	theme := seedTestTheme(t, stores, "replay", 6)
+	gameService := newTestGameService(db, stores)
+	ctx := context.Background()
+
+	game, err := gameService.CreateGame(ctx, model.GameSettings{
+		Seed:       1,
+		NbQuestion: 3,
+		NbAnswer:   2,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Store},
+		ThemeIds:   []model.ThemeId{theme.Id},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, game.HostToken)
+
+	// the replayed game is the created one
+	initial, err := gameService.RetrieveGame(ctx, game.Id)
+	require.NoError(t, err)
+	require.Equal(t, store.EncodeGameJson(game), store.EncodeGameJson(initial))
+	require.Equal(t, game.Players, initial.Players)
+
+	question := game.Questions[0]
+	var correct model.GameAnswerId
+	for _, answer := range question.Answers {
+		if answer.Correct {
+			correct = answer.Id
+		}
+	}
+	_, err = gameService.RenamePlayer(ctx, game.Id, game.Players[0].Id, "alice")
+	require.NoError(t, err)
+	_, err = gameService.AnswerQuestion(ctx, game.Id, question.Id, game.Players[0].Id, correct)
+	require.NoError(t, err)
+	revealed, err := gameService.RevealQuestion(ctx, game.Id, question.Id)
+	require.NoError(t, err)
+
+	// another service on the same database ( e.g. after a restart ) rebuilds the game from its events
+	retrieved, err := newTestGameService(db, stores).RetrieveGame(ctx, game.Id)
+	require.NoError(t, err)
+	require.Equal(t, revealed, retrieved)
+	require.Equal(t, game.HostToken, retrieved.HostToken)
+	require.Equal(t, "alice", retrieved.Players[0].Name)
+	require.Positive(t, retrieved.Players[0].Score)
+	require.True(t, retrieved.Questions[0].Revealed)
+
+	// the owner is kept as well
+	owned, err := gameService.CreateGame(withTestUser(ctx, 7, "owner"), model.GameSettings{
+		Seed:       1,
+		NbQuestion: 3,
+		NbAnswer:   2,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Store},
+		ThemeIds:   []model.ThemeId{theme.Id},
+	})
+	require.NoError(t, err)
+	retrieved, err = gameService.RetrieveGame(ctx, owned.Id)
+	require.NoError(t, err)
+	require.True(t, retrieved.IsOwnedBy(7))
+	require.Empty(t, retrieved.HostToken)
+
+	_, err = gameService.RetrieveGame(ctx, model.NewGameId(99))
+	require.ErrorIs(t, err, model.ErrGameNotFound)
+}
@@ -0,0 +1,412 @@
+package service
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/client"
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// game pack service
+
+type GamePackService interface {
+	ExportGame(ctx context.Context, id model.GameId, writer io.Writer) error
+	ImportGame(ctx context.Context, reader io.ReaderAt, size int64) (*model.Game, error)
+}
+
+func NewGamePackService(logger *zap.Logger, gameService GameService, downloadClient client.DownloadClient, musicFilter *model.FileFilter, imageFilter *model.FileFilter) GamePackService {
+	return &gamePackService{
+		logger:         logger,
+		gameService:    gameService,
+		downloadClient: downloadClient,
+		musicFilter:    musicFilter,
+		imageFilter:    imageFilter,
+	}
+}
+
+type gamePackService struct {
+	logger         *zap.Logger
+	gameService    GameService
+	downloadClient client.DownloadClient
+	musicFilter    *model.FileFilter
+	imageFilter    *model.FileFilter
+}
+
+// //////////////////////////////////////////////////
+// export
+
+// gamePackSource is a media to copy into the pack, from a local file or a remote url.
+type gamePackSource struct {
+	file *model.GamePackFile
+	url  model.Url
+}
+
+// ExportGame writes a zip with the game, its medias and a manifest.
+// remote medias are downloaded into the pack so that the game only references files of the pack.
+func (s *gamePackService) ExportGame(ctx context.Context, id model.GameId, writer io.Writer) error {
+
+	game, err := s.gameService.RetrieveGame(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	manifest := &model.GamePackManifest{
+		Format:     model.GamePackFormat,
+		GameId:     game.Id,
+		ExportedAt: time.Now(),
+	}
+	packed, sources := s.packGame(game)
+
+	zipWriter := zip.NewWriter(writer)
+
+	//
+	// medias
+	//
+
+	for _, source := range sources {
+		entry, err := zipWriter.Create(source.file.Path())
+		if err != nil {
+			return err
+		}
+		counter := &gamePackCounter{writer: entry}
+		if err := s.copySource(source, counter); err != nil {
+			s.logger.Info(fmt.Sprintf("[ KO ] pack file %s <<< %q", source.file.Path(), source.url), zap.Error(err))
+			return err
+		}
+		source.file.Size = counter.size
+		manifest.Files = append(manifest.Files, source.file)
+		s.logger.Info(fmt.Sprintf("[DEBUG] pack file %s <<< %q", source.file, source.url))
+	}
+
+	//
+	// game and manifest
+	//
+
+	// only the content of the game is packed: neither its owner, host token, players nor progress
+	if err := writeGamePackJson(zipWriter, model.GamePackGamePath, store.EncodeGameJson(packed)); err != nil {
+		return err
+	}
+	if err := writeGamePackJson(zipWriter, model.GamePackManifestPath, manifest); err != nil {
+		return err
+	}
+	if err := zipWriter.Close(); err != nil {
+		return err
+	}
+
+	s.logger.Info(fmt.Sprintf("[ OK ] export game %d", id), zap.Object("manifest", manifest))
+	return nil
+}
+
+// packGame returns a copy of the game referencing the files of the pack, with the sources of these files.
+func (s *gamePackService) packGame(game *model.Game) (*model.Game, []*gamePackSource) {
+
+	sources := make([]*gamePackSource, 0)
+	names := make(map[model.Url]model.Url)
+	pack := func(kind model.GamePackFileKind, url model.Url, remoteName model.Url) model.Url {
+		if url.IsEmpty() {
+			return url
+		}
+		if name, found := names[url]; found {
+			return name
+		}
+		name := url
+		if url.IsRemote() {
+			name = remoteName
+		}
+		names[url] = name
+		sources = append(sources, &gamePackSource{
+			file: &model.GamePackFile{Kind: kind, Name: name},
+			url:  url,
+		})
+		return name
+	}
+
+	renamed := func(url model.Url) model.Url {
+		if name, found := names[url]; found {
+			return name
+		}
+		return url
+	}
+
+	packed := game.Copy()
+	for _, question := range packed.Questions {
+		if question.Music == nil {
+			continue
+		}
+		music := *question.Music
+		music.Questions = nil
+		music.Mp3Url = pack(model.GamePackFileKind_Music, music.Mp3Url, music.GetMp3FileName())
+		if music.Artist != nil {
+			artist := *music.Artist
+			artist.Musics = nil
+			artist.ImgUrl = pack(model.GamePackFileKind_Image, artist.ImgUrl, artist.GetImageFileName())
+			music.Artist = &artist
+		}
+		if music.Album != nil {
+			album := *music.Album
+			album.Musics = nil
+			album.ImgUrl = pack(model.GamePackFileKind_Image, album.ImgUrl, album.GetImageFileName())
+			music.Album = &album
+		}
+		question.Music = &music
+		if question.Media != nil {
+			// the media only refers to the urls of the music, its artist and its album
+			media := *question.Media
+			media.Mp3Url = renamed(media.Mp3Url)
+			media.ImgUrl = renamed(media.ImgUrl)
+			question.Media = &media
+		}
+	}
+	return packed, sources
+}
+
+func (s *gamePackService) copySource(source *gamePackSource, writer io.Writer) error {
+	if source.url.IsRemote() {
+		return s.downloadClient.Download(source.url, writer)
+	}
+	filePath, err := s.localPath(source.file)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(filePath)
+	if err != nil {
+		return model.ErrPathNotFound(string(source.url))
+	}
+	defer file.Close()
+	_, err = io.Copy(writer, file)
+	return err
+}
+
+func writeGamePackJson(zipWriter *zip.Writer, path string, value any) error {
+	entry, err := zipWriter.Create(path)
+	if err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
+
+type gamePackCounter struct {
+	writer io.Writer
+	size   int64
+}
+
+func (c *gamePackCounter) Write(data []byte) (int, error) {
+	n, err := c.writer.Write(data)
+	c.size += int64(n)
+	return n, err
+}
+
+// //////////////////////////////////////////////////
+// import
+
+// ImportGame extracts the medias of a pack into the local directories and creates a new game from it.
+// a media already present locally is kept as is.
+func (s *gamePackService) ImportGame(ctx context.Context, reader io.ReaderAt, size int64) (*model.Game, error) {
+
+	game, err := s.importGame(reader, size)
+	if err != nil {
+		s.logger.Info("[ KO ] import game pack", zap.Error(err))
+		return nil, err
+	}
+	return s.gameService.ImportGame(ctx, game)
+}
+
+func (s *gamePackService) importGame(reader io.ReaderAt, size int64) (*model.Game, error) {
+
+	zipReader, err := zip.NewReader(reader, size)
+	if err != nil {
+		return nil, model.ErrInvalidGamePack
+	}
+	sizes := util.Convert(zipReader.File, func(file *zip.File) uint64 { return file.UncompressedSize64 })
+	if err := model.ValidateGamePackEntries(sizes); err != nil {
+		return nil, err
+	}
+
+	//
+	// manifest and game
+	//
+
+	manifest := &model.GamePackManifest{}
+	if err := readGamePackJson(zipReader, model.GamePackManifestPath, manifest); err != nil {
+		return nil, err
+	}
+	if err := manifest.Validate(); err != nil {
+		return nil, err
+	}
+	jsonGame := &store.GameJson{}
+	if err := readGamePackJson(zipReader, model.GamePackGamePath, jsonGame); err != nil {
+		return nil, err
+	}
+	game := store.DecodeGameJson(jsonGame)
+	if err := s.checkGameFiles(manifest, game); err != nil {
+		return nil, err
+	}
+
+	//
+	// medias
+	//
+
+	for _, file := range manifest.Files {
+		if err := s.extractFile(zipReader, file); err != nil {
+			s.logger.Info(fmt.Sprintf("[ KO ] extract file %s", file), zap.Error(err))
+			return nil, err
+		}
+	}
+
+	s.logger.Info(fmt.Sprintf("[DEBUG] extract %d files of game %d", len(manifest.Files), manifest.GameId), zap.Object("manifest", manifest))
+	return game, nil
+}
+
+// checkGameFiles ensures that every local media of the game is part of the pack.
+func (s *gamePackService) checkGameFiles(manifest *model.GamePackManifest, game *model.Game) error {
+	check := func(kind model.GamePackFileKind, url model.Url) error {
+		if url.IsEmpty() || url.IsRemote() {
+			return nil
+		}
+		if manifest.FindFile(kind, url) == nil {
+			return model.ErrMissingGamePackFile(string(url))
+		}
+		return nil
+	}
+	for _, question := range game.Questions {
+		if question.Music == nil {
+			continue
+		}
+		if err := check(model.GamePackFileKind_Music, question.Music.Mp3Url); err != nil {
+			return err
+		}
+		if question.Music.Artist != nil {
+			if err := check(model.GamePackFileKind_Image, question.Music.Artist.ImgUrl); err != nil {
+				return err
+			}
+		}
+		if question.Music.Album != nil {
+			if err := check(model.GamePackFileKind_Image, question.Music.Album.ImgUrl); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (s *gamePackService) extractFile(zipReader *zip.Reader, file *model.GamePackFile) error {
+
+	filePath, err := s.localPath(file)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(filePath); err == nil {
+		s.logger.Info(fmt.Sprintf("[DEBUG] keep existing file %q", filePath))
+		return nil
+	}
+
+	entry, err := openGamePackEntry(zipReader, file.Path())
+	if err != nil {
+		return err
+	}
+	defer entry.Close()
+
+	// written aside then renamed so that a failed import never leaves a truncated media
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = io.Copy(tmp, entry)
+	if err == nil {
+		err = tmp.Chmod(0644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filePath)
+	}
+	return err
+}
+
+func readGamePackJson(zipReader *zip.Reader, path string, value any) error {
+	entry, err := openGamePackEntry(zipReader, path)
+	if err != nil {
+		return err
+	}
+	defer entry.Close()
+	if err := json.NewDecoder(entry).Decode(value); err != nil {
+		if errors.Is(err, model.ErrGamePackTooLarge) {
+			return err
+		}
+		return model.ErrInvalidGamePack
+	}
+	return nil
+}
+
+// openGamePackEntry opens an entry of the zip which fails when reading more than its declared size.
+func openGamePackEntry(zipReader *zip.Reader, path string) (io.ReadCloser, error) {
+	entry, err := zipReader.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, model.ErrMissingGamePackFile(path)
+		}
+		return nil, err
+	}
+	info, err := entry.Stat()
+	if err != nil {
+		entry.Close()
+		return nil, err
+	}
+	return &gamePackEntry{File: entry, remaining: info.Size()}, nil
+}
+
+type gamePackEntry struct {
+	fs.File
+	remaining int64
+}
+
+func (e *gamePackEntry) Read(data []byte) (int, error) {
+	if e.remaining < 0 {
+		return 0, model.ErrGamePackTooLarge
+	}
+	n, err := io.LimitReader(e.File, e.remaining+1).Read(data)
+	e.remaining -= int64(n)
+	if e.remaining < 0 {
+		return n, model.ErrGamePackTooLarge
+	}
+	return n, err
+}
+
+// //////////////////////////////////////////////////
+// helper
+
+// localPath is the location of a pack file in the local directory of its kind.
+func (s *gamePackService) localPath(file *model.GamePackFile) (string, error) {
+	filter := s.musicFilter
+	if file.Kind == model.GamePackFileKind_Image {
+		filter = s.imageFilter
+	}
+	name, err := util.CleanLocalPath(string(file.Name))
+	if err != nil {
+		return "", err
+	}
+	if !filter.MatchExtension(name) {
+		return "", model.ErrInvalidExtension
+	}
+	return filepath.Join(filter.Directory, name), nil
+}
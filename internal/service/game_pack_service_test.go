@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+)
+
+func newTestGamePackService(t *testing.T, gameService service.GameService) (service.GamePackService, *model.FileFilter) {
+	musicFilter := &model.FileFilter{Directory: t.TempDir(), Extensions: []string{"mp3"}}
+	imageFilter := &model.FileFilter{Directory: t.TempDir(), Extensions: []string{"jpg"}}
+	return service.NewGamePackService(zap.NewNop(), gameService, nil, musicFilter, imageFilter), musicFilter
+}
+
+func TestGamePackRoundTrip(t *testing.T) {
+	db := newTestDb(t)
+	stores := newTestGameStores()
+	theme := seedTestTheme(t, stores, "pack", 4)
+	gameService := newTestGameService(db, stores)
+	ctx := withTestUser(context.Background(), 1, "alice")
+
+	game, err := gameService.CreateGame(ctx, model.GameSettings{
+		Seed:       1,
+		NbQuestion: 3,
+		NbAnswer:   2,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Store},
+		ThemeIds:   []model.ThemeId{theme.Id},
+	})
+	require.NoError(t, err)
+	_, err = gameService.RenamePlayer(ctx, game.Id, game.Players[0].Id, "alice")
+	require.NoError(t, err)
+	_, err = gameService.RevealQuestion(ctx, game.Id, game.Questions[0].Id)
+	require.NoError(t, err)
+
+	//
+	// export
+	//
+
+	exporter, exportMusicFilter := newTestGamePackService(t, gameService)
+	for _, question := range game.Questions {
+		require.NoError(t, os.WriteFile(filepath.Join(exportMusicFilter.Directory, string(question.Music.Mp3Url)), []byte("mp3 "+question.Music.Name), 0644))
+	}
+	var pack bytes.Buffer
+	require.NoError(t, exporter.ExportGame(ctx, game.Id, &pack))
+
+	// the pack only holds the content of the game
+	zipReader, err := zip.NewReader(bytes.NewReader(pack.Bytes()), int64(pack.Len()))
+	require.NoError(t, err)
+	entry, err := zipReader.Open(model.GamePackGamePath)
+	require.NoError(t, err)
+	data, err := io.ReadAll(entry)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "alice")
+	packed := map[string]any{}
+	require.NoError(t, json.Unmarshal(data, &packed))
+	for key := range packed {
+		require.Contains(t, []string{"id", "seed", "settings", "rounds", "questions"}, key)
+	}
+
+	//
+	// import
+	//
+
+	importer, importMusicFilter := newTestGamePackService(t, gameService)
+	imported, err := importer.ImportGame(withTestUser(context.Background(), 2, "bob"), bytes.NewReader(pack.Bytes()), int64(pack.Len()))
+	require.NoError(t, err)
+	require.NotEqual(t, game.Id, imported.Id)
+	require.Equal(t, model.UserId(2), imported.OwnerId)
+	require.Len(t, imported.Players, 2)
+	require.NotEqual(t, "alice", imported.Players[0].Name)
+	require.Len(t, imported.Questions, len(game.Questions))
+	for index, question := range game.Questions {
+		require.Equal(t, question.Music.Name, imported.Questions[index].Music.Name)
+		require.Equal(t, len(question.Answers), len(imported.Questions[index].Answers))
+		require.False(t, imported.Questions[index].Revealed)
+		content, err := os.ReadFile(filepath.Join(importMusicFilter.Directory, string(imported.Questions[index].Music.Mp3Url)))
+		require.NoError(t, err)
+		require.Equal(t, "mp3 "+question.Music.Name, string(content))
+	}
+
+	// no temporary file is left behind
+	files, err := os.ReadDir(importMusicFilter.Directory)
+	require.NoError(t, err)
+	require.Len(t, files, len(game.Questions))
+}
+
+func TestGamePackImportLimits(t *testing.T) {
+	importer, importMusicFilter := newTestGamePackService(t, nil)
+
+	var pack bytes.Buffer
+	zipWriter := zip.NewWriter(&pack)
+	for number := 0; number <= model.GamePackMaxNbEntry; number++ {
+		_, err := zipWriter.Create(fmt.Sprintf("music/%d.mp3", number))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zipWriter.Close())
+
+	_, err := importer.ImportGame(context.Background(), bytes.NewReader(pack.Bytes()), int64(pack.Len()))
+	require.ErrorIs(t, err, model.ErrGamePackTooLarge)
+	files, err := os.ReadDir(importMusicFilter.Directory)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
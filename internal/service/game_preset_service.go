@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// game preset service
+
+type GamePresetService interface {
+	ListGamePresets(ctx context.Context) ([]*model.GamePreset, error)
+	CreateGamePreset(ctx context.Context, preset *model.GamePreset) (*model.GamePreset, error)
+	RetrieveGamePreset(ctx context.Context, id model.GamePresetId) (*model.GamePreset, error)
+	UpdateGamePreset(ctx context.Context, preset *model.GamePreset, public *bool) (*model.GamePreset, error)
+	DeleteGamePreset(ctx context.Context, id model.GamePresetId) error
+}
+
+func NewGamePresetService(logger *zap.Logger, db *sql.DB, gamePresetStore store.GamePresetStore) GamePresetService {
+	return &gamePresetService{
+		logger:          logger,
+		db:              db,
+		gamePresetStore: gamePresetStore,
+	}
+}
+
+type gamePresetService struct {
+	logger          *zap.Logger
+	db              *sql.DB
+	gamePresetStore store.GamePresetStore
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (s *gamePresetService) ListGamePresets(ctx context.Context) ([]*model.GamePreset, error) {
+
+	var presets []*model.GamePreset
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// list public presets and presets of current user
+		//
+
+		filter := &model.GamePresetFilter{
+			PublicOnly: true,
+		}
+		if currentUser := model.GetCurrentUser(ctx); currentUser != nil {
+			filter.VisibleTo = currentUser.Id
+		}
+		s.logger.Info("[DEBUG] list game presets", zap.Object("filter", filter))
+		presets = s.gamePresetStore.List(ctx, tx, filter)
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] list game presets", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("[ OK ] list game presets")
+	return presets, nil
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *gamePresetService) CreateGamePreset(ctx context.Context, preset *model.GamePreset) (*model.GamePreset, error) {
+
+	var created *model.GamePreset
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// owner
+		//
+
+		currentUser := model.GetCurrentUser(ctx)
+		if currentUser == nil {
+			panic(model.ErrNotGamePresetOwner)
+		}
+		preset.OwnerId = currentUser.Id
+
+		//
+		// create preset
+		//
+
+		if err := preset.Validate(); err != nil {
+			panic(err)
+		}
+		s.logger.Info("[DEBUG] create game preset", zap.Object("preset", preset))
+		created = s.gamePresetStore.Create(ctx, tx, preset)
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] create game preset", zap.Object("preset", preset), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("[ OK ] create game preset", zap.Object("preset", created))
+	return created, nil
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (s *gamePresetService) RetrieveGamePreset(ctx context.Context, id model.GamePresetId) (*model.GamePreset, error) {
+
+	var preset *model.GamePreset
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		preset = s.retrieveVisibleGamePreset(ctx, tx, id)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve game preset %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve game preset %d", id))
+	return preset, nil
+}
+
+// retrieveVisibleGamePreset hides private presets of other users as if they did not exist.
+func (s *gamePresetService) retrieveVisibleGamePreset(ctx context.Context, tx *sql.Tx, id model.GamePresetId) *model.GamePreset {
+	s.logger.Info(fmt.Sprintf("[DEBUG] retrieve game preset %d", id))
+	preset := s.gamePresetStore.Retrieve(ctx, tx, id)
+	if !preset.IsVisibleTo(s.currentUserId(ctx)) {
+		panic(model.ErrGamePresetNotFound)
+	}
+	return preset
+}
+
+func (s *gamePresetService) retrieveOwnedGamePreset(ctx context.Context, tx *sql.Tx, id model.GamePresetId) *model.GamePreset {
+	preset := s.retrieveVisibleGamePreset(ctx, tx, id)
+	if !preset.IsOwnedBy(s.currentUserId(ctx)) {
+		panic(model.ErrNotGamePresetOwner)
+	}
+	return preset
+}
+
+func (s *gamePresetService) currentUserId(ctx context.Context) model.UserId {
+	if currentUser := model.GetCurrentUser(ctx); currentUser != nil {
+		return currentUser.Id
+	}
+	return 0
+}
+
+// //////////////////////////////////////////////////
+// update
+
+// UpdateGamePreset only updates the given values, the visibility being left unchanged when public is nil.
+func (s *gamePresetService) UpdateGamePreset(ctx context.Context, values *model.GamePreset, public *bool) (*model.GamePreset, error) {
+
+	id := values.Id
+	var updated *model.GamePreset
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve preset
+		//
+
+		orig := s.retrieveOwnedGamePreset(ctx, tx, id)
+
+		//
+		// update
+		//
+
+		target := orig.Copy()
+		if values.Name != "" {
+			target.Name = values.Name
+		}
+		if values.Settings != nil {
+			target.Settings = values.Settings
+		}
+		if public != nil {
+			target.Public = *public
+		}
+
+		if err := target.Validate(); err != nil {
+			panic(err)
+		}
+		s.logger.Info("[DEBUG] update game preset", zap.Object("preset", target))
+		updated = s.gamePresetStore.Update(ctx, tx, target)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] update game preset %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] update game preset %d", id), zap.Object("preset", updated))
+	return updated, nil
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (s *gamePresetService) DeleteGamePreset(ctx context.Context, id model.GamePresetId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve preset
+		//
+
+		_ = s.retrieveOwnedGamePreset(ctx, tx, id)
+
+		//
+		// delete preset
+		//
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] delete game preset %d", id))
+		s.gamePresetStore.Delete(ctx, tx, &model.GamePresetFilter{GamePresetId: id})
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] delete game preset %d", id), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] delete game preset %d", id))
+	return nil
+}
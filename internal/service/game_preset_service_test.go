@@ -0,0 +1,82 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+func newTestGamePresetSettings() *model.GameSettings {
+	return &model.GameSettings{NbQuestion: 10, NbAnswer: 4, NbPlayer: 2, Sources: []model.Source{model.Source_Store}}
+}
+
+func TestGamePresetOwnership(t *testing.T) {
+	db := newTestDb(t, "00004_game_preset")
+	presetService := service.NewGamePresetService(zap.NewNop(), db, store.NewGamePresetStore(zap.NewNop()))
+	anonymous := context.Background()
+	alice := withTestUser(anonymous, 1, "alice")
+	bob := withTestUser(anonymous, 2, "bob")
+
+	// anonymous users cannot create presets
+	_, err := presetService.CreateGamePreset(anonymous, &model.GamePreset{Name: "anonymous", Settings: newTestGamePresetSettings()})
+	require.ErrorIs(t, err, model.ErrNotGamePresetOwner)
+
+	// the settings are validated on save
+	_, err = presetService.CreateGamePreset(alice, &model.GamePreset{Name: "invalid", Settings: &model.GameSettings{NbQuestion: 10, NbAnswer: 4, NbPlayer: 1, Sources: []model.Source{model.Source_Store}}})
+	require.ErrorIs(t, err, model.ErrInvalidNbPlayer)
+
+	private, err := presetService.CreateGamePreset(alice, &model.GamePreset{Name: "private", Settings: newTestGamePresetSettings()})
+	require.NoError(t, err)
+	require.Equal(t, model.UserId(1), private.OwnerId)
+	public, err := presetService.CreateGamePreset(alice, &model.GamePreset{Name: "public", Public: true, Settings: newTestGamePresetSettings()})
+	require.NoError(t, err)
+
+	names := func(ctx context.Context) []string {
+		presets, err := presetService.ListGamePresets(ctx)
+		require.NoError(t, err)
+		values := []string{}
+		for _, preset := range presets {
+			values = append(values, preset.Name)
+		}
+		return values
+	}
+
+	// private presets are only visible to their owner
+	require.ElementsMatch(t, []string{"private", "public"}, names(alice))
+	require.Equal(t, []string{"public"}, names(bob))
+	require.Equal(t, []string{"public"}, names(anonymous))
+
+	_, err = presetService.RetrieveGamePreset(alice, private.Id)
+	require.NoError(t, err)
+	_, err = presetService.RetrieveGamePreset(bob, private.Id)
+	require.ErrorIs(t, err, model.ErrGamePresetNotFound)
+	_, err = presetService.RetrieveGamePreset(anonymous, private.Id)
+	require.ErrorIs(t, err, model.ErrGamePresetNotFound)
+	_, err = presetService.RetrieveGamePreset(bob, public.Id)
+	require.NoError(t, err)
+
+	// only the owner updates or deletes a preset, even a public one
+	_, err = presetService.UpdateGamePreset(bob, &model.GamePreset{Id: public.Id, Name: "stolen"}, nil)
+	require.ErrorIs(t, err, model.ErrNotGamePresetOwner)
+	_, err = presetService.UpdateGamePreset(bob, &model.GamePreset{Id: private.Id, Name: "stolen"}, nil)
+	require.ErrorIs(t, err, model.ErrGamePresetNotFound)
+	require.ErrorIs(t, presetService.DeleteGamePreset(bob, public.Id), model.ErrNotGamePresetOwner)
+
+	// the owner updates the settings, validated on save, and the visibility
+	_, err = presetService.UpdateGamePreset(alice, &model.GamePreset{Id: private.Id, Settings: &model.GameSettings{NbQuestion: 0, NbAnswer: 4, NbPlayer: 2, Sources: []model.Source{model.Source_Store}}}, nil)
+	require.ErrorIs(t, err, model.ErrInvalidNbQuestion)
+	visible := true
+	updated, err := presetService.UpdateGamePreset(alice, &model.GamePreset{Id: private.Id, Name: "shared"}, &visible)
+	require.NoError(t, err)
+	require.True(t, updated.Public)
+	require.ElementsMatch(t, []string{"shared", "public"}, names(bob))
+
+	require.NoError(t, presetService.DeleteGamePreset(alice, public.Id))
+	require.Equal(t, []string{"shared"}, names(anonymous))
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"fmt"
 	"math/rand"
+	"sort"
 
 	"github.com/gre-ory/amnezic-go/internal/client"
 	"github.com/gre-ory/amnezic-go/internal/model"
@@ -17,67 +18,268 @@ import (
 // game service
 
 type GameService interface {
+	PreviewGame(ctx context.Context, settings model.GameSettings) (*model.GamePreview, error)
 	CreateGame(ctx context.Context, settings model.GameSettings) (*model.Game, error)
+	GenerateGame(ctx context.Context, id model.GameId, settings model.GameSettings) (*model.Game, error)
+	ImportGame(ctx context.Context, game *model.Game) (*model.Game, error)
+	AnswerQuestion(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, answerId model.GameAnswerId) (*model.Game, error)
+	AnswerYear(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, year int) (*model.Game, error)
+	AnswerSlots(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, slotAnswers []*model.GameSlotAnswer) (*model.Game, error)
+	UseJoker(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, jokerType model.GameJokerType) (*model.Game, error)
+	RevealQuestion(ctx context.Context, id model.GameId, questionId model.GameQuestionId) (*model.Game, error)
+	FinishGame(ctx context.Context, id model.GameId) (*model.Game, error)
+	AddPlayer(ctx context.Context, id model.GameId, name string) (*model.Game, error)
+	RenamePlayer(ctx context.Context, id model.GameId, playerId model.GamePlayerId, name string) (*model.Game, error)
+	SetPlayerActive(ctx context.Context, id model.GameId, playerId model.GamePlayerId, active bool) (*model.Game, error)
+	AdjustScore(ctx context.Context, id model.GameId, playerId model.GamePlayerId, points int, reason string) (*model.Game, error)
+	RetrievePlayerView(ctx context.Context, id model.GameId, playerId model.GamePlayerId) (*model.GamePlayerView, error)
 	RetrieveGame(ctx context.Context, id model.GameId) (*model.Game, error)
+	RetrieveTimeline(ctx context.Context, id model.GameId, asOf int) (*model.GameTimeline, error)
 	DeleteGame(ctx context.Context, id model.GameId) error
 }
 
-func NewGameService(logger *zap.Logger, db *sql.DB, gameStore store.GameStore, gameQuestionStore store.GameQuestionStore, musicStore store.MusicStore, musiArtistStore store.MusicArtistStore, musicAlbumStore store.MusicAlbumStore, themeStore store.ThemeStore, themeQuestionStore store.ThemeQuestionStore, deezerClient client.DeezerClient) GameService {
+func NewGameService(logger *zap.Logger, db *sql.DB, gameEventStore store.GameEventStore, gameQuestionStore store.GameQuestionStore, musicStore store.MusicStore, musiArtistStore store.MusicArtistStore, musicAlbumStore store.MusicAlbumStore, musicContributorStore store.MusicContributorStore, themeStore store.ThemeStore, themeQuestionStore store.ThemeQuestionStore, deezerClient client.DeezerClient, webhookService WebhookService) GameService {
 	return &gameService{
-		logger:             logger,
-		db:                 db,
-		gameStore:          gameStore,
-		gameQuestionStore:  gameQuestionStore,
-		musicStore:         musicStore,
-		musiArtistStore:    musiArtistStore,
-		musicAlbumStore:    musicAlbumStore,
-		themeStore:         themeStore,
-		themeQuestionStore: themeQuestionStore,
-		deezerClient:       deezerClient,
+		logger:                logger,
+		db:                    db,
+		gameEventStore:        gameEventStore,
+		gameQuestionStore:     gameQuestionStore,
+		musicStore:            musicStore,
+		musiArtistStore:       musiArtistStore,
+		musicAlbumStore:       musicAlbumStore,
+		musicContributorStore: musicContributorStore,
+		themeStore:            themeStore,
+		themeQuestionStore:    themeQuestionStore,
+		deezerClient:          deezerClient,
+		webhookService:        webhookService,
 	}
 }
 
 type gameService struct {
-	logger             *zap.Logger
-	db                 *sql.DB
-	gameStore          store.GameStore
-	gameQuestionStore  store.GameQuestionStore
-	musicStore         store.MusicStore
-	musiArtistStore    store.MusicArtistStore
-	musicAlbumStore    store.MusicAlbumStore
-	themeStore         store.ThemeStore
-	themeQuestionStore store.ThemeQuestionStore
-	deezerClient       client.DeezerClient
+	logger                *zap.Logger
+	db                    *sql.DB
+	gameEventStore        store.GameEventStore
+	gameQuestionStore     store.GameQuestionStore
+	musicStore            store.MusicStore
+	musiArtistStore       store.MusicArtistStore
+	musicAlbumStore       store.MusicAlbumStore
+	musicContributorStore store.MusicContributorStore
+	themeStore            store.ThemeStore
+	themeQuestionStore    store.ThemeQuestionStore
+	deezerClient          client.DeezerClient
+	webhookService        WebhookService
+}
+
+func (s *gameService) PreviewGame(ctx context.Context, settings model.GameSettings) (*model.GamePreview, error) {
+
+	preview := &model.GamePreview{
+		Settings: &settings,
+	}
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		for index, round := range settings.GetRounds() {
+			roundSettings := settings.ForRound(index+1, round)
+			roundPreview := &model.GameRoundPreview{
+				Number:   index + 1,
+				Settings: round,
+			}
+			if roundSettings.UseDeezerPlaylist() {
+				roundPreview.Sources = append(roundPreview.Sources, s.previewDeezerSource(ctx, roundSettings))
+			} else if roundSettings.UseMusicBucket() {
+				roundPreview.Sources = append(roundPreview.Sources, s.previewBucketSource(ctx, tx, roundSettings))
+			} else if roundSettings.UseStore() {
+				roundPreview.Sources = append(roundPreview.Sources, s.previewStoreSource(ctx, tx, roundSettings))
+			} else {
+				for _, source := range roundSettings.Sources {
+					roundPreview.Sources = append(roundPreview.Sources, s.gameQuestionStore.PreviewSource(ctx, tx, source))
+				}
+			}
+			preview.Rounds = append(preview.Rounds, roundPreview)
+		}
+
+		preview.Check()
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] preview game", zap.Object("settings", &settings), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("[ OK ] preview game", zap.Object("settings", &settings), zap.Object("preview", preview))
+	return preview, nil
+}
+
+func (s *gameService) previewDeezerSource(ctx context.Context, settings model.GameSettings) *model.GameSourcePreview {
+
+	s.logger.Info(fmt.Sprintf("[DEBUG] retrieve deezer playlist %d", settings.DeezerPlaylistId))
+	playlist, err := s.deezerClient.GetPlaylist(settings.DeezerPlaylistId, true /* with tracks */)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[DEBUG] deezer playlist %d NOT found!", settings.DeezerPlaylistId), zap.Error(err))
+		panic(err)
+	}
+
+	answers := make(map[string]bool)
+	for _, music := range playlist.Musics {
+		answers[music.GetDefaultAnswerText()] = true
+	}
+
+	return &model.GameSourcePreview{
+		Source:     model.Source_Deezer,
+		NbQuestion: len(playlist.Musics),
+		NbAnswer:   len(answers),
+		Themes: []*model.GameThemePreview{
+			{
+				Id:         int64(playlist.DeezerId),
+				Title:      playlist.Name,
+				NbQuestion: len(playlist.Musics),
+				NbAnswer:   len(answers),
+			},
+		},
+	}
+}
+
+func (s *gameService) previewBucketSource(ctx context.Context, tx *sql.Tx, settings model.GameSettings) *model.GameSourcePreview {
+
+	s.logger.Info(fmt.Sprintf("[DEBUG] count musics and distinct artists of %s", settings.BucketTitle()))
+	musics := s.musicStore.List(ctx, tx, settings.ToMusicFilter())
+	artistIds := make(map[model.MusicArtistId]bool)
+	for _, music := range musics {
+		artistIds[music.ArtistId] = true
+	}
+
+	return &model.GameSourcePreview{
+		Source:     settings.BucketSource(),
+		NbQuestion: len(musics),
+		NbAnswer:   len(artistIds),
+		Themes: []*model.GameThemePreview{
+			{
+				Title:      settings.BucketTitle(),
+				NbQuestion: len(musics),
+				NbAnswer:   len(artistIds),
+			},
+		},
+	}
+}
+
+func (s *gameService) previewStoreSource(ctx context.Context, tx *sql.Tx, settings model.GameSettings) *model.GameSourcePreview {
+
+	s.logger.Info("[DEBUG] count questions and distinct answers by theme")
+	nbQuestions := s.themeQuestionStore.CountByTheme(ctx, tx)
+	nbAnswers := s.themeQuestionStore.CountDistinctTextByTheme(ctx, tx)
+
+	preview := &model.GameSourcePreview{
+		Source: model.Source_Store,
+	}
+	themeIds := make([]model.ThemeId, 0)
+	for _, theme := range s.themeStore.List(ctx, tx, nil) {
+		if len(settings.ThemeIds) > 0 && !util.Contains(settings.ThemeIds, theme.Id) {
+			continue
+		}
+		themeIds = append(themeIds, theme.Id)
+		preview.NbQuestion += nbQuestions[theme.Id]
+		preview.Themes = append(preview.Themes, &model.GameThemePreview{
+			Id:         int64(theme.Id),
+			Title:      theme.Title,
+			NbQuestion: nbQuestions[theme.Id],
+			NbAnswer:   nbAnswers[theme.Id],
+		})
+	}
+
+	// answers shared by several themes are counted once
+	if len(themeIds) > 0 {
+		preview.NbAnswer = s.themeQuestionStore.CountDistinctText(ctx, tx, &model.ThemeQuestionFilter{ThemeIds: themeIds})
+	}
+	return preview
 }
 
 func (s *gameService) CreateGame(ctx context.Context, settings model.GameSettings) (*model.Game, error) {
 
 	var game *model.Game
 	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		game = s.createGame(ctx, tx, settings)
+	})
 
-		var questions []*model.GameQuestion
-		if settings.UseDeezerPlaylist() {
-			questions = s.createDeezerQuestions(ctx, tx, settings)
-		} else if settings.UseStore() {
-			questions = s.createStoreQuestions(ctx, tx, settings)
-		} else {
-			questions = s.createLegacyQuestions(ctx, tx, settings)
+	if err != nil {
+		return nil, err
+	}
+	s.notifyGameCreated(game)
+	return game, nil
+}
+
+// createGame generates and stores a new game within the transaction of the caller.
+// the caller notifies the creation once its transaction is committed.
+func (s *gameService) createGame(ctx context.Context, tx *sql.Tx, settings model.GameSettings) *model.Game {
+	game := s.generateGame(ctx, tx, settings)
+	return s.storeGame(ctx, tx, game)
+}
+
+func (s *gameService) notifyGameCreated(game *model.Game) {
+	s.webhookService.Notify(model.NewGameWebhookEvent(model.WebhookEventType_GameCreated, game))
+}
+
+// ImportGame stores a restarted copy of a game generated elsewhere ( e.g. by another server ) as a new game.
+func (s *gameService) ImportGame(ctx context.Context, game *model.Game) (*model.Game, error) {
+
+	var imported *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		if game == nil || len(game.Questions) == 0 {
+			panic(model.ErrInvalidGamePack)
 		}
 
-		game = &model.Game{
-			Settings:  &settings,
-			Players:   s.createPlayers(settings.NbPlayer),
-			Questions: questions,
+		imported = game.Restart()
+		imported.Id = 0
+		imported.OwnerId = 0
+		imported.HostToken = ""
+		if len(imported.Players) == 0 && imported.Settings != nil {
+			// a pack does not hold the players of the original game
+			imported.Players = s.createPlayers(imported.Settings.NbPlayer)
 		}
+		imported = s.storeGame(ctx, tx, imported)
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] import game", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] import game %d", imported.Id))
+	s.notifyGameCreated(imported)
+	return imported, nil
+}
 
-		game = s.gameStore.Create(ctx, tx, game)
+// storeGame gives a new id to a game owned by the session user ( or an anonymous host ) and logs its initial events, the game being rebuilt from its events.
+func (s *gameService) storeGame(ctx context.Context, tx *sql.Tx, game *model.Game) *model.Game {
 
-		for questionIndex, question := range game.Questions {
-			question.Id = model.NewGameQuestionId(game.Id, questionIndex+1)
-			for answerIndex, answer := range question.Answers {
-				answer.Id = model.NewGameAnswerId(question.Id, answerIndex+1)
-			}
-		}
+	//
+	// owner: session user or anonymous host
+	//
+
+	if user := model.GetCurrentUser(ctx); user != nil {
+		game.OwnerId = user.Id
+	} else {
+		game.HostToken = model.NewGameHostToken()
+	}
+
+	game.Id = s.gameEventStore.NewGameId(ctx, tx)
+	s.assignQuestionIds(game)
+
+	//
+	// initial events
+	//
+
+	s.appendEvent(ctx, tx, game.Id, model.NewGameCreatedEvent(game))
+	for _, player := range game.Players {
+		s.appendEvent(ctx, tx, game.Id, model.NewPlayerJoinedEvent(player))
+	}
+	return game
+}
+
+// GenerateGame builds the questions and players of a game without storing it.
+func (s *gameService) GenerateGame(ctx context.Context, id model.GameId, settings model.GameSettings) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		game = s.generateGame(ctx, tx, settings)
+		game.Id = id
+		s.assignQuestionIds(game)
 	})
 
 	if err != nil {
@@ -86,11 +288,80 @@ func (s *gameService) CreateGame(ctx context.Context, settings model.GameSetting
 	return game, nil
 }
 
+func (s *gameService) generateGame(ctx context.Context, tx *sql.Tx, settings model.GameSettings) *model.Game {
+
+	game := &model.Game{
+		Settings: &settings,
+		Players:  s.createPlayers(settings.NbPlayer),
+	}
+
+	//
+	// rounds
+	//
+
+	for index, round := range settings.GetRounds() {
+		number := index + 1
+		roundSettings := settings.ForRound(number, round)
+
+		// one generator per round ( same seed => same questions )
+		random := rand.New(rand.NewSource(roundSettings.Seed))
+
+		var questions []*model.GameQuestion
+		if roundSettings.UseDeezerPlaylist() {
+			questions = s.createDeezerQuestions(ctx, tx, random, roundSettings)
+		} else if roundSettings.UseMusicBucket() {
+			questions = s.createBucketQuestions(ctx, tx, random, roundSettings)
+		} else if roundSettings.UseStore() {
+			questions = s.createStoreQuestions(ctx, tx, random, roundSettings)
+		} else {
+			questions = s.createLegacyQuestions(ctx, tx, roundSettings)
+		}
+		if err := round.ApplyMedia(random, questions); err != nil {
+			panic(err)
+		}
+		for _, question := range questions {
+			question.Round = number
+		}
+
+		game.Rounds = append(game.Rounds, &model.GameRound{
+			Number:       number,
+			Title:        round.Title,
+			QuestionType: round.QuestionType,
+			Scoring:      round.Scoring,
+			MediaKind:    round.MediaKind,
+			Guess:        round.Guess,
+			YearScoring:  round.YearScoring.Copy(),
+			Slots:        util.Convert(round.Slots, (*model.GameSlotSettings).Copy),
+		})
+		game.Questions = append(game.Questions, questions...)
+	}
+
+	return game
+}
+
+func (s *gameService) assignQuestionIds(game *model.Game) {
+	for questionIndex, question := range game.Questions {
+		question.Id = model.NewGameQuestionId(game.Id, questionIndex+1)
+		answerNumber := 0
+		for _, answer := range question.Answers {
+			answerNumber++
+			answer.Id = model.NewGameAnswerId(question.Id, answerNumber)
+		}
+		// answers of the slots follow each other
+		for _, slot := range question.Slots {
+			for _, answer := range slot.Answers {
+				answerNumber++
+				answer.Id = model.NewGameAnswerId(question.Id, answerNumber)
+			}
+		}
+	}
+}
+
 func (s *gameService) createLegacyQuestions(ctx context.Context, tx *sql.Tx, settings model.GameSettings) []*model.GameQuestion {
 	return s.gameQuestionStore.SelectRandomQuestions(ctx, tx, settings)
 }
 
-func (s *gameService) createDeezerQuestions(ctx context.Context, tx *sql.Tx, settings model.GameSettings) []*model.GameQuestion {
+func (s *gameService) createDeezerQuestions(ctx context.Context, tx *sql.Tx, random *rand.Rand, settings model.GameSettings) []*model.GameQuestion {
 
 	//
 	// retrieve deezer playlist
@@ -118,12 +389,6 @@ func (s *gameService) createDeezerQuestions(ctx context.Context, tx *sql.Tx, set
 		panic(model.ErrInvalidNumberOfAnswer)
 	}
 
-	//
-	// random seed
-	//
-
-	rand.Seed(settings.Seed)
-
 	//
 	// select & shuffle media ids
 	//
@@ -132,7 +397,7 @@ func (s *gameService) createDeezerQuestions(ctx context.Context, tx *sql.Tx, set
 	for index := range playlist.Musics {
 		musicIndexes = append(musicIndexes, index)
 	}
-	util.Shuffle(musicIndexes)
+	util.Shuffle(random, musicIndexes)
 
 	//
 	// select subset
@@ -149,17 +414,17 @@ func (s *gameService) createDeezerQuestions(ctx context.Context, tx *sql.Tx, set
 	questions := make([]*model.GameQuestion, 0, settings.NbQuestion)
 	for _, musicIndex := range musicIndexes {
 		music := playlist.Musics[musicIndex]
-		questions = append(questions, s.toPlaylistQuestion(ctx, playlist, music, settings.NbAnswer))
+		questions = append(questions, s.toPlaylistQuestion(ctx, random, playlist, music, settings.NbAnswer))
 	}
 
 	return questions
 }
 
-func (s *gameService) toPlaylistQuestion(ctx context.Context, playlist *model.Playlist, music *model.Music, nbAnswer int) *model.GameQuestion {
+func (s *gameService) toPlaylistQuestion(ctx context.Context, random *rand.Rand, playlist *model.Playlist, music *model.Music, nbAnswer int) *model.GameQuestion {
 	return &model.GameQuestion{
 		Theme:   s.toPlaylistTheme(ctx, playlist),
 		Music:   s.toMusic(ctx, music),
-		Answers: s.toPlaylistAnswers(ctx, playlist, music, nbAnswer),
+		Answers: s.toPlaylistAnswers(ctx, random, playlist, music, nbAnswer),
 	}
 }
 
@@ -170,11 +435,12 @@ func (s *gameService) toPlaylistTheme(ctx context.Context, playlist *model.Playl
 	}
 }
 
-func (s *gameService) toPlaylistAnswers(ctx context.Context, playlist *model.Playlist, music *model.Music, nbAnswer int) []*model.GameAnswer {
+func (s *gameService) toPlaylistAnswers(ctx context.Context, random *rand.Rand, playlist *model.Playlist, music *model.Music, nbAnswer int) []*model.GameAnswer {
 
 	others := util.Filter(playlist.Musics, func(other *model.Music) bool { return other.DeezerId != music.DeezerId })
 
-	util.Shuffle(others)
+	util.Shuffle(random, others)
+	others = model.DistinctAnswerMusics(music, others)
 
 	if len(others) > nbAnswer-1 {
 		others = others[:nbAnswer-1]
@@ -183,12 +449,121 @@ func (s *gameService) toPlaylistAnswers(ctx context.Context, playlist *model.Pla
 	answers := util.Convert(others, func(other *model.Music) *model.GameAnswer { return other.ToGameAnswer(false /* correct */) })
 	answers = append(answers, music.ToGameAnswer(true /* correct */))
 
-	util.Shuffle(answers)
+	util.Shuffle(random, answers)
+
+	return answers
+}
+
+// createBucketQuestions picks musics of the store catalog released in the decade and / or of the genre.
+// the wrong answers are other artists of the same bucket.
+func (s *gameService) createBucketQuestions(ctx context.Context, tx *sql.Tx, random *rand.Rand, settings model.GameSettings) []*model.GameQuestion {
+
+	//
+	// select musics
+	//
+
+	filter := settings.ToMusicFilter()
+	s.logger.Info(fmt.Sprintf("[DEBUG] select %d musics of %s", settings.NbQuestion, settings.BucketTitle()), zap.Object("filter", filter))
+	musics := s.musicStore.List(ctx, tx, filter)
+	if len(musics) == 0 {
+		s.logger.Info(fmt.Sprintf("[DEBUG] EMPTY bucket %s!", settings.BucketTitle()))
+		panic(model.ErrEmptyMusicBucket)
+	}
+
+	//
+	// shuffle from seed ( same seed => same questions )
+	//
+
+	sort.Slice(musics, func(i, j int) bool { return musics[i].Id < musics[j].Id })
+	util.Shuffle(random, musics)
+
+	//
+	// retrieve artists, contributors and albums
+	// ( only of the selected musics and of the wrong answers actually considered )
+	//
+
+	artists := map[model.MusicArtistId]*model.MusicArtist{}
+	albums := map[model.MusicAlbumId]*model.MusicAlbum{}
+	loaded := map[model.MusicId]bool{}
+	retrieveArtist := func(artistId model.MusicArtistId) *model.MusicArtist {
+		artist, found := artists[artistId]
+		if !found {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve artist %d", artistId))
+			artist = s.musiArtistStore.Retrieve(ctx, tx, artistId)
+			artists[artistId] = artist
+		}
+		return artist
+	}
+	load := func(music *model.Music) *model.Music {
+		if loaded[music.Id] {
+			return music
+		}
+		loaded[music.Id] = true
+		if music.ArtistId != 0 {
+			music.Artist = retrieveArtist(music.ArtistId)
+		}
+		music.Contributors = s.musicContributorStore.ListByMusic(ctx, tx, music.Id)
+		for _, contributor := range music.Contributors {
+			contributor.Artist = retrieveArtist(contributor.ArtistId)
+		}
+		if music.AlbumId != 0 {
+			album, found := albums[music.AlbumId]
+			if !found {
+				s.logger.Info(fmt.Sprintf("[DEBUG] retrieve album %d", music.AlbumId))
+				album = s.musicAlbumStore.Retrieve(ctx, tx, music.AlbumId)
+				albums[music.AlbumId] = album
+			}
+			music.Album = album
+		}
+		return music
+	}
+
+	//
+	// building questions
+	//
+
+	selected := musics
+	if len(selected) > settings.NbQuestion {
+		selected = selected[:settings.NbQuestion]
+	}
+	questions := make([]*model.GameQuestion, 0, len(selected))
+	for _, music := range selected {
+		load(music)
+		questions = append(questions, &model.GameQuestion{
+			Theme: &model.GameTheme{
+				Title: settings.BucketTitle(),
+			},
+			Music:   s.toMusic(ctx, music),
+			Answers: s.toBucketAnswers(ctx, random, musics, music, settings.NbAnswer, load),
+		})
+	}
+	return questions
+}
+
+// toBucketAnswers loads the shuffled candidates one by one, only until enough distinct wrong answers are found.
+func (s *gameService) toBucketAnswers(ctx context.Context, random *rand.Rand, bucket []*model.Music, music *model.Music, nbAnswer int, load func(*model.Music) *model.Music) []*model.GameAnswer {
+
+	others := util.Filter(bucket, func(other *model.Music) bool { return other.Id != music.Id })
+
+	util.Shuffle(random, others)
+
+	distinct := make([]*model.Music, 0, nbAnswer-1)
+	for _, other := range others {
+		if len(distinct) >= nbAnswer-1 {
+			break
+		}
+		distinct = model.DistinctAnswerMusics(music, append(distinct, load(other)))
+	}
+
+	answers := util.Convert(distinct, func(other *model.Music) *model.GameAnswer { return other.ToGameAnswer(false /* correct */) })
+	answers = append(answers, music.ToGameAnswer(true /* correct */))
+
+	util.Shuffle(random, answers)
 
 	return answers
 }
 
-func (s *gameService) createStoreQuestions(ctx context.Context, tx *sql.Tx, settings model.GameSettings) []*model.GameQuestion {
+func (s *gameService) createStoreQuestions(ctx context.Context, tx *sql.Tx, random *rand.Rand, settings model.GameSettings) []*model.GameQuestion {
 
 	//
 	// select questions
@@ -196,12 +571,20 @@ func (s *gameService) createStoreQuestions(ctx context.Context, tx *sql.Tx, sett
 
 	s.logger.Info(fmt.Sprintf("[DEBUG] select %d questions", settings.NbQuestion))
 	filter := &model.ThemeQuestionFilter{
-		Random:   true,
-		Limit:    settings.NbQuestion,
 		ThemeIds: settings.ThemeIds,
 	}
 	questions := s.themeQuestionStore.List(ctx, tx, filter)
 
+	//
+	// shuffle from seed ( same seed => same questions )
+	//
+
+	sort.Slice(questions, func(i, j int) bool { return questions[i].Id < questions[j].Id })
+	util.Shuffle(random, questions)
+	if len(questions) > settings.NbQuestion {
+		questions = questions[:settings.NbQuestion]
+	}
+
 	//
 	// retrieve themes
 	//
@@ -226,6 +609,8 @@ func (s *gameService) createStoreQuestions(ctx context.Context, tx *sql.Tx, sett
 				ThemeIds: []model.ThemeId{theme.Id},
 			}
 			theme.Questions = s.themeQuestionStore.List(ctx, tx, filter)
+			sort.Slice(theme.Questions, func(i, j int) bool { return theme.Questions[i].Id < theme.Questions[j].Id })
+			themes[theme.Id] = theme
 		}
 
 		//
@@ -250,22 +635,24 @@ func (s *gameService) createStoreQuestions(ctx context.Context, tx *sql.Tx, sett
 					music.Album = s.musicAlbumStore.Retrieve(ctx, tx, music.AlbumId)
 				}
 			}
+			music.Contributors = retrieveContributors(ctx, tx, s.musicContributorStore, s.musiArtistStore, music.Id)
 		}
 
 		//
 		// select other answers
 		//
 
-		result = append(result, s.toQuestion(ctx, theme, question, music, settings.NbAnswer))
+		result = append(result, s.toQuestion(ctx, random, theme, question, music, settings.NbAnswer))
 	}
 	return result
 }
 
-func (s *gameService) toQuestion(ctx context.Context, theme *model.Theme, question *model.ThemeQuestion, music *model.Music, nbAnswer int) *model.GameQuestion {
+func (s *gameService) toQuestion(ctx context.Context, random *rand.Rand, theme *model.Theme, question *model.ThemeQuestion, music *model.Music, nbAnswer int) *model.GameQuestion {
 	return &model.GameQuestion{
 		Theme:   s.toTheme(ctx, theme),
 		Music:   s.toMusic(ctx, music),
-		Answers: s.toAnswers(ctx, theme, question, nbAnswer),
+		Excerpt: question.Excerpt,
+		Answers: s.toAnswers(ctx, random, theme, question, nbAnswer),
 	}
 }
 
@@ -281,8 +668,16 @@ func (s *gameService) toMusic(ctx context.Context, music *model.Music) *model.Mu
 		Id:     model.MusicId(music.Id),
 		Name:   music.Name,
 		Mp3Url: music.Mp3Url,
+		Year:   music.Year,
 		Artist: s.toArtist(ctx, music.Artist),
 		Album:  s.toAlbum(ctx, music.Album),
+		Contributors: util.Convert(music.Contributors, func(contributor *model.MusicContributor) *model.MusicContributor {
+			return &model.MusicContributor{
+				ArtistId: contributor.ArtistId,
+				Role:     contributor.Role,
+				Artist:   s.toArtist(ctx, contributor.Artist),
+			}
+		}),
 	}
 }
 
@@ -310,11 +705,11 @@ func (s *gameService) toAlbum(ctx context.Context, album *model.MusicAlbum) *mod
 	}
 }
 
-func (s *gameService) toAnswers(ctx context.Context, theme *model.Theme, question *model.ThemeQuestion, nbAnswer int) []*model.GameAnswer {
+func (s *gameService) toAnswers(ctx context.Context, random *rand.Rand, theme *model.Theme, question *model.ThemeQuestion, nbAnswer int) []*model.GameAnswer {
 
 	others := util.Filter(theme.Questions, func(other *model.ThemeQuestion) bool { return other.Id != question.Id })
 
-	util.Shuffle(others)
+	util.Shuffle(random, others)
 
 	if len(others) > nbAnswer-1 {
 		others = others[:nbAnswer-1]
@@ -323,7 +718,7 @@ func (s *gameService) toAnswers(ctx context.Context, theme *model.Theme, questio
 	answers := util.Convert(others, func(other *model.ThemeQuestion) *model.GameAnswer { return s.toAnswer(ctx, other, false) })
 	answers = append(answers, s.toAnswer(ctx, question, true))
 
-	util.Shuffle(answers)
+	util.Shuffle(random, answers)
 
 	return answers
 }
@@ -353,11 +748,414 @@ func (s *gameService) createPlayer(playerNumber int) *model.GamePlayer {
 	}
 }
 
+func (s *gameService) AnswerQuestion(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, answerId model.GameAnswerId) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+		phase := game.Phase()
+
+		//
+		// answer
+		//
+
+		playerAnswer, err := game.AnswerQuestion(questionId, playerId, answerId)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] player %d answered question %d", playerId, questionId), zap.Object("player-answer", playerAnswer))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewAnswerSubmittedEvent(questionId, playerId, answerId))
+		s.appendPhaseEvent(ctx, tx, id, phase, game)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] answer question %d of game %d", questionId, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] answer question %d of game %d", questionId, id))
+	return game, nil
+}
+
+func (s *gameService) AnswerYear(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, year int) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+		phase := game.Phase()
+
+		//
+		// answer
+		//
+
+		playerAnswer, err := game.AnswerYear(questionId, playerId, year)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] player %d guessed year %d for question %d", playerId, year, questionId), zap.Object("player-answer", playerAnswer))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewYearSubmittedEvent(questionId, playerId, year))
+		s.appendPhaseEvent(ctx, tx, id, phase, game)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] answer year of question %d of game %d", questionId, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] answer year of question %d of game %d", questionId, id))
+	return game, nil
+}
+
+func (s *gameService) AnswerSlots(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, slotAnswers []*model.GameSlotAnswer) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+		phase := game.Phase()
+
+		//
+		// answer
+		//
+
+		playerAnswer, err := game.AnswerSlots(questionId, playerId, slotAnswers)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] player %d answered %d parts of question %d", playerId, len(slotAnswers), questionId), zap.Object("player-answer", playerAnswer))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewSlotsSubmittedEvent(questionId, playerId, slotAnswers))
+		s.appendPhaseEvent(ctx, tx, id, phase, game)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] answer parts of question %d of game %d", questionId, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] answer parts of question %d of game %d", questionId, id))
+	return game, nil
+}
+
+func (s *gameService) UseJoker(ctx context.Context, id model.GameId, questionId model.GameQuestionId, playerId model.GamePlayerId, jokerType model.GameJokerType) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+		phase := game.Phase()
+
+		//
+		// use joker
+		//
+
+		playerJoker, err := game.UseJoker(questionId, playerId, jokerType)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] player %d used joker %s on question %d", playerId, jokerType, questionId), zap.Object("player-joker", playerJoker))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewJokerUsedEvent(questionId, playerId, jokerType))
+		s.appendPhaseEvent(ctx, tx, id, phase, game)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] use joker %s on question %d of game %d", jokerType, questionId, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] use joker %s on question %d of game %d", jokerType, questionId, id))
+	return game, nil
+}
+
+func (s *gameService) RevealQuestion(ctx context.Context, id model.GameId, questionId model.GameQuestionId) (*model.Game, error) {
+
+	var game *model.Game
+	var question *model.GameQuestion
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+		phase := game.Phase()
+
+		//
+		// reveal
+		//
+
+		var err error
+		question, err = game.RevealQuestion(questionId)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] reveal question %d", questionId))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewQuestionRevealedEvent(questionId))
+		s.appendPhaseEvent(ctx, tx, id, phase, game)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] reveal question %d of game %d", questionId, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] reveal question %d of game %d", questionId, id))
+	s.webhookService.Notify(model.NewQuestionWebhookEvent(model.WebhookEventType_QuestionRevealed, game, question))
+	return game, nil
+}
+
+func (s *gameService) FinishGame(ctx context.Context, id model.GameId) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+		phase := game.Phase()
+
+		//
+		// finish
+		//
+
+		if err := game.Finish(); err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] finish game %d", id))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewGameFinishedEvent())
+		s.appendPhaseEvent(ctx, tx, id, phase, game)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] finish game %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] finish game %d", id))
+	s.webhookService.Notify(model.NewGameWebhookEvent(model.WebhookEventType_GameFinished, game))
+	return game, nil
+}
+
+func (s *gameService) AddPlayer(ctx context.Context, id model.GameId, name string) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+
+		//
+		// add player
+		//
+
+		player, err := game.AddPlayer(name)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] add player %d", player.Id), zap.Object("player", player))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewPlayerJoinedEvent(player))
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] add player %q to game %d", name, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] add player %q to game %d", name, id))
+	return game, nil
+}
+
+func (s *gameService) RenamePlayer(ctx context.Context, id model.GameId, playerId model.GamePlayerId, name string) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		game = s.renamePlayer(ctx, tx, id, playerId, name)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] rename player %d of game %d", playerId, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] rename player %d of game %d", playerId, id))
+	return game, nil
+}
+
+// renamePlayer renames a player within the transaction of the caller.
+func (s *gameService) renamePlayer(ctx context.Context, tx *sql.Tx, id model.GameId, playerId model.GamePlayerId, name string) *model.Game {
+
+	//
+	// retrieve game
+	//
+
+	game := s.retrieveGame(ctx, tx, id)
+
+	//
+	// rename player
+	//
+
+	player, err := game.RenamePlayer(playerId, name)
+	if err != nil {
+		panic(err)
+	}
+	s.logger.Info(fmt.Sprintf("[DEBUG] rename player %d", playerId), zap.Object("player", player))
+
+	//
+	// update game
+	//
+
+	s.appendEvent(ctx, tx, id, model.NewPlayerRenamedEvent(player))
+	return game
+}
+
+func (s *gameService) SetPlayerActive(ctx context.Context, id model.GameId, playerId model.GamePlayerId, active bool) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+
+		//
+		// (de)activate player
+		//
+
+		player, err := game.SetPlayerActive(playerId, active)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] set player %d active: %t", playerId, active), zap.Object("player", player))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewPlayerActiveEvent(player))
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] set player %d of game %d active: %t", playerId, id, active), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] set player %d of game %d active: %t", playerId, id, active))
+	return game, nil
+}
+
+func (s *gameService) AdjustScore(ctx context.Context, id model.GameId, playerId model.GamePlayerId, points int, reason string) (*model.Game, error) {
+
+	var game *model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve game
+		//
+
+		game = s.retrieveGame(ctx, tx, id)
+
+		//
+		// adjust score
+		//
+
+		adjustment, err := game.AdjustScore(playerId, points, reason)
+		if err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] adjust score of player %d", playerId), zap.Object("adjustment", adjustment))
+
+		//
+		// update game
+		//
+
+		s.appendEvent(ctx, tx, id, model.NewScoreAdjustedEvent(playerId, adjustment.Points, adjustment.Reason))
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] adjust score of player %d of game %d by %d", playerId, id, points), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] adjust score of player %d of game %d by %d", playerId, id, points))
+	return game, nil
+}
+
+func (s *gameService) RetrievePlayerView(ctx context.Context, id model.GameId, playerId model.GamePlayerId) (*model.GamePlayerView, error) {
+
+	var view *model.GamePlayerView
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		game := s.retrieveGame(ctx, tx, id)
+
+		var err error
+		view, err = game.PlayerView(playerId)
+		if err != nil {
+			panic(err)
+		}
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve view of player %d for game %d", playerId, id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve view of player %d for game %d", playerId, id))
+	return view, nil
+}
+
 func (s *gameService) RetrieveGame(ctx context.Context, id model.GameId) (*model.Game, error) {
 
 	var game *model.Game
 	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
-		game = s.gameStore.Retrieve(ctx, tx, id)
+		game = s.retrieveGame(ctx, tx, id)
 	})
 	if err != nil {
 		return nil, err
@@ -365,8 +1163,84 @@ func (s *gameService) RetrieveGame(ctx context.Context, id model.GameId) (*model
 	return game, nil
 }
 
+func (s *gameService) RetrieveTimeline(ctx context.Context, id model.GameId, asOf int) (*model.GameTimeline, error) {
+
+	var timeline *model.GameTimeline
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve events
+		//
+
+		events := s.listEvents(ctx, tx, id)
+		if asOf < 0 || asOf > len(events) {
+			panic(model.ErrGameEventNotFound)
+		}
+
+		//
+		// replay
+		//
+
+		game, err := model.ReplayGame(events, asOf)
+		if err != nil {
+			panic(err)
+		}
+		if asOf == 0 {
+			asOf = len(events)
+		}
+		timeline = &model.GameTimeline{
+			Events: events,
+			AsOf:   asOf,
+			Game:   game,
+		}
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve timeline of game %d as of %d", id, asOf), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve timeline of game %d as of %d", id, timeline.AsOf), zap.Int("nb-events", len(timeline.Events)))
+	return timeline, nil
+}
+
+// DeleteGame logs the deletion of the game, its events being kept.
 func (s *gameService) DeleteGame(ctx context.Context, id model.GameId) error {
 	return util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
-		s.gameStore.Delete(ctx, tx, id)
+		_ = s.listEvents(ctx, tx, id)
+		s.appendEvent(ctx, tx, id, model.NewGameDeletedEvent())
 	})
 }
+
+// listEvents lists the events of a game, a deleted game being not found.
+func (s *gameService) listEvents(ctx context.Context, tx *sql.Tx, id model.GameId) []*model.GameEvent {
+	events := s.gameEventStore.List(ctx, tx, id)
+	if events[len(events)-1].Type == model.GameEventType_GameDeleted {
+		panic(model.ErrGameNotFound)
+	}
+	return events
+}
+
+// retrieveGame rebuilds the current state of a game by replaying its events.
+func (s *gameService) retrieveGame(ctx context.Context, tx *sql.Tx, id model.GameId) *model.Game {
+	game, err := model.ReplayGame(s.listEvents(ctx, tx, id), 0)
+	if err != nil {
+		panic(err)
+	}
+	return game
+}
+
+// appendPhaseEvent logs the move of the game into another phase, if any, after the event causing it.
+func (s *gameService) appendPhaseEvent(ctx context.Context, tx *sql.Tx, id model.GameId, previous model.GamePhase, game *model.Game) {
+	if phase := game.Phase(); phase != previous {
+		s.appendEvent(ctx, tx, id, model.NewPhaseChangedEvent(previous, phase))
+	}
+}
+
+// appendEvent logs a mutation of the game, on behalf of the session user if any.
+func (s *gameService) appendEvent(ctx context.Context, tx *sql.Tx, id model.GameId, event *model.GameEvent) {
+	if user := model.GetCurrentUser(ctx); user != nil {
+		event.UserId = user.Id
+	}
+	event = s.gameEventStore.Append(ctx, tx, id, event)
+	s.logger.Info(fmt.Sprintf("[DEBUG] append event %s to game %d", event.Type, id), zap.Object("event", event))
+}
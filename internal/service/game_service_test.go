@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+func TestGenerateGameIsDeterministic(t *testing.T) {
+	db := newTestDb(t)
+	stores := newTestGameStores()
+	theme := seedTestTheme(t, stores, "seed", 20)
+	gameService := newTestGameService(db, stores)
+	ctx := context.Background()
+
+	settings := model.GameSettings{
+		Seed:       42,
+		NbQuestion: 5,
+		NbAnswer:   4,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Store},
+		ThemeIds:   []model.ThemeId{theme.Id},
+	}
+	texts := func(game *model.Game) []string {
+		values := []string{}
+		for _, question := range game.Questions {
+			for _, answer := range question.Answers {
+				values = append(values, answer.Text)
+			}
+		}
+		return values
+	}
+
+	first, err := gameService.GenerateGame(ctx, model.NewGameId(1), settings)
+	require.NoError(t, err)
+	second, err := gameService.GenerateGame(ctx, model.NewGameId(1), settings)
+	require.NoError(t, err)
+	require.Equal(t, texts(first), texts(second))
+
+	settings.Seed = 43
+	other, err := gameService.GenerateGame(ctx, model.NewGameId(1), settings)
+	require.NoError(t, err)
+	require.NotEqual(t, texts(first), texts(other))
+}
+
+func TestPreviewGame(t *testing.T) {
+	db := newTestDb(t)
+	stores := newTestGameStores()
+	small := seedTestTheme(t, stores, "small", 3)
+	big := seedTestTheme(t, stores, "big", 10)
+	gameService := newTestGameService(db, stores)
+	ctx := context.Background()
+
+	// the small theme alone can neither fill the questions nor the answers
+	preview, err := gameService.PreviewGame(ctx, model.GameSettings{
+		NbQuestion: 5,
+		NbAnswer:   4,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Store},
+		ThemeIds:   []model.ThemeId{small.Id},
+	})
+	require.NoError(t, err)
+	require.False(t, preview.IsFillable())
+	require.Equal(t, 3, preview.NbQuestion)
+	require.Len(t, preview.Rounds, 1)
+	require.Equal(t, 3, preview.Rounds[0].NbQuestion)
+	require.Len(t, preview.Rounds[0].Sources, 1)
+	source := preview.Rounds[0].Sources[0]
+	require.Equal(t, model.Source_Store, source.Source)
+	require.Equal(t, 3, source.NbQuestion)
+	require.Equal(t, 3, source.NbAnswer)
+	require.Equal(t, []*model.GameThemePreview{
+		{Id: int64(small.Id), Title: "small", NbQuestion: 3, NbAnswer: 3},
+	}, source.Themes)
+	require.Equal(t, []string{
+		`round 1: theme "small" from source store only provides 3 distinct answer(s) out of 4`,
+		"round 1: only 3 question(s) available out of 5",
+	}, preview.Warnings)
+
+	// the big theme fills the questions, the small one still lacks answers
+	preview, err = gameService.PreviewGame(ctx, model.GameSettings{
+		NbQuestion: 5,
+		NbAnswer:   4,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Store},
+		ThemeIds:   []model.ThemeId{small.Id, big.Id},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 13, preview.NbQuestion)
+	source = preview.Rounds[0].Sources[0]
+	require.Equal(t, 13, source.NbAnswer)
+	require.Equal(t, []string{
+		`round 1: theme "small" from source store only provides 3 distinct answer(s) out of 4`,
+	}, preview.Warnings)
+
+	// the big theme alone is fillable
+	preview, err = gameService.PreviewGame(ctx, model.GameSettings{
+		NbQuestion: 5,
+		NbAnswer:   4,
+		NbPlayer:   2,
+		Sources:    []model.Source{model.Source_Store},
+		ThemeIds:   []model.ThemeId{big.Id},
+	})
+	require.NoError(t, err)
+	require.True(t, preview.IsFillable())
+	require.Empty(t, preview.Warnings)
+}
@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gre-ory/amnezic-go/internal/client"
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// genre service
+
+type GenreService interface {
+	ListGenre(ctx context.Context, filter *model.MusicGenreFilter) (*model.MusicGenrePage, error)
+	GetGenre(ctx context.Context, id model.MusicGenreId) (*model.MusicGenre, error)
+	CreateGenre(ctx context.Context, genre *model.MusicGenre) (*model.MusicGenre, error)
+	UpdateGenre(ctx context.Context, genre *model.MusicGenre) (*model.MusicGenre, error)
+	DeleteGenre(ctx context.Context, id model.MusicGenreId) error
+
+	LinkAlbum(ctx context.Context, id model.MusicGenreId, albumId model.MusicAlbumId) error
+	UnlinkAlbum(ctx context.Context, id model.MusicGenreId, albumId model.MusicAlbumId) error
+	LinkMusic(ctx context.Context, id model.MusicGenreId, musicId model.MusicId) error
+	UnlinkMusic(ctx context.Context, id model.MusicGenreId, musicId model.MusicId) error
+}
+
+func NewGenreService(logger *zap.Logger, downloadClient client.DownloadClient, db *sql.DB, genreStore store.MusicGenreStore, albumStore store.MusicAlbumStore, musicStore store.MusicStore, imageFileValidator model.PathValidator) GenreService {
+	return &genreService{
+		logger:             logger,
+		downloadClient:     downloadClient,
+		db:                 db,
+		genreStore:         genreStore,
+		albumStore:         albumStore,
+		musicStore:         musicStore,
+		imageFileValidator: imageFileValidator,
+	}
+}
+
+type genreService struct {
+	logger             *zap.Logger
+	downloadClient     client.DownloadClient
+	db                 *sql.DB
+	genreStore         store.MusicGenreStore
+	albumStore         store.MusicAlbumStore
+	musicStore         store.MusicStore
+	imageFileValidator model.PathValidator
+}
+
+// //////////////////////////////////////////////////
+// list genre
+
+// ListGenre returns a page of genres along with the total number of matching genres and the number of albums and musics of each genre.
+func (s *genreService) ListGenre(ctx context.Context, filter *model.MusicGenreFilter) (*model.MusicGenrePage, error) {
+	page := &model.MusicGenrePage{
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	}
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		page.Genres = s.genreStore.List(ctx, tx, filter)
+		page.Total = s.genreStore.Count(ctx, tx, filter)
+		nbAlbums := s.genreStore.CountAlbums(ctx, tx)
+		nbMusics := s.genreStore.CountMusics(ctx, tx)
+		for _, genre := range page.Genres {
+			genre.NbAlbum = nbAlbums[genre.Id]
+			genre.NbMusic = nbMusics[genre.Id]
+		}
+	})
+	if err != nil {
+		s.logger.Info("[ KO ] list genre", zap.Object("filter", filter), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] list %d / %d genre", len(page.Genres), page.Total), zap.Object("filter", filter))
+	return page, nil
+}
+
+// //////////////////////////////////////////////////
+// create genre
+
+func (s *genreService) CreateGenre(ctx context.Context, genre *model.MusicGenre) (*model.MusicGenre, error) {
+
+	var err error
+
+	//
+	// validate
+	//
+
+	if genre == nil {
+		return nil, model.ErrMissingGenre
+	}
+	if err = genre.Validate(s.imageFileValidator); err != nil {
+		return nil, err
+	}
+
+	//
+	// download remote files
+	//
+
+	s.DownloadRemoteFiles(ctx, genre)
+
+	//
+	// create
+	//
+
+	err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// check if genre exists
+		//
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve genre from name %q", genre.Name))
+		if other := s.genreStore.SearchByName(ctx, tx, genre.Name); other != nil {
+			panic(model.ErrExistingGenre)
+		}
+		if genre.DeezerId != 0 {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve genre from deezer id %d", genre.DeezerId))
+			if other := s.genreStore.SearchByDeezerId(ctx, tx, genre.DeezerId); other != nil {
+				panic(model.ErrExistingGenre)
+			}
+		}
+
+		//
+		// create genre
+		//
+
+		genre = s.genreStore.Create(ctx, tx, genre)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] create genre %q", genre.Name), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] create genre %q", genre.Name))
+	return genre, nil
+}
+
+// //////////////////////////////////////////////////
+// get genre
+
+func (s *genreService) GetGenre(ctx context.Context, id model.MusicGenreId) (*model.MusicGenre, error) {
+
+	var genre *model.MusicGenre
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve genre
+		//
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve genre %d", id))
+		genre = s.genreStore.Retrieve(ctx, tx, id)
+
+		//
+		// retrieve related albums
+		//
+
+		genre.Albums = s.albumStore.List(ctx, tx, model.NewMusicAlbumFilter().WithGenreId(genre.Id))
+		genre.NbAlbum = len(genre.Albums)
+		genre.NbMusic = s.musicStore.Count(ctx, tx, model.NewMusicFilter().WithGenreId(genre.Id))
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve genre %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve genre %d", id))
+	return genre, nil
+}
+
+// //////////////////////////////////////////////////
+// update genre
+
+func (s *genreService) UpdateGenre(ctx context.Context, genre *model.MusicGenre) (*model.MusicGenre, error) {
+
+	//
+	// pre-validate
+	//
+
+	if genre == nil {
+		return nil, model.ErrMissingGenre
+	}
+	if err := genre.Validate(nil); err != nil {
+		return nil, err
+	}
+
+	//
+	// download remote files
+	//
+
+	s.DownloadRemoteFiles(ctx, genre)
+
+	//
+	// update
+	//
+
+	var updated *model.MusicGenre
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// check if another genre has the same name
+		//
+
+		if other := s.genreStore.SearchByName(ctx, tx, genre.Name); other != nil && other.Id != genre.Id {
+			panic(model.ErrExistingGenre)
+		}
+
+		updated = s.genreStore.Update(ctx, tx, genre)
+
+		if err := updated.Validate(s.imageFileValidator); err != nil {
+			panic(err)
+		}
+
+		updated.Albums = s.albumStore.List(ctx, tx, model.NewMusicAlbumFilter().WithGenreId(updated.Id))
+		updated.NbAlbum = len(updated.Albums)
+		updated.NbMusic = s.musicStore.Count(ctx, tx, model.NewMusicFilter().WithGenreId(updated.Id))
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] update genre %d - %s", genre.Id, genre.Name), zap.Object("genre", genre), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] update genre %d - %s", updated.Id, updated.Name), zap.Object("genre", updated))
+	return updated, nil
+}
+
+// //////////////////////////////////////////////////
+// delete genre
+
+// DeleteGenre deletes the genre and unlinks its albums and musics.
+func (s *genreService) DeleteGenre(ctx context.Context, id model.MusicGenreId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve genre %d", id))
+		s.genreStore.Retrieve(ctx, tx, id)
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] delete genre %d", id))
+		s.genreStore.Delete(ctx, tx, id)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] delete genre %d", id), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] delete genre %d", id))
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// link album
+
+func (s *genreService) LinkAlbum(ctx context.Context, id model.MusicGenreId, albumId model.MusicAlbumId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.genreStore.Retrieve(ctx, tx, id)
+		s.albumStore.Retrieve(ctx, tx, albumId)
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] link genre %d to album %d", id, albumId))
+		s.genreStore.LinkAlbum(ctx, tx, id, albumId)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] link genre %d to album %d", id, albumId), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] link genre %d to album %d", id, albumId))
+	return nil
+}
+
+func (s *genreService) UnlinkAlbum(ctx context.Context, id model.MusicGenreId, albumId model.MusicAlbumId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.logger.Info(fmt.Sprintf("[DEBUG] unlink genre %d from album %d", id, albumId))
+		s.genreStore.UnlinkAlbum(ctx, tx, id, albumId)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] unlink genre %d from album %d", id, albumId), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] unlink genre %d from album %d", id, albumId))
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// link music
+
+func (s *genreService) LinkMusic(ctx context.Context, id model.MusicGenreId, musicId model.MusicId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.genreStore.Retrieve(ctx, tx, id)
+		s.musicStore.Retrieve(ctx, tx, musicId)
+
+		s.logger.Info(fmt.Sprintf("[DEBUG] link genre %d to music %d", id, musicId))
+		s.genreStore.LinkMusic(ctx, tx, id, musicId)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] link genre %d to music %d", id, musicId), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] link genre %d to music %d", id, musicId))
+	return nil
+}
+
+func (s *genreService) UnlinkMusic(ctx context.Context, id model.MusicGenreId, musicId model.MusicId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.logger.Info(fmt.Sprintf("[DEBUG] unlink genre %d from music %d", id, musicId))
+		s.genreStore.UnlinkMusic(ctx, tx, id, musicId)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] unlink genre %d from music %d", id, musicId), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] unlink genre %d from music %d", id, musicId))
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// download remote files
+
+func (s *genreService) DownloadRemoteFiles(ctx context.Context, genre *model.MusicGenre) {
+	downloadGenreImage(s.logger, s.downloadClient, genre)
+}
+
+func downloadGenreImage(logger *zap.Logger, downloadClient client.DownloadClient, genre *model.MusicGenre) {
+	if genre == nil {
+		return
+	}
+	if genre.ImgUrl.IsRemote() {
+		fileName := genre.GetImageFileName()
+		url := genre.ImgUrl
+		err := downloadClient.DownloadImage(url, fileName)
+		if err != nil {
+			logger.Info(fmt.Sprintf("[ KO ] download image %s <<< %q", fileName, url), zap.Error(err))
+		} else {
+			logger.Info(fmt.Sprintf("[ OK ] download image %s <<< %q", fileName, url))
+			genre.ImgUrl = fileName
+		}
+	}
+}
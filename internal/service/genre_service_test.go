@@ -0,0 +1,206 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/client"
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// catalog
+
+// newTestCatalogDb opens an in-memory sqlite database with the catalog tables.
+func newTestCatalogDb(t *testing.T) *sql.DB {
+	db := newTestDb(t, "00001_theme", "00002_theme_labels", "00007_theme_question_excerpt", "00008_music_year")
+
+	// the search index requires fts5, a plain table with the same columns is enough here
+	_, err := db.Exec("CREATE TABLE search (kind TEXT, ref_id INTEGER, parent_id INTEGER, text TEXT)")
+	require.NoError(t, err)
+	for _, migration := range []string{"00010_music_metadata", "00011_music_genre", "00012_music_contributor", "00013_music_identity", "00017_music_title_key"} {
+		applyTestMigration(t, db, migration)
+	}
+	return db
+}
+
+type testCatalogStores struct {
+	music         store.MusicStore
+	artist        store.MusicArtistStore
+	album         store.MusicAlbumStore
+	genre         store.MusicGenreStore
+	contributor   store.MusicContributorStore
+	theme         store.ThemeStore
+	themeQuestion store.ThemeQuestionStore
+}
+
+func newTestCatalogStores() *testCatalogStores {
+	logger := zap.NewNop()
+	searchStore := store.NewSearchStore(logger)
+	return &testCatalogStores{
+		music:         store.NewMusicStore(logger, searchStore),
+		artist:        store.NewMusicArtistStore(logger, searchStore),
+		album:         store.NewMusicAlbumStore(logger, searchStore),
+		genre:         store.NewMusicGenreStore(logger),
+		contributor:   store.NewMusicContributorStore(logger),
+		theme:         store.NewThemeStore(logger),
+		themeQuestion: store.NewThemeQuestionStore(logger, searchStore),
+	}
+}
+
+type testDeezerClient struct {
+	client.DeezerClient
+	musics map[model.DeezerMusicId]*model.Music
+}
+
+func (c *testDeezerClient) GetMusic(trackId model.DeezerMusicId) (*model.Music, error) {
+	if music, ok := c.musics[trackId]; ok {
+		return music, nil
+	}
+	return nil, model.ErrMusicNotFound
+}
+
+// //////////////////////////////////////////////////
+// genre
+
+func TestGenreService(t *testing.T) {
+	ctx := context.Background()
+	db := newTestCatalogDb(t)
+	stores := newTestCatalogStores()
+	genreService := service.NewGenreService(zap.NewNop(), nil, db, stores.genre, stores.album, stores.music, nil)
+
+	var album *model.MusicAlbum
+	var music, other *model.Music
+	require.NoError(t, util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+		artist := stores.artist.Create(ctx, tx, &model.MusicArtist{Name: "ABBA"})
+		album = stores.album.Create(ctx, tx, &model.MusicAlbum{Name: "Arrival"})
+		music = stores.music.Create(ctx, tx, &model.Music{Name: "Dancing Queen", Mp3Url: "dancing-queen.mp3", ArtistId: artist.Id, AlbumId: album.Id})
+		other = stores.music.Create(ctx, tx, &model.Music{Name: "Money, Money, Money", Mp3Url: "money.mp3", ArtistId: artist.Id, AlbumId: album.Id})
+	}))
+
+	// create
+	_, err := genreService.CreateGenre(ctx, nil)
+	require.ErrorIs(t, err, model.ErrMissingGenre)
+	_, err = genreService.CreateGenre(ctx, &model.MusicGenre{})
+	require.ErrorIs(t, err, model.ErrInvalidGenreName)
+	pop, err := genreService.CreateGenre(ctx, &model.MusicGenre{Name: "Pop"})
+	require.NoError(t, err)
+	require.NotZero(t, pop.Id)
+	disco, err := genreService.CreateGenre(ctx, &model.MusicGenre{Name: "Disco", DeezerId: 113})
+	require.NoError(t, err)
+	_, err = genreService.CreateGenre(ctx, &model.MusicGenre{Name: "pop"})
+	require.ErrorIs(t, err, model.ErrExistingGenre)
+	_, err = genreService.CreateGenre(ctx, &model.MusicGenre{Name: "Dance", DeezerId: 113})
+	require.ErrorIs(t, err, model.ErrExistingGenre)
+
+	// link
+	require.NoError(t, genreService.LinkAlbum(ctx, pop.Id, album.Id))
+	require.NoError(t, genreService.LinkMusic(ctx, pop.Id, music.Id))
+	require.NoError(t, genreService.LinkMusic(ctx, disco.Id, other.Id))
+	require.ErrorIs(t, genreService.LinkMusic(ctx, pop.Id, 999), model.ErrMusicNotFound)
+	require.ErrorIs(t, genreService.LinkAlbum(ctx, 999, album.Id), model.ErrMusicGenreNotFound)
+
+	// the music filter only counts the musics linked to the genre
+	retrieved, err := genreService.GetGenre(ctx, pop.Id)
+	require.NoError(t, err)
+	require.Equal(t, 1, retrieved.NbAlbum)
+	require.Equal(t, 1, retrieved.NbMusic)
+	require.NoError(t, util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+		musics := stores.music.List(ctx, tx, model.NewMusicFilter().WithGenreId(disco.Id))
+		require.Len(t, musics, 1)
+		require.Equal(t, other.Id, musics[0].Id)
+	}))
+
+	// update
+	pop.Name = "Disco"
+	_, err = genreService.UpdateGenre(ctx, pop)
+	require.ErrorIs(t, err, model.ErrExistingGenre)
+	pop.Name = "Pop music"
+	updated, err := genreService.UpdateGenre(ctx, pop)
+	require.NoError(t, err)
+	require.Equal(t, "Pop music", updated.Name)
+	require.Equal(t, 1, updated.NbMusic)
+
+	// unlink
+	require.NoError(t, genreService.UnlinkMusic(ctx, pop.Id, music.Id))
+	require.NoError(t, genreService.UnlinkAlbum(ctx, pop.Id, album.Id))
+	retrieved, err = genreService.GetGenre(ctx, pop.Id)
+	require.NoError(t, err)
+	require.Zero(t, retrieved.NbAlbum)
+	require.Zero(t, retrieved.NbMusic)
+
+	// delete unlinks the musics
+	require.NoError(t, genreService.DeleteGenre(ctx, disco.Id))
+	_, err = genreService.GetGenre(ctx, disco.Id)
+	require.ErrorIs(t, err, model.ErrMusicGenreNotFound)
+	require.ErrorIs(t, genreService.DeleteGenre(ctx, disco.Id), model.ErrMusicGenreNotFound)
+	require.NoError(t, util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+		require.Empty(t, stores.genre.ListByMusic(ctx, tx, other.Id))
+	}))
+}
+
+func TestImportDeezerGenres(t *testing.T) {
+	ctx := context.Background()
+	db := newTestCatalogDb(t)
+	stores := newTestCatalogStores()
+
+	deezerMusic := func(id model.DeezerMusicId, name string, genres ...*model.MusicGenre) *model.Music {
+		return &model.Music{
+			DeezerId: id,
+			Name:     name,
+			Mp3Url:   model.Url(name + ".mp3"),
+			Artist:   &model.MusicArtist{DeezerId: 1, Name: "ABBA"},
+			Album:    &model.MusicAlbum{DeezerId: model.DeezerAlbumId(id), Name: name, Genres: genres},
+		}
+	}
+	deezerClient := &testDeezerClient{
+		musics: map[model.DeezerMusicId]*model.Music{
+			1: deezerMusic(1, "Waterloo", &model.MusicGenre{DeezerId: 132, Name: "Pop"}, &model.MusicGenre{DeezerId: 113, Name: "Disco"}),
+			2: deezerMusic(2, "Fernando", &model.MusicGenre{DeezerId: 132, Name: "Pop music"}, &model.MusicGenre{Name: "Unknown"}),
+		},
+	}
+	musicService := service.NewMusicService(zap.NewNop(), deezerClient, nil, db, stores.music, stores.album, stores.artist, stores.genre, stores.contributor, stores.theme, stores.themeQuestion, nil, nil)
+
+	// a genre created by hand
+	var pop *model.MusicGenre
+	require.NoError(t, util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+		pop = stores.genre.Create(ctx, tx, &model.MusicGenre{Name: "pop", ImgUrl: "pop.jpg"})
+	}))
+
+	names := func(genres []*model.MusicGenre) []string {
+		names := make([]string, 0, len(genres))
+		for _, genre := range genres {
+			names = append(names, genre.Name)
+		}
+		return names
+	}
+
+	// the genre of the same name is bound to the deezer genre, the others are created
+	waterloo, err := musicService.AddDeezerMusic(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, []string{"pop", "Disco"}, names(waterloo.Genres))
+	require.Equal(t, pop.Id, waterloo.Genres[0].Id)
+	require.Equal(t, model.DeezerGenreId(132), waterloo.Genres[0].DeezerId)
+	require.Equal(t, model.Url("pop.jpg"), waterloo.Genres[0].ImgUrl)
+
+	// the deezer id wins over a renamed genre, genres without deezer id are skipped
+	fernando, err := musicService.AddDeezerMusic(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"pop"}, names(fernando.Genres))
+	require.Equal(t, pop.Id, fernando.Genres[0].Id)
+
+	// the genres are linked to the albums and the musics
+	require.NoError(t, util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+		require.Equal(t, 2, stores.genre.Count(ctx, tx, nil))
+		require.ElementsMatch(t, []string{"pop", "Disco"}, names(stores.genre.ListByAlbum(ctx, tx, waterloo.AlbumId)))
+		require.Equal(t, []string{"pop"}, names(stores.genre.ListByMusic(ctx, tx, fernando.Id)))
+		require.Equal(t, 2, stores.music.Count(ctx, tx, model.NewMusicFilter().WithGenreId(pop.Id)))
+	}))
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/store/memory"
+)
+
+// newTestDb opens an in-memory sqlite database with the given migrations of the db directory ( e.g. "00005_daily_challenge" ).
+// the memory stores ignore the database, which only provides the transactions.
+func newTestDb(t *testing.T, migrations ...string) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	// a new connection would open another in-memory database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, migration := range migrations {
+		applyTestMigration(t, db, migration)
+	}
+	return db
+}
+
+// applyTestMigration runs the up part of the given migration of the db directory.
+func applyTestMigration(t *testing.T, db *sql.DB, migration string) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "db", migration+".sql"))
+	require.NoError(t, err)
+	up, _, _ := strings.Cut(string(data), "-- +goose Down")
+	_, err = db.Exec(up)
+	require.NoError(t, err, migration)
+}
+
+// //////////////////////////////////////////////////
+// game
+
+type testGameStores struct {
+	gameEvent     store.GameEventStore
+	music         store.MusicStore
+	artist        store.MusicArtistStore
+	album         store.MusicAlbumStore
+	contributor   store.MusicContributorStore
+	theme         store.ThemeStore
+	themeQuestion store.ThemeQuestionStore
+}
+
+func newTestGameStores() *testGameStores {
+	return &testGameStores{
+		gameEvent:     memory.NewGameEventMemoryStore(),
+		music:         memory.NewMusicMemoryStore(),
+		artist:        memory.NewMusicArtistMemoryStore(),
+		album:         memory.NewMusicAlbumMemoryStore(),
+		contributor:   memory.NewMusicContributorMemoryStore(),
+		theme:         memory.NewThemeMemoryStore(),
+		themeQuestion: memory.NewThemeQuestionMemoryStore(),
+	}
+}
+
+func newTestGameService(db *sql.DB, stores *testGameStores) service.GameService {
+	return service.NewGameService(zap.NewNop(), db, stores.gameEvent, nil, stores.music, stores.artist, stores.album, stores.contributor, stores.theme, stores.themeQuestion, nil, &testWebhookService{})
+}
+
+// seedTestTheme creates a theme with one question per music, each music by its own artist.
+func seedTestTheme(t *testing.T, stores *testGameStores, title string, nbQuestion int) *model.Theme {
+	ctx := context.Background()
+	theme := stores.theme.Create(ctx, nil, &model.Theme{Title: title})
+	for number := 1; number <= nbQuestion; number++ {
+		artist := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: fmt.Sprintf("%s artist %d", title, number)})
+		music := stores.music.Create(ctx, nil, &model.Music{
+			Name:     fmt.Sprintf("%s music %d", title, number),
+			Mp3Url:   model.Url(fmt.Sprintf("%s-%d.mp3", title, number)),
+			ArtistId: artist.Id,
+		})
+		stores.themeQuestion.Create(ctx, nil, &model.ThemeQuestion{
+			ThemeId: theme.Id,
+			MusicId: music.Id,
+			Text:    artist.Name,
+		})
+	}
+	return theme
+}
+
+// //////////////////////////////////////////////////
+// webhook
+
+type testWebhookService struct {
+	service.WebhookService
+	events []*model.WebhookEvent
+}
+
+func (s *testWebhookService) Notify(event *model.WebhookEvent) {
+	s.events = append(s.events, event)
+}
+
+// //////////////////////////////////////////////////
+// session
+
+func withTestUser(ctx context.Context, id model.UserId, name string) context.Context {
+	user := &model.User{Id: id, Name: name}
+	return model.WithSession(ctx, &model.Session{UserId: id, User: user})
+}
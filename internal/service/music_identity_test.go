@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+)
+
+func TestMusicIdentityMigration(t *testing.T) {
+	db := newTestDb(t, "00001_theme", "00002_theme_labels", "00007_theme_question_excerpt", "00008_music_year")
+
+	// the search index requires fts5, a plain table with the same columns is enough here
+	_, err := db.Exec("CREATE TABLE search (kind TEXT, ref_id INTEGER, parent_id INTEGER, text TEXT)")
+	require.NoError(t, err)
+	for _, migration := range []string{"00010_music_metadata", "00011_music_genre", "00012_music_contributor"} {
+		applyTestMigration(t, db, migration)
+	}
+
+	exec := func(query string, args ...any) {
+		_, err := db.Exec(query, args...)
+		require.NoError(t, err, query)
+	}
+	exec("INSERT INTO theme (id, title) VALUES (1, 'first'), (2, 'second')")
+	exec("INSERT INTO music (id, deezer_id, artist_id, name, mp3_url) VALUES (1, 0, 1, 'Song', 'a.mp3'), (2, 0, 1, 'song', 'b.mp3'), (3, 10, 2, 'Other', 'c.mp3'), (4, 10, 3, 'Other', 'd.mp3'), (5, 0, 1, 'Unique', 'e.mp3')")
+	// theme 1 has both duplicates, theme 2 only the second one
+	exec("INSERT INTO theme_question (id, theme_id, music_id, text) VALUES (1, 1, 1, 'first'), (2, 1, 2, 'first again'), (3, 2, 2, 'second'), (4, 2, 4, 'other')")
+	exec("INSERT INTO music_contributor (music_id, artist_id, role) VALUES (1, 1, 'main'), (2, 1, 'main'), (2, 4, 'featuring')")
+	exec("INSERT INTO music_genre (id, deezer_id, name) VALUES (1, 0, 'pop')")
+	exec("INSERT INTO music_genre_music (genre_id, music_id) VALUES (1, 2)")
+	exec("INSERT INTO search (kind, ref_id, parent_id, text) VALUES ('music', 1, 0, 'Song'), ('music', 2, 0, 'song'), ('theme-question', 2, 1, 'first again')")
+
+	applyTestMigration(t, db, "00013_music_identity")
+
+	ids := func(query string) []int64 {
+		rows, err := db.Query(query)
+		require.NoError(t, err)
+		defer rows.Close()
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		return ids
+	}
+
+	// the first registered music survives, by title and artist then by deezer id
+	require.Equal(t, []int64{1, 3, 5}, ids("SELECT id FROM music ORDER BY id"))
+
+	// the theme questions are moved to the survivor, a theme keeps a single question per music
+	require.Equal(t, []int64{1, 3, 4}, ids("SELECT id FROM theme_question ORDER BY id"))
+	require.Equal(t, []int64{1, 1, 3}, ids("SELECT music_id FROM theme_question ORDER BY id"))
+
+	// the dropped questions are kept along with the music they were merged into
+	require.Equal(t, []int64{2}, ids("SELECT id FROM music_identity_deleted_question"))
+	require.Equal(t, []int64{1}, ids("SELECT merged_music_id FROM music_identity_deleted_question"))
+	var text string
+	require.NoError(t, db.QueryRow("SELECT text FROM music_identity_deleted_question WHERE id = 2").Scan(&text))
+	require.Equal(t, "first again", text)
+
+	// the contributors and genres are moved to the survivor
+	require.Equal(t, []int64{1, 4}, ids("SELECT artist_id FROM music_contributor WHERE music_id = 1 ORDER BY artist_id"))
+	require.Equal(t, []int64{1}, ids("SELECT music_id FROM music_genre_music"))
+
+	// the search index forgets the deleted rows
+	require.Equal(t, []int64{1}, ids("SELECT ref_id FROM search WHERE kind = 'music'"))
+	require.Empty(t, ids("SELECT ref_id FROM search WHERE kind = 'theme-question'"))
+
+	// the indexes are created
+	exec("INSERT INTO music (deezer_id, artist_id, name, mp3_url) VALUES (0, 3, 'Song', 'f.mp3')")
+	_, err = db.Exec("INSERT INTO music (deezer_id, artist_id, name, mp3_url) VALUES (0, 1, 'SONG', 'g.mp3')")
+	require.Error(t, err)
+	_, err = db.Exec("INSERT INTO music (deezer_id, artist_id, name, mp3_url) VALUES (10, 4, 'New', 'h.mp3')")
+	require.Error(t, err)
+}
+
+func TestKeyMusicTitles(t *testing.T) {
+	ctx := context.Background()
+	db := newTestCatalogDb(t)
+	stores := newTestCatalogStores()
+	musicService := service.NewMusicService(zap.NewNop(), nil, nil, db, stores.music, stores.album, stores.artist, stores.genre, stores.contributor, stores.theme, stores.themeQuestion, nil, nil)
+
+	exec := func(query string, args ...any) error {
+		_, err := db.Exec(query, args...)
+		return err
+	}
+	// musics registered before the title keys, the first two only differ by their accents and punctuation
+	require.NoError(t, exec("INSERT INTO theme (id, title) VALUES (1, 'first'), (2, 'second')"))
+	require.NoError(t, exec("INSERT INTO music (id, deezer_id, artist_id, album_id, name, mp3_url) VALUES (1, 0, 1, 0, 'Café', 'a.mp3'), (2, 0, 1, 0, 'cafe!', 'b.mp3'), (3, 0, 2, 0, 'Café', 'c.mp3')"))
+	require.NoError(t, exec("INSERT INTO theme_question (id, theme_id, music_id, text) VALUES (1, 1, 1, 'first'), (2, 1, 2, 'first again'), (3, 2, 2, 'second')"))
+
+	require.NoError(t, musicService.KeyMusicTitles(ctx))
+
+	ids := func(query string) []int64 {
+		rows, err := db.Query(query)
+		require.NoError(t, err)
+		defer rows.Close()
+		var ids []int64
+		for rows.Next() {
+			var id int64
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		return ids
+	}
+
+	// the duplicate is merged into the first registered music, the others are keyed
+	require.Equal(t, []int64{1, 3}, ids("SELECT id FROM music WHERE title_key = 'cafe' ORDER BY id"))
+	require.Empty(t, ids("SELECT id FROM music WHERE title_key = ''"))
+	require.Equal(t, []int64{1, 3}, ids("SELECT id FROM theme_question ORDER BY id"))
+	require.Equal(t, []int64{1, 1}, ids("SELECT music_id FROM theme_question ORDER BY id"))
+
+	// new musics are keyed by the store and found by the service
+	_, err := musicService.CreateMusic(ctx, &model.Music{Name: "CAFÉ", Mp3Url: "d.mp3", ArtistId: 1, Artist: &model.MusicArtist{Id: 1, Name: "first"}})
+	require.ErrorIs(t, err, model.ErrExistingMusic)
+
+	// the index covers the musics without artist
+	require.NoError(t, exec("INSERT INTO music (deezer_id, artist_id, name, mp3_url, title_key) VALUES (0, NULL, 'Intro', 'e.mp3', 'intro')"))
+	require.Error(t, exec("INSERT INTO music (deezer_id, artist_id, name, mp3_url, title_key) VALUES (0, NULL, 'intro', 'f.mp3', 'intro')"))
+	require.Error(t, exec("INSERT INTO music (deezer_id, artist_id, name, mp3_url, title_key) VALUES (0, 0, 'INTRO', 'g.mp3', 'intro')"))
+	require.Error(t, exec("INSERT INTO music (deezer_id, artist_id, name, mp3_url, title_key) VALUES (0, 1, 'Cafe', 'h.mp3', 'cafe')"))
+}
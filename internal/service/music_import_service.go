@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// music import service
+
+type MusicImportService interface {
+	ImportLocalMusics(ctx context.Context, dryRun bool) (*model.MusicImportReport, error)
+}
+
+func NewMusicImportService(logger *zap.Logger, db *sql.DB, fileStore store.FileStore, musicFilter *model.FileFilter, imageFilter *model.FileFilter, musicService MusicService, musicStore store.MusicStore, albumStore store.MusicAlbumStore, artistStore store.MusicArtistStore) MusicImportService {
+	return &musicImportService{
+		logger:       logger,
+		db:           db,
+		fileStore:    fileStore,
+		musicFilter:  musicFilter,
+		imageFilter:  imageFilter,
+		musicService: musicService,
+		musicStore:   musicStore,
+		albumStore:   albumStore,
+		artistStore:  artistStore,
+	}
+}
+
+type musicImportService struct {
+	logger       *zap.Logger
+	db           *sql.DB
+	fileStore    store.FileStore
+	musicFilter  *model.FileFilter
+	imageFilter  *model.FileFilter
+	musicService MusicService
+	musicStore   store.MusicStore
+	albumStore   store.MusicAlbumStore
+	artistStore  store.MusicArtistStore
+}
+
+// //////////////////////////////////////////////////
+// import local musics
+
+// ImportLocalMusics registers the mp3 files of the music directory from their ID3 tags, reporting what would be created on dry run.
+func (s *musicImportService) ImportLocalMusics(ctx context.Context, dryRun bool) (*model.MusicImportReport, error) {
+
+	report := &model.MusicImportReport{
+		DryRun: dryRun,
+	}
+
+	paths, err := s.fileStore.List(ctx, s.musicFilter)
+	if err != nil {
+		s.logger.Info("[ KO ] import local musics", zap.Error(err))
+		return nil, err
+	}
+
+	//
+	// files already registered
+	//
+
+	registered := make(map[model.Url]*model.Music)
+	err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		for _, music := range s.musicStore.List(ctx, tx, &model.MusicFilter{}) {
+			if music.Mp3Url != "" {
+				registered[music.Mp3Url] = music
+			}
+		}
+	})
+	if err != nil {
+		s.logger.Info("[ KO ] import local musics", zap.Error(err))
+		return nil, err
+	}
+
+	//
+	// import
+	//
+
+	covers := make(map[string]model.Url)
+	for _, path := range paths {
+		item := s.importLocalMusic(ctx, path, dryRun, registered, covers)
+		s.logger.Info(fmt.Sprintf("[DEBUG] import %s: %s", item.Status, item.Path), zap.Object("item", item))
+		report.Add(item)
+	}
+
+	s.logger.Info(fmt.Sprintf("[ OK ] import %d local musics", len(report.Items)), zap.Object("report", report))
+	return report, nil
+}
+
+func (s *musicImportService) importLocalMusic(ctx context.Context, path model.Url, dryRun bool, registered map[model.Url]*model.Music, covers map[string]model.Url) *model.MusicImportItem {
+
+	item := &model.MusicImportItem{
+		Path: path,
+	}
+	invalid := func(err error) *model.MusicImportItem {
+		item.Status = model.MusicImportStatus_Invalid
+		item.Reason = err.Error()
+		return item
+	}
+	existing := func(music *model.Music) *model.MusicImportItem {
+		item.Status = model.MusicImportStatus_Existing
+		item.Music = music
+		return item
+	}
+
+	if music, found := registered[path]; found {
+		return existing(music)
+	}
+
+	//
+	// read tags
+	//
+
+	data, err := s.fileStore.Read(ctx, s.musicFilter, string(path))
+	if err != nil {
+		return invalid(err)
+	}
+	tag, err := util.ParseId3(data)
+	if err != nil {
+		return invalid(err)
+	}
+	music := model.ToImportedMusic(path, tag)
+	item.Music = music
+	if music.Artist == nil {
+		return invalid(model.ErrMissingArtist)
+	}
+
+	//
+	// check if the artist already has the music
+	//
+
+	var found *model.Music
+	var album *model.MusicAlbum
+	err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		if artist := s.artistStore.SearchByName(ctx, tx, music.Artist.Name); artist != nil {
+			found = s.musicStore.SearchByIdentity(ctx, tx, artist.Id, music.Name)
+			if music.Album != nil {
+				album = s.searchArtistAlbum(ctx, tx, artist.Id, music.Album.Name)
+			}
+		}
+	})
+	if err != nil {
+		return invalid(err)
+	}
+	if found != nil {
+		return existing(found)
+	}
+
+	//
+	// cover ( once per album of the artist )
+	//
+
+	key := strings.ToLower(music.Artist.Name)
+	if music.Album != nil {
+		key += "\x00" + strings.ToLower(music.Album.Name)
+	}
+	cover, extracted := covers[key]
+	if !extracted && len(tag.Picture) > 0 && music.Album != nil && (album == nil || album.ImgUrl == "") {
+		cover = music.GetCoverFileName(tag)
+	}
+	item.CoverUrl = cover
+
+	item.Status = model.MusicImportStatus_New
+	if dryRun {
+		return item
+	}
+
+	//
+	// create
+	//
+
+	// an album of the same name may belong to another artist, the album of the artist is then created beforehand
+	newAlbum := false
+	if music.Album != nil && album == nil {
+		err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+			album = s.albumStore.Create(ctx, tx, &model.MusicAlbum{Name: music.Album.Name})
+		})
+		if err != nil {
+			return invalid(err)
+		}
+		newAlbum = true
+	}
+	if album != nil {
+		music.AlbumId = album.Id
+	}
+
+	created, err := s.musicService.CreateMusic(ctx, music)
+	if err != nil {
+		if newAlbum {
+			s.deleteAlbum(ctx, album)
+		}
+		var conflict *model.MusicConflictError
+		if errors.As(err, &conflict) {
+			return existing(conflict.Existing)
+		}
+		return invalid(err)
+	}
+	item.Music = created
+
+	//
+	// extract cover once the music is created
+	//
+
+	if cover != "" && !extracted && !s.fileStore.Exists(ctx, s.imageFilter, string(cover)) {
+		if err := s.fileStore.Write(ctx, s.imageFilter, string(cover), tag.Picture); err != nil {
+			s.logger.Info(fmt.Sprintf("[ KO ] extract cover %s of %s", cover, path), zap.Error(err))
+			cover = ""
+			item.CoverUrl = ""
+		}
+	}
+	covers[key] = cover
+
+	//
+	// complete the album without image
+	//
+
+	if album != nil && album.ImgUrl == "" && cover != "" {
+		err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+			album.ImgUrl = cover
+			created.Album = s.albumStore.Update(ctx, tx, album)
+		})
+		if err != nil {
+			s.logger.Info(fmt.Sprintf("[ KO ] set cover %s of album %d", cover, album.Id), zap.Error(err))
+		}
+	}
+	return item
+}
+
+// searchArtistAlbum returns the album of the given name holding a music of the artist, since two artists may release albums of the same name.
+func (s *musicImportService) searchArtistAlbum(ctx context.Context, tx *sql.Tx, artistId model.MusicArtistId, name string) *model.MusicAlbum {
+	checked := make(map[model.MusicAlbumId]bool)
+	for _, music := range s.musicStore.List(ctx, tx, &model.MusicFilter{ArtistId: artistId}) {
+		if music.AlbumId == 0 || checked[music.AlbumId] {
+			continue
+		}
+		checked[music.AlbumId] = true
+		if album := s.albumStore.Retrieve(ctx, tx, music.AlbumId); strings.EqualFold(album.Name, name) {
+			return album
+		}
+	}
+	return nil
+}
+
+// deleteAlbum removes the album created for a music that could not be created.
+func (s *musicImportService) deleteAlbum(ctx context.Context, album *model.MusicAlbum) {
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.albumStore.Delete(ctx, tx, album.Id)
+	})
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] delete album %d", album.Id), zap.Error(err))
+	}
+}
@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/store/memory"
+)
+
+// newTestMp3 builds an mp3 with an ID3v2.3 tag holding the title, artist, album and a png cover.
+func newTestMp3(title, artist, album string, cover []byte) []byte {
+	var body bytes.Buffer
+	writeFrame := func(id string, frame []byte) {
+		body.WriteString(id)
+		binary.Write(&body, binary.BigEndian, uint32(len(frame)))
+		body.Write([]byte{0, 0})
+		body.Write(frame)
+	}
+	writeFrame("TIT2", append([]byte{0}, title...))
+	writeFrame("TPE1", append([]byte{0}, artist...))
+	writeFrame("TALB", append([]byte{0}, album...))
+	writeFrame("APIC", append([]byte("\x00image/png\x00\x03\x00"), cover...))
+	size := body.Len()
+	header := []byte{'I', 'D', '3', 3, 0, 0, byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(append(header, body.Bytes()...), bytes.Repeat([]byte{0xFF, 0xFB, 0x90, 0x00}, 10)...)
+}
+
+// failingMusicService fails to create musics.
+type failingMusicService struct {
+	service.MusicService
+}
+
+func (s *failingMusicService) CreateMusic(ctx context.Context, music *model.Music) (*model.Music, error) {
+	return nil, fmt.Errorf("music service failure")
+}
+
+func TestImportLocalMusicsCovers(t *testing.T) {
+	db := newTestDb(t)
+	stores := newTestGameStores()
+	fileStore := store.NewFileStore(zap.NewNop())
+	musicFilter := &model.FileFilter{Directory: t.TempDir(), Extensions: []string{".mp3"}}
+	imageFilter := &model.FileFilter{Directory: t.TempDir(), Extensions: []string{".jpg", ".png"}}
+	ctx := context.Background()
+
+	queenCover := []byte("\x89PNG\r\n\x1a\nqueen")
+	abbaCover := []byte("\x89PNG\r\n\x1a\nabba")
+	for path, data := range map[string][]byte{
+		"abba/gimme.mp3":  newTestMp3("Gimme! Gimme! Gimme!", "ABBA", "Greatest Hits", abbaCover),
+		"queen/bites.mp3": newTestMp3("Another One Bites the Dust", "Queen", "Greatest Hits", queenCover),
+		"queen/rhaps.mp3": newTestMp3("Bohemian Rhapsody", "Queen", "greatest hits", queenCover),
+	} {
+		path = filepath.Join(musicFilter.Directory, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, data, 0644))
+	}
+
+	// another artist already has an album of the same name
+	beeGees := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: "Bee Gees"})
+	beeGeesAlbum := stores.album.Create(ctx, nil, &model.MusicAlbum{Name: "Greatest Hits"})
+	stores.music.Create(ctx, nil, &model.Music{Name: "Stayin' Alive", Mp3Url: "stayin-alive.mp3", ArtistId: beeGees.Id, AlbumId: beeGeesAlbum.Id})
+
+	musicService := service.NewMusicService(zap.NewNop(), nil, nil, db, stores.music, stores.album, stores.artist, memory.NewMusicGenreMemoryStore(), stores.contributor, stores.theme, stores.themeQuestion, fileStore.PathValidator(ctx, musicFilter), fileStore.PathValidator(ctx, imageFilter))
+	newImportService := func(musicService service.MusicService) service.MusicImportService {
+		return service.NewMusicImportService(zap.NewNop(), db, fileStore, musicFilter, imageFilter, musicService, stores.music, stores.album, stores.artist)
+	}
+	listCovers := func() []model.Url {
+		covers, err := fileStore.List(ctx, imageFilter)
+		require.NoError(t, err)
+		return covers
+	}
+
+	// a failed creation leaves neither cover nor album behind
+	report, err := newImportService(&failingMusicService{}).ImportLocalMusics(ctx, false)
+	require.NoError(t, err)
+	for _, item := range report.Items {
+		require.Equal(t, model.MusicImportStatus_Invalid, item.Status, item.Path)
+	}
+	require.Empty(t, listCovers())
+	require.Len(t, stores.album.List(ctx, nil, &model.MusicAlbumFilter{}), 1)
+
+	// the dry run reports a cover per artist without writing it
+	report, err = newImportService(musicService).ImportLocalMusics(ctx, true)
+	require.NoError(t, err)
+	require.Len(t, report.Items, 3)
+	require.Equal(t, model.Url("album_abba_greatest-hits.png"), report.Items[0].CoverUrl)
+	require.Equal(t, model.Url("album_queen_greatest-hits.png"), report.Items[1].CoverUrl)
+	require.Empty(t, listCovers())
+
+	// each artist gets its own album and cover, the album of the other artist is left alone
+	report, err = newImportService(musicService).ImportLocalMusics(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, report.Items, 3)
+	for _, item := range report.Items {
+		require.Equal(t, model.MusicImportStatus_New, item.Status, item.Reason)
+	}
+	require.ElementsMatch(t, []model.Url{"album_abba_greatest-hits.png", "album_queen_greatest-hits.png"}, listCovers())
+	data, err := fileStore.Read(ctx, imageFilter, "album_queen_greatest-hits.png")
+	require.NoError(t, err)
+	require.Equal(t, queenCover, data)
+
+	abba, queen, rhapsody := report.Items[0].Music, report.Items[1].Music, report.Items[2].Music
+	require.NotEqual(t, beeGeesAlbum.Id, abba.AlbumId)
+	require.NotEqual(t, beeGeesAlbum.Id, queen.AlbumId)
+	require.NotEqual(t, abba.AlbumId, queen.AlbumId)
+	require.Equal(t, queen.AlbumId, rhapsody.AlbumId)
+	require.Equal(t, model.Url("album_abba_greatest-hits.png"), stores.album.Retrieve(ctx, nil, abba.AlbumId).ImgUrl)
+	require.Equal(t, model.Url("album_queen_greatest-hits.png"), stores.album.Retrieve(ctx, nil, queen.AlbumId).ImgUrl)
+	require.Empty(t, stores.album.Retrieve(ctx, nil, beeGeesAlbum.Id).ImgUrl)
+}
@@ -22,14 +22,16 @@ type MusicService interface {
 	SearchDeezerMusic(ctx context.Context, search *model.SearchDeezerMusicRequest) ([]*model.Music, error)
 	AddDeezerMusic(ctx context.Context, deezerId model.DeezerMusicId) (*model.Music, error)
 
-	ListMusic(ctx context.Context, filter *model.MusicFilter) ([]*model.Music, error)
+	ListMusic(ctx context.Context, filter *model.MusicFilter) (*model.MusicPage, error)
 	GetMusic(ctx context.Context, id model.MusicId) (*model.Music, error)
 	CreateMusic(ctx context.Context, music *model.Music) (*model.Music, error)
 	UpdateMusic(ctx context.Context, music *model.Music) (*model.Music, error)
 	DeleteMusic(ctx context.Context, id model.MusicId) error
+
+	KeyMusicTitles(ctx context.Context) error
 }
 
-func NewMusicService(logger *zap.Logger, deezerClient client.DeezerClient, downloadClient client.DownloadClient, db *sql.DB, musicStore store.MusicStore, albumStore store.MusicAlbumStore, artistStore store.MusicArtistStore, themeStore store.ThemeStore, themeQuestionStore store.ThemeQuestionStore, musicFileValidator model.PathValidator, imageFileValidator model.PathValidator) MusicService {
+func NewMusicService(logger *zap.Logger, deezerClient client.DeezerClient, downloadClient client.DownloadClient, db *sql.DB, musicStore store.MusicStore, albumStore store.MusicAlbumStore, artistStore store.MusicArtistStore, genreStore store.MusicGenreStore, contributorStore store.MusicContributorStore, themeStore store.ThemeStore, themeQuestionStore store.ThemeQuestionStore, musicFileValidator model.PathValidator, imageFileValidator model.PathValidator) MusicService {
 	return &musicService{
 		logger:             logger,
 		deezerClient:       deezerClient,
@@ -38,6 +40,8 @@ func NewMusicService(logger *zap.Logger, deezerClient client.DeezerClient, downl
 		musicStore:         musicStore,
 		albumStore:         albumStore,
 		artistStore:        artistStore,
+		genreStore:         genreStore,
+		contributorStore:   contributorStore,
 		themeStore:         themeStore,
 		themeQuestionStore: themeQuestionStore,
 		musicFileValidator: musicFileValidator,
@@ -53,6 +57,8 @@ type musicService struct {
 	musicStore         store.MusicStore
 	albumStore         store.MusicAlbumStore
 	artistStore        store.MusicArtistStore
+	genreStore         store.MusicGenreStore
+	contributorStore   store.MusicContributorStore
 	themeStore         store.ThemeStore
 	themeQuestionStore store.ThemeQuestionStore
 	musicFileValidator model.PathValidator
@@ -92,6 +98,7 @@ func (s *musicService) AddDeezerMusic(ctx context.Context, deezerId model.Deezer
 	var music *model.Music
 	var album *model.MusicAlbum
 	var artist *model.MusicArtist
+	var genres []*model.MusicGenre
 	var err error
 
 	//
@@ -145,10 +152,30 @@ func (s *musicService) AddDeezerMusic(ctx context.Context, deezerId model.Deezer
 				music.Artist = s.artistStore.Retrieve(ctx, tx, music.ArtistId)
 			}
 
+			//
+			// retrieve genres
+			//
+
+			music.Genres = s.genreStore.ListByMusic(ctx, tx, music.Id)
+
+			//
+			// retrieve contributors
+			//
+
+			music.Contributors = retrieveContributors(ctx, tx, s.contributorStore, s.artistStore, music.Id)
+
 			// stop as it already exists
 			return
 		}
 
+		//
+		// create genres ( if necessary )
+		//
+
+		if music.Album != nil {
+			genres = s.importDeezerGenres(ctx, tx, music.Album.Genres)
+		}
+
 		//
 		// create album ( if necessary )
 		//
@@ -162,6 +189,10 @@ func (s *musicService) AddDeezerMusic(ctx context.Context, deezerId model.Deezer
 				album = s.albumStore.Create(ctx, tx, music.Album)
 			}
 			music.AlbumId = album.Id
+			for _, genre := range genres {
+				s.genreStore.LinkAlbum(ctx, tx, genre.Id, album.Id)
+			}
+			album.Genres = genres
 		}
 
 		//
@@ -179,6 +210,18 @@ func (s *musicService) AddDeezerMusic(ctx context.Context, deezerId model.Deezer
 			music.ArtistId = artist.Id
 		}
 
+		//
+		// create contributors ( if necessary )
+		//
+
+		contributors := s.importDeezerContributors(ctx, tx, artist, music.Contributors)
+
+		//
+		// check if the artist already has the music ( e.g. created by hand )
+		//
+
+		s.checkMusicIdentity(ctx, tx, music)
+
 		//
 		// create music
 		//
@@ -187,8 +230,16 @@ func (s *musicService) AddDeezerMusic(ctx context.Context, deezerId model.Deezer
 		s.logger.Info("[DEBUG] music... 3", zap.Object("music", music))
 		music = s.musicStore.Create(ctx, tx, music)
 		s.logger.Info("[DEBUG] music... 4", zap.Object("music", music))
+		for _, genre := range genres {
+			s.genreStore.LinkMusic(ctx, tx, genre.Id, music.Id)
+		}
+		for _, contributor := range contributors {
+			s.contributorStore.Link(ctx, tx, music.Id, contributor)
+		}
 		music.Album = album
 		music.Artist = artist
+		music.Genres = genres
+		music.Contributors = contributors
 	})
 
 	if err != nil {
@@ -200,20 +251,146 @@ func (s *musicService) AddDeezerMusic(ctx context.Context, deezerId model.Deezer
 
 }
 
+// importDeezerGenres retrieves or creates the deezer genres of an album.
+// a genre of the same name without deezer id ( e.g. created by hand ) is bound to the deezer genre.
+func (s *musicService) importDeezerGenres(ctx context.Context, tx *sql.Tx, deezerGenres []*model.MusicGenre) []*model.MusicGenre {
+	genres := make([]*model.MusicGenre, 0, len(deezerGenres))
+	for _, deezerGenre := range deezerGenres {
+		if deezerGenre.DeezerId == 0 || deezerGenre.Name == "" {
+			continue
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve genre from deezer id %d", deezerGenre.DeezerId))
+		genre := s.genreStore.SearchByDeezerId(ctx, tx, deezerGenre.DeezerId)
+		if genre == nil {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve genre from name %q", deezerGenre.Name))
+			genre = s.genreStore.SearchByName(ctx, tx, deezerGenre.Name)
+			if genre != nil && genre.DeezerId == 0 {
+				s.logger.Info(fmt.Sprintf("[DEBUG] bind genre %d to deezer id %d", genre.Id, deezerGenre.DeezerId))
+				genre.DeezerId = deezerGenre.DeezerId
+				if genre.ImgUrl == "" {
+					genre.ImgUrl = deezerGenre.ImgUrl
+				}
+				genre = s.genreStore.Update(ctx, tx, genre)
+			}
+		}
+		if genre == nil {
+			s.logger.Info(fmt.Sprintf("[DEBUG] create genre: %#v", deezerGenre.Copy()))
+			genre = s.genreStore.Create(ctx, tx, deezerGenre)
+		}
+		genres = append(genres, genre)
+	}
+	return genres
+}
+
+// importDeezerContributors retrieves or creates the artists of the deezer contributors.
+// the artist of the music always comes first as main artist.
+func (s *musicService) importDeezerContributors(ctx context.Context, tx *sql.Tx, artist *model.MusicArtist, deezerContributors []*model.MusicContributor) []*model.MusicContributor {
+	contributors := make([]*model.MusicContributor, 0, len(deezerContributors)+1)
+	if artist != nil {
+		contributors = append(contributors, &model.MusicContributor{ArtistId: artist.Id, Role: model.MusicArtistRole_Main, Artist: artist})
+	}
+	for _, deezerContributor := range deezerContributors {
+		if deezerContributor.Artist == nil || deezerContributor.Artist.DeezerId == 0 {
+			continue
+		}
+		var contributor *model.MusicArtist
+		if artist != nil && artist.DeezerId == deezerContributor.Artist.DeezerId {
+			contributor = artist
+		} else {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve contributor from deezer id %d", deezerContributor.Artist.DeezerId))
+			contributor = s.artistStore.SearchByDeezerId(ctx, tx, deezerContributor.Artist.DeezerId)
+			if contributor == nil {
+				s.logger.Info(fmt.Sprintf("[DEBUG] create contributor: %#v", deezerContributor.Artist.Copy()))
+				contributor = s.artistStore.Create(ctx, tx, deezerContributor.Artist)
+			}
+		}
+		if _, found := util.FindIf(contributors, func(existing *model.MusicContributor) bool {
+			return existing.ArtistId == contributor.Id && existing.Role == deezerContributor.Role
+		}); found {
+			continue
+		}
+		contributors = append(contributors, &model.MusicContributor{ArtistId: contributor.Id, Role: deezerContributor.Role, Artist: contributor})
+	}
+	return contributors
+}
+
+// checkMusicIdentity rejects a music having the title of another music of the same artist or the deezer id of another music.
+func (s *musicService) checkMusicIdentity(ctx context.Context, tx *sql.Tx, music *model.Music) {
+	s.logger.Info(fmt.Sprintf("[DEBUG] retrieve music of artist %d from title %q", music.ArtistId, music.Name))
+	if other := s.musicStore.SearchByIdentity(ctx, tx, music.ArtistId, music.Name); other != nil && other.Id != music.Id {
+		panic(model.NewMusicConflictError(other))
+	}
+	if music.DeezerId != 0 {
+		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve music from deezer id %d", music.DeezerId))
+		if other := s.musicStore.SearchByDeezerId(ctx, tx, music.DeezerId); other != nil && other.Id != music.Id {
+			panic(model.NewMusicConflictError(other))
+		}
+	}
+}
+
+// retrieveContributors lists the contributors of a music along with their artists.
+func retrieveContributors(ctx context.Context, tx *sql.Tx, contributorStore store.MusicContributorStore, artistStore store.MusicArtistStore, musicId model.MusicId) []*model.MusicContributor {
+	contributors := contributorStore.ListByMusic(ctx, tx, musicId)
+	for _, contributor := range contributors {
+		contributor.Artist = artistStore.Retrieve(ctx, tx, contributor.ArtistId)
+	}
+	return contributors
+}
+
+// mergeMusic moves the theme questions, contributors and genres of the duplicate music to the surviving one, completes it, then deletes the duplicate.
+// a theme already asking the surviving music drops the question of the duplicate.
+func mergeMusic(ctx context.Context, tx *sql.Tx, logger *zap.Logger, musicStore store.MusicStore, contributorStore store.MusicContributorStore, genreStore store.MusicGenreStore, themeQuestionStore store.ThemeQuestionStore, music *model.Music, duplicate *model.Music) {
+	logger.Info(fmt.Sprintf("[DEBUG] merge music %d into music %d - %s", duplicate.Id, music.Id, music.Name))
+
+	for _, question := range themeQuestionStore.List(ctx, tx, &model.ThemeQuestionFilter{MusicId: duplicate.Id}) {
+		if themeQuestionStore.IsMusicInTheme(ctx, tx, question.ThemeId, music.Id) {
+			logger.Info(fmt.Sprintf("[DEBUG] delete question %d of theme %d", question.Id, question.ThemeId))
+			themeQuestionStore.Delete(ctx, tx, &model.ThemeQuestionFilter{ThemeQuestionId: question.Id})
+			continue
+		}
+		question.MusicId = music.Id
+		themeQuestionStore.Update(ctx, tx, question)
+	}
+
+	for _, contributor := range contributorStore.ListByMusic(ctx, tx, duplicate.Id) {
+		contributorStore.Link(ctx, tx, music.Id, contributor)
+	}
+	for _, genre := range genreStore.ListByMusic(ctx, tx, duplicate.Id) {
+		genreStore.LinkMusic(ctx, tx, genre.Id, music.Id)
+	}
+
+	// deleted first: the surviving music may take over its deezer id
+	contributorStore.UnlinkAllMusic(ctx, tx, duplicate.Id)
+	genreStore.UnlinkAllMusic(ctx, tx, duplicate.Id)
+	musicStore.Delete(ctx, tx, duplicate.Id)
+
+	music.MergeWith(duplicate)
+	musicStore.Update(ctx, tx, music)
+}
+
 // //////////////////////////////////////////////////
 // list music
 
-func (s *musicService) ListMusic(ctx context.Context, filter *model.MusicFilter) ([]*model.Music, error) {
-	var musics []*model.Music
+// ListMusic returns a page of musics along with the total number of matching musics and the number of themes using each music.
+func (s *musicService) ListMusic(ctx context.Context, filter *model.MusicFilter) (*model.MusicPage, error) {
+	page := &model.MusicPage{
+		Offset: filter.Offset,
+		Limit:  filter.Limit,
+	}
 	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
-		musics = s.musicStore.List(ctx, tx, filter)
+		page.Musics = s.musicStore.List(ctx, tx, filter)
+		page.Total = s.musicStore.Count(ctx, tx, filter)
+		nbThemes := s.themeQuestionStore.CountThemesByMusic(ctx, tx)
+		for _, music := range page.Musics {
+			music.NbTheme = nbThemes[music.Id]
+		}
 	})
 	if err != nil {
-		s.logger.Info("[ KO ] list music", zap.Error(err))
+		s.logger.Info("[ KO ] list music", zap.Object("filter", filter), zap.Error(err))
 		return nil, err
 	}
-	s.logger.Info("[ OK ] list music")
-	return musics, nil
+	s.logger.Info(fmt.Sprintf("[ OK ] list %d / %d music", len(page.Musics), page.Total), zap.Object("filter", filter))
+	return page, nil
 }
 
 // //////////////////////////////////////////////////
@@ -248,22 +425,15 @@ func (s *musicService) CreateMusic(ctx context.Context, music *model.Music) (*mo
 
 	err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
 
-		//
-		// check if music exists
-		//
-
-		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve music from name %q", music.Name))
-		orig := s.musicStore.SearchByName(ctx, tx, music.Name)
-		if orig != nil {
-			panic(model.ErrExistingMusic)
-		}
-
 		//
 		// create album ( if necessary )
 		//
 
 		s.logger.Info("[DEBUG] music... 2", zap.Object("music", music))
-		if music.Album != nil && music.Album.Name != "" {
+		if music.AlbumId != 0 {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve album %d", music.AlbumId))
+			album = s.albumStore.Retrieve(ctx, tx, music.AlbumId)
+		} else if music.Album != nil && music.Album.Name != "" {
 			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve album from name %q", music.Album.Name))
 			album = s.albumStore.SearchByName(ctx, tx, music.Album.Name)
 			if album == nil {
@@ -288,6 +458,12 @@ func (s *musicService) CreateMusic(ctx context.Context, music *model.Music) (*mo
 			music.ArtistId = artist.Id
 		}
 
+		//
+		// check if the artist already has the music
+		//
+
+		s.checkMusicIdentity(ctx, tx, music)
+
 		//
 		// create music
 		//
@@ -296,6 +472,10 @@ func (s *musicService) CreateMusic(ctx context.Context, music *model.Music) (*mo
 		s.logger.Info("[DEBUG] music... 3", zap.Object("music", music))
 		music = s.musicStore.Create(ctx, tx, music)
 		s.logger.Info("[DEBUG] music... 4", zap.Object("music", music))
+		if artist != nil {
+			s.contributorStore.Link(ctx, tx, music.Id, &model.MusicContributor{ArtistId: artist.Id, Role: model.MusicArtistRole_Main})
+			music.Contributors = retrieveContributors(ctx, tx, s.contributorStore, s.artistStore, music.Id)
+		}
 		music.Album = album
 		music.Artist = artist
 	})
@@ -342,6 +522,18 @@ func (s *musicService) GetMusic(ctx context.Context, id model.MusicId) (*model.M
 			music.Artist = s.artistStore.Retrieve(ctx, tx, music.ArtistId)
 		}
 
+		//
+		// retrieve genres
+		//
+
+		music.Genres = s.genreStore.ListByMusic(ctx, tx, music.Id)
+
+		//
+		// retrieve contributors
+		//
+
+		music.Contributors = retrieveContributors(ctx, tx, s.contributorStore, s.artistStore, music.Id)
+
 		//
 		// retrieve related questions
 		//
@@ -363,6 +555,17 @@ func (s *musicService) GetMusic(ctx context.Context, id model.MusicId) (*model.M
 
 func (s *musicService) UpdateMusic(ctx context.Context, music *model.Music) (*model.Music, error) {
 
+	//
+	// release: the edited field wins over the other one
+	//
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		music.WithEditedRelease(s.musicStore.Retrieve(ctx, tx, music.Id))
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	//
 	// pre-validate
 	//
@@ -382,11 +585,30 @@ func (s *musicService) UpdateMusic(ctx context.Context, music *model.Music) (*mo
 	//
 
 	var updated *model.Music
-	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+	err = util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.checkMusicIdentity(ctx, tx, music)
+		existing := s.musicStore.Retrieve(ctx, tx, music.Id)
 		updated = s.musicStore.Update(ctx, tx, music)
 		updated.Artist = s.artistStore.Update(ctx, tx, music.Artist)
 		updated.Album = s.albumStore.Update(ctx, tx, music.Album)
 
+		//
+		// contributors: the ones of the body ( if any ) with the artist of the music as main contributor
+		//
+
+		contributors := music.Contributors
+		if contributors == nil {
+			contributors = s.contributorStore.ListByMusic(ctx, tx, music.Id)
+		}
+		contributors = model.SyncMainContributor(contributors, existing.ArtistId, music.ArtistId)
+		s.contributorStore.UnlinkAllMusic(ctx, tx, music.Id)
+		for _, contributor := range contributors {
+			s.logger.Info(fmt.Sprintf("[DEBUG] retrieve contributor %d", contributor.ArtistId))
+			s.artistStore.Retrieve(ctx, tx, contributor.ArtistId)
+			s.contributorStore.Link(ctx, tx, music.Id, contributor)
+		}
+		updated.Contributors = retrieveContributors(ctx, tx, s.contributorStore, s.artistStore, music.Id)
+
 		if err := updated.Validate(s.musicFileValidator, s.imageFileValidator); err != nil {
 			panic(err)
 		}
@@ -426,7 +648,10 @@ func (s *musicService) DeleteMusic(ctx context.Context, id model.MusicId) error
 		music := s.musicStore.Retrieve(ctx, tx, id)
 
 		s.logger.Info(fmt.Sprintf("[DEBUG] delete music %d", id))
+		contributors := s.contributorStore.ListByMusic(ctx, tx, id)
 		s.musicStore.Delete(ctx, tx, id)
+		s.genreStore.UnlinkAllMusic(ctx, tx, id)
+		s.contributorStore.UnlinkAllMusic(ctx, tx, id)
 
 		//
 		// delete album if no more used
@@ -437,6 +662,7 @@ func (s *musicService) DeleteMusic(ctx context.Context, id model.MusicId) error
 			if used := s.musicStore.IsAlbumUsed(ctx, tx, music.AlbumId); !used {
 				s.logger.Info(fmt.Sprintf("[DEBUG] delete unused album %d", music.AlbumId))
 				s.albumStore.Delete(ctx, tx, music.AlbumId)
+				s.genreStore.UnlinkAllAlbum(ctx, tx, music.AlbumId)
 			}
 		}
 
@@ -451,6 +677,21 @@ func (s *musicService) DeleteMusic(ctx context.Context, id model.MusicId) error
 				s.artistStore.Delete(ctx, tx, music.ArtistId)
 			}
 		}
+
+		//
+		// delete contributors if no more used
+		//
+
+		for _, contributor := range contributors {
+			if contributor.ArtistId == music.ArtistId {
+				continue
+			}
+			s.logger.Info(fmt.Sprintf("[DEBUG] check usage of contributor %d", contributor.ArtistId))
+			if used := s.musicStore.IsArtistUsed(ctx, tx, contributor.ArtistId); !used {
+				s.logger.Info(fmt.Sprintf("[DEBUG] delete unused contributor %d", contributor.ArtistId))
+				s.artistStore.Delete(ctx, tx, contributor.ArtistId)
+			}
+		}
 	})
 
 	if err != nil {
@@ -461,6 +702,35 @@ func (s *musicService) DeleteMusic(ctx context.Context, id model.MusicId) error
 	return nil
 }
 
+// //////////////////////////////////////////////////
+// key music titles
+
+// KeyMusicTitles stores the normalized title of the musics registered before the title keys, backing the identity index.
+// a music with the identity of an already keyed music is merged into the latter.
+func (s *musicService) KeyMusicTitles(ctx context.Context) error {
+
+	var nbKeyed, nbMerged int
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		for _, music := range s.musicStore.ListWithoutTitleKey(ctx, tx) {
+			if other := s.musicStore.SearchByIdentity(ctx, tx, music.ArtistId, music.Name); other != nil {
+				s.logger.Info(fmt.Sprintf("[DEBUG] music %d - %s has the identity of music %d - %s", music.Id, music.Name, other.Id, other.Name))
+				mergeMusic(ctx, tx, s.logger, s.musicStore, s.contributorStore, s.genreStore, s.themeQuestionStore, other, music)
+				nbMerged++
+				continue
+			}
+			s.musicStore.Update(ctx, tx, music)
+			nbKeyed++
+		}
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] key music titles", zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] key %d music titles, merge %d duplicate musics", nbKeyed, nbMerged))
+	return nil
+}
+
 // //////////////////////////////////////////////////
 // attach theme
 
@@ -482,7 +752,19 @@ func (s *musicService) DownloadRemoteFiles(ctx context.Context, music *model.Mus
 	}
 	downloadMusic(s.logger, s.downloadClient, music)
 	downloadArtistImage(s.logger, s.downloadClient, music.Artist)
+	for _, contributor := range music.Contributors {
+		if music.Artist != nil && contributor.Artist != nil && contributor.Artist.DeezerId != 0 && contributor.Artist.DeezerId == music.Artist.DeezerId {
+			contributor.Artist.ImgUrl = music.Artist.ImgUrl
+			continue
+		}
+		downloadArtistImage(s.logger, s.downloadClient, contributor.Artist)
+	}
 	downloadAlbumImage(s.logger, s.downloadClient, music.Album)
+	if music.Album != nil {
+		for _, genre := range music.Album.Genres {
+			downloadGenreImage(s.logger, s.downloadClient, genre)
+		}
+	}
 }
 
 func downloadMusic(logger *zap.Logger, downloadClient client.DownloadClient, music *model.Music) {
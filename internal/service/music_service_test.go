@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store/memory"
+)
+
+func TestUpdateMusicContributors(t *testing.T) {
+	db := newTestDb(t)
+	stores := newTestGameStores()
+	musicService := service.NewMusicService(zap.NewNop(), nil, nil, db, stores.music, stores.album, stores.artist, memory.NewMusicGenreMemoryStore(), stores.contributor, stores.theme, stores.themeQuestion, nil, nil)
+	ctx := context.Background()
+
+	abba := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: "ABBA"})
+	queen := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: "Queen"})
+	bowie := stores.artist.Create(ctx, nil, &model.MusicArtist{Name: "David Bowie"})
+	album := stores.album.Create(ctx, nil, &model.MusicAlbum{Name: "Hot Space"})
+	music := stores.music.Create(ctx, nil, &model.Music{Name: "Under Pressure", Mp3Url: "under-pressure.mp3", ArtistId: abba.Id, AlbumId: album.Id})
+	stores.contributor.Link(ctx, nil, music.Id, &model.MusicContributor{ArtistId: abba.Id, Role: model.MusicArtistRole_Main})
+	stores.contributor.Link(ctx, nil, music.Id, &model.MusicContributor{ArtistId: bowie.Id, Role: model.MusicArtistRole_Featured})
+
+	edit := func(artist *model.MusicArtist, contributors []*model.MusicContributor) *model.Music {
+		edited := music.Copy()
+		edited.ArtistId = artist.Id
+		edited.Artist = artist
+		edited.Album = album
+		edited.Contributors = contributors
+		updated, err := musicService.UpdateMusic(ctx, edited)
+		require.NoError(t, err)
+		return updated
+	}
+	roles := func(music *model.Music) map[model.MusicArtistId]model.MusicArtistRole {
+		roles := map[model.MusicArtistId]model.MusicArtistRole{}
+		for _, contributor := range music.Contributors {
+			roles[contributor.ArtistId] = contributor.Role
+		}
+		return roles
+	}
+
+	// a new artist replaces the former main contributor, the others are kept
+	updated := edit(queen, nil)
+	require.Equal(t, map[model.MusicArtistId]model.MusicArtistRole{
+		queen.Id: model.MusicArtistRole_Main,
+		bowie.Id: model.MusicArtistRole_Featured,
+	}, roles(updated))
+	require.Equal(t, []string{"Queen"}, updated.GetArtistNames(model.MusicArtistRole_Main))
+
+	// the contributors of the body replace the stored ones
+	updated = edit(queen, []*model.MusicContributor{
+		{ArtistId: bowie.Id, Role: model.MusicArtistRole_Main},
+		{ArtistId: abba.Id, Role: model.MusicArtistRole_Composer},
+	})
+	require.Equal(t, map[model.MusicArtistId]model.MusicArtistRole{
+		queen.Id: model.MusicArtistRole_Main,
+		bowie.Id: model.MusicArtistRole_Main,
+		abba.Id:  model.MusicArtistRole_Composer,
+	}, roles(updated))
+	require.Equal(t, []string{"Queen", "David Bowie"}, updated.GetArtistNames(model.MusicArtistRole_Main))
+
+	// unknown artists and roles are rejected
+	edited := music.Copy()
+	edited.Artist, edited.ArtistId, edited.Album = queen, queen.Id, album
+	edited.Contributors = []*model.MusicContributor{{ArtistId: 999, Role: model.MusicArtistRole_Featured}}
+	_, err := musicService.UpdateMusic(ctx, edited)
+	require.ErrorIs(t, err, model.ErrMusicArtistNotFound)
+	edited.Contributors = []*model.MusicContributor{{ArtistId: bowie.Id, Role: "singer"}}
+	_, err = musicService.UpdateMusic(ctx, edited)
+	require.ErrorIs(t, err, model.ErrInvalidMusicArtistRole)
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// search service
+
+type SearchService interface {
+	Search(ctx context.Context, query string, limit int) (*model.SearchResult, error)
+}
+
+func NewSearchService(logger *zap.Logger, db *sql.DB, searchStore store.SearchStore, themeStore store.ThemeStore) SearchService {
+	return &searchService{
+		logger:      logger,
+		db:          db,
+		searchStore: searchStore,
+		themeStore:  themeStore,
+	}
+}
+
+type searchService struct {
+	logger      *zap.Logger
+	db          *sql.DB
+	searchStore store.SearchStore
+	themeStore  store.ThemeStore
+}
+
+// //////////////////////////////////////////////////
+// search
+
+// Search returns, for each kind, the best ranked entries matching every word of the query regardless of case and accents.
+// the limit applies per kind, the theme questions being limited before being grouped by theme.
+func (s *searchService) Search(ctx context.Context, query string, limit int) (*model.SearchResult, error) {
+
+	if util.ToFtsQuery(query) == "" {
+		return nil, model.ErrInvalidSearchQuery
+	}
+
+	result := &model.SearchResult{
+		Query: query,
+	}
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		result.Musics = s.searchStore.Search(ctx, tx, model.SearchKind_Music, query, limit)
+		result.Artists = s.searchStore.Search(ctx, tx, model.SearchKind_Artist, query, limit)
+		result.Albums = s.searchStore.Search(ctx, tx, model.SearchKind_Album, query, limit)
+		result.Themes = model.ToSearchThemeHits(s.searchStore.Search(ctx, tx, model.SearchKind_ThemeQuestion, query, limit))
+		for _, hit := range result.Themes {
+			hit.Title = s.themeStore.Retrieve(ctx, tx, hit.ThemeId).Title
+		}
+	})
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] search %q", query), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] search %q", query), zap.Object("result", result))
+	return result, nil
+}
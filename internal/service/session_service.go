@@ -20,6 +20,7 @@ import (
 
 type SessionService interface {
 	Login(ctx context.Context, login *model.LoginRequest) (*model.Session, error)
+	Authenticate(ctx context.Context, token model.SessionToken) (*model.Session, error)
 	IsGranted(ctx context.Context, token model.SessionToken, permission model.Permission) (*model.Session, error)
 	Logout(ctx context.Context, token model.SessionToken) error
 
@@ -189,62 +190,42 @@ func (s *sessionService) newSession(userId model.UserId, ttl time.Duration) (*mo
 }
 
 // //////////////////////////////////////////////////
-// retrieve
-
-func (s *sessionService) IsGranted(ctx context.Context, token model.SessionToken, permission model.Permission) (*model.Session, error) {
+// authenticate
 
-	now := time.Now()
+func (s *sessionService) Authenticate(ctx context.Context, token model.SessionToken) (*model.Session, error) {
 
 	var session *model.Session
-	var user *model.User
 	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		session = s.retrieveSession(ctx, tx, token)
+	})
 
-		//
-		// clean-up expired sessions
-		//
-
-		s.logger.Info("[DEBUG] clean-up expired sessions")
-		s.sessionStore.CleanUp(ctx, tx, now)
-
-		//
-		// retrieve session
-		//
+	if err != nil {
+		s.logger.Info("[ KO ] authenticate", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] authenticate user %d", session.UserId))
+	return session, nil
+}
 
-		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve session %s", token))
-		session = s.sessionStore.Retrieve(ctx, tx, token)
+// //////////////////////////////////////////////////
+// is granted
 
-		//
-		// validate session
-		//
+func (s *sessionService) IsGranted(ctx context.Context, token model.SessionToken, permission model.Permission) (*model.Session, error) {
 
-		jwtToken, err := jwt.Parse(
-			session.Token.String(),
-			func(token *jwt.Token) (interface{}, error) {
-				return []byte(s.secretKey), nil
-			},
-		)
-		if err != nil {
-			s.logger.Info(fmt.Sprintf("[DEBUG] invalid session token: %s", token), zap.Error(err))
-			panic(model.ErrInvalidSessionToken)
-		}
-		if !jwtToken.Valid {
-			s.logger.Info(fmt.Sprintf("[DEBUG] invalid session token: %s", token))
-			panic(model.ErrInvalidSessionToken)
-		}
-		// TODO need more validation
+	var session *model.Session
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
 
 		//
-		// retrieve user
+		// retrieve session
 		//
 
-		s.logger.Info(fmt.Sprintf("[DEBUG] retrieve user %s", session.UserId))
-		user = s.userStore.Retrieve(ctx, tx, session.UserId)
+		session = s.retrieveSession(ctx, tx, token)
 
 		//
 		// check permission
 		//
 
-		if !user.HasPermission(permission) {
+		if !session.User.HasPermission(permission) {
 			panic(model.ErrUserNotGranted)
 		}
 	})
@@ -254,10 +235,57 @@ func (s *sessionService) IsGranted(ctx context.Context, token model.SessionToken
 		return nil, err
 	}
 	s.logger.Info(fmt.Sprintf("[ OK ] is granted for permission %s", permission))
-	session.User = user
 	return session, nil
 }
 
+func (s *sessionService) retrieveSession(ctx context.Context, tx *sql.Tx, token model.SessionToken) *model.Session {
+
+	now := time.Now()
+
+	//
+	// clean-up expired sessions
+	//
+
+	s.logger.Info("[DEBUG] clean-up expired sessions")
+	s.sessionStore.CleanUp(ctx, tx, now)
+
+	//
+	// retrieve session
+	//
+
+	s.logger.Info(fmt.Sprintf("[DEBUG] retrieve session %s", token))
+	session := s.sessionStore.Retrieve(ctx, tx, token)
+
+	//
+	// validate session
+	//
+
+	jwtToken, err := jwt.Parse(
+		session.Token.String(),
+		func(token *jwt.Token) (interface{}, error) {
+			return []byte(s.secretKey), nil
+		},
+	)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[DEBUG] invalid session token: %s", token), zap.Error(err))
+		panic(model.ErrInvalidSessionToken)
+	}
+	if !jwtToken.Valid {
+		s.logger.Info(fmt.Sprintf("[DEBUG] invalid session token: %s", token))
+		panic(model.ErrInvalidSessionToken)
+	}
+	// TODO need more validation
+
+	//
+	// retrieve user
+	//
+
+	s.logger.Info(fmt.Sprintf("[DEBUG] retrieve user %s", session.UserId))
+	session.User = s.userStore.Retrieve(ctx, tx, session.UserId)
+
+	return session
+}
+
 // //////////////////////////////////////////////////
 // retrieve
 
@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+func newTestSessionService(db *sql.DB, secretKey string) service.SessionService {
+	return service.NewSessionService(zap.NewNop(), secretKey, db, store.NewSessionStore(zap.NewNop()), store.NewUserStore(zap.NewNop()))
+}
+
+func createTestUser(t *testing.T, db *sql.DB, name string, password string) *model.User {
+	user := &model.User{Name: name, Password: password}
+	require.NoError(t, user.HashPassword())
+	err := util.SqlTransaction(context.Background(), db, func(tx *sql.Tx) {
+		user = store.NewUserStore(zap.NewNop()).Create(context.Background(), tx, user)
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	db := newTestDb(t, "00003_user")
+	sessionService := newTestSessionService(db, "secret")
+	alice := createTestUser(t, db, "alice", "password")
+
+	session, err := sessionService.Login(ctx, &model.LoginRequest{Name: "alice", Password: "password"})
+	require.NoError(t, err)
+
+	// valid token
+	authenticated, err := sessionService.Authenticate(ctx, session.Token)
+	require.NoError(t, err)
+	require.Equal(t, alice.Id, authenticated.UserId)
+	require.Equal(t, "alice", authenticated.User.Name)
+
+	// unknown token
+	_, err = sessionService.Authenticate(ctx, "unknown")
+	require.ErrorIs(t, err, model.ErrSessionNotFound)
+
+	// token signed with another secret
+	_, err = newTestSessionService(db, "other").Authenticate(ctx, session.Token)
+	require.ErrorIs(t, err, model.ErrInvalidSessionToken)
+
+	// logged out token
+	require.NoError(t, sessionService.Logout(ctx, session.Token))
+	_, err = sessionService.Authenticate(ctx, session.Token)
+	require.ErrorIs(t, err, model.ErrSessionNotFound)
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// tournament service
+
+type TournamentService interface {
+	CreateTournament(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error)
+	RetrieveTournament(ctx context.Context, id model.TournamentId) (*model.Tournament, error)
+	RetrieveStandings(ctx context.Context, id model.TournamentId) ([]*model.TournamentStanding, error)
+	NextStage(ctx context.Context, id model.TournamentId) (*model.Tournament, error)
+	DeleteTournament(ctx context.Context, id model.TournamentId) error
+}
+
+// tournamentGameService creates and retrieves the games of a stage within the transaction of the tournament.
+type tournamentGameService interface {
+	createGame(ctx context.Context, tx *sql.Tx, settings model.GameSettings) *model.Game
+	renamePlayer(ctx context.Context, tx *sql.Tx, id model.GameId, playerId model.GamePlayerId, name string) *model.Game
+	retrieveGame(ctx context.Context, tx *sql.Tx, id model.GameId) *model.Game
+	notifyGameCreated(game *model.Game)
+}
+
+func NewTournamentService(logger *zap.Logger, db *sql.DB, gameService GameService, tournamentStore store.TournamentStore) TournamentService {
+	return &tournamentService{
+		logger:          logger,
+		db:              db,
+		gameService:     gameService.(tournamentGameService),
+		tournamentStore: tournamentStore,
+	}
+}
+
+type tournamentService struct {
+	logger          *zap.Logger
+	db              *sql.DB
+	gameService     tournamentGameService
+	tournamentStore store.TournamentStore
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *tournamentService) CreateTournament(ctx context.Context, tournament *model.Tournament) (*model.Tournament, error) {
+
+	var created *model.Tournament
+	var games []*model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// owner
+		//
+
+		currentUser := model.GetCurrentUser(ctx)
+		if currentUser == nil {
+			panic(model.ErrNotTournamentOwner)
+		}
+		tournament.OwnerId = currentUser.Id
+
+		//
+		// participants: registration order is the seed order
+		//
+
+		for index, participant := range tournament.Participants {
+			participant.Id = model.TournamentParticipantId(index + 1)
+		}
+		if err := tournament.Validate(); err != nil {
+			panic(err)
+		}
+
+		//
+		// first stage
+		//
+
+		participantIds := util.Convert(tournament.Participants, func(participant *model.TournamentParticipant) model.TournamentParticipantId { return participant.Id })
+		games = s.createStage(ctx, tx, tournament, participantIds)
+
+		s.logger.Info("[DEBUG] create tournament", zap.Object("tournament", tournament))
+		created = s.tournamentStore.Create(ctx, tx, tournament)
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] create tournament", zap.Object("tournament", tournament), zap.Error(err))
+		return nil, err
+	}
+	s.notifyGamesCreated(games)
+	s.logger.Info("[ OK ] create tournament", zap.Object("tournament", created))
+	return created, nil
+}
+
+// createStage seeds the participants into a new stage and creates the game of each table with the participant names.
+// the games are created within the transaction of the tournament so that a failure leaves no orphan game.
+func (s *tournamentService) createStage(ctx context.Context, tx *sql.Tx, tournament *model.Tournament, participantIds []model.TournamentParticipantId) []*model.Game {
+
+	stage := tournament.NewStage(participantIds)
+	settings := make([]model.GameSettings, 0, len(stage.Tables))
+	for _, table := range stage.Tables {
+		tableSettings := tournament.GameSettings(stage, table)
+		if err := tableSettings.Validate(); err != nil {
+			panic(err)
+		}
+		settings = append(settings, tableSettings)
+	}
+
+	games := make([]*model.Game, 0, len(stage.Tables))
+	for index, table := range stage.Tables {
+		s.logger.Info(fmt.Sprintf("[DEBUG] create game for table %d of stage %d", table.Number, stage.Number))
+		game := s.gameService.createGame(ctx, tx, settings[index])
+
+		for _, seat := range table.Seats {
+			if player := game.FindPlayer(seat.PlayerId); player != nil {
+				game = s.gameService.renamePlayer(ctx, tx, game.Id, player.Id, tournament.FindParticipant(seat.ParticipantId).Name)
+			}
+		}
+		table.GameId = game.Id
+		games = append(games, game)
+	}
+	return games
+}
+
+func (s *tournamentService) notifyGamesCreated(games []*model.Game) {
+	for _, game := range games {
+		s.gameService.notifyGameCreated(game)
+	}
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (s *tournamentService) RetrieveTournament(ctx context.Context, id model.TournamentId) (*model.Tournament, error) {
+
+	var tournament *model.Tournament
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		tournament = s.tournamentStore.Retrieve(ctx, tx, id)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve tournament %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve tournament %d", id), zap.Object("tournament", tournament))
+	return tournament, nil
+}
+
+func (s *tournamentService) retrieveOwnedTournament(ctx context.Context, tx *sql.Tx, id model.TournamentId) *model.Tournament {
+	tournament := s.tournamentStore.Retrieve(ctx, tx, id)
+	if !tournament.IsOwnedBy(s.currentUserId(ctx)) {
+		panic(model.ErrNotTournamentOwner)
+	}
+	return tournament
+}
+
+func (s *tournamentService) currentUserId(ctx context.Context) model.UserId {
+	if currentUser := model.GetCurrentUser(ctx); currentUser != nil {
+		return currentUser.Id
+	}
+	return 0
+}
+
+// //////////////////////////////////////////////////
+// standings
+
+func (s *tournamentService) RetrieveStandings(ctx context.Context, id model.TournamentId) ([]*model.TournamentStanding, error) {
+
+	var standings []*model.TournamentStanding
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		tournament := s.tournamentStore.Retrieve(ctx, tx, id)
+		standings = tournament.Standings(s.retrieveResults(ctx, tx, tournament))
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve standings of tournament %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve standings of tournament %d", id))
+	return standings, nil
+}
+
+// retrieveResults collects the result of every table game still available.
+func (s *tournamentService) retrieveResults(ctx context.Context, tx *sql.Tx, tournament *model.Tournament) map[model.GameId]*model.GameResult {
+	results := make(map[model.GameId]*model.GameResult)
+	for _, stage := range tournament.Stages {
+		for _, table := range stage.Tables {
+			if game := s.searchGame(ctx, tx, table.GameId); game != nil {
+				results[game.Id] = game.Result()
+			}
+		}
+	}
+	return results
+}
+
+func (s *tournamentService) searchGame(ctx context.Context, tx *sql.Tx, id model.GameId) (game *model.Game) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != model.ErrGameNotFound {
+				panic(r)
+			}
+			s.logger.Info(fmt.Sprintf("[DEBUG] game %d NOT found", id))
+			game = nil
+		}
+	}()
+	return s.gameService.retrieveGame(ctx, tx, id)
+}
+
+// //////////////////////////////////////////////////
+// next stage
+
+// NextStage promotes the top participants of each table to a new stage, or finishes the tournament after the final table.
+func (s *tournamentService) NextStage(ctx context.Context, id model.TournamentId) (*model.Tournament, error) {
+
+	var tournament *model.Tournament
+	var games []*model.Game
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve tournament
+		//
+
+		tournament = s.retrieveOwnedTournament(ctx, tx, id).Copy()
+		if tournament.Finished {
+			panic(model.ErrTournamentFinished)
+		}
+
+		//
+		// promote or finish
+		//
+
+		if tournament.IsFinal() {
+			s.logger.Info(fmt.Sprintf("[DEBUG] finish tournament %d", id))
+			tournament.Finished = true
+		} else {
+			promoted := tournament.Promote(s.retrieveResults(ctx, tx, tournament))
+			s.logger.Info(fmt.Sprintf("[DEBUG] promote %d participants of tournament %d", len(promoted), id))
+			games = s.createStage(ctx, tx, tournament, promoted)
+		}
+
+		tournament = s.tournamentStore.Update(ctx, tx, tournament)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] next stage of tournament %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.notifyGamesCreated(games)
+	s.logger.Info(fmt.Sprintf("[ OK ] next stage of tournament %d", id), zap.Object("tournament", tournament))
+	return tournament, nil
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (s *tournamentService) DeleteTournament(ctx context.Context, id model.TournamentId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.retrieveOwnedTournament(ctx, tx, id)
+		s.tournamentStore.Delete(ctx, tx, id)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] delete tournament %d", id), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] delete tournament %d", id))
+	return nil
+}
@@ -0,0 +1,87 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/service"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/store/memory"
+)
+
+// failingTournamentStore fails to store new tournaments.
+type failingTournamentStore struct {
+	store.TournamentStore
+}
+
+func (s *failingTournamentStore) Create(ctx context.Context, tx *sql.Tx, tournament *model.Tournament) *model.Tournament {
+	panic(fmt.Errorf("tournament store failure"))
+}
+
+func newTestTournament(themeId model.ThemeId) *model.Tournament {
+	tournament := &model.Tournament{
+		Name:       "cup",
+		TableSize:  2,
+		PromoteTop: 1,
+		Settings: &model.GameSettings{
+			Seed:       1,
+			NbQuestion: 3,
+			NbAnswer:   2,
+			Sources:    []model.Source{model.Source_Store},
+			ThemeIds:   []model.ThemeId{themeId},
+		},
+	}
+	for _, name := range []string{"alice", "bob", "carol", "dave"} {
+		tournament.Participants = append(tournament.Participants, &model.TournamentParticipant{Name: name})
+	}
+	return tournament
+}
+
+func TestTournamentStageRollback(t *testing.T) {
+	db := newTestDb(t, "00015_game_event", "00016_game_sequence")
+	stores := newTestGameStores()
+	// the events of the games are stored in the transaction of the tournament
+	stores.gameEvent = store.NewGameEventStore(zap.NewNop())
+	theme := seedTestTheme(t, stores, "cup", 5)
+	webhookService := &testWebhookService{}
+	gameService := service.NewGameService(zap.NewNop(), db, stores.gameEvent, nil, stores.music, stores.artist, stores.album, stores.contributor, stores.theme, stores.themeQuestion, nil, webhookService)
+	ctx := withTestUser(context.Background(), 1, "alice")
+
+	countGames := func() int {
+		var count int
+		require.NoError(t, db.QueryRow("SELECT count(DISTINCT game_id) FROM game_event").Scan(&count))
+		return count
+	}
+
+	// the games of the first stage are rolled back along with the tournament
+	failing := service.NewTournamentService(zap.NewNop(), db, gameService, &failingTournamentStore{TournamentStore: memory.NewTournamentMemoryStore()})
+	_, err := failing.CreateTournament(ctx, newTestTournament(theme.Id))
+	require.Error(t, err)
+	require.Equal(t, 0, countGames())
+	require.Empty(t, webhookService.events)
+
+	// the games of the stage are committed with the tournament
+	tournamentService := service.NewTournamentService(zap.NewNop(), db, gameService, memory.NewTournamentMemoryStore())
+	tournament, err := tournamentService.CreateTournament(ctx, newTestTournament(theme.Id))
+	require.NoError(t, err)
+	require.Len(t, tournament.Stages, 1)
+	require.Len(t, tournament.Stages[0].Tables, 2)
+	require.Equal(t, 2, countGames())
+	require.Len(t, webhookService.events, 2)
+	for _, table := range tournament.Stages[0].Tables {
+		require.NotZero(t, table.GameId)
+
+		// the players are renamed after the participants through the events of the game
+		game, err := gameService.RetrieveGame(ctx, table.GameId)
+		require.NoError(t, err)
+		for _, seat := range table.Seats {
+			require.Equal(t, tournament.FindParticipant(seat.ParticipantId).Name, game.FindPlayer(seat.PlayerId).Name)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/gre-ory/amnezic-go/internal/client"
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// webhook service
+
+type WebhookService interface {
+	ListWebhooks(ctx context.Context) ([]*model.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	RetrieveWebhook(ctx context.Context, id model.WebhookId) (*model.Webhook, error)
+	UpdateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error)
+	DeleteWebhook(ctx context.Context, id model.WebhookId) error
+	ListDeliveries(ctx context.Context, filter *model.WebhookDeliveryFilter) ([]*model.WebhookDelivery, error)
+	Notify(event *model.WebhookEvent)
+}
+
+func NewWebhookService(logger *zap.Logger, db *sql.DB, webhookClient client.WebhookClient, webhookStore store.WebhookStore, webhookDeliveryStore store.WebhookDeliveryStore) WebhookService {
+	return &webhookService{
+		logger:               logger,
+		db:                   db,
+		webhookClient:        webhookClient,
+		webhookStore:         webhookStore,
+		webhookDeliveryStore: webhookDeliveryStore,
+	}
+}
+
+type webhookService struct {
+	logger               *zap.Logger
+	db                   *sql.DB
+	webhookClient        client.WebhookClient
+	webhookStore         store.WebhookStore
+	webhookDeliveryStore store.WebhookDeliveryStore
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (s *webhookService) ListWebhooks(ctx context.Context) ([]*model.Webhook, error) {
+
+	var webhooks []*model.Webhook
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		webhooks = s.webhookStore.List(ctx, tx, nil)
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] list webhooks", zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("[ OK ] list webhooks")
+	return webhooks, nil
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *webhookService) CreateWebhook(ctx context.Context, webhook *model.Webhook) (*model.Webhook, error) {
+
+	var created *model.Webhook
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		if err := webhook.Validate(); err != nil {
+			panic(err)
+		}
+		if webhook.Secret == "" {
+			webhook.Secret = model.NewWebhookSecret()
+		}
+		s.logger.Info("[DEBUG] create webhook", zap.Object("webhook", webhook))
+		created = s.webhookStore.Create(ctx, tx, webhook)
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] create webhook", zap.Object("webhook", webhook), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("[ OK ] create webhook", zap.Object("webhook", created))
+	return created, nil
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (s *webhookService) RetrieveWebhook(ctx context.Context, id model.WebhookId) (*model.Webhook, error) {
+
+	var webhook *model.Webhook
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		webhook = s.webhookStore.Retrieve(ctx, tx, id)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] retrieve webhook %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] retrieve webhook %d", id), zap.Object("webhook", webhook))
+	return webhook, nil
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (s *webhookService) UpdateWebhook(ctx context.Context, values *model.Webhook) (*model.Webhook, error) {
+
+	id := values.Id
+
+	var webhook *model.Webhook
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+
+		//
+		// retrieve webhook
+		//
+
+		webhook = s.webhookStore.Retrieve(ctx, tx, id)
+
+		//
+		// update webhook
+		//
+
+		webhook.Url = values.Url
+		webhook.Events = values.Events
+		webhook.Active = values.Active
+		if values.Secret != "" {
+			webhook.Secret = values.Secret
+		}
+		if err := webhook.Validate(); err != nil {
+			panic(err)
+		}
+		s.logger.Info(fmt.Sprintf("[DEBUG] update webhook %d", id), zap.Object("webhook", webhook))
+		webhook = s.webhookStore.Update(ctx, tx, webhook)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] update webhook %d", id), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] update webhook %d", id), zap.Object("webhook", webhook))
+	return webhook, nil
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (s *webhookService) DeleteWebhook(ctx context.Context, id model.WebhookId) error {
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.webhookStore.Retrieve(ctx, tx, id)
+		s.logger.Info(fmt.Sprintf("[DEBUG] delete deliveries of webhook %d", id))
+		s.webhookDeliveryStore.DeleteByWebhook(ctx, tx, id)
+		s.logger.Info(fmt.Sprintf("[DEBUG] delete webhook %d", id))
+		s.webhookStore.Delete(ctx, tx, id)
+	})
+
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] delete webhook %d", id), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] delete webhook %d", id))
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// deliveries
+
+func (s *webhookService) ListDeliveries(ctx context.Context, filter *model.WebhookDeliveryFilter) ([]*model.WebhookDelivery, error) {
+
+	var deliveries []*model.WebhookDelivery
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		s.webhookStore.Retrieve(ctx, tx, filter.WebhookId)
+		deliveries = s.webhookDeliveryStore.List(ctx, tx, filter)
+	})
+
+	if err != nil {
+		s.logger.Info("[ KO ] list webhook deliveries", zap.Object("filter", filter), zap.Error(err))
+		return nil, err
+	}
+	s.logger.Info("[ OK ] list webhook deliveries", zap.Object("filter", filter))
+	return deliveries, nil
+}
+
+// //////////////////////////////////////////////////
+// notify
+
+// Notify delivers the event to every subscribed webhook in the background and logs each attempt.
+// it never fails the caller: errors are only logged.
+func (s *webhookService) Notify(event *model.WebhookEvent) {
+	go func() {
+		ctx := context.Background()
+
+		var webhooks []*model.Webhook
+		err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+			filter := &model.WebhookFilter{
+				ActiveOnly: true,
+			}
+			webhooks = util.Filter(s.webhookStore.List(ctx, tx, filter), func(webhook *model.Webhook) bool { return webhook.Subscribes(event.Type) })
+		})
+		if err != nil {
+			s.logger.Info("[ KO ] notify webhooks", zap.Object("event", event), zap.Error(err))
+			return
+		}
+
+		for _, webhook := range webhooks {
+			go s.deliver(ctx, webhook, event)
+		}
+	}()
+}
+
+func (s *webhookService) deliver(ctx context.Context, webhook *model.Webhook, event *model.WebhookEvent) {
+
+	deliveries := s.webhookClient.Deliver(ctx, webhook, event)
+
+	err := util.SqlTransaction(ctx, s.db, func(tx *sql.Tx) {
+		for _, delivery := range deliveries {
+			s.webhookDeliveryStore.Create(ctx, tx, delivery)
+		}
+	})
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] deliver %s to webhook %d", event.Type, webhook.Id), zap.Error(err))
+		return
+	}
+	if len(deliveries) == 0 || !deliveries[len(deliveries)-1].Success {
+		s.logger.Info(fmt.Sprintf("[ KO ] deliver %s to webhook %d", event.Type, webhook.Id), zap.Int("nb-attempts", len(deliveries)))
+		return
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] deliver %s to webhook %d", event.Type, webhook.Id), zap.Int("nb-attempts", len(deliveries)))
+}
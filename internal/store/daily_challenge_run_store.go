@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// daily challenge run store
+
+type DailyChallengeRunStore interface {
+	Create(ctx context.Context, tx *sql.Tx, run *model.DailyChallengeRun) *model.DailyChallengeRun
+	Exists(ctx context.Context, tx *sql.Tx, date model.DailyChallengeDate, userId model.UserId) bool
+	ListByDate(ctx context.Context, tx *sql.Tx, date model.DailyChallengeDate) []*model.DailyChallengeRun
+}
+
+func NewDailyChallengeRunStore(logger *zap.Logger) DailyChallengeRunStore {
+	return &dailyChallengeRunStore{
+		SqlTable: util.NewSqlTable[DailyChallengeRunRow](logger, DailyChallengeRunTable, model.ErrDailyChallengeRunNotFound),
+	}
+}
+
+type dailyChallengeRunStore struct {
+	util.SqlTable[DailyChallengeRunRow]
+	util.SqlEncoder[model.DailyChallengeRun, DailyChallengeRunRow]
+	util.SqlDecoder[DailyChallengeRunRow, model.DailyChallengeRun]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const DailyChallengeRunTable = "daily_challenge_run"
+
+// //////////////////////////////////////////////////
+// row
+
+type DailyChallengeRunRow struct {
+	Id          int64  `sql:"id,auto-generated"`
+	Date        string `sql:"date"`
+	UserId      int64  `sql:"user_id"`
+	UserName    string `sql:"user_name"`
+	Score       int    `sql:"score"`
+	NbCorrect   int    `sql:"nb_correct"`
+	SubmittedAt int64  `sql:"submitted_at"`
+}
+
+func (s *dailyChallengeRunStore) EncodeRow(obj *model.DailyChallengeRun) *DailyChallengeRunRow {
+	return &DailyChallengeRunRow{
+		Id:          int64(obj.Id),
+		Date:        obj.Date.String(),
+		UserId:      obj.UserId.ToInt64(),
+		UserName:    obj.UserName,
+		Score:       obj.Score,
+		NbCorrect:   obj.NbCorrect,
+		SubmittedAt: obj.SubmittedAt.Unix(),
+	}
+}
+
+func (s *dailyChallengeRunStore) DecodeRow(row *DailyChallengeRunRow) *model.DailyChallengeRun {
+	if row == nil {
+		return nil
+	}
+	return &model.DailyChallengeRun{
+		Id:          model.DailyChallengeRunId(row.Id),
+		Date:        model.DailyChallengeDate(row.Date),
+		UserId:      model.UserId(row.UserId),
+		UserName:    row.UserName,
+		Score:       row.Score,
+		NbCorrect:   row.NbCorrect,
+		SubmittedAt: time.Unix(row.SubmittedAt, 0),
+	}
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *dailyChallengeRunStore) Create(ctx context.Context, tx *sql.Tx, obj *model.DailyChallengeRun) *model.DailyChallengeRun {
+	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+}
+
+// //////////////////////////////////////////////////
+// exists
+
+func (s *dailyChallengeRunStore) Exists(ctx context.Context, tx *sql.Tx, date model.DailyChallengeDate, userId model.UserId) bool {
+	return s.ExistsRow(ctx, tx, s.matchingDate(date).WithCondition("user_id = $_", userId))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+// ListByDate returns the runs of the day, best score first and earliest submission first on ties.
+func (s *dailyChallengeRunStore) ListByDate(ctx context.Context, tx *sql.Tx, date model.DailyChallengeDate) []*model.DailyChallengeRun {
+	return util.Convert(s.ListRows(ctx, tx, s.matchingDate(date).WithOrderBy("score DESC, submitted_at ASC")), s.DecodeRow)
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *dailyChallengeRunStore) matchingDate(date model.DailyChallengeDate) util.SqlWhereClause {
+	return util.NewSqlCondition("date = $_", date.String())
+}
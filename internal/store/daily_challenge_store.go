@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// daily challenge store
+
+type DailyChallengeStore interface {
+	Create(ctx context.Context, tx *sql.Tx, challenge *model.DailyChallenge) *model.DailyChallenge
+	SearchByDate(ctx context.Context, tx *sql.Tx, date model.DailyChallengeDate) *model.DailyChallenge
+	Update(ctx context.Context, tx *sql.Tx, challenge *model.DailyChallenge) *model.DailyChallenge
+}
+
+func NewDailyChallengeStore(logger *zap.Logger) DailyChallengeStore {
+	return &dailyChallengeStore{
+		SqlTable: util.NewSqlTable[DailyChallengeRow](logger, DailyChallengeTable, model.ErrDailyChallengeNotFound),
+	}
+}
+
+type dailyChallengeStore struct {
+	util.SqlTable[DailyChallengeRow]
+	util.SqlEncoder[model.DailyChallenge, DailyChallengeRow]
+	util.SqlDecoder[DailyChallengeRow, model.DailyChallenge]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const DailyChallengeTable = "daily_challenge"
+
+// //////////////////////////////////////////////////
+// row
+
+type DailyChallengeRow struct {
+	Date     string `sql:"date"`
+	Settings string `sql:"settings"`
+	Game     string `sql:"game"`
+}
+
+func (s *dailyChallengeStore) EncodeRow(obj *model.DailyChallenge) *DailyChallengeRow {
+	return &DailyChallengeRow{
+		Date:     obj.Date.String(),
+		Settings: EncodeGameSettings(obj.Settings),
+		Game:     EncodeGame(obj.Game),
+	}
+}
+
+func (s *dailyChallengeStore) DecodeRow(row *DailyChallengeRow) *model.DailyChallenge {
+	if row == nil {
+		return nil
+	}
+	date := model.DailyChallengeDate(row.Date)
+	settings := DecodeGameSettings(row.Settings)
+	settings.Seed = date.Seed()
+	game := DecodeGame(row.Game)
+	if game != nil {
+		game.Settings = settings
+	}
+	return &model.DailyChallenge{
+		Date:     date,
+		Settings: settings,
+		Game:     game,
+	}
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *dailyChallengeStore) Create(ctx context.Context, tx *sql.Tx, obj *model.DailyChallenge) *model.DailyChallenge {
+	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (s *dailyChallengeStore) Update(ctx context.Context, tx *sql.Tx, obj *model.DailyChallenge) *model.DailyChallenge {
+	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingDate(obj.Date)))
+}
+
+// //////////////////////////////////////////////////
+// search by date
+
+func (s *dailyChallengeStore) SearchByDate(ctx context.Context, tx *sql.Tx, date model.DailyChallengeDate) *model.DailyChallenge {
+	row, err := s.SelectRow(ctx, tx, s.matchingDate(date))
+	if err != nil {
+		if errors.Is(err, model.ErrDailyChallengeNotFound) {
+			return nil
+		} else {
+			panic(err)
+		}
+	}
+	return s.DecodeRow(row)
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *dailyChallengeStore) matchingDate(date model.DailyChallengeDate) util.SqlWhereClause {
+	return util.NewSqlCondition("date = $_", date.String())
+}
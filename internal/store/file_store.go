@@ -18,6 +18,8 @@ import (
 type FileStore interface {
 	List(ctx context.Context, filter *model.FileFilter) ([]model.Url, error)
 	Exists(ctx context.Context, filter *model.FileFilter, path string) bool
+	Read(ctx context.Context, filter *model.FileFilter, path string) ([]byte, error)
+	Write(ctx context.Context, filter *model.FileFilter, path string, data []byte) error
 	PathValidator(ctx context.Context, filter *model.FileFilter) model.PathValidator
 }
 
@@ -75,6 +77,56 @@ func (s *fileStore) Exists(_ context.Context, filter *model.FileFilter, path str
 	return err == nil
 }
 
+func (s *fileStore) Read(ctx context.Context, filter *model.FileFilter, path string) ([]byte, error) {
+	if !s.Exists(ctx, filter, path) {
+		return nil, model.ErrPathNotFound(path)
+	}
+	path = filepath.Join(filter.Directory, filepath.Clean(path))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] read file %q", path), zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
+// Write creates the file through a temporary file renamed once fully written, so that a partial file is never served.
+func (s *fileStore) Write(ctx context.Context, filter *model.FileFilter, path string, data []byte) error {
+	if !filter.MatchExtension(path) {
+		return model.ErrInvalidExtension
+	}
+	if s.Exists(ctx, filter, path) {
+		return model.ErrFileAlreadyExists(path)
+	}
+	path = filepath.Join(filter.Directory, filepath.Clean(path))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] write file %q", path), zap.Error(err))
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] write file %q", path), zap.Error(err))
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(0644)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		s.logger.Info(fmt.Sprintf("[ KO ] write file %q", path), zap.Error(err))
+		return err
+	}
+	s.logger.Info(fmt.Sprintf("[ OK ] write %d bytes to file %q", len(data), path))
+	return nil
+}
+
 func (s *fileStore) PathValidator(ctx context.Context, filter *model.FileFilter) model.PathValidator {
 	return func(path string) error {
 		if !s.Exists(ctx, filter, path) {
@@ -0,0 +1,409 @@
+package store
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// game encoding
+
+// GameJson is the json representation of the content of a game: its settings, rounds and questions.
+// neither the owner, the host token, the players nor the progress of the game are part of it.
+type GameJson struct {
+	Id        int64               `json:"id,omitempty"`
+	Seed      int64               `json:"seed,omitempty"`
+	Settings  *GameSettingsJson   `json:"settings,omitempty"`
+	Rounds    []*GameRoundJson    `json:"rounds,omitempty"`
+	Questions []*GameQuestionJson `json:"questions,omitempty"`
+}
+
+type GameRoundJson struct {
+	Number       int                     `json:"number"`
+	Title        string                  `json:"title,omitempty"`
+	QuestionType string                  `json:"question_type,omitempty"`
+	ScoreCorrect int                     `json:"score_correct,omitempty"`
+	ScoreWrong   int                     `json:"score_wrong,omitempty"`
+	MediaKind    string                  `json:"media_kind,omitempty"`
+	Guess        string                  `json:"guess,omitempty"`
+	YearScoring  []*GameYearStepJson     `json:"year_scoring,omitempty"`
+	Slots        []*GameSlotSettingsJson `json:"slots,omitempty"`
+}
+
+type GameQuestionJson struct {
+	Id              int64                 `json:"id"`
+	Round           int                   `json:"round,omitempty"`
+	Theme           *GameThemeJson        `json:"theme,omitempty"`
+	Music           *GameMusicJson        `json:"music,omitempty"`
+	Media           *GameMediaJson        `json:"media,omitempty"`
+	ExcerptStart    int64                 `json:"excerpt_start,omitempty"`
+	ExcerptDuration int64                 `json:"excerpt_duration,omitempty"`
+	Year            int                   `json:"year,omitempty"`
+	Answers         []*GameAnswerJson     `json:"answers,omitempty"`
+	Slots           []*GameAnswerSlotJson `json:"slots,omitempty"`
+}
+
+type GameThemeJson struct {
+	Id     int64  `json:"id,omitempty"`
+	Title  string `json:"title,omitempty"`
+	ImgUrl string `json:"img_url,omitempty"`
+}
+
+type GameMusicJson struct {
+	Id           int64                       `json:"id,omitempty"`
+	DeezerId     int64                       `json:"deezer_id,omitempty"`
+	Name         string                      `json:"name"`
+	Mp3Url       string                      `json:"mp3_url,omitempty"`
+	Year         int                         `json:"year,omitempty"`
+	Artist       *GameMusicArtistJson        `json:"artist,omitempty"`
+	Album        *GameMusicAlbumJson         `json:"album,omitempty"`
+	Contributors []*GameMusicContributorJson `json:"contributors,omitempty"`
+}
+
+type GameMusicArtistJson struct {
+	Id       int64  `json:"id,omitempty"`
+	DeezerId int64  `json:"deezer_id,omitempty"`
+	Name     string `json:"name"`
+	ImgUrl   string `json:"img_url,omitempty"`
+}
+
+type GameMusicAlbumJson struct {
+	Id       int64  `json:"id,omitempty"`
+	DeezerId int64  `json:"deezer_id,omitempty"`
+	Name     string `json:"name"`
+	ImgUrl   string `json:"img_url,omitempty"`
+}
+
+type GameMusicContributorJson struct {
+	ArtistId int64                `json:"artist_id,omitempty"`
+	Role     string               `json:"role"`
+	Artist   *GameMusicArtistJson `json:"artist,omitempty"`
+}
+
+type GameMediaJson struct {
+	Kind   string `json:"kind"`
+	Mp3Url string `json:"mp3_url,omitempty"`
+	ImgUrl string `json:"img_url,omitempty"`
+}
+
+type GameAnswerJson struct {
+	Id      int64  `json:"id"`
+	Text    string `json:"text"`
+	Hint    string `json:"hint,omitempty"`
+	Correct bool   `json:"correct,omitempty"`
+}
+
+type GameAnswerSlotJson struct {
+	Number   int               `json:"number"`
+	Guess    string            `json:"guess"`
+	Points   int               `json:"points"`
+	Answers  []*GameAnswerJson `json:"answers,omitempty"`
+	Expected string            `json:"expected,omitempty"`
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func EncodeGame(game *model.Game) string {
+	if game == nil {
+		return ""
+	}
+	data, err := json.Marshal(EncodeGameJson(game))
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+func EncodeGameJson(game *model.Game) *GameJson {
+	jsonGame := &GameJson{
+		Id:        int64(game.Id),
+		Rounds:    util.Convert(game.Rounds, encodeGameRound),
+		Questions: util.Convert(game.Questions, encodeGameQuestion),
+	}
+	if game.Settings != nil {
+		jsonGame.Seed = game.Settings.Seed
+		jsonGame.Settings = encodeGameSettingsJson(game.Settings)
+	}
+	return jsonGame
+}
+
+func encodeGameRound(round *model.GameRound) *GameRoundJson {
+	return &GameRoundJson{
+		Number:       round.Number,
+		Title:        round.Title,
+		QuestionType: round.QuestionType.String(),
+		ScoreCorrect: round.Scoring.Correct,
+		ScoreWrong:   round.Scoring.Wrong,
+		MediaKind:    round.MediaKind.String(),
+		Guess:        round.Guess.String(),
+		YearScoring:  util.Convert(round.YearScoring.Steps, encodeGameYearStep),
+		Slots:        util.Convert(round.Slots, encodeGameSlotSettings),
+	}
+}
+
+func encodeGameQuestion(question *model.GameQuestion) *GameQuestionJson {
+	return &GameQuestionJson{
+		Id:              int64(question.Id),
+		Round:           question.Round,
+		Theme:           encodeGameTheme(question.Theme),
+		Music:           encodeGameMusic(question.Music),
+		Media:           encodeGameMedia(question.Media),
+		ExcerptStart:    question.Excerpt.Start.Milliseconds(),
+		ExcerptDuration: question.Excerpt.Duration.Milliseconds(),
+		Year:            question.Year,
+		Answers:         util.Convert(question.Answers, encodeGameAnswer),
+		Slots:           util.Convert(question.Slots, encodeGameAnswerSlot),
+	}
+}
+
+func encodeGameTheme(theme *model.GameTheme) *GameThemeJson {
+	if theme == nil {
+		return nil
+	}
+	return &GameThemeJson{
+		Id:     theme.Id,
+		Title:  theme.Title,
+		ImgUrl: theme.ImgUrl,
+	}
+}
+
+func encodeGameMusic(music *model.Music) *GameMusicJson {
+	if music == nil {
+		return nil
+	}
+	return &GameMusicJson{
+		Id:           int64(music.Id),
+		DeezerId:     int64(music.DeezerId),
+		Name:         music.Name,
+		Mp3Url:       string(music.Mp3Url),
+		Year:         music.Year,
+		Artist:       encodeGameMusicArtist(music.Artist),
+		Album:        encodeGameMusicAlbum(music.Album),
+		Contributors: util.Convert(music.Contributors, encodeGameMusicContributor),
+	}
+}
+
+func encodeGameMusicArtist(artist *model.MusicArtist) *GameMusicArtistJson {
+	if artist == nil {
+		return nil
+	}
+	return &GameMusicArtistJson{
+		Id:       int64(artist.Id),
+		DeezerId: int64(artist.DeezerId),
+		Name:     artist.Name,
+		ImgUrl:   string(artist.ImgUrl),
+	}
+}
+
+func encodeGameMusicAlbum(album *model.MusicAlbum) *GameMusicAlbumJson {
+	if album == nil {
+		return nil
+	}
+	return &GameMusicAlbumJson{
+		Id:       int64(album.Id),
+		DeezerId: int64(album.DeezerId),
+		Name:     album.Name,
+		ImgUrl:   string(album.ImgUrl),
+	}
+}
+
+func encodeGameMusicContributor(contributor *model.MusicContributor) *GameMusicContributorJson {
+	return &GameMusicContributorJson{
+		ArtistId: int64(contributor.ArtistId),
+		Role:     contributor.Role.String(),
+		Artist:   encodeGameMusicArtist(contributor.Artist),
+	}
+}
+
+func encodeGameMedia(media *model.GameMedia) *GameMediaJson {
+	if media == nil {
+		return nil
+	}
+	return &GameMediaJson{
+		Kind:   media.Kind.String(),
+		Mp3Url: string(media.Mp3Url),
+		ImgUrl: string(media.ImgUrl),
+	}
+}
+
+func encodeGameAnswer(answer *model.GameAnswer) *GameAnswerJson {
+	return &GameAnswerJson{
+		Id:      int64(answer.Id),
+		Text:    answer.Text,
+		Hint:    answer.Hint,
+		Correct: answer.Correct,
+	}
+}
+
+func encodeGameAnswerSlot(slot *model.GameAnswerSlot) *GameAnswerSlotJson {
+	return &GameAnswerSlotJson{
+		Number:   slot.Number,
+		Guess:    slot.Guess.String(),
+		Points:   slot.Points,
+		Answers:  util.Convert(slot.Answers, encodeGameAnswer),
+		Expected: slot.Expected,
+	}
+}
+
+// //////////////////////////////////////////////////
+// decode
+
+// DecodeGame returns nil when no game is stored.
+func DecodeGame(game string) *model.Game {
+	if game == "" {
+		return nil
+	}
+	var jsonGame GameJson
+	if err := json.Unmarshal([]byte(game), &jsonGame); err != nil {
+		panic(err)
+	}
+	return DecodeGameJson(&jsonGame)
+}
+
+func DecodeGameJson(jsonGame *GameJson) *model.Game {
+	game := &model.Game{
+		Id:        model.GameId(jsonGame.Id),
+		Rounds:    util.Convert(jsonGame.Rounds, decodeGameRound),
+		Questions: util.Convert(jsonGame.Questions, decodeGameQuestion),
+	}
+	if jsonGame.Settings != nil {
+		game.Settings = decodeGameSettingsJson(jsonGame.Settings)
+		game.Settings.Seed = jsonGame.Seed
+	}
+	return game
+}
+
+func decodeGameRound(round *GameRoundJson) *model.GameRound {
+	return &model.GameRound{
+		Number:       round.Number,
+		Title:        round.Title,
+		QuestionType: model.ToGameQuestionType(round.QuestionType),
+		Scoring: model.GameScoring{
+			Correct: round.ScoreCorrect,
+			Wrong:   round.ScoreWrong,
+		},
+		MediaKind: model.ToGameMediaKind(round.MediaKind),
+		Guess:     model.ToGameGuess(round.Guess),
+		YearScoring: model.GameYearScoring{
+			Steps: util.Convert(round.YearScoring, decodeGameYearStep),
+		},
+		Slots: util.Convert(round.Slots, decodeGameSlotSettings),
+	}
+}
+
+func decodeGameQuestion(question *GameQuestionJson) *model.GameQuestion {
+	return &model.GameQuestion{
+		Id:    model.GameQuestionId(question.Id),
+		Round: question.Round,
+		Theme: decodeGameTheme(question.Theme),
+		Music: decodeGameMusic(question.Music),
+		Media: decodeGameMedia(question.Media),
+		Excerpt: model.AudioExcerpt{
+			Start:    time.Duration(question.ExcerptStart) * time.Millisecond,
+			Duration: time.Duration(question.ExcerptDuration) * time.Millisecond,
+		},
+		Year:    question.Year,
+		Answers: util.Convert(question.Answers, decodeGameAnswer),
+		Slots:   util.Convert(question.Slots, decodeGameAnswerSlot),
+	}
+}
+
+func decodeGameTheme(theme *GameThemeJson) *model.GameTheme {
+	if theme == nil {
+		return nil
+	}
+	return &model.GameTheme{
+		Id:     theme.Id,
+		Title:  theme.Title,
+		ImgUrl: theme.ImgUrl,
+	}
+}
+
+func decodeGameMusic(music *GameMusicJson) *model.Music {
+	if music == nil {
+		return nil
+	}
+	decoded := &model.Music{
+		Id:           model.MusicId(music.Id),
+		DeezerId:     model.DeezerMusicId(music.DeezerId),
+		Name:         music.Name,
+		Mp3Url:       model.Url(music.Mp3Url),
+		Year:         music.Year,
+		Artist:       decodeGameMusicArtist(music.Artist),
+		Album:        decodeGameMusicAlbum(music.Album),
+		Contributors: util.Convert(music.Contributors, decodeGameMusicContributor),
+	}
+	if decoded.Artist != nil {
+		decoded.ArtistId = decoded.Artist.Id
+	}
+	if decoded.Album != nil {
+		decoded.AlbumId = decoded.Album.Id
+	}
+	return decoded
+}
+
+func decodeGameMusicArtist(artist *GameMusicArtistJson) *model.MusicArtist {
+	if artist == nil {
+		return nil
+	}
+	return &model.MusicArtist{
+		Id:       model.MusicArtistId(artist.Id),
+		DeezerId: model.DeezerArtistId(artist.DeezerId),
+		Name:     artist.Name,
+		ImgUrl:   model.Url(artist.ImgUrl),
+	}
+}
+
+func decodeGameMusicAlbum(album *GameMusicAlbumJson) *model.MusicAlbum {
+	if album == nil {
+		return nil
+	}
+	return &model.MusicAlbum{
+		Id:       model.MusicAlbumId(album.Id),
+		DeezerId: model.DeezerAlbumId(album.DeezerId),
+		Name:     album.Name,
+		ImgUrl:   model.Url(album.ImgUrl),
+	}
+}
+
+func decodeGameMusicContributor(contributor *GameMusicContributorJson) *model.MusicContributor {
+	return &model.MusicContributor{
+		ArtistId: model.MusicArtistId(contributor.ArtistId),
+		Role:     model.ToMusicArtistRole(contributor.Role),
+		Artist:   decodeGameMusicArtist(contributor.Artist),
+	}
+}
+
+func decodeGameMedia(media *GameMediaJson) *model.GameMedia {
+	if media == nil {
+		return nil
+	}
+	return &model.GameMedia{
+		Kind:   model.ToGameMediaKind(media.Kind),
+		Mp3Url: model.Url(media.Mp3Url),
+		ImgUrl: model.Url(media.ImgUrl),
+	}
+}
+
+func decodeGameAnswer(answer *GameAnswerJson) *model.GameAnswer {
+	return &model.GameAnswer{
+		Id:      model.GameAnswerId(answer.Id),
+		Text:    answer.Text,
+		Hint:    answer.Hint,
+		Correct: answer.Correct,
+	}
+}
+
+func decodeGameAnswerSlot(slot *GameAnswerSlotJson) *model.GameAnswerSlot {
+	return &model.GameAnswerSlot{
+		Number:   slot.Number,
+		Guess:    model.ToGameGuess(slot.Guess),
+		Points:   slot.Points,
+		Answers:  util.Convert(slot.Answers, decodeGameAnswer),
+		Expected: slot.Expected,
+	}
+}
@@ -0,0 +1,210 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// game event store
+
+// GameEventStore is an append-only log of the events of each game.
+// the events are never deleted: the id of a game is never reused.
+type GameEventStore interface {
+	NewGameId(ctx context.Context, tx *sql.Tx) model.GameId
+	Append(ctx context.Context, tx *sql.Tx, gameId model.GameId, event *model.GameEvent) *model.GameEvent
+	List(ctx context.Context, tx *sql.Tx, gameId model.GameId) []*model.GameEvent
+}
+
+func NewGameEventStore(logger *zap.Logger) GameEventStore {
+	return &gameEventStore{
+		SqlTable: util.NewSqlTable[GameEventRow](logger, GameEventTable, model.ErrGameNotFound),
+	}
+}
+
+type gameEventStore struct {
+	util.SqlTable[GameEventRow]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const (
+	GameEventTable    = "game_event"
+	GameSequenceTable = "game_sequence"
+)
+
+// //////////////////////////////////////////////////
+// row
+
+type GameEventRow struct {
+	GameId     int64  `sql:"game_id"`
+	Sequence   int    `sql:"sequence"`
+	Type       string `sql:"type"`
+	OccurredAt int64  `sql:"occurred_at"`
+	UserId     int64  `sql:"user_id"`
+	Data       string `sql:"data"`
+}
+
+// GameEventDataJson is the json representation of the fields of an event related to its type.
+type GameEventDataJson struct {
+	Game          *GameJson             `json:"game,omitempty"`
+	OwnerId       int64                 `json:"owner_id,omitempty"`
+	HostToken     string                `json:"host_token,omitempty"`
+	PlayerId      int64                 `json:"player_id,omitempty"`
+	PlayerName    string                `json:"player_name,omitempty"`
+	QuestionId    int64                 `json:"question_id,omitempty"`
+	AnswerId      int64                 `json:"answer_id,omitempty"`
+	Year          int                   `json:"year,omitempty"`
+	Parts         []*GameSlotAnswerJson `json:"parts,omitempty"`
+	Joker         string                `json:"joker,omitempty"`
+	Points        int                   `json:"points,omitempty"`
+	Reason        string                `json:"reason,omitempty"`
+	PreviousPhase string                `json:"previous_phase,omitempty"`
+	Phase         string                `json:"phase,omitempty"`
+}
+
+type GameSlotAnswerJson struct {
+	Slot     int    `json:"slot"`
+	AnswerId int64  `json:"answer_id,omitempty"`
+	Text     string `json:"text,omitempty"`
+}
+
+func (s *gameEventStore) EncodeRow(gameId model.GameId, obj *model.GameEvent) *GameEventRow {
+	data := &GameEventDataJson{
+		PlayerId:   int64(obj.PlayerId),
+		PlayerName: obj.PlayerName,
+		QuestionId: int64(obj.QuestionId),
+		AnswerId:   int64(obj.AnswerId),
+		Year:       obj.Year,
+		Parts: util.Convert(obj.Parts, func(part *model.GameSlotAnswer) *GameSlotAnswerJson {
+			return &GameSlotAnswerJson{
+				Slot:     part.Slot,
+				AnswerId: int64(part.AnswerId),
+				Text:     part.Text,
+			}
+		}),
+		Joker:         obj.JokerType.String(),
+		Points:        obj.Points,
+		Reason:        obj.Reason,
+		PreviousPhase: obj.PreviousPhase.String(),
+		Phase:         obj.Phase.String(),
+	}
+	if obj.Game != nil {
+		// the game json only holds the content of the game
+		data.Game = EncodeGameJson(obj.Game)
+		data.OwnerId = obj.Game.OwnerId.ToInt64()
+		data.HostToken = obj.Game.HostToken.String()
+	}
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		panic(err)
+	}
+	return &GameEventRow{
+		GameId:     int64(gameId),
+		Sequence:   obj.Sequence,
+		Type:       obj.Type.String(),
+		OccurredAt: obj.OccurredAt.UnixMilli(),
+		UserId:     obj.UserId.ToInt64(),
+		Data:       string(encoded),
+	}
+}
+
+func (s *gameEventStore) DecodeRow(row *GameEventRow) *model.GameEvent {
+	if row == nil {
+		return nil
+	}
+	var data GameEventDataJson
+	if err := json.Unmarshal([]byte(row.Data), &data); err != nil {
+		panic(err)
+	}
+	event := &model.GameEvent{
+		Sequence:   row.Sequence,
+		Type:       model.GameEventType(row.Type),
+		OccurredAt: time.UnixMilli(row.OccurredAt),
+		UserId:     model.UserId(row.UserId),
+		PlayerId:   model.GamePlayerId(data.PlayerId),
+		PlayerName: data.PlayerName,
+		QuestionId: model.GameQuestionId(data.QuestionId),
+		AnswerId:   model.GameAnswerId(data.AnswerId),
+		Year:       data.Year,
+		Parts: util.Convert(data.Parts, func(part *GameSlotAnswerJson) *model.GameSlotAnswer {
+			return &model.GameSlotAnswer{
+				Slot:     part.Slot,
+				AnswerId: model.GameAnswerId(part.AnswerId),
+				Text:     part.Text,
+			}
+		}),
+		JokerType:     model.GameJokerType(data.Joker),
+		Points:        data.Points,
+		Reason:        data.Reason,
+		PreviousPhase: model.GamePhase(data.PreviousPhase),
+		Phase:         model.GamePhase(data.Phase),
+	}
+	if data.Game != nil {
+		event.Game = DecodeGameJson(data.Game)
+		event.Game.OwnerId = model.UserId(data.OwnerId)
+		event.Game.HostToken = model.GameHostToken(data.HostToken)
+	}
+	return event
+}
+
+// //////////////////////////////////////////////////
+// new game id
+
+// NewGameId reserves the id of a new game, never used by any former game even deleted.
+func (s *gameEventStore) NewGameId(ctx context.Context, tx *sql.Tx) model.GameId {
+	number := 0
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "INSERT INTO "+GameSequenceTable+" (created_at) VALUES ($1) RETURNING number", time.Now().UnixMilli()),
+		func(rows *sql.Rows) {
+			if err := rows.Scan(&number); err != nil {
+				panic(err)
+			}
+		},
+	)
+	return model.NewGameId(number)
+}
+
+// //////////////////////////////////////////////////
+// append
+
+// Append stores the event after the last event of the game.
+func (s *gameEventStore) Append(ctx context.Context, tx *sql.Tx, gameId model.GameId, event *model.GameEvent) *model.GameEvent {
+	sequence := 0
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "SELECT coalesce(max(sequence), 0) FROM "+GameEventTable+" WHERE game_id = $1", int64(gameId)),
+		func(rows *sql.Rows) {
+			if err := rows.Scan(&sequence); err != nil {
+				panic(err)
+			}
+		},
+	)
+	event.Sequence = sequence + 1
+	s.InsertRow(ctx, tx, s.EncodeRow(gameId, event))
+	return event
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (s *gameEventStore) List(ctx context.Context, tx *sql.Tx, gameId model.GameId) []*model.GameEvent {
+	events := util.Convert(s.ListRows(ctx, tx, s.matchingGame(gameId).WithOrderBy("sequence")), s.DecodeRow)
+	if len(events) == 0 {
+		panic(model.ErrGameNotFound)
+	}
+	return events
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *gameEventStore) matchingGame(gameId model.GameId) util.SqlWhereClause {
+	return util.NewSqlCondition("game_id = $_", int64(gameId))
+}
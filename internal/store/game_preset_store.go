@@ -0,0 +1,136 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// game preset store
+
+type GamePresetStore interface {
+	Create(ctx context.Context, tx *sql.Tx, preset *model.GamePreset) *model.GamePreset
+	Retrieve(ctx context.Context, tx *sql.Tx, id model.GamePresetId) *model.GamePreset
+	Update(ctx context.Context, tx *sql.Tx, preset *model.GamePreset) *model.GamePreset
+	Delete(ctx context.Context, tx *sql.Tx, filter *model.GamePresetFilter)
+	List(ctx context.Context, tx *sql.Tx, filter *model.GamePresetFilter) []*model.GamePreset
+}
+
+func NewGamePresetStore(logger *zap.Logger) GamePresetStore {
+	return &gamePresetStore{
+		SqlTable: util.NewSqlTable[GamePresetRow](logger, GamePresetTable, model.ErrGamePresetNotFound),
+	}
+}
+
+type gamePresetStore struct {
+	util.SqlTable[GamePresetRow]
+	util.SqlEncoder[model.GamePreset, GamePresetRow]
+	util.SqlDecoder[GamePresetRow, model.GamePreset]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const GamePresetTable = "game_preset"
+
+// //////////////////////////////////////////////////
+// row
+
+type GamePresetRow struct {
+	Id       int64  `sql:"id,auto-generated"`
+	Name     string `sql:"name"`
+	OwnerId  int64  `sql:"owner_id"`
+	Settings string `sql:"settings"`
+	Public   bool   `sql:"public"`
+}
+
+func (s *gamePresetStore) EncodeRow(obj *model.GamePreset) *GamePresetRow {
+	return &GamePresetRow{
+		Id:       obj.Id.ToInt64(),
+		Name:     obj.Name,
+		OwnerId:  obj.OwnerId.ToInt64(),
+		Settings: EncodeGameSettings(obj.Settings),
+		Public:   obj.Public,
+	}
+}
+
+func (s *gamePresetStore) DecodeRow(row *GamePresetRow) *model.GamePreset {
+	if row == nil {
+		return nil
+	}
+	return &model.GamePreset{
+		Id:       model.GamePresetId(row.Id),
+		Name:     row.Name,
+		OwnerId:  model.UserId(row.OwnerId),
+		Settings: DecodeGameSettings(row.Settings),
+		Public:   row.Public,
+	}
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *gamePresetStore) Create(ctx context.Context, tx *sql.Tx, obj *model.GamePreset) *model.GamePreset {
+	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (s *gamePresetStore) Retrieve(ctx context.Context, tx *sql.Tx, id model.GamePresetId) *model.GamePreset {
+	row, err := s.SelectRow(ctx, tx, s.matchingId(id))
+	if err != nil {
+		panic(err)
+	}
+	return s.DecodeRow(row)
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (s *gamePresetStore) Update(ctx context.Context, tx *sql.Tx, obj *model.GamePreset) *model.GamePreset {
+	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (s *gamePresetStore) Delete(ctx context.Context, tx *sql.Tx, filter *model.GamePresetFilter) {
+	s.DeleteRows(ctx, tx, s.whereClause(filter))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (s *gamePresetStore) List(ctx context.Context, tx *sql.Tx, filter *model.GamePresetFilter) []*model.GamePreset {
+	return util.Convert(s.ListRows(ctx, tx, s.whereClause(filter)), s.DecodeRow)
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *gamePresetStore) matchingId(id model.GamePresetId) util.SqlWhereClause {
+	return util.NewSqlCondition("id = $_", id)
+}
+
+func (s *gamePresetStore) whereClause(filter *model.GamePresetFilter) util.SqlWhereClause {
+	wc := util.NewSqlWhereClause()
+	if filter != nil {
+		if filter.GamePresetId != 0 {
+			wc.WithCondition("id = $_", filter.GamePresetId)
+		}
+		if filter.OwnerId != 0 {
+			wc.WithCondition("owner_id = $_", filter.OwnerId)
+		}
+		if filter.VisibleTo != 0 {
+			wc.WithCondition("(public = 1 OR owner_id = $_)", filter.VisibleTo)
+		} else if filter.PublicOnly {
+			wc.WithCondition("public = 1")
+		}
+	}
+	return wc
+}
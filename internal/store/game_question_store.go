@@ -12,4 +12,5 @@ import (
 
 type GameQuestionStore interface {
 	SelectRandomQuestions(cxt context.Context, tx *sql.Tx, settings model.GameSettings) []*model.GameQuestion
+	PreviewSource(ctx context.Context, tx *sql.Tx, source model.Source) *model.GameSourcePreview
 }
@@ -0,0 +1,214 @@
+package store
+
+import (
+	"encoding/json"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// game settings encoding
+
+// GameSettingsJson is the json representation of game settings stored in a text column ( seed excluded ).
+type GameSettingsJson struct {
+	NbQuestion       int      `json:"nb_question,omitempty"`
+	NbAnswer         int      `json:"nb_answer,omitempty"`
+	NbPlayer         int      `json:"nb_player,omitempty"`
+	Sources          []string `json:"sources,omitempty"`
+	ThemeIds         []int64  `json:"theme_ids,omitempty"`
+	DeezerPlaylistId int64    `json:"deezer_playlist_id,omitempty"`
+	Decade           int      `json:"decade,omitempty"`
+	Genre            string   `json:"genre,omitempty"`
+
+	Rounds []*GameRoundSettingsJson `json:"rounds,omitempty"`
+	Jokers []*GameJokerSettingsJson `json:"jokers,omitempty"`
+}
+
+type GameRoundSettingsJson struct {
+	Title            string   `json:"title,omitempty"`
+	NbQuestion       int      `json:"nb_question,omitempty"`
+	NbAnswer         int      `json:"nb_answer,omitempty"`
+	Sources          []string `json:"sources,omitempty"`
+	ThemeIds         []int64  `json:"theme_ids,omitempty"`
+	DeezerPlaylistId int64    `json:"deezer_playlist_id,omitempty"`
+	Decade           int      `json:"decade,omitempty"`
+	Genre            string   `json:"genre,omitempty"`
+	QuestionType     string   `json:"question_type,omitempty"`
+	ScoreCorrect     int      `json:"score_correct,omitempty"`
+	ScoreWrong       int      `json:"score_wrong,omitempty"`
+	MediaKind        string   `json:"media_kind,omitempty"`
+	Guess            string   `json:"guess,omitempty"`
+
+	YearScoring []*GameYearStepJson     `json:"year_scoring,omitempty"`
+	Slots       []*GameSlotSettingsJson `json:"slots,omitempty"`
+}
+
+type GameSlotSettingsJson struct {
+	Guess    string `json:"guess"`
+	Points   int    `json:"points"`
+	FreeText bool   `json:"free_text,omitempty"`
+}
+
+type GameYearStepJson struct {
+	Within int `json:"within"`
+	Points int `json:"points"`
+}
+
+type GameJokerSettingsJson struct {
+	Type  string `json:"type"`
+	Count int    `json:"count"`
+	Cost  int    `json:"cost,omitempty"`
+}
+
+// //////////////////////////////////////////////////
+// encode
+
+func EncodeGameSettings(settings *model.GameSettings) string {
+	if settings == nil {
+		return "{}"
+	}
+	data, err := json.Marshal(encodeGameSettingsJson(settings))
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
+
+func encodeGameSettingsJson(settings *model.GameSettings) *GameSettingsJson {
+	return &GameSettingsJson{
+		NbQuestion:       settings.NbQuestion,
+		NbAnswer:         settings.NbAnswer,
+		NbPlayer:         settings.NbPlayer,
+		Sources:          util.Convert(settings.Sources, model.Source.String),
+		ThemeIds:         util.Convert(settings.ThemeIds, model.ThemeId.ToInt64),
+		DeezerPlaylistId: int64(settings.DeezerPlaylistId),
+		Decade:           int(settings.Decade),
+		Genre:            settings.Genre,
+		Rounds:           util.Convert(settings.Rounds, encodeGameRoundSettings),
+		Jokers:           util.Convert(settings.Jokers, encodeGameJokerSettings),
+	}
+}
+
+func encodeGameRoundSettings(round *model.GameRoundSettings) *GameRoundSettingsJson {
+	return &GameRoundSettingsJson{
+		Title:            round.Title,
+		NbQuestion:       round.NbQuestion,
+		NbAnswer:         round.NbAnswer,
+		Sources:          util.Convert(round.Sources, model.Source.String),
+		ThemeIds:         util.Convert(round.ThemeIds, model.ThemeId.ToInt64),
+		DeezerPlaylistId: int64(round.DeezerPlaylistId),
+		Decade:           int(round.Decade),
+		Genre:            round.Genre,
+		QuestionType:     round.QuestionType.String(),
+		ScoreCorrect:     round.Scoring.Correct,
+		ScoreWrong:       round.Scoring.Wrong,
+		MediaKind:        round.MediaKind.String(),
+		Guess:            round.Guess.String(),
+		YearScoring:      util.Convert(round.YearScoring.Steps, encodeGameYearStep),
+		Slots:            util.Convert(round.Slots, encodeGameSlotSettings),
+	}
+}
+
+func encodeGameSlotSettings(slot *model.GameSlotSettings) *GameSlotSettingsJson {
+	return &GameSlotSettingsJson{
+		Guess:    slot.Guess.String(),
+		Points:   slot.Points,
+		FreeText: slot.FreeText,
+	}
+}
+
+func encodeGameYearStep(step model.GameYearStep) *GameYearStepJson {
+	return &GameYearStepJson{
+		Within: step.Within,
+		Points: step.Points,
+	}
+}
+
+func encodeGameJokerSettings(joker *model.GameJokerSettings) *GameJokerSettingsJson {
+	return &GameJokerSettingsJson{
+		Type:  joker.Type.String(),
+		Count: joker.Count,
+		Cost:  joker.Cost,
+	}
+}
+
+// //////////////////////////////////////////////////
+// decode
+
+func DecodeGameSettings(settings string) *model.GameSettings {
+	var jsonSettings GameSettingsJson
+	if settings != "" {
+		err := json.Unmarshal([]byte(settings), &jsonSettings)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return decodeGameSettingsJson(&jsonSettings)
+}
+
+func decodeGameSettingsJson(jsonSettings *GameSettingsJson) *model.GameSettings {
+	return &model.GameSettings{
+		NbQuestion:       jsonSettings.NbQuestion,
+		NbAnswer:         jsonSettings.NbAnswer,
+		NbPlayer:         jsonSettings.NbPlayer,
+		Sources:          util.Convert(jsonSettings.Sources, model.ToSource),
+		ThemeIds:         util.Convert(jsonSettings.ThemeIds, toThemeId),
+		DeezerPlaylistId: model.DeezerPlaylistId(jsonSettings.DeezerPlaylistId),
+		Decade:           model.GameDecade(jsonSettings.Decade),
+		Genre:            jsonSettings.Genre,
+		Rounds:           util.Convert(jsonSettings.Rounds, decodeGameRoundSettings),
+		Jokers:           util.Convert(jsonSettings.Jokers, decodeGameJokerSettings),
+	}
+}
+
+func decodeGameRoundSettings(round *GameRoundSettingsJson) *model.GameRoundSettings {
+	return &model.GameRoundSettings{
+		Title:            round.Title,
+		NbQuestion:       round.NbQuestion,
+		NbAnswer:         round.NbAnswer,
+		Sources:          util.Convert(round.Sources, model.ToSource),
+		ThemeIds:         util.Convert(round.ThemeIds, toThemeId),
+		DeezerPlaylistId: model.DeezerPlaylistId(round.DeezerPlaylistId),
+		Decade:           model.GameDecade(round.Decade),
+		Genre:            round.Genre,
+		QuestionType:     model.ToGameQuestionType(round.QuestionType),
+		Scoring: model.GameScoring{
+			Correct: round.ScoreCorrect,
+			Wrong:   round.ScoreWrong,
+		},
+		MediaKind: model.ToGameMediaKind(round.MediaKind),
+		Guess:     model.ToGameGuess(round.Guess),
+		YearScoring: model.GameYearScoring{
+			Steps: util.Convert(round.YearScoring, decodeGameYearStep),
+		},
+		Slots: util.Convert(round.Slots, decodeGameSlotSettings),
+	}
+}
+
+func decodeGameSlotSettings(slot *GameSlotSettingsJson) *model.GameSlotSettings {
+	return &model.GameSlotSettings{
+		Guess:    model.ToGameGuess(slot.Guess),
+		Points:   slot.Points,
+		FreeText: slot.FreeText,
+	}
+}
+
+func decodeGameYearStep(step *GameYearStepJson) model.GameYearStep {
+	return model.GameYearStep{
+		Within: step.Within,
+		Points: step.Points,
+	}
+}
+
+func decodeGameJokerSettings(joker *GameJokerSettingsJson) *model.GameJokerSettings {
+	return &model.GameJokerSettings{
+		Type:  model.ToGameJokerType(joker.Type),
+		Count: joker.Count,
+		Cost:  joker.Cost,
+	}
+}
+
+func toThemeId(id int64) model.ThemeId {
+	return model.ThemeId(id)
+}
@@ -28,6 +28,7 @@ func NewGameQuestionLegacyStore(logger *zap.Logger, rootPath string) store.GameQ
 		logger:           logger,
 		rootPath:         strings.TrimRight(rootPath, "/"),
 		mediaIdsBySource: make(map[model.Source][]int64, 10),
+		genreIdsBySource: make(map[model.Source][]int64, 10),
 		media:            make(map[int64]*JsonLegacyMedia, 2000),
 		genres:           make(map[int64]*JsonLegacyGenre, 200),
 	}
@@ -42,6 +43,7 @@ type gameQuestionLegacyMusicStore struct {
 	logger           *zap.Logger
 	rootPath         string
 	mediaIdsBySource map[model.Source][]int64
+	genreIdsBySource map[model.Source][]int64
 	media            map[int64]*JsonLegacyMedia
 	genres           map[int64]*JsonLegacyGenre
 }
@@ -63,7 +65,7 @@ func (s *gameQuestionLegacyMusicStore) SelectRandomQuestions(ctx context.Context
 	// random seed
 	//
 
-	rand.Seed(settings.Seed)
+	random := rand.New(rand.NewSource(settings.Seed))
 
 	//
 	// select & shuffle media ids
@@ -73,7 +75,7 @@ func (s *gameQuestionLegacyMusicStore) SelectRandomQuestions(ctx context.Context
 	for _, source := range settings.Sources {
 		mediaIds = append(mediaIds, s.mediaIdsBySource[source]...)
 	}
-	util.Shuffle(mediaIds)
+	util.Shuffle(random, mediaIds)
 
 	//
 	// select subset
@@ -91,17 +93,45 @@ func (s *gameQuestionLegacyMusicStore) SelectRandomQuestions(ctx context.Context
 	for _, mediaId := range mediaIds {
 		media := s.media[mediaId]
 		genre := s.genres[media.GenreId]
-		questions = append(questions, s.toQuestion(ctx, genre, media, settings.NbAnswer))
+		questions = append(questions, s.toQuestion(ctx, random, genre, media, settings.NbAnswer))
 	}
 
 	return questions
 }
 
-func (s *gameQuestionLegacyMusicStore) toQuestion(ctx context.Context, genre *JsonLegacyGenre, media *JsonLegacyMedia, nbAnswer int) *model.GameQuestion {
+func (s *gameQuestionLegacyMusicStore) PreviewSource(ctx context.Context, _ *sql.Tx, source model.Source) *model.GameSourcePreview {
+
+	preview := &model.GameSourcePreview{
+		Source:     source,
+		NbQuestion: len(s.mediaIdsBySource[source]),
+	}
+
+	allAnswers := make(map[string]bool)
+	for _, genreId := range s.genreIdsBySource[source] {
+		genre := s.genres[genreId]
+		answers := make(map[string]bool)
+		for _, media := range genre.Media {
+			text := s.toAnswer(ctx, media, false).Text
+			answers[text] = true
+			allAnswers[text] = true
+		}
+		preview.Themes = append(preview.Themes, &model.GameThemePreview{
+			Id:         genre.Id,
+			Title:      genre.Genre,
+			NbQuestion: len(genre.Media),
+			NbAnswer:   len(answers),
+		})
+	}
+	preview.NbAnswer = len(allAnswers)
+
+	return preview
+}
+
+func (s *gameQuestionLegacyMusicStore) toQuestion(ctx context.Context, random *rand.Rand, genre *JsonLegacyGenre, media *JsonLegacyMedia, nbAnswer int) *model.GameQuestion {
 	return &model.GameQuestion{
 		Theme:   s.toTheme(ctx, genre),
 		Music:   s.toMusic(ctx, media),
-		Answers: s.toAnswers(ctx, genre, media, nbAnswer),
+		Answers: s.toAnswers(ctx, random, genre, media, nbAnswer),
 	}
 }
 
@@ -136,11 +166,11 @@ func (s *gameQuestionLegacyMusicStore) toArtist(ctx context.Context, artist *Jso
 	}
 }
 
-func (s *gameQuestionLegacyMusicStore) toAnswers(ctx context.Context, genre *JsonLegacyGenre, media *JsonLegacyMedia, nbAnswer int) []*model.GameAnswer {
+func (s *gameQuestionLegacyMusicStore) toAnswers(ctx context.Context, random *rand.Rand, genre *JsonLegacyGenre, media *JsonLegacyMedia, nbAnswer int) []*model.GameAnswer {
 
 	others := util.Filter(genre.Media, func(other *JsonLegacyMedia) bool { return other.Id != media.Id })
 
-	util.Shuffle(others)
+	util.Shuffle(random, others)
 
 	if len(others) > nbAnswer-1 {
 		others = others[:nbAnswer-1]
@@ -149,7 +179,7 @@ func (s *gameQuestionLegacyMusicStore) toAnswers(ctx context.Context, genre *Jso
 	answers := util.Convert(others, func(other *JsonLegacyMedia) *model.GameAnswer { return s.toAnswer(ctx, other, false) })
 	answers = append(answers, s.toAnswer(ctx, media, true))
 
-	util.Shuffle(answers)
+	util.Shuffle(random, answers)
 
 	return answers
 }
@@ -200,9 +230,11 @@ func (s *gameQuestionLegacyMusicStore) LoadSource(source model.Source, embedByte
 	sourceId := int64(1000 * 1000 * (sourceIndex + 1))
 
 	mediaIds := make([]int64, 0, 1000)
+	genreIds := make([]int64, 0, len(jsonLegacy.Genres))
 
 	for genreIndex, genre := range jsonLegacy.Genres {
 		genre.Id = sourceId + int64(1000*(genreIndex+1))
+		genreIds = append(genreIds, genre.Id)
 		s.genres[genre.Id] = genre
 		for mediaIndex, media := range genre.Media {
 			media.GenreId = genre.Id
@@ -213,6 +245,7 @@ func (s *gameQuestionLegacyMusicStore) LoadSource(source model.Source, embedByte
 	}
 
 	s.mediaIdsBySource[source] = mediaIds
+	s.genreIdsBySource[source] = genreIds
 
 	return s
 }
@@ -0,0 +1,53 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+// //////////////////////////////////////////////////
+// game event memory store
+
+func NewGameEventMemoryStore() store.GameEventStore {
+	return &gameEventMemoryStore{
+		events: make(map[model.GameId][]*model.GameEvent),
+	}
+}
+
+type gameEventMemoryStore struct {
+	lastNumber int
+	events     map[model.GameId][]*model.GameEvent
+	eventsLock sync.RWMutex
+}
+
+func (s *gameEventMemoryStore) NewGameId(ctx context.Context, _ *sql.Tx) model.GameId {
+	s.eventsLock.Lock()
+	defer s.eventsLock.Unlock()
+
+	s.lastNumber++
+	return model.NewGameId(s.lastNumber)
+}
+
+func (s *gameEventMemoryStore) Append(ctx context.Context, _ *sql.Tx, gameId model.GameId, event *model.GameEvent) *model.GameEvent {
+	s.eventsLock.Lock()
+	defer s.eventsLock.Unlock()
+
+	event.Sequence = len(s.events[gameId]) + 1
+	s.events[gameId] = append(s.events[gameId], event)
+	return event
+}
+
+func (s *gameEventMemoryStore) List(ctx context.Context, _ *sql.Tx, gameId model.GameId) []*model.GameEvent {
+	s.eventsLock.RLock()
+	defer s.eventsLock.RUnlock()
+
+	events, found := s.events[gameId]
+	if !found {
+		panic(model.ErrGameNotFound)
+	}
+	return append([]*model.GameEvent(nil), events...)
+}
@@ -37,6 +37,19 @@ func (s *musicAlbumMemoryStore) List(ctx context.Context, tx *sql.Tx, filter *mo
 	return filtered
 }
 
+func (s *musicAlbumMemoryStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicAlbumFilter) int {
+	s.musicAlbumsLock.Lock()
+	defer s.musicAlbumsLock.Unlock()
+
+	count := 0
+	for _, candidate := range s.musicAlbums {
+		if filter.IsMatching(0, candidate) {
+			count++
+		}
+	}
+	return count
+}
+
 func (s *musicAlbumMemoryStore) Create(ctx context.Context, _ *sql.Tx, musicAlbum *model.MusicAlbum) *model.MusicAlbum {
 	s.musicAlbumsLock.Lock()
 	defer s.musicAlbumsLock.Unlock()
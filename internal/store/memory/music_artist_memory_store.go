@@ -37,6 +37,19 @@ func (s *musicArtistMemoryStore) List(ctx context.Context, tx *sql.Tx, filter *m
 	return filtered
 }
 
+func (s *musicArtistMemoryStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicArtistFilter) int {
+	s.musicArtistsLock.Lock()
+	defer s.musicArtistsLock.Unlock()
+
+	count := 0
+	for _, candidate := range s.musicArtists {
+		if filter.IsMatching(0, candidate) {
+			count++
+		}
+	}
+	return count
+}
+
 func (s *musicArtistMemoryStore) Create(ctx context.Context, _ *sql.Tx, musicArtist *model.MusicArtist) *model.MusicArtist {
 	s.musicArtistsLock.Lock()
 	defer s.musicArtistsLock.Unlock()
@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// //////////////////////////////////////////////////
+// music contributor memory store
+
+func NewMusicContributorMemoryStore() store.MusicContributorStore {
+	return &musicContributorMemoryStore{
+		contributors: make(map[model.MusicId][]*model.MusicContributor),
+	}
+}
+
+type musicContributorMemoryStore struct {
+	contributors     map[model.MusicId][]*model.MusicContributor
+	contributorsLock sync.RWMutex
+}
+
+func (s *musicContributorMemoryStore) ListByMusic(ctx context.Context, _ *sql.Tx, musicId model.MusicId) []*model.MusicContributor {
+	s.contributorsLock.Lock()
+	defer s.contributorsLock.Unlock()
+
+	contributors := make([]*model.MusicContributor, 0, len(s.contributors[musicId]))
+	for _, contributor := range s.contributors[musicId] {
+		contributors = append(contributors, contributor.Copy())
+	}
+	return contributors
+}
+
+func (s *musicContributorMemoryStore) Link(ctx context.Context, _ *sql.Tx, musicId model.MusicId, contributor *model.MusicContributor) {
+	s.contributorsLock.Lock()
+	defer s.contributorsLock.Unlock()
+
+	for _, existing := range s.contributors[musicId] {
+		if existing.ArtistId == contributor.ArtistId && existing.Role == contributor.Role {
+			return
+		}
+	}
+	s.contributors[musicId] = append(s.contributors[musicId], &model.MusicContributor{ArtistId: contributor.ArtistId, Role: contributor.Role})
+}
+
+func (s *musicContributorMemoryStore) ReassignArtist(ctx context.Context, _ *sql.Tx, fromId, toId model.MusicArtistId) {
+	s.contributorsLock.Lock()
+	defer s.contributorsLock.Unlock()
+
+	for musicId, contributors := range s.contributors {
+		reassigned := make([]*model.MusicContributor, 0, len(contributors))
+		for _, contributor := range contributors {
+			if contributor.ArtistId == fromId {
+				contributor = &model.MusicContributor{ArtistId: toId, Role: contributor.Role}
+			}
+			if _, found := util.FindIf(reassigned, func(existing *model.MusicContributor) bool {
+				return existing.ArtistId == contributor.ArtistId && existing.Role == contributor.Role
+			}); found {
+				continue
+			}
+			reassigned = append(reassigned, contributor)
+		}
+		s.contributors[musicId] = reassigned
+	}
+}
+
+func (s *musicContributorMemoryStore) UnlinkAllMusic(ctx context.Context, _ *sql.Tx, musicId model.MusicId) {
+	s.contributorsLock.Lock()
+	defer s.contributorsLock.Unlock()
+
+	delete(s.contributors, musicId)
+}
@@ -0,0 +1,255 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+// //////////////////////////////////////////////////
+// music genre memory store
+
+func NewMusicGenreMemoryStore() store.MusicGenreStore {
+	return &musicGenreMemoryStore{
+		musicGenres: make(map[model.MusicGenreId]*model.MusicGenre),
+		albumLinks:  make(map[model.MusicGenreId]map[model.MusicAlbumId]bool),
+		musicLinks:  make(map[model.MusicGenreId]map[model.MusicId]bool),
+	}
+}
+
+type musicGenreMemoryStore struct {
+	musicGenres     map[model.MusicGenreId]*model.MusicGenre
+	albumLinks      map[model.MusicGenreId]map[model.MusicAlbumId]bool
+	musicLinks      map[model.MusicGenreId]map[model.MusicId]bool
+	musicGenresLock sync.RWMutex
+}
+
+var (
+	NextMusicGenreId = 0
+)
+
+func (s *musicGenreMemoryStore) List(ctx context.Context, tx *sql.Tx, filter *model.MusicGenreFilter) []*model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	filtered := make([]*model.MusicGenre, 0, len(s.musicGenres))
+	for _, musicGenre := range s.musicGenres {
+		if filter.IsMatching(len(filtered), musicGenre) {
+			filtered = append(filtered, musicGenre.Copy())
+		}
+	}
+	return filtered
+}
+
+func (s *musicGenreMemoryStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicGenreFilter) int {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	count := 0
+	for _, candidate := range s.musicGenres {
+		if filter.IsMatching(0, candidate) {
+			count++
+		}
+	}
+	return count
+}
+
+func (s *musicGenreMemoryStore) Create(ctx context.Context, _ *sql.Tx, musicGenre *model.MusicGenre) *model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	NextMusicGenreId++
+	musicGenre.Id = model.MusicGenreId(NextMusicGenreId)
+	s.musicGenres[musicGenre.Id] = musicGenre.Copy()
+	return s.musicGenres[musicGenre.Id].Copy()
+}
+
+func (s *musicGenreMemoryStore) Retrieve(ctx context.Context, _ *sql.Tx, id model.MusicGenreId) *model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	musicGenre, found := s.musicGenres[id]
+	if !found {
+		panic(model.ErrMusicGenreNotFound)
+	}
+	return musicGenre.Copy()
+}
+
+func (s *musicGenreMemoryStore) SearchByDeezerId(ctx context.Context, _ *sql.Tx, deezerId model.DeezerGenreId) *model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	if deezerId == 0 {
+		panic(model.ErrInvalidDeezerId)
+	}
+
+	for _, musicGenre := range s.musicGenres {
+		if musicGenre.DeezerId == deezerId {
+			return musicGenre.Copy()
+		}
+	}
+	return nil
+}
+
+func (s *musicGenreMemoryStore) SearchByName(ctx context.Context, _ *sql.Tx, name string) *model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	if name == "" {
+		panic(model.ErrInvalidGenreName)
+	}
+
+	for _, musicGenre := range s.musicGenres {
+		if strings.EqualFold(musicGenre.Name, name) {
+			return musicGenre.Copy()
+		}
+	}
+	return nil
+}
+
+func (s *musicGenreMemoryStore) Update(ctx context.Context, _ *sql.Tx, musicGenre *model.MusicGenre) *model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	_, found := s.musicGenres[musicGenre.Id]
+	if !found {
+		panic(model.ErrMusicGenreNotFound)
+	}
+	s.musicGenres[musicGenre.Id] = musicGenre.Copy()
+	return s.musicGenres[musicGenre.Id].Copy()
+}
+
+func (s *musicGenreMemoryStore) Delete(ctx context.Context, _ *sql.Tx, id model.MusicGenreId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	_, found := s.musicGenres[id]
+	if !found {
+		panic(model.ErrMusicGenreNotFound)
+	}
+	delete(s.musicGenres, id)
+	delete(s.albumLinks, id)
+	delete(s.musicLinks, id)
+}
+
+func (s *musicGenreMemoryStore) ListByAlbum(ctx context.Context, _ *sql.Tx, albumId model.MusicAlbumId) []*model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	genres := make([]*model.MusicGenre, 0)
+	for id, albumIds := range s.albumLinks {
+		if albumIds[albumId] {
+			genres = append(genres, s.musicGenres[id].Copy())
+		}
+	}
+	return sortGenres(genres)
+}
+
+func (s *musicGenreMemoryStore) ListByMusic(ctx context.Context, _ *sql.Tx, musicId model.MusicId) []*model.MusicGenre {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	genres := make([]*model.MusicGenre, 0)
+	for id, musicIds := range s.musicLinks {
+		if musicIds[musicId] {
+			genres = append(genres, s.musicGenres[id].Copy())
+		}
+	}
+	return sortGenres(genres)
+}
+
+func (s *musicGenreMemoryStore) LinkAlbum(ctx context.Context, _ *sql.Tx, id model.MusicGenreId, albumId model.MusicAlbumId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	if s.albumLinks[id] == nil {
+		s.albumLinks[id] = make(map[model.MusicAlbumId]bool)
+	}
+	s.albumLinks[id][albumId] = true
+}
+
+func (s *musicGenreMemoryStore) UnlinkAlbum(ctx context.Context, _ *sql.Tx, id model.MusicGenreId, albumId model.MusicAlbumId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	delete(s.albumLinks[id], albumId)
+}
+
+func (s *musicGenreMemoryStore) LinkMusic(ctx context.Context, _ *sql.Tx, id model.MusicGenreId, musicId model.MusicId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	if s.musicLinks[id] == nil {
+		s.musicLinks[id] = make(map[model.MusicId]bool)
+	}
+	s.musicLinks[id][musicId] = true
+}
+
+func (s *musicGenreMemoryStore) UnlinkMusic(ctx context.Context, _ *sql.Tx, id model.MusicGenreId, musicId model.MusicId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	delete(s.musicLinks[id], musicId)
+}
+
+func (s *musicGenreMemoryStore) UnlinkAllAlbum(ctx context.Context, _ *sql.Tx, albumId model.MusicAlbumId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	for _, albumIds := range s.albumLinks {
+		delete(albumIds, albumId)
+	}
+}
+
+func (s *musicGenreMemoryStore) UnlinkAllMusic(ctx context.Context, _ *sql.Tx, musicId model.MusicId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	for _, musicIds := range s.musicLinks {
+		delete(musicIds, musicId)
+	}
+}
+
+func (s *musicGenreMemoryStore) ReassignAlbum(ctx context.Context, _ *sql.Tx, fromId, toId model.MusicAlbumId) {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	for _, albumIds := range s.albumLinks {
+		if albumIds[fromId] {
+			delete(albumIds, fromId)
+			albumIds[toId] = true
+		}
+	}
+}
+
+func (s *musicGenreMemoryStore) CountAlbums(ctx context.Context, _ *sql.Tx) map[model.MusicGenreId]int {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	result := make(map[model.MusicGenreId]int, len(s.albumLinks))
+	for id, albumIds := range s.albumLinks {
+		result[id] = len(albumIds)
+	}
+	return result
+}
+
+func (s *musicGenreMemoryStore) CountMusics(ctx context.Context, _ *sql.Tx) map[model.MusicGenreId]int {
+	s.musicGenresLock.Lock()
+	defer s.musicGenresLock.Unlock()
+
+	result := make(map[model.MusicGenreId]int, len(s.musicLinks))
+	for id, musicIds := range s.musicLinks {
+		result[id] = len(musicIds)
+	}
+	return result
+}
+
+func sortGenres(genres []*model.MusicGenre) []*model.MusicGenre {
+	sort.Slice(genres, func(i, j int) bool { return strings.ToLower(genres[i].Name) < strings.ToLower(genres[j].Name) })
+	return genres
+}
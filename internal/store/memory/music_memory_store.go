@@ -37,6 +37,19 @@ func (s *musicMemoryStore) List(ctx context.Context, tx *sql.Tx, filter *model.M
 	return filtered
 }
 
+func (s *musicMemoryStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicFilter) int {
+	s.musicsLock.Lock()
+	defer s.musicsLock.Unlock()
+
+	count := 0
+	for _, candidate := range s.musics {
+		if filter.IsMatching(0, candidate) {
+			count++
+		}
+	}
+	return count
+}
+
 func (s *musicMemoryStore) Create(ctx context.Context, _ *sql.Tx, music *model.Music) *model.Music {
 	s.musicsLock.Lock()
 	defer s.musicsLock.Unlock()
@@ -74,7 +87,7 @@ func (s *musicMemoryStore) SearchByDeezerId(ctx context.Context, _ *sql.Tx, deez
 	return nil
 }
 
-func (s *musicMemoryStore) SearchByName(ctx context.Context, _ *sql.Tx, name string) *model.Music {
+func (s *musicMemoryStore) SearchByIdentity(ctx context.Context, _ *sql.Tx, artistId model.MusicArtistId, name string) *model.Music {
 	s.musicsLock.Lock()
 	defer s.musicsLock.Unlock()
 
@@ -83,13 +96,18 @@ func (s *musicMemoryStore) SearchByName(ctx context.Context, _ *sql.Tx, name str
 	}
 
 	for _, music := range s.musics {
-		if music.Name == name {
+		if music.IsSameIdentity(artistId, name) {
 			return music.Copy()
 		}
 	}
 	return nil
 }
 
+// ListWithoutTitleKey returns no music: the memory store compares the titles on the fly.
+func (s *musicMemoryStore) ListWithoutTitleKey(ctx context.Context, _ *sql.Tx) []*model.Music {
+	return nil
+}
+
 func (s *musicMemoryStore) Update(ctx context.Context, _ *sql.Tx, music *model.Music) *model.Music {
 	s.musicsLock.Lock()
 	defer s.musicsLock.Unlock()
@@ -136,3 +154,53 @@ func (s *musicMemoryStore) IsArtistUsed(ctx context.Context, _ *sql.Tx, artistId
 	}
 	return false
 }
+
+func (s *musicMemoryStore) ReassignAlbum(ctx context.Context, _ *sql.Tx, fromId, toId model.MusicAlbumId) int {
+	s.musicsLock.Lock()
+	defer s.musicsLock.Unlock()
+
+	count := 0
+	for _, music := range s.musics {
+		if music.AlbumId == fromId {
+			music.AlbumId = toId
+			count++
+		}
+	}
+	return count
+}
+
+func (s *musicMemoryStore) ReassignArtist(ctx context.Context, _ *sql.Tx, fromId, toId model.MusicArtistId) int {
+	s.musicsLock.Lock()
+	defer s.musicsLock.Unlock()
+
+	count := 0
+	for _, music := range s.musics {
+		if music.ArtistId == fromId {
+			music.ArtistId = toId
+			count++
+		}
+	}
+	return count
+}
+
+func (s *musicMemoryStore) CountByArtist(ctx context.Context, _ *sql.Tx) map[model.MusicArtistId]int {
+	s.musicsLock.Lock()
+	defer s.musicsLock.Unlock()
+
+	count := make(map[model.MusicArtistId]int, 0)
+	for _, music := range s.musics {
+		count[music.ArtistId]++
+	}
+	return count
+}
+
+func (s *musicMemoryStore) CountByAlbum(ctx context.Context, _ *sql.Tx) map[model.MusicAlbumId]int {
+	s.musicsLock.Lock()
+	defer s.musicsLock.Unlock()
+
+	count := make(map[model.MusicAlbumId]int, 0)
+	for _, music := range s.musics {
+		count[music.AlbumId]++
+	}
+	return count
+}
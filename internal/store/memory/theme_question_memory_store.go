@@ -97,6 +97,58 @@ func (s *themeQuestionMemoryStore) CountByTheme(ctx context.Context, _ *sql.Tx)
 	return count
 }
 
+func (s *themeQuestionMemoryStore) CountThemesByMusic(ctx context.Context, _ *sql.Tx) map[model.MusicId]int {
+	s.themeQuestionsLock.Lock()
+	defer s.themeQuestionsLock.Unlock()
+
+	themes := make(map[model.MusicId]map[model.ThemeId]bool, 0)
+	for _, question := range s.themeQuestions {
+		if themes[question.MusicId] == nil {
+			themes[question.MusicId] = make(map[model.ThemeId]bool, 0)
+		}
+		themes[question.MusicId][question.ThemeId] = true
+	}
+
+	count := make(map[model.MusicId]int, len(themes))
+	for musicId, themeIds := range themes {
+		count[musicId] = len(themeIds)
+	}
+	return count
+}
+
+func (s *themeQuestionMemoryStore) CountDistinctTextByTheme(ctx context.Context, _ *sql.Tx) map[model.ThemeId]int {
+	s.themeQuestionsLock.Lock()
+	defer s.themeQuestionsLock.Unlock()
+
+	texts := make(map[model.ThemeId]map[string]bool, 0)
+	for _, question := range s.themeQuestions {
+		if texts[question.ThemeId] == nil {
+			texts[question.ThemeId] = make(map[string]bool)
+		}
+		texts[question.ThemeId][question.Text] = true
+	}
+
+	count := make(map[model.ThemeId]int, len(texts))
+	for themeId, themeTexts := range texts {
+		count[themeId] = len(themeTexts)
+	}
+
+	return count
+}
+
+func (s *themeQuestionMemoryStore) CountDistinctText(ctx context.Context, _ *sql.Tx, filter *model.ThemeQuestionFilter) int {
+	s.themeQuestionsLock.Lock()
+	defer s.themeQuestionsLock.Unlock()
+
+	texts := make(map[string]bool, 0)
+	for _, question := range s.themeQuestions {
+		if filter.IsMatching(question) {
+			texts[question.Text] = true
+		}
+	}
+	return len(texts)
+}
+
 func (s *themeQuestionMemoryStore) IsMusicInTheme(ctx context.Context, tx *sql.Tx, themeId model.ThemeId, musicId model.MusicId) bool {
 	s.themeQuestionsLock.Lock()
 	defer s.themeQuestionsLock.Unlock()
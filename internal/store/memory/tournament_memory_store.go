@@ -0,0 +1,77 @@
+package memory
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/store"
+)
+
+// //////////////////////////////////////////////////
+// tournament memory store
+
+func NewTournamentMemoryStore() store.TournamentStore {
+	return &tournamentMemoryStore{
+		tournaments: make(map[model.TournamentId]*model.Tournament),
+	}
+}
+
+type tournamentMemoryStore struct {
+	tournaments     map[model.TournamentId]*model.Tournament
+	tournamentsLock sync.RWMutex
+}
+
+var (
+	NextTournamentId = 0
+)
+
+func (s *tournamentMemoryStore) Create(ctx context.Context, _ *sql.Tx, tournament *model.Tournament) *model.Tournament {
+	s.tournamentsLock.Lock()
+	defer s.tournamentsLock.Unlock()
+
+	NextTournamentId++
+	tournament.Id = model.TournamentId(NextTournamentId)
+	tournament.Version = 1
+	s.tournaments[tournament.Id] = tournament
+	return s.tournaments[tournament.Id]
+}
+
+func (s *tournamentMemoryStore) Retrieve(ctx context.Context, _ *sql.Tx, id model.TournamentId) *model.Tournament {
+	s.tournamentsLock.Lock()
+	defer s.tournamentsLock.Unlock()
+
+	tournament, found := s.tournaments[id]
+	if !found {
+		panic(model.ErrTournamentNotFound)
+	}
+	return tournament
+}
+
+func (s *tournamentMemoryStore) Update(ctx context.Context, _ *sql.Tx, tournament *model.Tournament) *model.Tournament {
+	s.tournamentsLock.Lock()
+	defer s.tournamentsLock.Unlock()
+
+	orig, found := s.tournaments[tournament.Id]
+	if !found {
+		panic(model.ErrTournamentNotFound)
+	}
+	if orig.Version != tournament.Version {
+		panic(model.ErrConcurrentUpdate)
+	}
+	tournament.Version++
+	s.tournaments[tournament.Id] = tournament
+	return s.tournaments[tournament.Id]
+}
+
+func (s *tournamentMemoryStore) Delete(ctx context.Context, _ *sql.Tx, id model.TournamentId) {
+	s.tournamentsLock.Lock()
+	defer s.tournamentsLock.Unlock()
+
+	_, found := s.tournaments[id]
+	if !found {
+		panic(model.ErrTournamentNotFound)
+	}
+	delete(s.tournaments, id)
+}
@@ -14,6 +14,7 @@ import (
 
 type MusicAlbumStore interface {
 	List(ctx context.Context, tx *sql.Tx, filter *model.MusicAlbumFilter) []*model.MusicAlbum
+	Count(ctx context.Context, tx *sql.Tx, filter *model.MusicAlbumFilter) int
 	Create(ctx context.Context, tx *sql.Tx, music *model.MusicAlbum) *model.MusicAlbum
 	Retrieve(ctx context.Context, tx *sql.Tx, id model.MusicAlbumId) *model.MusicAlbum
 	SearchByDeezerId(ctx context.Context, tx *sql.Tx, deezerId model.DeezerAlbumId) *model.MusicAlbum
@@ -22,13 +23,15 @@ type MusicAlbumStore interface {
 	Delete(ctx context.Context, tx *sql.Tx, id model.MusicAlbumId)
 }
 
-func NewMusicAlbumStore(logger *zap.Logger) MusicAlbumStore {
+func NewMusicAlbumStore(logger *zap.Logger, searchStore SearchStore) MusicAlbumStore {
 	return &musicAlbumStore{
-		SqlTable: util.NewSqlTable[MusicAlbumRow](logger, "music_album", model.ErrMusicAlbumNotFound),
+		searchStore: searchStore,
+		SqlTable:    util.NewSqlTable[MusicAlbumRow](logger, "music_album", model.ErrMusicAlbumNotFound),
 	}
 }
 
 type musicAlbumStore struct {
+	searchStore SearchStore
 	util.SqlTable[MusicAlbumRow]
 	util.SqlEncoder[model.MusicArtist, MusicArtistRow]
 	util.SqlDecoder[MusicArtistRow, model.MusicArtist]
@@ -69,27 +72,50 @@ func (s *musicAlbumStore) DecodeRow(row *MusicAlbumRow) *model.MusicAlbum {
 // list
 
 func (s *musicAlbumStore) List(ctx context.Context, tx *sql.Tx, filter *model.MusicAlbumFilter) []*model.MusicAlbum {
-	return util.Convert(s.ListRows(ctx, tx, s.whereClause(filter)), s.DecodeRow)
+	return util.Convert(s.ListRows(ctx, tx, s.listClause(filter)), s.DecodeRow)
+}
+
+func (s *musicAlbumStore) listClause(filter *model.MusicAlbumFilter) util.SqlWhereClause {
+	wc := s.whereClause(filter)
+	if filter != nil {
+		wc.WithOrderBy(toMusicOrderBy(filter.Sort, "(SELECT count(1) FROM music WHERE music.album_id = music_album.id)"))
+		if filter.Limit > 0 {
+			wc.WithLimit(filter.Limit)
+		}
+		if filter.Offset > 0 {
+			wc.WithOffset(filter.Offset)
+		}
+	}
+	return wc
 }
 
 func (s *musicAlbumStore) whereClause(filter *model.MusicAlbumFilter) util.SqlWhereClause {
 	wc := util.NewSqlWhereClause()
 	if filter != nil {
 		if filter.Name != "" {
-			wc.WithCondition("name LIKE '%' || $_ || '%'", filter.Name)
+			withSearchCondition(wc, model.SearchKind_Album, filter.Name)
 		}
-		if filter.Limit > 0 {
-			wc.WithLimit(filter.Limit)
+		if filter.GenreId > 0 {
+			wc.WithCondition("id IN (SELECT album_id FROM music_genre_album WHERE genre_id = $_)", filter.GenreId)
 		}
 	}
 	return wc
 }
 
+// //////////////////////////////////////////////////
+// count
+
+func (s *musicAlbumStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicAlbumFilter) int {
+	return s.CountRows(ctx, tx, s.whereClause(filter))
+}
+
 // //////////////////////////////////////////////////
 // create
 
 func (s *musicAlbumStore) Create(ctx context.Context, tx *sql.Tx, obj *model.MusicAlbum) *model.MusicAlbum {
-	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	created := s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	s.searchStore.Index(ctx, tx, model.NewAlbumSearchEntry(created))
+	return created
 }
 
 // //////////////////////////////////////////////////
@@ -123,7 +149,9 @@ func (s *musicAlbumStore) SearchByName(ctx context.Context, tx *sql.Tx, name str
 // update
 
 func (s *musicAlbumStore) Update(ctx context.Context, tx *sql.Tx, obj *model.MusicAlbum) *model.MusicAlbum {
-	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	updated := s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	s.searchStore.Index(ctx, tx, model.NewAlbumSearchEntry(updated))
+	return updated
 }
 
 // //////////////////////////////////////////////////
@@ -131,6 +159,7 @@ func (s *musicAlbumStore) Update(ctx context.Context, tx *sql.Tx, obj *model.Mus
 
 func (s *musicAlbumStore) Delete(ctx context.Context, tx *sql.Tx, id model.MusicAlbumId) {
 	s.DeleteRows(ctx, tx, s.matchingId(id))
+	s.searchStore.Unindex(ctx, tx, model.SearchKind_Album, int64(id))
 }
 
 // //////////////////////////////////////////////////
@@ -14,6 +14,7 @@ import (
 
 type MusicArtistStore interface {
 	List(ctx context.Context, tx *sql.Tx, filter *model.MusicArtistFilter) []*model.MusicArtist
+	Count(ctx context.Context, tx *sql.Tx, filter *model.MusicArtistFilter) int
 	Create(ctx context.Context, tx *sql.Tx, music *model.MusicArtist) *model.MusicArtist
 	Retrieve(ctx context.Context, tx *sql.Tx, id model.MusicArtistId) *model.MusicArtist
 	SearchByDeezerId(ctx context.Context, tx *sql.Tx, deezerId model.DeezerArtistId) *model.MusicArtist
@@ -22,13 +23,15 @@ type MusicArtistStore interface {
 	Delete(ctx context.Context, tx *sql.Tx, id model.MusicArtistId)
 }
 
-func NewMusicArtistStore(logger *zap.Logger) MusicArtistStore {
+func NewMusicArtistStore(logger *zap.Logger, searchStore SearchStore) MusicArtistStore {
 	return &musicArtistStore{
-		SqlTable: util.NewSqlTable[MusicArtistRow](logger, "music_artist", model.ErrMusicArtistNotFound),
+		searchStore: searchStore,
+		SqlTable:    util.NewSqlTable[MusicArtistRow](logger, "music_artist", model.ErrMusicArtistNotFound),
 	}
 }
 
 type musicArtistStore struct {
+	searchStore SearchStore
 	util.SqlTable[MusicArtistRow]
 	util.SqlEncoder[model.MusicArtist, MusicArtistRow]
 	util.SqlDecoder[MusicArtistRow, model.MusicArtist]
@@ -69,27 +72,47 @@ func (s *musicArtistStore) DecodeRow(row *MusicArtistRow) *model.MusicArtist {
 // list
 
 func (s *musicArtistStore) List(ctx context.Context, tx *sql.Tx, filter *model.MusicArtistFilter) []*model.MusicArtist {
-	return util.Convert(s.ListRows(ctx, tx, s.whereClause(filter)), s.DecodeRow)
+	return util.Convert(s.ListRows(ctx, tx, s.listClause(filter)), s.DecodeRow)
+}
+
+func (s *musicArtistStore) listClause(filter *model.MusicArtistFilter) util.SqlWhereClause {
+	wc := s.whereClause(filter)
+	if filter != nil {
+		wc.WithOrderBy(toMusicOrderBy(filter.Sort, "(SELECT count(1) FROM music WHERE music.artist_id = music_artist.id)"))
+		if filter.Limit > 0 {
+			wc.WithLimit(filter.Limit)
+		}
+		if filter.Offset > 0 {
+			wc.WithOffset(filter.Offset)
+		}
+	}
+	return wc
 }
 
 func (s *musicArtistStore) whereClause(filter *model.MusicArtistFilter) util.SqlWhereClause {
 	wc := util.NewSqlWhereClause()
 	if filter != nil {
 		if filter.Name != "" {
-			wc.WithCondition("name LIKE '%' || $_ || '%'", filter.Name)
-		}
-		if filter.Limit > 0 {
-			wc.WithLimit(filter.Limit)
+			withSearchCondition(wc, model.SearchKind_Artist, filter.Name)
 		}
 	}
 	return wc
 }
 
+// //////////////////////////////////////////////////
+// count
+
+func (s *musicArtistStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicArtistFilter) int {
+	return s.CountRows(ctx, tx, s.whereClause(filter))
+}
+
 // //////////////////////////////////////////////////
 // create
 
 func (s *musicArtistStore) Create(ctx context.Context, tx *sql.Tx, obj *model.MusicArtist) *model.MusicArtist {
-	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	created := s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	s.searchStore.Index(ctx, tx, model.NewArtistSearchEntry(created))
+	return created
 }
 
 // //////////////////////////////////////////////////
@@ -123,7 +146,9 @@ func (s *musicArtistStore) SearchByName(ctx context.Context, tx *sql.Tx, name st
 // update
 
 func (s *musicArtistStore) Update(ctx context.Context, tx *sql.Tx, obj *model.MusicArtist) *model.MusicArtist {
-	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	updated := s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	s.searchStore.Index(ctx, tx, model.NewArtistSearchEntry(updated))
+	return updated
 }
 
 // //////////////////////////////////////////////////
@@ -131,6 +156,7 @@ func (s *musicArtistStore) Update(ctx context.Context, tx *sql.Tx, obj *model.Mu
 
 func (s *musicArtistStore) Delete(ctx context.Context, tx *sql.Tx, id model.MusicArtistId) {
 	s.DeleteRows(ctx, tx, s.matchingId(id))
+	s.searchStore.Unindex(ctx, tx, model.SearchKind_Artist, int64(id))
 }
 
 // //////////////////////////////////////////////////
@@ -0,0 +1,108 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// music contributor store
+
+// MusicContributorStore manages the artists taking part in a music along with their roles.
+type MusicContributorStore interface {
+	ListByMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId) []*model.MusicContributor
+	Link(ctx context.Context, tx *sql.Tx, musicId model.MusicId, contributor *model.MusicContributor)
+	UnlinkAllMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId)
+	ReassignArtist(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicArtistId)
+}
+
+func NewMusicContributorStore(logger *zap.Logger) MusicContributorStore {
+	return &musicContributorStore{
+		SqlTable: util.NewSqlTable[MusicContributorRow](logger, MusicContributorTable, model.ErrMusicNotFound),
+	}
+}
+
+type musicContributorStore struct {
+	util.SqlTable[MusicContributorRow]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const (
+	MusicContributorTable = "music_contributor"
+)
+
+// //////////////////////////////////////////////////
+// row
+
+type MusicContributorRow struct {
+	MusicId  int64  `sql:"music_id"`
+	ArtistId int64  `sql:"artist_id"`
+	Role     string `sql:"role"`
+}
+
+// //////////////////////////////////////////////////
+// decoder
+
+func (s *musicContributorStore) DecodeRow(row *MusicContributorRow) *model.MusicContributor {
+	if row == nil {
+		return nil
+	}
+	return &model.MusicContributor{
+		ArtistId: model.MusicArtistId(row.ArtistId),
+		Role:     model.ToMusicArtistRole(row.Role),
+	}
+}
+
+// //////////////////////////////////////////////////
+// list by music
+
+// ListByMusic lists the contributors in the order they were linked.
+func (s *musicContributorStore) ListByMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId) []*model.MusicContributor {
+	wc := s.matchingMusicId(musicId).
+		WithOrderBy("rowid")
+	return util.Convert(s.ListRows(ctx, tx, wc), s.DecodeRow)
+}
+
+// //////////////////////////////////////////////////
+// link
+
+// Link links the artist to the music with its role, if not already linked.
+func (s *musicContributorStore) Link(ctx context.Context, tx *sql.Tx, musicId model.MusicId, contributor *model.MusicContributor) {
+	if s.ExistsRow(ctx, tx, s.matchingLink(musicId, contributor)) {
+		return
+	}
+	s.InsertRow(ctx, tx, &MusicContributorRow{MusicId: int64(musicId), ArtistId: int64(contributor.ArtistId), Role: contributor.Role.String()})
+}
+
+// UnlinkAllMusic removes the contributors of a deleted music.
+func (s *musicContributorStore) UnlinkAllMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId) {
+	s.DeleteRows(ctx, tx, s.matchingMusicId(musicId))
+}
+
+// //////////////////////////////////////////////////
+// reassign
+
+// ReassignArtist moves the contributions of an artist to another one, dropping the contributions the other one already has.
+func (s *musicContributorStore) ReassignArtist(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicArtistId) {
+	util.SqlExec(ctx, tx, "UPDATE OR IGNORE music_contributor SET artist_id = $1 WHERE artist_id = $2", toId, fromId)
+	s.DeleteRows(ctx, tx, util.NewSqlCondition("artist_id = $_", fromId))
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *musicContributorStore) matchingMusicId(musicId model.MusicId) util.SqlWhereClause {
+	return util.NewSqlCondition("music_id = $_", musicId)
+}
+
+func (s *musicContributorStore) matchingLink(musicId model.MusicId, contributor *model.MusicContributor) util.SqlWhereClause {
+	return s.matchingMusicId(musicId).
+		WithCondition("artist_id = $_", contributor.ArtistId).
+		WithCondition("role = $_", contributor.Role.String())
+}
@@ -0,0 +1,294 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// music genre store
+
+// MusicGenreStore manages the genres and their links to albums and musics.
+type MusicGenreStore interface {
+	List(ctx context.Context, tx *sql.Tx, filter *model.MusicGenreFilter) []*model.MusicGenre
+	Count(ctx context.Context, tx *sql.Tx, filter *model.MusicGenreFilter) int
+	Create(ctx context.Context, tx *sql.Tx, genre *model.MusicGenre) *model.MusicGenre
+	Retrieve(ctx context.Context, tx *sql.Tx, id model.MusicGenreId) *model.MusicGenre
+	SearchByDeezerId(ctx context.Context, tx *sql.Tx, deezerId model.DeezerGenreId) *model.MusicGenre
+	SearchByName(ctx context.Context, tx *sql.Tx, name string) *model.MusicGenre
+	Update(ctx context.Context, tx *sql.Tx, genre *model.MusicGenre) *model.MusicGenre
+	Delete(ctx context.Context, tx *sql.Tx, id model.MusicGenreId)
+
+	ListByAlbum(ctx context.Context, tx *sql.Tx, albumId model.MusicAlbumId) []*model.MusicGenre
+	ListByMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId) []*model.MusicGenre
+	LinkAlbum(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, albumId model.MusicAlbumId)
+	UnlinkAlbum(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, albumId model.MusicAlbumId)
+	LinkMusic(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, musicId model.MusicId)
+	UnlinkMusic(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, musicId model.MusicId)
+	UnlinkAllAlbum(ctx context.Context, tx *sql.Tx, albumId model.MusicAlbumId)
+	UnlinkAllMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId)
+	ReassignAlbum(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicAlbumId)
+	CountAlbums(ctx context.Context, tx *sql.Tx) map[model.MusicGenreId]int
+	CountMusics(ctx context.Context, tx *sql.Tx) map[model.MusicGenreId]int
+}
+
+func NewMusicGenreStore(logger *zap.Logger) MusicGenreStore {
+	return &musicGenreStore{
+		SqlTable:   util.NewSqlTable[MusicGenreRow](logger, MusicGenreTable, model.ErrMusicGenreNotFound),
+		albumLinks: util.NewSqlTable[MusicGenreAlbumRow](logger, MusicGenreAlbumTable, model.ErrMusicGenreNotFound),
+		musicLinks: util.NewSqlTable[MusicGenreMusicRow](logger, MusicGenreMusicTable, model.ErrMusicGenreNotFound),
+	}
+}
+
+type musicGenreStore struct {
+	util.SqlTable[MusicGenreRow]
+	util.SqlEncoder[model.MusicGenre, MusicGenreRow]
+	util.SqlDecoder[MusicGenreRow, model.MusicGenre]
+	albumLinks util.SqlTable[MusicGenreAlbumRow]
+	musicLinks util.SqlTable[MusicGenreMusicRow]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const (
+	MusicGenreTable      = "music_genre"
+	MusicGenreAlbumTable = "music_genre_album"
+	MusicGenreMusicTable = "music_genre_music"
+)
+
+// //////////////////////////////////////////////////
+// row
+
+type MusicGenreRow struct {
+	Id       int64  `sql:"id,auto-generated"`
+	DeezerId int64  `sql:"deezer_id"`
+	Name     string `sql:"name"`
+	ImgUrl   string `sql:"img_url"`
+}
+
+type MusicGenreAlbumRow struct {
+	GenreId int64 `sql:"genre_id"`
+	AlbumId int64 `sql:"album_id"`
+}
+
+type MusicGenreMusicRow struct {
+	GenreId int64 `sql:"genre_id"`
+	MusicId int64 `sql:"music_id"`
+}
+
+func (s *musicGenreStore) EncodeRow(obj *model.MusicGenre) *MusicGenreRow {
+	return &MusicGenreRow{
+		Id:       int64(obj.Id),
+		DeezerId: int64(obj.DeezerId),
+		Name:     obj.Name,
+		ImgUrl:   string(obj.ImgUrl),
+	}
+}
+
+func (s *musicGenreStore) DecodeRow(row *MusicGenreRow) *model.MusicGenre {
+	if row == nil {
+		return nil
+	}
+	return &model.MusicGenre{
+		Id:       model.MusicGenreId(row.Id),
+		DeezerId: model.DeezerGenreId(row.DeezerId),
+		Name:     row.Name,
+		ImgUrl:   model.Url(row.ImgUrl),
+	}
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (s *musicGenreStore) List(ctx context.Context, tx *sql.Tx, filter *model.MusicGenreFilter) []*model.MusicGenre {
+	return util.Convert(s.ListRows(ctx, tx, s.listClause(filter)), s.DecodeRow)
+}
+
+func (s *musicGenreStore) listClause(filter *model.MusicGenreFilter) util.SqlWhereClause {
+	wc := s.whereClause(filter)
+	if filter != nil {
+		wc.WithOrderBy(toMusicOrderBy(filter.Sort, "(SELECT count(1) FROM music_genre_music WHERE music_genre_music.genre_id = music_genre.id)"))
+		if filter.Limit > 0 {
+			wc.WithLimit(filter.Limit)
+		}
+		if filter.Offset > 0 {
+			wc.WithOffset(filter.Offset)
+		}
+	}
+	return wc
+}
+
+func (s *musicGenreStore) whereClause(filter *model.MusicGenreFilter) util.SqlWhereClause {
+	wc := util.NewSqlWhereClause()
+	if filter != nil {
+		if filter.Name != "" {
+			wc.WithCondition("name LIKE '%' || $_ || '%'", filter.Name)
+		}
+	}
+	return wc
+}
+
+// //////////////////////////////////////////////////
+// count
+
+func (s *musicGenreStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicGenreFilter) int {
+	return s.CountRows(ctx, tx, s.whereClause(filter))
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *musicGenreStore) Create(ctx context.Context, tx *sql.Tx, obj *model.MusicGenre) *model.MusicGenre {
+	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (s *musicGenreStore) Retrieve(ctx context.Context, tx *sql.Tx, id model.MusicGenreId) *model.MusicGenre {
+	row, err := s.SelectRow(ctx, tx, s.matchingId(id))
+	if err != nil {
+		panic(err)
+	}
+	return s.DecodeRow(row)
+}
+
+// //////////////////////////////////////////////////
+// retrieve by deezer id
+
+func (s *musicGenreStore) SearchByDeezerId(ctx context.Context, tx *sql.Tx, deezerId model.DeezerGenreId) *model.MusicGenre {
+	row, _ := s.SelectRow(ctx, tx, util.NewSqlCondition("deezer_id = $_", deezerId))
+	return s.DecodeRow(row)
+}
+
+// //////////////////////////////////////////////////
+// retrieve by name
+
+func (s *musicGenreStore) SearchByName(ctx context.Context, tx *sql.Tx, name string) *model.MusicGenre {
+	row, _ := s.SelectRow(ctx, tx, util.NewSqlCondition("name = $_ COLLATE NOCASE", name))
+	return s.DecodeRow(row)
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (s *musicGenreStore) Update(ctx context.Context, tx *sql.Tx, obj *model.MusicGenre) *model.MusicGenre {
+	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+// Delete removes the genre along with its links.
+func (s *musicGenreStore) Delete(ctx context.Context, tx *sql.Tx, id model.MusicGenreId) {
+	s.albumLinks.DeleteRows(ctx, tx, s.matchingGenreId(id))
+	s.musicLinks.DeleteRows(ctx, tx, s.matchingGenreId(id))
+	s.DeleteRows(ctx, tx, s.matchingId(id))
+}
+
+// //////////////////////////////////////////////////
+// list by album / music
+
+func (s *musicGenreStore) ListByAlbum(ctx context.Context, tx *sql.Tx, albumId model.MusicAlbumId) []*model.MusicGenre {
+	wc := util.NewSqlCondition("id IN (SELECT genre_id FROM music_genre_album WHERE album_id = $_)", albumId).
+		WithOrderBy("name COLLATE NOCASE")
+	return util.Convert(s.ListRows(ctx, tx, wc), s.DecodeRow)
+}
+
+func (s *musicGenreStore) ListByMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId) []*model.MusicGenre {
+	wc := util.NewSqlCondition("id IN (SELECT genre_id FROM music_genre_music WHERE music_id = $_)", musicId).
+		WithOrderBy("name COLLATE NOCASE")
+	return util.Convert(s.ListRows(ctx, tx, wc), s.DecodeRow)
+}
+
+// //////////////////////////////////////////////////
+// link
+
+// LinkAlbum links the genre to the album, if not already linked.
+func (s *musicGenreStore) LinkAlbum(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, albumId model.MusicAlbumId) {
+	if s.albumLinks.ExistsRow(ctx, tx, s.matchingAlbumLink(id, albumId)) {
+		return
+	}
+	s.albumLinks.InsertRow(ctx, tx, &MusicGenreAlbumRow{GenreId: int64(id), AlbumId: int64(albumId)})
+}
+
+func (s *musicGenreStore) UnlinkAlbum(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, albumId model.MusicAlbumId) {
+	s.albumLinks.DeleteRows(ctx, tx, s.matchingAlbumLink(id, albumId))
+}
+
+// LinkMusic links the genre to the music, if not already linked.
+func (s *musicGenreStore) LinkMusic(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, musicId model.MusicId) {
+	if s.musicLinks.ExistsRow(ctx, tx, s.matchingMusicLink(id, musicId)) {
+		return
+	}
+	s.musicLinks.InsertRow(ctx, tx, &MusicGenreMusicRow{GenreId: int64(id), MusicId: int64(musicId)})
+}
+
+func (s *musicGenreStore) UnlinkMusic(ctx context.Context, tx *sql.Tx, id model.MusicGenreId, musicId model.MusicId) {
+	s.musicLinks.DeleteRows(ctx, tx, s.matchingMusicLink(id, musicId))
+}
+
+// UnlinkAllAlbum removes the links of a deleted album.
+func (s *musicGenreStore) UnlinkAllAlbum(ctx context.Context, tx *sql.Tx, albumId model.MusicAlbumId) {
+	s.albumLinks.DeleteRows(ctx, tx, util.NewSqlCondition("album_id = $_", albumId))
+}
+
+// UnlinkAllMusic removes the links of a deleted music.
+func (s *musicGenreStore) UnlinkAllMusic(ctx context.Context, tx *sql.Tx, musicId model.MusicId) {
+	s.musicLinks.DeleteRows(ctx, tx, util.NewSqlCondition("music_id = $_", musicId))
+}
+
+// ReassignAlbum moves the links of an album to another one, dropping the links the other one already has.
+func (s *musicGenreStore) ReassignAlbum(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicAlbumId) {
+	util.SqlExec(ctx, tx, "UPDATE OR IGNORE music_genre_album SET album_id = $1 WHERE album_id = $2", toId, fromId)
+	s.albumLinks.DeleteRows(ctx, tx, util.NewSqlCondition("album_id = $_", fromId))
+}
+
+// //////////////////////////////////////////////////
+// count
+
+func (s *musicGenreStore) CountAlbums(ctx context.Context, tx *sql.Tx) map[model.MusicGenreId]int {
+	return s.countLinks(ctx, tx, MusicGenreAlbumTable)
+}
+
+func (s *musicGenreStore) CountMusics(ctx context.Context, tx *sql.Tx) map[model.MusicGenreId]int {
+	return s.countLinks(ctx, tx, MusicGenreMusicTable)
+}
+
+func (s *musicGenreStore) countLinks(ctx context.Context, tx *sql.Tx, table string) map[model.MusicGenreId]int {
+	result := make(map[model.MusicGenreId]int, 0)
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "SELECT genre_id, count(1) AS count FROM "+table+" GROUP BY genre_id"),
+		func(rows *sql.Rows) {
+			var genreId int64
+			var count int
+			rows.Scan(&genreId, &count)
+			result[model.MusicGenreId(genreId)] = count
+		},
+	)
+	return result
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *musicGenreStore) matchingId(id model.MusicGenreId) util.SqlWhereClause {
+	return util.NewSqlCondition("id = $_", id)
+}
+
+func (s *musicGenreStore) matchingGenreId(id model.MusicGenreId) util.SqlWhereClause {
+	return util.NewSqlCondition("genre_id = $_", id)
+}
+
+func (s *musicGenreStore) matchingAlbumLink(id model.MusicGenreId, albumId model.MusicAlbumId) util.SqlWhereClause {
+	return util.NewSqlCondition("genre_id = $_", id).WithCondition("album_id = $_", albumId)
+}
+
+func (s *musicGenreStore) matchingMusicLink(id model.MusicGenreId, musicId model.MusicId) util.SqlWhereClause {
+	return util.NewSqlCondition("genre_id = $_", id).WithCondition("music_id = $_", musicId)
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/gre-ory/amnezic-go/internal/model"
 	"github.com/gre-ory/amnezic-go/internal/util"
@@ -14,23 +15,31 @@ import (
 
 type MusicStore interface {
 	List(ctx context.Context, tx *sql.Tx, filter *model.MusicFilter) []*model.Music
+	Count(ctx context.Context, tx *sql.Tx, filter *model.MusicFilter) int
 	Create(ctx context.Context, tx *sql.Tx, music *model.Music) *model.Music
 	Retrieve(ctx context.Context, tx *sql.Tx, id model.MusicId) *model.Music
 	SearchByDeezerId(ctx context.Context, tx *sql.Tx, deezerId model.DeezerMusicId) *model.Music
-	SearchByName(ctx context.Context, tx *sql.Tx, name string) *model.Music
+	SearchByIdentity(ctx context.Context, tx *sql.Tx, artistId model.MusicArtistId, name string) *model.Music
+	ListWithoutTitleKey(ctx context.Context, tx *sql.Tx) []*model.Music
 	Update(ctx context.Context, tx *sql.Tx, music *model.Music) *model.Music
 	Delete(ctx context.Context, tx *sql.Tx, id model.MusicId)
 	IsAlbumUsed(ctx context.Context, tx *sql.Tx, albumId model.MusicAlbumId) bool
 	IsArtistUsed(ctx context.Context, tx *sql.Tx, artistId model.MusicArtistId) bool
+	ReassignAlbum(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicAlbumId) int
+	ReassignArtist(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicArtistId) int
+	CountByArtist(ctx context.Context, tx *sql.Tx) map[model.MusicArtistId]int
+	CountByAlbum(ctx context.Context, tx *sql.Tx) map[model.MusicAlbumId]int
 }
 
-func NewMusicStore(logger *zap.Logger) MusicStore {
+func NewMusicStore(logger *zap.Logger, searchStore SearchStore) MusicStore {
 	return &musicStore{
-		SqlTable: util.NewSqlTable[MusicRow](logger, "music", model.ErrMusicNotFound),
+		searchStore: searchStore,
+		SqlTable:    util.NewSqlTable[MusicRow](logger, "music", model.ErrMusicNotFound),
 	}
 }
 
 type musicStore struct {
+	searchStore SearchStore
 	util.SqlTable[MusicRow]
 	util.SqlEncoder[model.Music, MusicRow]
 	util.SqlDecoder[MusicRow, model.Music]
@@ -40,22 +49,36 @@ type musicStore struct {
 // row
 
 type MusicRow struct {
-	Id       int64  `sql:"id,auto-generated"`
-	DeezerId int64  `sql:"deezer_id"`
-	ArtistId int64  `sql:"artist_id"`
-	AlbumId  int64  `sql:"album_id"`
-	Name     string `sql:"name"`
-	Mp3Url   string `sql:"mp3_url"`
+	Id          int64  `sql:"id,auto-generated"`
+	DeezerId    int64  `sql:"deezer_id"`
+	ArtistId    int64  `sql:"artist_id"`
+	AlbumId     int64  `sql:"album_id"`
+	Name        string `sql:"name"`
+	TitleKey    string `sql:"title_key"`
+	Mp3Url      string `sql:"mp3_url"`
+	ReleaseDate string `sql:"release_date"`
+	Year        int64  `sql:"year"`
+	Duration    int64  `sql:"duration"`
+	Genre       string `sql:"genre"`
+	Explicit    bool   `sql:"explicit"`
+	Rank        int64  `sql:"rank"`
 }
 
 func (s *musicStore) EncodeRow(obj *model.Music) *MusicRow {
 	return &MusicRow{
-		Id:       int64(obj.Id),
-		DeezerId: int64(obj.DeezerId),
-		Name:     obj.Name,
-		Mp3Url:   string(obj.Mp3Url),
-		ArtistId: int64(obj.ArtistId),
-		AlbumId:  int64(obj.AlbumId),
+		Id:          int64(obj.Id),
+		DeezerId:    int64(obj.DeezerId),
+		Name:        obj.Name,
+		TitleKey:    model.ToMusicTitleKey(obj.Name),
+		Mp3Url:      string(obj.Mp3Url),
+		ArtistId:    int64(obj.ArtistId),
+		AlbumId:     int64(obj.AlbumId),
+		ReleaseDate: obj.ReleaseDate,
+		Year:        int64(obj.Year),
+		Duration:    int64(obj.Duration / time.Second),
+		Genre:       obj.Genre,
+		Explicit:    obj.Explicit,
+		Rank:        int64(obj.Rank),
 	}
 }
 
@@ -64,12 +87,18 @@ func (s *musicStore) DecodeRow(row *MusicRow) *model.Music {
 		return nil
 	}
 	return &model.Music{
-		Id:       model.MusicId(row.Id),
-		DeezerId: model.DeezerMusicId(row.DeezerId),
-		Name:     row.Name,
-		Mp3Url:   model.Url(row.Mp3Url),
-		ArtistId: model.MusicArtistId(row.ArtistId),
-		AlbumId:  model.MusicAlbumId(row.AlbumId),
+		Id:          model.MusicId(row.Id),
+		DeezerId:    model.DeezerMusicId(row.DeezerId),
+		Name:        row.Name,
+		Mp3Url:      model.Url(row.Mp3Url),
+		ArtistId:    model.MusicArtistId(row.ArtistId),
+		AlbumId:     model.MusicAlbumId(row.AlbumId),
+		ReleaseDate: row.ReleaseDate,
+		Year:        int(row.Year),
+		Duration:    time.Duration(row.Duration) * time.Second,
+		Genre:       row.Genre,
+		Explicit:    row.Explicit,
+		Rank:        int(row.Rank),
 	}
 }
 
@@ -77,33 +106,77 @@ func (s *musicStore) DecodeRow(row *MusicRow) *model.Music {
 // list
 
 func (s *musicStore) List(ctx context.Context, tx *sql.Tx, filter *model.MusicFilter) []*model.Music {
-	return util.Convert(s.ListRows(ctx, tx, s.whereClause(filter)), s.DecodeRow)
+	return util.Convert(s.ListRows(ctx, tx, s.listClause(filter)), s.DecodeRow)
+}
+
+func (s *musicStore) listClause(filter *model.MusicFilter) util.SqlWhereClause {
+	wc := s.whereClause(filter)
+	if filter != nil {
+		wc.WithOrderBy(toMusicOrderBy(filter.Sort, "(SELECT count(DISTINCT theme_id) FROM "+ThemeQuestionTable+" WHERE "+ThemeQuestionTable+".music_id = music.id)"))
+		if filter.Limit > 0 {
+			wc.WithLimit(filter.Limit)
+		}
+		if filter.Offset > 0 {
+			wc.WithOffset(filter.Offset)
+		}
+	}
+	return wc
 }
 
 func (s *musicStore) whereClause(filter *model.MusicFilter) util.SqlWhereClause {
 	wc := util.NewSqlWhereClause()
 	if filter != nil {
 		if filter.Name != "" {
-			wc.WithCondition("name LIKE '%' || $_ || '%'", filter.Name)
+			withSearchCondition(wc, model.SearchKind_Music, filter.Name)
 		}
 		if filter.ArtistId > 0 {
 			wc.WithCondition("artist_id = $_", filter.ArtistId)
 		}
 		if filter.AlbumId > 0 {
-			wc.WithCondition("artist_id = $_", filter.ArtistId)
+			wc.WithCondition("album_id = $_", filter.AlbumId)
 		}
-		if filter.Limit > 0 {
-			wc.WithLimit(filter.Limit)
+		if filter.GenreId > 0 {
+			wc.WithCondition("id IN (SELECT music_id FROM music_genre_music WHERE genre_id = $_)", filter.GenreId)
+		}
+		if filter.MinYear > 0 {
+			wc.WithCondition("year >= $_", filter.MinYear)
+		}
+		if filter.MaxYear > 0 {
+			wc.WithCondition("year BETWEEN 1 AND $_", filter.MaxYear)
+		}
+		if filter.Genre != "" {
+			wc.WithCondition("(genre = $_ COLLATE NOCASE OR id IN (SELECT music_id FROM music_genre_music JOIN music_genre ON music_genre.id = music_genre_music.genre_id WHERE music_genre.name = $_ COLLATE NOCASE))", filter.Genre, filter.Genre)
+		}
+		if filter.Explicit != nil {
+			wc.WithCondition("explicit = $_", *filter.Explicit)
+		}
+		if filter.MinDuration > 0 {
+			wc.WithCondition("duration >= $_", int64(filter.MinDuration/time.Second))
+		}
+		if filter.MaxDuration > 0 {
+			wc.WithCondition("duration BETWEEN 1 AND $_", int64(filter.MaxDuration/time.Second))
+		}
+		if filter.MinRank > 0 {
+			wc.WithCondition("rank >= $_", filter.MinRank)
 		}
 	}
 	return wc
 }
 
+// //////////////////////////////////////////////////
+// count
+
+func (s *musicStore) Count(ctx context.Context, tx *sql.Tx, filter *model.MusicFilter) int {
+	return s.CountRows(ctx, tx, s.whereClause(filter))
+}
+
 // //////////////////////////////////////////////////
 // create
 
 func (s *musicStore) Create(ctx context.Context, tx *sql.Tx, obj *model.Music) *model.Music {
-	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	created := s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	s.searchStore.Index(ctx, tx, model.NewMusicSearchEntry(created))
+	return created
 }
 
 // //////////////////////////////////////////////////
@@ -126,18 +199,26 @@ func (s *musicStore) SearchByDeezerId(ctx context.Context, tx *sql.Tx, deezerId
 }
 
 // //////////////////////////////////////////////////
-// search by deezer id
+// search by identity
 
-func (s *musicStore) SearchByName(ctx context.Context, tx *sql.Tx, name string) *model.Music {
-	row, _ := s.SelectRow(ctx, tx, util.NewSqlCondition("name = $_", name))
+// SearchByIdentity returns the music of the artist having the same normalized title.
+func (s *musicStore) SearchByIdentity(ctx context.Context, tx *sql.Tx, artistId model.MusicArtistId, name string) *model.Music {
+	row, _ := s.SelectRow(ctx, tx, util.NewSqlCondition("IFNULL(artist_id, 0) = $_ AND title_key = $_", artistId, model.ToMusicTitleKey(name)))
 	return s.DecodeRow(row)
 }
 
+// ListWithoutTitleKey returns the musics registered before the title keys, from the first registered one.
+func (s *musicStore) ListWithoutTitleKey(ctx context.Context, tx *sql.Tx) []*model.Music {
+	return util.Convert(s.ListRows(ctx, tx, util.NewSqlCondition("title_key = ''").WithOrderBy("id")), s.DecodeRow)
+}
+
 // //////////////////////////////////////////////////
 // update
 
 func (s *musicStore) Update(ctx context.Context, tx *sql.Tx, obj *model.Music) *model.Music {
-	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	updated := s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	s.searchStore.Index(ctx, tx, model.NewMusicSearchEntry(updated))
+	return updated
 }
 
 // //////////////////////////////////////////////////
@@ -145,6 +226,7 @@ func (s *musicStore) Update(ctx context.Context, tx *sql.Tx, obj *model.Music) *
 
 func (s *musicStore) Delete(ctx context.Context, tx *sql.Tx, id model.MusicId) {
 	s.DeleteRows(ctx, tx, s.matchingId(id))
+	s.searchStore.Unindex(ctx, tx, model.SearchKind_Music, int64(id))
 }
 
 // //////////////////////////////////////////////////
@@ -158,7 +240,20 @@ func (s *musicStore) IsAlbumUsed(ctx context.Context, tx *sql.Tx, albumId model.
 // artist usage
 
 func (s *musicStore) IsArtistUsed(ctx context.Context, tx *sql.Tx, artistId model.MusicArtistId) bool {
-	return s.ExistsRow(ctx, tx, util.NewSqlCondition("artist_id = $_", artistId))
+	return s.ExistsRow(ctx, tx, util.NewSqlCondition("(artist_id = $_ OR id IN (SELECT music_id FROM music_contributor WHERE artist_id = $_))", artistId, artistId))
+}
+
+// //////////////////////////////////////////////////
+// reassign
+
+// ReassignAlbum moves the musics of an album to another one and returns the number of moved musics.
+func (s *musicStore) ReassignAlbum(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicAlbumId) int {
+	return int(util.SqlExec(ctx, tx, "UPDATE music SET album_id = $1 WHERE album_id = $2", toId, fromId))
+}
+
+// ReassignArtist moves the musics of an artist to another one and returns the number of moved musics.
+func (s *musicStore) ReassignArtist(ctx context.Context, tx *sql.Tx, fromId, toId model.MusicArtistId) int {
+	return int(util.SqlExec(ctx, tx, "UPDATE music SET artist_id = $1 WHERE artist_id = $2", toId, fromId))
 }
 
 // //////////////////////////////////////////////////
@@ -167,3 +262,60 @@ func (s *musicStore) IsArtistUsed(ctx context.Context, tx *sql.Tx, artistId mode
 func (s *musicStore) matchingId(id model.MusicId) util.SqlWhereClause {
 	return util.NewSqlCondition("id = $_", id)
 }
+
+// //////////////////////////////////////////////////
+// count by artist
+
+func (s *musicStore) CountByArtist(ctx context.Context, tx *sql.Tx) map[model.MusicArtistId]int {
+	result := make(map[model.MusicArtistId]int, 0)
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "SELECT artist_id, count(1) AS count FROM music GROUP BY artist_id"),
+		func(rows *sql.Rows) {
+			var artistId int64
+			var count int
+			rows.Scan(&artistId, &count)
+			result[model.MusicArtistId(artistId)] = count
+		},
+	)
+	return result
+}
+
+// //////////////////////////////////////////////////
+// count by album
+
+func (s *musicStore) CountByAlbum(ctx context.Context, tx *sql.Tx) map[model.MusicAlbumId]int {
+	result := make(map[model.MusicAlbumId]int, 0)
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "SELECT album_id, count(1) AS count FROM music GROUP BY album_id"),
+		func(rows *sql.Rows) {
+			var albumId int64
+			var count int
+			rows.Scan(&albumId, &count)
+			result[model.MusicAlbumId(albumId)] = count
+		},
+	)
+	return result
+}
+
+// //////////////////////////////////////////////////
+// order by
+
+// toMusicOrderBy translates a catalog sort into an order by clause, the usage being computed by the given sub-query.
+func toMusicOrderBy(sort model.MusicSort, usage string) string {
+	var orderBy string
+	switch sort.Key() {
+	case model.MusicSortKey_Id:
+		orderBy = "id"
+	case model.MusicSortKey_Usage:
+		orderBy = usage
+	default:
+		orderBy = "name COLLATE NOCASE"
+	}
+	if sort.IsDesc() {
+		orderBy += " DESC"
+	}
+	if sort.Key() != model.MusicSortKey_Id {
+		orderBy += ", id"
+	}
+	return orderBy
+}
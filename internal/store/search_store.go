@@ -0,0 +1,130 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// search store
+
+// SearchStore maintains the FTS5 index over catalog names and theme question texts.
+// the entries are kept in sync by the music, artist, album and theme question stores.
+type SearchStore interface {
+	Index(ctx context.Context, tx *sql.Tx, entry *model.SearchEntry)
+	Unindex(ctx context.Context, tx *sql.Tx, kind model.SearchKind, id int64)
+	Search(ctx context.Context, tx *sql.Tx, kind model.SearchKind, text string, limit int) []*model.SearchEntry
+}
+
+func NewSearchStore(logger *zap.Logger) SearchStore {
+	return &searchStore{
+		SqlTable: util.NewSqlTable[SearchRow](logger, SearchTable, model.ErrSearchEntryNotFound),
+	}
+}
+
+type searchStore struct {
+	util.SqlTable[SearchRow]
+	util.SqlEncoder[model.SearchEntry, SearchRow]
+	util.SqlDecoder[SearchRow, model.SearchEntry]
+}
+
+// CheckSearchSupport ensures that the sqlite library provides FTS5, required by the search table.
+// mattn/go-sqlite3 only embeds FTS5 when built with `-tags sqlite_fts5`.
+func CheckSearchSupport(ctx context.Context, db *sql.DB) error {
+	var enabled int
+	if err := db.QueryRowContext(ctx, "SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&enabled); err != nil {
+		return err
+	}
+	if enabled == 0 {
+		return model.ErrSearchNotSupported
+	}
+	return nil
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const SearchTable = "search"
+
+// //////////////////////////////////////////////////
+// row
+
+type SearchRow struct {
+	Kind     string `sql:"kind"`
+	RefId    int64  `sql:"ref_id"`
+	ParentId int64  `sql:"parent_id"`
+	Text     string `sql:"text"`
+}
+
+func (s *searchStore) EncodeRow(obj *model.SearchEntry) *SearchRow {
+	return &SearchRow{
+		Kind:     obj.Kind.String(),
+		RefId:    obj.Id,
+		ParentId: obj.ParentId,
+		Text:     obj.Text,
+	}
+}
+
+func (s *searchStore) DecodeRow(row *SearchRow) *model.SearchEntry {
+	if row == nil {
+		return nil
+	}
+	return &model.SearchEntry{
+		Kind:     model.SearchKind(row.Kind),
+		Id:       row.RefId,
+		ParentId: row.ParentId,
+		Text:     row.Text,
+	}
+}
+
+// //////////////////////////////////////////////////
+// index
+
+// Index replaces the entry of the same kind and id, an empty text only removing it.
+func (s *searchStore) Index(ctx context.Context, tx *sql.Tx, entry *model.SearchEntry) {
+	s.Unindex(ctx, tx, entry.Kind, entry.Id)
+	if entry.Text == "" {
+		return
+	}
+	s.InsertRow(ctx, tx, s.EncodeRow(entry))
+}
+
+// //////////////////////////////////////////////////
+// unindex
+
+func (s *searchStore) Unindex(ctx context.Context, tx *sql.Tx, kind model.SearchKind, id int64) {
+	s.DeleteRows(ctx, tx, util.NewSqlCondition("kind = $_ AND ref_id = $_", kind.String(), id))
+}
+
+// //////////////////////////////////////////////////
+// search
+
+// Search returns the best ranked entries of a kind matching every word of the text as a prefix, regardless of case and accents.
+func (s *searchStore) Search(ctx context.Context, tx *sql.Tx, kind model.SearchKind, text string, limit int) []*model.SearchEntry {
+	query := util.ToFtsQuery(text)
+	if query == "" {
+		return nil
+	}
+	wc := util.NewSqlCondition(SearchTable+" MATCH $_ AND kind = $_", query, kind.String()).
+		WithOrderBy("rank")
+	if limit > 0 {
+		wc.WithLimit(limit)
+	}
+	return util.Convert(s.ListRows(ctx, tx, wc), s.DecodeRow)
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+// withSearchCondition restricts a where clause to the ids of a kind whose indexed text matches the given text.
+func withSearchCondition(wc util.SqlWhereClause, kind model.SearchKind, text string) {
+	query := util.ToFtsQuery(text)
+	if query == "" {
+		return
+	}
+	wc.WithCondition("id IN ( SELECT ref_id FROM "+SearchTable+" WHERE "+SearchTable+" MATCH $_ AND kind = $_ )", query, kind.String())
+}
@@ -3,6 +3,7 @@ package store
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/gre-ory/amnezic-go/internal/model"
 	"github.com/gre-ory/amnezic-go/internal/util"
@@ -19,17 +20,22 @@ type ThemeQuestionStore interface {
 	Delete(ctx context.Context, tx *sql.Tx, filter *model.ThemeQuestionFilter)
 	List(ctx context.Context, tx *sql.Tx, filter *model.ThemeQuestionFilter) []*model.ThemeQuestion
 	CountByTheme(ctx context.Context, tx *sql.Tx) map[model.ThemeId]int
+	CountThemesByMusic(ctx context.Context, tx *sql.Tx) map[model.MusicId]int
+	CountDistinctTextByTheme(ctx context.Context, tx *sql.Tx) map[model.ThemeId]int
+	CountDistinctText(ctx context.Context, tx *sql.Tx, filter *model.ThemeQuestionFilter) int
 	IsMusicInTheme(ctx context.Context, tx *sql.Tx, themeId model.ThemeId, musicId model.MusicId) bool
 	IsMusicUsed(ctx context.Context, tx *sql.Tx, musicId model.MusicId) bool
 }
 
-func NewThemeQuestionStore(logger *zap.Logger) ThemeQuestionStore {
+func NewThemeQuestionStore(logger *zap.Logger, searchStore SearchStore) ThemeQuestionStore {
 	return &themeQuestionStore{
-		SqlTable: util.NewSqlTable[ThemeQuestionRow](logger, ThemeQuestionTable, model.ErrThemeQuestionNotFound),
+		searchStore: searchStore,
+		SqlTable:    util.NewSqlTable[ThemeQuestionRow](logger, ThemeQuestionTable, model.ErrThemeQuestionNotFound),
 	}
 }
 
 type themeQuestionStore struct {
+	searchStore SearchStore
 	util.SqlTable[ThemeQuestionRow]
 	util.SqlEncoder[model.ThemeQuestion, ThemeQuestionRow]
 	util.SqlDecoder[ThemeQuestionRow, model.ThemeQuestion]
@@ -44,20 +50,24 @@ const ThemeQuestionTable = "theme_question"
 // row
 
 type ThemeQuestionRow struct {
-	Id      int64  `sql:"id,auto-generated"`
-	ThemeId int64  `sql:"theme_id"`
-	MusicId int64  `sql:"music_id"`
-	Text    string `sql:"text"`
-	Hint    string `sql:"hint"`
+	Id              int64  `sql:"id,auto-generated"`
+	ThemeId         int64  `sql:"theme_id"`
+	MusicId         int64  `sql:"music_id"`
+	Text            string `sql:"text"`
+	Hint            string `sql:"hint"`
+	ExcerptStart    int64  `sql:"excerpt_start"`
+	ExcerptDuration int64  `sql:"excerpt_duration"`
 }
 
 func (s *themeQuestionStore) EncodeRow(obj *model.ThemeQuestion) *ThemeQuestionRow {
 	return &ThemeQuestionRow{
-		Id:      int64(obj.Id),
-		ThemeId: int64(obj.ThemeId),
-		MusicId: int64(obj.MusicId),
-		Text:    obj.Text,
-		Hint:    obj.Hint,
+		Id:              int64(obj.Id),
+		ThemeId:         int64(obj.ThemeId),
+		MusicId:         int64(obj.MusicId),
+		Text:            obj.Text,
+		Hint:            obj.Hint,
+		ExcerptStart:    obj.Excerpt.Start.Milliseconds(),
+		ExcerptDuration: obj.Excerpt.Duration.Milliseconds(),
 	}
 }
 
@@ -71,6 +81,10 @@ func (s *themeQuestionStore) DecodeRow(row *ThemeQuestionRow) *model.ThemeQuesti
 		MusicId: model.MusicId(row.MusicId),
 		Text:    row.Text,
 		Hint:    row.Hint,
+		Excerpt: model.AudioExcerpt{
+			Start:    time.Duration(row.ExcerptStart) * time.Millisecond,
+			Duration: time.Duration(row.ExcerptDuration) * time.Millisecond,
+		},
 	}
 }
 
@@ -78,7 +92,9 @@ func (s *themeQuestionStore) DecodeRow(row *ThemeQuestionRow) *model.ThemeQuesti
 // create
 
 func (s *themeQuestionStore) Create(ctx context.Context, tx *sql.Tx, obj *model.ThemeQuestion) *model.ThemeQuestion {
-	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	created := s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+	s.searchStore.Index(ctx, tx, model.NewThemeQuestionSearchEntry(created))
+	return created
 }
 
 // //////////////////////////////////////////////////
@@ -96,13 +112,18 @@ func (s *themeQuestionStore) Retrieve(ctx context.Context, tx *sql.Tx, id model.
 // update
 
 func (s *themeQuestionStore) Update(ctx context.Context, tx *sql.Tx, obj *model.ThemeQuestion) *model.ThemeQuestion {
-	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	updated := s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+	s.searchStore.Index(ctx, tx, model.NewThemeQuestionSearchEntry(updated))
+	return updated
 }
 
 // //////////////////////////////////////////////////
 // delete
 
 func (s *themeQuestionStore) Delete(ctx context.Context, tx *sql.Tx, filter *model.ThemeQuestionFilter) {
+	for _, question := range s.List(ctx, tx, filter) {
+		s.searchStore.Unindex(ctx, tx, model.SearchKind_ThemeQuestion, int64(question.Id))
+	}
 	s.DeleteRows(ctx, tx, s.whereClause(filter))
 }
 
@@ -130,6 +151,55 @@ func (s *themeQuestionStore) CountByTheme(ctx context.Context, tx *sql.Tx) map[m
 	return result
 }
 
+// //////////////////////////////////////////////////
+// count themes by music
+
+func (s *themeQuestionStore) CountThemesByMusic(ctx context.Context, tx *sql.Tx) map[model.MusicId]int {
+	result := make(map[model.MusicId]int, 0)
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "SELECT music_id, count(DISTINCT theme_id) AS count FROM "+ThemeQuestionTable+" GROUP BY music_id"),
+		func(rows *sql.Rows) {
+			var musicId int64
+			var count int
+			rows.Scan(&musicId, &count)
+			result[model.MusicId(musicId)] = count
+		},
+	)
+	return result
+}
+
+// //////////////////////////////////////////////////
+// count distinct text
+
+func (s *themeQuestionStore) CountDistinctTextByTheme(ctx context.Context, tx *sql.Tx) map[model.ThemeId]int {
+	result := make(map[model.ThemeId]int, 0)
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "SELECT theme_id, count(DISTINCT text) AS count FROM "+ThemeQuestionTable+" GROUP BY theme_id"),
+		func(rows *sql.Rows) {
+			var themeId int64
+			var count int
+			rows.Scan(&themeId, &count)
+			result[model.ThemeId(themeId)] = count
+		},
+	)
+	return result
+}
+
+// CountDistinctText counts the distinct texts of the matching questions, a text shared by several themes being counted once.
+func (s *themeQuestionStore) CountDistinctText(ctx context.Context, tx *sql.Tx, filter *model.ThemeQuestionFilter) int {
+	whereClause, args := s.whereClause(filter).Generate(0)
+	count := 0
+	util.SqlScan(
+		util.SqlQuery(ctx, tx, "SELECT count(DISTINCT text) AS count FROM "+ThemeQuestionTable+" "+whereClause, args...),
+		func(rows *sql.Rows) {
+			if err := rows.Scan(&count); err != nil {
+				panic(err)
+			}
+		},
+	)
+	return count
+}
+
 // //////////////////////////////////////////////////
 // is music used
 
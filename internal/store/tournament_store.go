@@ -0,0 +1,18 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+)
+
+// //////////////////////////////////////////////////
+// tournament store
+
+type TournamentStore interface {
+	Create(ctx context.Context, tx *sql.Tx, tournament *model.Tournament) *model.Tournament
+	Retrieve(ctx context.Context, tx *sql.Tx, id model.TournamentId) *model.Tournament
+	Update(ctx context.Context, tx *sql.Tx, tournament *model.Tournament) *model.Tournament
+	Delete(ctx context.Context, tx *sql.Tx, id model.TournamentId)
+}
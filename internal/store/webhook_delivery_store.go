@@ -0,0 +1,122 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// webhook delivery store
+
+type WebhookDeliveryStore interface {
+	Create(ctx context.Context, tx *sql.Tx, delivery *model.WebhookDelivery) *model.WebhookDelivery
+	List(ctx context.Context, tx *sql.Tx, filter *model.WebhookDeliveryFilter) []*model.WebhookDelivery
+	DeleteByWebhook(ctx context.Context, tx *sql.Tx, webhookId model.WebhookId)
+}
+
+func NewWebhookDeliveryStore(logger *zap.Logger) WebhookDeliveryStore {
+	return &webhookDeliveryStore{
+		SqlTable: util.NewSqlTable[WebhookDeliveryRow](logger, WebhookDeliveryTable, model.ErrWebhookDeliveryNotFound),
+	}
+}
+
+type webhookDeliveryStore struct {
+	util.SqlTable[WebhookDeliveryRow]
+	util.SqlEncoder[model.WebhookDelivery, WebhookDeliveryRow]
+	util.SqlDecoder[WebhookDeliveryRow, model.WebhookDelivery]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const WebhookDeliveryTable = "webhook_delivery"
+
+// //////////////////////////////////////////////////
+// row
+
+type WebhookDeliveryRow struct {
+	Id          int64  `sql:"id,auto-generated"`
+	WebhookId   int64  `sql:"webhook_id"`
+	Event       string `sql:"event"`
+	GameId      int64  `sql:"game_id"`
+	Attempt     int    `sql:"attempt"`
+	StatusCode  int    `sql:"status_code"`
+	Error       string `sql:"error"`
+	Success     bool   `sql:"success"`
+	DeliveredAt int64  `sql:"delivered_at"`
+}
+
+func (s *webhookDeliveryStore) EncodeRow(obj *model.WebhookDelivery) *WebhookDeliveryRow {
+	return &WebhookDeliveryRow{
+		Id:          int64(obj.Id),
+		WebhookId:   obj.WebhookId.ToInt64(),
+		Event:       obj.Event.String(),
+		GameId:      int64(obj.GameId),
+		Attempt:     obj.Attempt,
+		StatusCode:  obj.StatusCode,
+		Error:       obj.Error,
+		Success:     obj.Success,
+		DeliveredAt: obj.DeliveredAt.Unix(),
+	}
+}
+
+func (s *webhookDeliveryStore) DecodeRow(row *WebhookDeliveryRow) *model.WebhookDelivery {
+	if row == nil {
+		return nil
+	}
+	return &model.WebhookDelivery{
+		Id:          model.WebhookDeliveryId(row.Id),
+		WebhookId:   model.WebhookId(row.WebhookId),
+		Event:       model.ToWebhookEventType(row.Event),
+		GameId:      model.GameId(row.GameId),
+		Attempt:     row.Attempt,
+		StatusCode:  row.StatusCode,
+		Error:       row.Error,
+		Success:     row.Success,
+		DeliveredAt: time.Unix(row.DeliveredAt, 0),
+	}
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *webhookDeliveryStore) Create(ctx context.Context, tx *sql.Tx, obj *model.WebhookDelivery) *model.WebhookDelivery {
+	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+// List returns the latest deliveries first.
+func (s *webhookDeliveryStore) List(ctx context.Context, tx *sql.Tx, filter *model.WebhookDeliveryFilter) []*model.WebhookDelivery {
+	return util.Convert(s.ListRows(ctx, tx, s.whereClause(filter)), s.DecodeRow)
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (s *webhookDeliveryStore) DeleteByWebhook(ctx context.Context, tx *sql.Tx, webhookId model.WebhookId) {
+	s.DeleteRows(ctx, tx, util.NewSqlCondition("webhook_id = $_", webhookId))
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *webhookDeliveryStore) whereClause(filter *model.WebhookDeliveryFilter) util.SqlWhereClause {
+	wc := util.NewSqlWhereClause()
+	if filter != nil {
+		if filter.WebhookId != 0 {
+			wc.WithCondition("webhook_id = $_", filter.WebhookId)
+		}
+		if filter.Limit != 0 {
+			wc.WithLimit(filter.Limit)
+		}
+	}
+	wc.WithOrderBy("id DESC")
+	return wc
+}
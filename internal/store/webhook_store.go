@@ -0,0 +1,129 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/gre-ory/amnezic-go/internal/model"
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"go.uber.org/zap"
+)
+
+// //////////////////////////////////////////////////
+// webhook store
+
+type WebhookStore interface {
+	Create(ctx context.Context, tx *sql.Tx, webhook *model.Webhook) *model.Webhook
+	Retrieve(ctx context.Context, tx *sql.Tx, id model.WebhookId) *model.Webhook
+	Update(ctx context.Context, tx *sql.Tx, webhook *model.Webhook) *model.Webhook
+	Delete(ctx context.Context, tx *sql.Tx, id model.WebhookId)
+	List(ctx context.Context, tx *sql.Tx, filter *model.WebhookFilter) []*model.Webhook
+}
+
+func NewWebhookStore(logger *zap.Logger) WebhookStore {
+	return &webhookStore{
+		SqlTable: util.NewSqlTable[WebhookRow](logger, WebhookTable, model.ErrWebhookNotFound),
+	}
+}
+
+type webhookStore struct {
+	util.SqlTable[WebhookRow]
+	util.SqlEncoder[model.Webhook, WebhookRow]
+	util.SqlDecoder[WebhookRow, model.Webhook]
+}
+
+// //////////////////////////////////////////////////
+// table
+
+const WebhookTable = "webhook"
+
+// //////////////////////////////////////////////////
+// row
+
+type WebhookRow struct {
+	Id     int64  `sql:"id,auto-generated"`
+	Url    string `sql:"url"`
+	Secret string `sql:"secret"`
+	Events string `sql:"events"`
+	Active bool   `sql:"active"`
+}
+
+func (s *webhookStore) EncodeRow(obj *model.Webhook) *WebhookRow {
+	return &WebhookRow{
+		Id:     obj.Id.ToInt64(),
+		Url:    obj.Url,
+		Secret: obj.Secret,
+		Events: util.ConvertAndJoin(obj.Events, model.WebhookEventType.String, ","),
+		Active: obj.Active,
+	}
+}
+
+func (s *webhookStore) DecodeRow(row *WebhookRow) *model.Webhook {
+	if row == nil {
+		return nil
+	}
+	return &model.Webhook{
+		Id:     model.WebhookId(row.Id),
+		Url:    row.Url,
+		Secret: row.Secret,
+		Events: util.Filter(util.Convert(strings.Split(row.Events, ","), model.ToWebhookEventType), func(event model.WebhookEventType) bool { return event != "" }),
+		Active: row.Active,
+	}
+}
+
+// //////////////////////////////////////////////////
+// create
+
+func (s *webhookStore) Create(ctx context.Context, tx *sql.Tx, obj *model.Webhook) *model.Webhook {
+	return s.DecodeRow(s.InsertRow(ctx, tx, s.EncodeRow(obj)))
+}
+
+// //////////////////////////////////////////////////
+// retrieve
+
+func (s *webhookStore) Retrieve(ctx context.Context, tx *sql.Tx, id model.WebhookId) *model.Webhook {
+	row, err := s.SelectRow(ctx, tx, s.matchingId(id))
+	if err != nil {
+		panic(err)
+	}
+	return s.DecodeRow(row)
+}
+
+// //////////////////////////////////////////////////
+// update
+
+func (s *webhookStore) Update(ctx context.Context, tx *sql.Tx, obj *model.Webhook) *model.Webhook {
+	return s.DecodeRow(s.UpdateRow(ctx, tx, s.EncodeRow(obj), s.matchingId(obj.Id)))
+}
+
+// //////////////////////////////////////////////////
+// delete
+
+func (s *webhookStore) Delete(ctx context.Context, tx *sql.Tx, id model.WebhookId) {
+	s.DeleteRows(ctx, tx, s.matchingId(id))
+}
+
+// //////////////////////////////////////////////////
+// list
+
+func (s *webhookStore) List(ctx context.Context, tx *sql.Tx, filter *model.WebhookFilter) []*model.Webhook {
+	return util.Convert(s.ListRows(ctx, tx, s.whereClause(filter)), s.DecodeRow)
+}
+
+// //////////////////////////////////////////////////
+// where clause
+
+func (s *webhookStore) matchingId(id model.WebhookId) util.SqlWhereClause {
+	return util.NewSqlCondition("id = $_", id)
+}
+
+func (s *webhookStore) whereClause(filter *model.WebhookFilter) util.SqlWhereClause {
+	wc := util.NewSqlWhereClause()
+	if filter != nil {
+		if filter.ActiveOnly {
+			wc.WithCondition("active = 1")
+		}
+	}
+	return wc
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// //////////////////////////////////////////////////
+// content type
+
+const (
+	Mp3ContentType = "audio/mpeg"
+)
+
+// contentTypeExtensions lists the file extensions of the sniffed content types.
+var contentTypeExtensions = map[string][]string{
+	Mp3ContentType: {".mp3"},
+	"image/bmp":    {".bmp"},
+	"image/gif":    {".gif"},
+	"image/jpeg":   {".jpg", ".jpeg"},
+	"image/png":    {".png"},
+	"image/webp":   {".webp"},
+	"image/x-icon": {".ico"},
+}
+
+// DetectContentType sniffs the content type of the data, also recognizing mp3 without ID3v2 tag which http.DetectContentType does not.
+func DetectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	if contentType == "application/octet-stream" && len(data) >= 4 {
+		if _, _, ok := parseMp3FrameHeader(data[:4]); ok {
+			return Mp3ContentType
+		}
+	}
+	return contentType
+}
+
+// MatchContentType tells whether the extension of the file name is one of the sniffed content type.
+func MatchContentType(fileName string, contentType string) bool {
+	extension := strings.ToLower(filepath.Ext(fileName))
+	for _, candidate := range contentTypeExtensions[contentType] {
+		if extension == candidate {
+			return true
+		}
+	}
+	return false
+}
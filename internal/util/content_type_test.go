@@ -0,0 +1,46 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+func TestDetectContentType(t *testing.T) {
+	data := newTestMp3(10)
+	require.Equal(t, util.Mp3ContentType, util.DetectContentType(data))
+	// without ID3v2 tag
+	require.Equal(t, util.Mp3ContentType, util.DetectContentType(data[15:]))
+	require.Equal(t, "image/png", util.DetectContentType([]byte("\x89PNG\x0D\x0A\x1A\x0A0000")))
+	require.Equal(t, "application/octet-stream", util.DetectContentType([]byte{0x00, 0x01, 0xFF, 0xFB}))
+	require.Equal(t, "text/plain; charset=utf-8", util.DetectContentType([]byte("not a mp3 at all")))
+}
+
+func TestMatchContentType(t *testing.T) {
+	tests := []struct {
+		fileName    string
+		contentType string
+		wantMatch   bool
+	}{
+		{"song.mp3", util.Mp3ContentType, true},
+		{"SONG.MP3", util.Mp3ContentType, true},
+		{"dir/song.mp3", util.Mp3ContentType, true},
+		{"cover.jpg", "image/jpeg", true},
+		{"cover.jpeg", "image/jpeg", true},
+		{"cover.png", "image/png", true},
+		{"cover.png", "image/jpeg", false},
+		{"song.mp3", "image/png", false},
+		{"cover.png.mp3", "image/png", false},
+		{"song", util.Mp3ContentType, false},
+		{"notes.txt", "text/plain; charset=utf-8", false},
+		{"data.bin", "application/octet-stream", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fileName+"["+tt.contentType+"]", func(t *testing.T) {
+			require.Equal(t, tt.wantMatch, util.MatchContentType(tt.fileName, tt.contentType))
+		})
+	}
+}
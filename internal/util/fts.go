@@ -0,0 +1,45 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+// //////////////////////////////////////////////////
+// full-text search
+
+// ToFtsQuery turns a free text into a FTS5 query matching every word as a prefix ( e.g. `beyon dang` gives `"beyon"* "dang"*` ).
+// punctuation is dropped so that the text can never be interpreted as FTS5 syntax.
+func ToFtsQuery(text string) string {
+	words := toFtsWords(text)
+	terms := make([]string, 0, len(words))
+	for _, word := range words {
+		terms = append(terms, "\""+word+"\"*")
+	}
+	return strings.Join(terms, " ")
+}
+
+// MatchFts tells whether a text matches a free text the way the FTS5 query of ToFtsQuery does on the search index:
+// every word of the free text prefixes a word of the text, ignoring case and diacritics.
+func MatchFts(text string, freeText string) bool {
+	words := toFtsWords(strings.ToLower(RemoveAccents(text)))
+	for _, prefix := range toFtsWords(strings.ToLower(RemoveAccents(freeText))) {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, prefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func toFtsWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
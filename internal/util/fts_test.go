@@ -0,0 +1,52 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToFtsQuery(t *testing.T) {
+	tests := []struct {
+		text      string
+		wantQuery string
+	}{
+		{"", ""},
+		{"  ", ""},
+		{"beyonce", `"beyonce"*`},
+		{"Beyoncé  Crazy", `"Beyoncé"* "Crazy"*`},
+		{`AC/DC "back" OR in-black*`, `"AC"* "DC"* "back"* "OR"* "in"* "black"*`},
+	}
+
+	for _, tt := range tests {
+		t.Run("text["+tt.text+"]", func(t *testing.T) {
+			gotQuery := util.ToFtsQuery(tt.text)
+			require.Equal(t, tt.wantQuery, gotQuery)
+		})
+	}
+}
+
+func TestMatchFts(t *testing.T) {
+	tests := []struct {
+		text      string
+		freeText  string
+		wantMatch bool
+	}{
+		{"Crazy in Love", "", true},
+		{"Crazy in Love", "!?", true},
+		{"Beyoncé", "beyonce", true},
+		{"Beyoncé", "BEYON", true},
+		{"Crazy in Love", "lov cra", true},
+		{"Crazy in Love", "azy", false},
+		{"Crazy in Love", "love hate", false},
+		{"AC/DC", "dc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run("text["+tt.text+"]-free-text["+tt.freeText+"]", func(t *testing.T) {
+			gotMatch := util.MatchFts(tt.text, tt.freeText)
+			require.Equal(t, tt.wantMatch, gotMatch)
+		})
+	}
+}
@@ -0,0 +1,330 @@
+package util
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// //////////////////////////////////////////////////
+// id3
+
+var (
+	ErrMissingId3 = fmt.Errorf("missing id3 tag")
+)
+
+// Id3Tag holds the tags of a mp3 used to register a music.
+type Id3Tag struct {
+	Title       string
+	Artist      string
+	Album       string
+	Year        int
+	Picture     []byte
+	PictureMime string
+}
+
+// ParseId3 reads the ID3v2 ( 2.2, 2.3 and 2.4 ) tag at the beginning of a mp3, completed by the ID3v1 tag at its end.
+func ParseId3(data []byte) (*Id3Tag, error) {
+	tag := &Id3Tag{}
+	foundV2 := parseId3v2(data, tag)
+	foundV1 := parseId3v1(data, tag)
+	if !foundV2 && !foundV1 {
+		return nil, ErrMissingId3
+	}
+	return tag, nil
+}
+
+// PictureExtension returns the file extension matching the mime type of the picture.
+func (o *Id3Tag) PictureExtension() string {
+	switch strings.ToLower(o.PictureMime) {
+	case "image/png", "png":
+		return ".png"
+	default:
+		return ".jpg"
+	}
+}
+
+// //////////////////////////////////////////////////
+// id3v2
+
+var (
+	// frame ids by [ major version == 2 ? 0 : 1 ]
+	id3TitleFrames   = [2]string{"TT2", "TIT2"}
+	id3ArtistFrames  = [2]string{"TP1", "TPE1"}
+	id3AlbumFrames   = [2]string{"TAL", "TALB"}
+	id3YearFrames    = [2]string{"TYE", "TYER"}
+	id3PictureFrames = [2]string{"PIC", "APIC"}
+)
+
+const (
+	id3RecordingTimeFrame = "TDRC" // 2.4
+	id3FrontCoverType     = 0x03
+)
+
+func parseId3v2(data []byte, tag *Id3Tag) bool {
+	if len(data) < 10 || string(data[:3]) != "ID3" {
+		return false
+	}
+	major := data[3]
+	flags := data[5]
+	if major < 2 || major > 4 {
+		return false
+	}
+	end := id3v2Size(data)
+	if flags&0x10 != 0 {
+		// footer
+		end -= 10
+	}
+	if end < 10 {
+		return false
+	}
+	body := data[10:end]
+	if flags&0x80 != 0 && major < 4 {
+		// unsynchronisation of the whole tag
+		body = removeUnsynchronisation(body)
+	}
+	if flags&0x40 != 0 && major > 2 {
+		// extended header
+		body = skipId3ExtendedHeader(body, major)
+	}
+
+	index := 1
+	idSize, headerSize := 4, 10
+	if major == 2 {
+		index = 0
+		idSize, headerSize = 3, 6
+	}
+
+	picturePriority := -1
+	for offset := 0; offset+headerSize <= len(body); {
+		id := string(body[offset : offset+idSize])
+		if id[0] == 0 {
+			// padding
+			break
+		}
+		var size int
+		switch major {
+		case 2:
+			size = int(body[offset+3])<<16 | int(body[offset+4])<<8 | int(body[offset+5])
+		case 3:
+			size = int(binary.BigEndian.Uint32(body[offset+4 : offset+8]))
+		default:
+			size = syncSafe(body[offset+4 : offset+8])
+		}
+		start := offset + headerSize
+		if size <= 0 || start+size > len(body) {
+			break
+		}
+		frame := body[start : start+size]
+		if major == 4 && body[offset+9]&0x02 != 0 {
+			frame = removeUnsynchronisation(frame)
+		}
+		offset = start + size
+
+		switch id {
+		case id3TitleFrames[index]:
+			tag.Title = decodeId3Text(frame)
+		case id3ArtistFrames[index]:
+			tag.Artist = decodeId3Text(frame)
+		case id3AlbumFrames[index]:
+			tag.Album = decodeId3Text(frame)
+		case id3YearFrames[index], id3RecordingTimeFrame:
+			if year := toId3Year(decodeId3Text(frame)); year != 0 {
+				tag.Year = year
+			}
+		case id3PictureFrames[index]:
+			// prefer the front cover over any other picture
+			mime, pictureType, picture := decodeId3Picture(frame, major)
+			priority := 0
+			if pictureType == id3FrontCoverType {
+				priority = 1
+			}
+			if len(picture) > 0 && priority > picturePriority {
+				tag.PictureMime = mime
+				tag.Picture = picture
+				picturePriority = priority
+			}
+		}
+	}
+	return true
+}
+
+func skipId3ExtendedHeader(body []byte, major byte) []byte {
+	if len(body) < 4 {
+		return body
+	}
+	var size int
+	if major == 3 {
+		size = int(binary.BigEndian.Uint32(body[:4])) + 4
+	} else {
+		size = syncSafe(body[:4])
+	}
+	if size > len(body) {
+		return nil
+	}
+	return body[size:]
+}
+
+func syncSafe(data []byte) int {
+	return int(data[0]&0x7F)<<21 | int(data[1]&0x7F)<<14 | int(data[2]&0x7F)<<7 | int(data[3]&0x7F)
+}
+
+func removeUnsynchronisation(data []byte) []byte {
+	return bytes.ReplaceAll(data, []byte{0xFF, 0x00}, []byte{0xFF})
+}
+
+// decodeId3Text decodes a text frame: an encoding byte followed by the text.
+func decodeId3Text(frame []byte) string {
+	if len(frame) == 0 {
+		return ""
+	}
+	text, _ := decodeId3String(frame[0], frame[1:])
+	return text
+}
+
+// decodeId3Picture decodes an APIC frame ( or a PIC frame for ID3v2.2 ).
+func decodeId3Picture(frame []byte, major byte) (string, byte, []byte) {
+	if len(frame) < 2 {
+		return "", 0, nil
+	}
+	encoding := frame[0]
+	rest := frame[1:]
+	var mime string
+	if major == 2 {
+		if len(rest) < 3 {
+			return "", 0, nil
+		}
+		mime = strings.ToLower(string(rest[:3]))
+		rest = rest[3:]
+	} else {
+		end := bytes.IndexByte(rest, 0)
+		if end < 0 {
+			return "", 0, nil
+		}
+		mime = strings.ToLower(string(rest[:end]))
+		rest = rest[end+1:]
+	}
+	if len(rest) < 1 {
+		return "", 0, nil
+	}
+	pictureType := rest[0]
+	_, size := decodeId3String(encoding, rest[1:])
+	if 1+size > len(rest) {
+		return "", 0, nil
+	}
+	return mime, pictureType, rest[1+size:]
+}
+
+// decodeId3String decodes the first string of the data and returns it along with the number of bytes consumed, terminator included.
+func decodeId3String(encoding byte, data []byte) (string, int) {
+	switch encoding {
+	case 1, 2:
+		// utf-16 with bom / utf-16 big endian
+		end := len(data)
+		consumed := len(data)
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 && data[i+1] == 0 {
+				end = i
+				consumed = i + 2
+				break
+			}
+		}
+		return decodeUtf16(encoding, data[:end]), consumed
+	default:
+		// iso-8859-1 / utf-8
+		end := bytes.IndexByte(data, 0)
+		consumed := end + 1
+		if end < 0 {
+			end = len(data)
+			consumed = len(data)
+		}
+		if encoding == 3 {
+			return strings.Trim(string(data[:end]), " "), consumed
+		}
+		return decodeLatin1(data[:end]), consumed
+	}
+}
+
+func decodeUtf16(encoding byte, data []byte) string {
+	bigEndian := encoding == 2
+	if len(data) >= 2 {
+		switch {
+		case data[0] == 0xFE && data[1] == 0xFF:
+			bigEndian = true
+			data = data[2:]
+		case data[0] == 0xFF && data[1] == 0xFE:
+			bigEndian = false
+			data = data[2:]
+		}
+	}
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, binary.BigEndian.Uint16(data[i:]))
+		} else {
+			units = append(units, binary.LittleEndian.Uint16(data[i:]))
+		}
+	}
+	return strings.Trim(string(utf16.Decode(units)), " ")
+}
+
+func decodeLatin1(data []byte) string {
+	runes := make([]rune, 0, len(data))
+	for _, b := range data {
+		runes = append(runes, rune(b))
+	}
+	return strings.Trim(string(runes), " ")
+}
+
+// toId3Year returns the year of a date such as "1994" or "1994-03-08", 0 when invalid.
+func toId3Year(value string) int {
+	if len(value) < 4 {
+		return 0
+	}
+	year, err := strconv.Atoi(value[:4])
+	if err != nil {
+		return 0
+	}
+	return year
+}
+
+// //////////////////////////////////////////////////
+// id3v1
+
+const (
+	id3v1Size = 128
+)
+
+// parseId3v1 completes the tag with the fields of the ID3v1 tag missing from the ID3v2 tag.
+func parseId3v1(data []byte, tag *Id3Tag) bool {
+	if len(data) < id3v1Size {
+		return false
+	}
+	v1 := data[len(data)-id3v1Size:]
+	if string(v1[:3]) != "TAG" {
+		return false
+	}
+	field := func(from, to int) string {
+		value := v1[from:to]
+		if end := bytes.IndexByte(value, 0); end >= 0 {
+			value = value[:end]
+		}
+		return decodeLatin1(value)
+	}
+	if tag.Title == "" {
+		tag.Title = field(3, 33)
+	}
+	if tag.Artist == "" {
+		tag.Artist = field(33, 63)
+	}
+	if tag.Album == "" {
+		tag.Album = field(63, 93)
+	}
+	if tag.Year == 0 {
+		tag.Year = toId3Year(field(93, 97))
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package util_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// newTestId3v23 builds an ID3v2.3 tag with the given frames.
+func newTestId3v23(frames map[string][]byte, order ...string) []byte {
+	var body bytes.Buffer
+	for _, id := range order {
+		frame := frames[id]
+		body.WriteString(id)
+		binary.Write(&body, binary.BigEndian, uint32(len(frame)))
+		body.Write([]byte{0, 0})
+		body.Write(frame)
+	}
+	// padding
+	body.Write(make([]byte, 16))
+	size := body.Len()
+	header := []byte{'I', 'D', '3', 3, 0, 0, byte(size >> 21 & 0x7F), byte(size >> 14 & 0x7F), byte(size >> 7 & 0x7F), byte(size & 0x7F)}
+	return append(header, body.Bytes()...)
+}
+
+// newTestId3v1 builds an ID3v1 tag.
+func newTestId3v1(title, artist, album, year string) []byte {
+	tag := make([]byte, 128)
+	copy(tag, "TAG")
+	copy(tag[3:33], title)
+	copy(tag[33:63], artist)
+	copy(tag[63:93], album)
+	copy(tag[93:97], year)
+	return tag
+}
+
+func TestParseId3v2(t *testing.T) {
+	picture := []byte{0x89, 'P', 'N', 'G', 0xFF, 0x00}
+	apic := append([]byte{0}, []byte("image/png\x00\x03cover\x00")...)
+	apic = append(apic, picture...)
+	data := newTestId3v23(map[string][]byte{
+		"TIT2": append([]byte{0}, []byte("Smalltown Boy")...),
+		// utf-16 with bom
+		"TPE1": {1, 0xFF, 0xFE, 'B', 0, 'r', 0, 'o', 0, 'n', 0, 's', 0, 'k', 0, 'i', 0, 0, 0},
+		"TALB": append([]byte{3}, []byte("The Age of Consent\x00")...),
+		"TYER": append([]byte{0}, []byte("1984")...),
+		"APIC": apic,
+	}, "TIT2", "TPE1", "TALB", "TYER", "APIC")
+	data = append(data, bytes.Repeat([]byte{0xFF, 0xFB, 0x90, 0x00}, 10)...)
+
+	tag, err := util.ParseId3(data)
+	require.NoError(t, err)
+	require.Equal(t, "Smalltown Boy", tag.Title)
+	require.Equal(t, "Bronski", tag.Artist)
+	require.Equal(t, "The Age of Consent", tag.Album)
+	require.Equal(t, 1984, tag.Year)
+	require.Equal(t, "image/png", tag.PictureMime)
+	require.Equal(t, picture, tag.Picture)
+	require.Equal(t, ".png", tag.PictureExtension())
+}
+
+func TestParseId3v1(t *testing.T) {
+	data := newTestId3v23(map[string][]byte{
+		"TIT2": append([]byte{0}, []byte("Hurt")...),
+	}, "TIT2")
+	data = append(data, bytes.Repeat([]byte{0xFF, 0xFB, 0x90, 0x00}, 10)...)
+	data = append(data, newTestId3v1("Other", "Johnny Cash", "American IV", "2002")...)
+
+	tag, err := util.ParseId3(data)
+	require.NoError(t, err)
+	require.Equal(t, "Hurt", tag.Title)
+	require.Equal(t, "Johnny Cash", tag.Artist)
+	require.Equal(t, "American IV", tag.Album)
+	require.Equal(t, 2002, tag.Year)
+	require.Nil(t, tag.Picture)
+	require.Equal(t, ".jpg", tag.PictureExtension())
+
+	_, err = util.ParseId3(bytes.Repeat([]byte{0xFF, 0xFB, 0x90, 0x00}, 50))
+	require.ErrorIs(t, err, util.ErrMissingId3)
+}
@@ -0,0 +1,164 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+)
+
+// //////////////////////////////////////////////////
+// mp3
+
+var (
+	ErrInvalidMp3      = fmt.Errorf("invalid mp3")
+	ErrEmptyMp3Excerpt = fmt.Errorf("empty mp3 excerpt")
+)
+
+// Mp3Frame locates a MPEG audio frame in a mp3 file.
+type Mp3Frame struct {
+	Offset   int
+	Size     int
+	Start    time.Duration
+	Duration time.Duration
+}
+
+var (
+	// kbps by [ mpeg1 ? 0 : 1 ][ layer - 1 ][ index ]
+	mp3Bitrates = [2][3][16]int{
+		{
+			{0, 32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448, 0},
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384, 0},
+			{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+		},
+		{
+			{0, 32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256, 0},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+			{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+		},
+	}
+	// hz by version bits ( 0: mpeg2.5, 2: mpeg2, 3: mpeg1 )
+	mp3SampleRates = [4][3]int{
+		{11025, 12000, 8000},
+		{0, 0, 0},
+		{22050, 24000, 16000},
+		{44100, 48000, 32000},
+	}
+)
+
+// ParseMp3Frames lists the audio frames of a mp3, skipping the leading ID3v2 tag, the Xing / Info frame and any garbage between frames.
+func ParseMp3Frames(data []byte) ([]Mp3Frame, error) {
+	frames := make([]Mp3Frame, 0)
+	offset := id3v2Size(data)
+	start := time.Duration(0)
+	for offset+4 <= len(data) {
+		size, duration, ok := parseMp3FrameHeader(data[offset : offset+4])
+		if !ok || offset+size > len(data) {
+			offset++
+			continue
+		}
+		if len(frames) == 0 && isMp3InfoFrame(data[offset:offset+size]) {
+			offset += size
+			continue
+		}
+		frames = append(frames, Mp3Frame{
+			Offset:   offset,
+			Size:     size,
+			Start:    start,
+			Duration: duration,
+		})
+		offset += size
+		start += duration
+	}
+	if len(frames) == 0 {
+		return nil, ErrInvalidMp3
+	}
+	return frames, nil
+}
+
+// TrimMp3 returns the frames starting within [ start, start + duration [, or until the end when duration is 0.
+// the cut is made on frame boundaries so the excerpt is itself a valid mp3, without tags nor Xing / Info frame.
+func TrimMp3(data []byte, start time.Duration, duration time.Duration) ([]byte, error) {
+	frames, err := ParseMp3Frames(data)
+	if err != nil {
+		return nil, err
+	}
+	var excerpt bytes.Buffer
+	for _, frame := range frames {
+		if frame.Start < start {
+			continue
+		}
+		if duration > 0 && frame.Start >= start+duration {
+			break
+		}
+		excerpt.Write(data[frame.Offset : frame.Offset+frame.Size])
+	}
+	if excerpt.Len() == 0 {
+		return nil, ErrEmptyMp3Excerpt
+	}
+	return excerpt.Bytes(), nil
+}
+
+// parseMp3FrameHeader returns the size and the duration of a frame from its 4 bytes header.
+func parseMp3FrameHeader(header []byte) (int, time.Duration, bool) {
+	if header[0] != 0xFF || header[1]&0xE0 != 0xE0 {
+		return 0, 0, false
+	}
+	version := int(header[1]>>3) & 0x03
+	layer := 4 - int(header[1]>>1)&0x03
+	bitrateIndex := int(header[2]>>4) & 0x0F
+	sampleRateIndex := int(header[2]>>2) & 0x03
+	padding := int(header[2]>>1) & 0x01
+	if version == 1 || layer == 4 || bitrateIndex == 0 || bitrateIndex == 15 || sampleRateIndex == 3 {
+		return 0, 0, false
+	}
+
+	mpeg1 := version == 3
+	bitrateTable := 1
+	if mpeg1 {
+		bitrateTable = 0
+	}
+	bitrate := mp3Bitrates[bitrateTable][layer-1][bitrateIndex] * 1000
+	sampleRate := mp3SampleRates[version][sampleRateIndex]
+
+	var size, samples int
+	switch {
+	case layer == 1:
+		size = (12*bitrate/sampleRate + padding) * 4
+		samples = 384
+	case layer == 2 || mpeg1:
+		size = 144*bitrate/sampleRate + padding
+		samples = 1152
+	default:
+		size = 72*bitrate/sampleRate + padding
+		samples = 576
+	}
+	if size < 4 {
+		return 0, 0, false
+	}
+	return size, time.Duration(samples) * time.Second / time.Duration(sampleRate), true
+}
+
+// isMp3InfoFrame tells whether the frame only holds a Xing / Info header ( no audio ).
+func isMp3InfoFrame(frame []byte) bool {
+	if len(frame) > 64 {
+		frame = frame[:64]
+	}
+	return bytes.Contains(frame, []byte("Xing")) || bytes.Contains(frame, []byte("Info"))
+}
+
+// id3v2Size returns the size of the ID3v2 tag at the beginning of the data, if any.
+func id3v2Size(data []byte) int {
+	if len(data) < 10 || string(data[:3]) != "ID3" {
+		return 0
+	}
+	size := int(data[6]&0x7F)<<21 | int(data[7]&0x7F)<<14 | int(data[8]&0x7F)<<7 | int(data[9]&0x7F)
+	size += 10
+	if data[5]&0x10 != 0 {
+		// footer
+		size += 10
+	}
+	if size > len(data) {
+		return len(data)
+	}
+	return size
+}
@@ -0,0 +1,64 @@
+package util_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+)
+
+// newTestMp3 builds an ID3v2 tag, a Xing frame and audio frames ( mpeg1 layer III, 128 kbps, 44.1 kHz ).
+func newTestMp3(nbFrame int) []byte {
+	var data bytes.Buffer
+	data.Write([]byte{'I', 'D', '3', 3, 0, 0, 0, 0, 0, 5})
+	data.Write([]byte("title"))
+	newFrame := func(fill byte) []byte {
+		frame := bytes.Repeat([]byte{fill}, 417)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0x00})
+		return frame
+	}
+	xing := newFrame(0)
+	copy(xing[36:], []byte("Xing"))
+	data.Write(xing)
+	for index := 0; index < nbFrame; index++ {
+		data.Write(newFrame(byte(index%200 + 1)))
+	}
+	return data.Bytes()
+}
+
+func TestParseMp3Frames(t *testing.T) {
+	frames, err := util.ParseMp3Frames(newTestMp3(100))
+	require.NoError(t, err)
+	require.Len(t, frames, 100)
+	require.Equal(t, 15+417, frames[0].Offset)
+	require.Equal(t, 417, frames[0].Size)
+	require.Equal(t, time.Duration(26122448), frames[0].Duration)
+	require.Equal(t, 10*frames[0].Duration, frames[10].Start)
+
+	_, err = util.ParseMp3Frames([]byte("not a mp3 at all"))
+	require.ErrorIs(t, err, util.ErrInvalidMp3)
+}
+
+func TestTrimMp3(t *testing.T) {
+	data := newTestMp3(100)
+
+	excerpt, err := util.TrimMp3(data, time.Second, time.Second)
+	require.NoError(t, err)
+	// frames starting at 1.018s ( #39 ) to 1.985s ( #76 )
+	require.Len(t, excerpt, 38*417)
+	require.Equal(t, byte(40), excerpt[4])
+
+	frames, err := util.ParseMp3Frames(excerpt)
+	require.NoError(t, err)
+	require.Len(t, frames, 38)
+
+	excerpt, err = util.TrimMp3(data, 2*time.Second, 0)
+	require.NoError(t, err)
+	require.Len(t, excerpt, (100-77)*417)
+
+	_, err = util.TrimMp3(data, time.Minute, time.Second)
+	require.ErrorIs(t, err, util.ErrEmptyMp3Excerpt)
+}
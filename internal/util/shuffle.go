@@ -5,10 +5,11 @@ import "math/rand"
 // //////////////////////////////////////////////////
 // convert
 
-func Shuffle[T any](items []T) {
+// Shuffle shuffles the items with the given random generator so that the same seed gives the same order.
+func Shuffle[T any](random *rand.Rand, items []T) {
 	nb := len(items)
 	for i := 0; i < nb; i++ {
-		index := rand.Intn(nb)
+		index := random.Intn(nb)
 		if index != i {
 			items[i], items[index] = items[index], items[i]
 		}
@@ -0,0 +1,108 @@
+package util
+
+import (
+	"sort"
+	"strings"
+)
+
+// //////////////////////////////////////////////////
+// similarity
+
+// NormalizeName reduces a name to its lower alphanumeric characters without accents nor leading article
+// ( e.g. "The Beatles" and "beatles" or "AC/DC" and "ACDC" are normalized the same way ).
+func NormalizeName(value string) string {
+	parts := strings.Split(SanitizeAlphaLower(value), "-")
+	if len(parts) > 1 && (parts[0] == "the" || parts[0] == "les" || parts[0] == "le" || parts[0] == "la") {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// Similarity returns the similarity percentage of two normalized values based on their edit distance.
+func Similarity(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	if longest == 0 {
+		return 100
+	}
+	return 100 * (longest - levenshtein(ra, rb)) / longest
+}
+
+func levenshtein(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// GroupSimilar groups the items whose normalized names are at least similar to the given percentage.
+// an item joins a group as soon as it is similar to one of its members, groups of a single item are dropped.
+func GroupSimilar[T any](items []T, nameFn func(item T) string, minSimilarity int) [][]T {
+
+	names := make([]string, len(items))
+	indexes := make([]int, len(items))
+	for index, item := range items {
+		names[index] = NormalizeName(nameFn(item))
+		indexes[index] = index
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return len(names[indexes[i]]) < len(names[indexes[j]]) })
+
+	// union-find of similar items
+	parents := make([]int, len(items))
+	for index := range parents {
+		parents[index] = index
+	}
+	var root func(index int) int
+	root = func(index int) int {
+		if parents[index] != index {
+			parents[index] = root(parents[index])
+		}
+		return parents[index]
+	}
+
+	for i, a := range indexes {
+		if names[a] == "" {
+			continue
+		}
+		for _, b := range indexes[i+1:] {
+			// names are sorted by length: the similarity cannot exceed the ratio of their lengths
+			if 100*len(names[a]) < minSimilarity*len(names[b]) {
+				break
+			}
+			if Similarity(names[a], names[b]) >= minSimilarity {
+				parents[root(b)] = root(a)
+			}
+		}
+	}
+
+	// keep the order of the items
+	groupIndexes := make(map[int]int)
+	groups := make([][]T, 0)
+	for index, item := range items {
+		key := root(index)
+		groupIndex, found := groupIndexes[key]
+		if !found {
+			groupIndex = len(groups)
+			groupIndexes[key] = groupIndex
+			groups = append(groups, nil)
+		}
+		groups[groupIndex] = append(groups[groupIndex], item)
+	}
+	return Filter(groups, func(group []T) bool { return len(group) > 1 })
+}
@@ -0,0 +1,42 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/gre-ory/amnezic-go/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		value          string
+		wantNormalized string
+	}{
+		{"", ""},
+		{"The Beatles", "beatles"},
+		{"AC/DC", "acdc"},
+		{"Beyoncé", "beyonce"},
+		{"Theory of a Deadman", "theoryofadeadman"},
+	}
+
+	for _, tt := range tests {
+		t.Run("value["+tt.value+"]", func(t *testing.T) {
+			require.Equal(t, tt.wantNormalized, util.NormalizeName(tt.value))
+		})
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	require.Equal(t, 100, util.Similarity("", ""))
+	require.Equal(t, 100, util.Similarity("abba", "abba"))
+	require.Equal(t, 75, util.Similarity("abba", "abda"))
+	require.Equal(t, 0, util.Similarity("abc", "xyz"))
+}
+
+func TestGroupSimilar(t *testing.T) {
+	names := []string{"The Beatles", "Madonna", "Beatles", "ACDC", "AC/DC", "Madona", "Queen", "", ""}
+
+	groups := util.GroupSimilar(names, func(name string) string { return name }, 85)
+
+	require.Equal(t, [][]string{{"The Beatles", "Beatles"}, {"Madonna", "Madona"}, {"ACDC", "AC/DC"}}, groups)
+}
@@ -22,6 +22,29 @@ func SqlQuery(ctx context.Context, tx *sql.Tx, query string, args ...any) *sql.R
 	return rows
 }
 
+// //////////////////////////////////////////////////
+// exec
+
+// SqlExec runs a statement and returns the number of affected rows.
+func SqlExec(ctx context.Context, tx *sql.Tx, query string, args ...any) int64 {
+
+	stmt, err := tx.Prepare(query) // to avoid SQL injection
+	if err != nil {
+		panic(err)
+	}
+
+	result, err := stmt.Exec(args...)
+	if err != nil {
+		panic(err)
+	}
+
+	nb, err := result.RowsAffected()
+	if err != nil {
+		panic(err)
+	}
+	return nb
+}
+
 // //////////////////////////////////////////////////
 // sql scan
 
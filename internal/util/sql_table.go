@@ -18,6 +18,7 @@ type SqlTable[Row any] interface {
 	InsertRow(ctx context.Context, tx *sql.Tx, row *Row) *Row
 	SelectRow(ctx context.Context, tx *sql.Tx, wc SqlWhereClause) (*Row, error)
 	ExistsRow(ctx context.Context, tx *sql.Tx, wc SqlWhereClause) bool
+	CountRows(ctx context.Context, tx *sql.Tx, wc SqlWhereClause) int
 	UpdateRow(ctx context.Context, tx *sql.Tx, row *Row, wc SqlWhereClause) *Row
 	DeleteRows(ctx context.Context, tx *sql.Tx, wc SqlWhereClause) int64
 }
@@ -144,6 +145,10 @@ func (t *sqlTable[Row]) InsertRow(ctx context.Context, tx *sql.Tx, row *Row) *Ro
 	for rows.Next() {
 		return t.scanRow(ctx, rows)
 	}
+	if err := rows.Err(); err != nil {
+		// e.g. unique constraint
+		panic(err)
+	}
 
 	panic(t.errNotFound)
 }
@@ -226,6 +231,45 @@ func (t *sqlTable[Row]) ExistsRow(ctx context.Context, tx *sql.Tx, wc SqlWhereCl
 	return false
 }
 
+// //////////////////////////////////////////////////
+// count rows
+
+func (t *sqlTable[Row]) CountRows(ctx context.Context, tx *sql.Tx, wc SqlWhereClause) int {
+
+	whereClause, whereClauseArgs := wc.Generate(0)
+
+	query := fmt.Sprintf(
+		"SELECT count(1) FROM %s %s",
+		t.name,
+		whereClause,
+	)
+	t.logger.Info(fmt.Sprintf("[DEBUG] query: %s, args: %#v", query, whereClauseArgs))
+
+	stmt, err := tx.Prepare(query) // to avoid SQL injection
+	if err != nil {
+		panic(err)
+	}
+
+	rows, err := stmt.Query(whereClauseArgs...)
+	if err != nil {
+		panic(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var count int
+		if err := rows.Scan(&count); err != nil {
+			panic(err)
+		}
+		return count
+	}
+	if err := rows.Err(); err != nil {
+		panic(err)
+	}
+
+	return 0
+}
+
 // //////////////////////////////////////////////////
 // update row
 
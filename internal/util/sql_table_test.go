@@ -254,6 +254,94 @@ func TestSqlTable(t *testing.T) {
 		}
 	})
 
+	//
+	// count rows
+	//
+
+	t.Run("count-rows", func(t *testing.T) {
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare("SELECT count(1) FROM test WHERE name LIKE '%' || $1 || '%'").
+			ExpectQuery().
+			WithArgs("my").
+			WillReturnRows(
+				sqlmock.NewRows([]string{"count"}).
+					AddRow(42),
+			)
+		mock.ExpectCommit()
+
+		table := util.NewSqlTable[TestRow](logger, "test", ErrTestRowNotFound)
+		var gotCount int
+		gotErr := util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+			gotCount = table.CountRows(ctx, tx, util.NewSqlCondition("name LIKE '%' || $_ || '%'", "my"))
+		})
+
+		require.Equal(t, nil, gotErr, "wrong error")
+		require.Equal(t, 42, gotCount, "wrong count")
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	//
+	// count rows with scan error
+	//
+
+	t.Run("count-rows-scan-error", func(t *testing.T) {
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare("SELECT count(1) FROM test").
+			ExpectQuery().
+			WillReturnRows(
+				sqlmock.NewRows([]string{"count"}).
+					AddRow("not-a-count"),
+			)
+		mock.ExpectRollback()
+
+		table := util.NewSqlTable[TestRow](logger, "test", ErrTestRowNotFound)
+		gotCount := -1
+		gotErr := util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+			gotCount = table.CountRows(ctx, tx, util.NewSqlWhereClause())
+		})
+
+		require.Error(t, gotErr, "missing error")
+		require.Equal(t, -1, gotCount, "unexpected count")
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
+	//
+	// list rows with offset
+	//
+
+	t.Run("list-rows-offset", func(t *testing.T) {
+
+		mock.ExpectBegin()
+		mock.ExpectPrepare("SELECT id,name,value FROM test ORDER BY name DESC LIMIT -1 OFFSET 10").
+			ExpectQuery().
+			WillReturnRows(
+				sqlmock.NewRows([]string{"id", "name", "value"}).
+					AddRow(1011, "my-name", 99),
+			)
+		mock.ExpectCommit()
+
+		table := util.NewSqlTable[TestRow](logger, "test", ErrTestRowNotFound)
+		var gotRows []*TestRow
+		gotErr := util.SqlTransaction(ctx, db, func(tx *sql.Tx) {
+			gotRows = table.ListRows(ctx, tx, util.NewSqlWhereClause().WithOrderBy("name DESC").WithOffset(10))
+		})
+
+		require.Equal(t, nil, gotErr, "wrong error")
+		require.Equal(t, []*TestRow{{Id: 1011, Name: "my-name", Value: 99}}, gotRows, "wrong rows")
+
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("there were unfulfilled expectations: %s", err)
+		}
+	})
+
 	//
 	// update row
 	//
@@ -9,7 +9,9 @@ type SqlWhereClause interface {
 	IsEmpty() bool
 	WithCondition(condition string, args ...any) SqlWhereClause
 	WithRandomOrder() SqlWhereClause
+	WithOrderBy(orderBy string) SqlWhereClause
 	WithLimit(limit int) SqlWhereClause
+	WithOffset(offset int) SqlWhereClause
 	Generate(placeHolder int) (string, []any)
 }
 
@@ -37,6 +39,7 @@ type sqlWhereClause struct {
 	placeHolder int
 	orderBy     string
 	limit       int
+	offset      int
 }
 
 func (wc *sqlWhereClause) IsEmpty() bool {
@@ -62,11 +65,21 @@ func (wc *sqlWhereClause) WithRandomOrder() SqlWhereClause {
 	return wc
 }
 
+func (wc *sqlWhereClause) WithOrderBy(orderBy string) SqlWhereClause {
+	wc.orderBy = orderBy
+	return wc
+}
+
 func (wc *sqlWhereClause) WithLimit(limit int) SqlWhereClause {
 	wc.limit = limit
 	return wc
 }
 
+func (wc *sqlWhereClause) WithOffset(offset int) SqlWhereClause {
+	wc.offset = offset
+	return wc
+}
+
 func (wc *sqlWhereClause) Generate(placeHolder int) (string, []any) {
 	var whereClause string
 	if !wc.IsEmpty() {
@@ -82,11 +95,19 @@ func (wc *sqlWhereClause) Generate(placeHolder int) (string, []any) {
 		}
 		whereClause += fmt.Sprintf("ORDER BY %s", wc.orderBy)
 	}
-	if wc.limit != 0 {
+	if wc.limit != 0 || wc.offset != 0 {
 		if whereClause != "" {
 			whereClause += " "
 		}
-		whereClause += fmt.Sprintf("LIMIT %d", wc.limit)
+		limit := wc.limit
+		if limit == 0 {
+			// sqlite requires a limit along with an offset
+			limit = -1
+		}
+		whereClause += fmt.Sprintf("LIMIT %d", limit)
+	}
+	if wc.offset != 0 {
+		whereClause += fmt.Sprintf(" OFFSET %d", wc.offset)
 	}
 	return whereClause, wc.args
 }